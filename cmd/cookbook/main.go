@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/subosito/gotenv"
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/config"
+	cookbookConfig "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/config"
+)
+
+const usage = `usage: cookbook <command> [flags]
+
+commands:
+  import-nutrition    import a local food composition dataset (CSV or JSON) into ingredients nutrition data
+  check-names         list (and repair with -repair) recipe ingredients whose name differs from their ingredient or sub-recipe
+  purge-trash         permanently remove records deleted more than -retention-days ago
+`
+
+func main() {
+	_ = gotenv.Load()
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	renamePolicy, err := config.RenamePolicy()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	db, err := config.BuildPostgres()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	cookbookCommand := cookbookConfig.RegisterCookbookCommand(db, renamePolicy, config.MediaDir())
+
+	commands := map[string]func(ctx context.Context, args []string) error{
+		"import-nutrition": cookbookCommand.ImportNutrition,
+		"check-names":      cookbookCommand.CheckNames,
+		"purge-trash":      cookbookCommand.PurgeTrash,
+	}
+
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := command(context.Background(), os.Args[2:]); err != nil {
+		log.Fatal(err.Error())
+	}
+}
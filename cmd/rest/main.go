@@ -5,6 +5,7 @@ import (
 	"github.com/subosito/gotenv"
 	"github.com/tlab-backend-test-naufal/cookbook-management/internal/config"
 	cookbookConfig "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/config"
+	mealPlanConfig "github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/config"
 	"log"
 	"net/http"
 )
@@ -12,39 +13,133 @@ import (
 func main() {
 	_ = gotenv.Load()
 
+	renamePolicy, err := config.RenamePolicy()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
 	db, err := config.BuildPostgres()
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	cookbookHandler := cookbookConfig.RegisterCookbookHandler(db)
+	cookbookHandler, err := cookbookConfig.RegisterCookbookHandler(db, renamePolicy, config.MediaDir(), config.MediaMaxBytes(), config.TemplatesDir(), config.Reviewers())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	mealPlanHandler := mealPlanConfig.RegisterMealPlanHandler(db, cookbookConfig.RegisterRecipeReader(db, renamePolicy, config.Reviewers()))
 
 	mux := chi.NewRouter()
 
 	mux.Route("/v1", func(r chi.Router) {
 		r.Get("/recipes/{id}/summary", cookbookHandler.GetRecipeSummary)
+		r.Get("/recipes/{id}/nutrition", cookbookHandler.GetRecipeNutrition)
+		r.Get("/recipes/{id}/cost", cookbookHandler.GetRecipeCost)
+		r.Post("/recipes/{id}/substitution-suggestions", cookbookHandler.SuggestSubstitutions)
+		r.Post("/recipes/{id}/cook", cookbookHandler.CookRecipe)
+		r.Get("/recipes/{id}/cook-logs", cookbookHandler.ListCookLogs)
+		r.Post("/recipes/{id}/cook-logs", cookbookHandler.CreateCookLog)
+		r.Delete("/cook-logs/{id}", cookbookHandler.DeleteCookLog)
+		r.Post("/cook-logs/{id}/photo", cookbookHandler.UploadCookLogPhoto)
+		r.Get("/recipes/facets", cookbookHandler.ListRecipeFacets)
 		r.Get("/recipes", cookbookHandler.ListRecipes)
 		r.Post("/recipes", cookbookHandler.CreateRecipe)
+		r.Post("/recipes/import", cookbookHandler.ImportRecipe)
 		r.Patch("/recipes/{id}", cookbookHandler.UpdateRecipe)
 		r.Delete("/recipes/{id}", cookbookHandler.DeleteRecipe)
+		r.Post("/recipes/{id}/restore", cookbookHandler.RestoreRecipe)
+		r.Post("/recipes/{id}/status", cookbookHandler.TransitionRecipe)
+		r.Get("/recipes/{id}/status-history", cookbookHandler.ListRecipeStatusChanges)
+		r.Post("/recipes/{id}/clone", cookbookHandler.CloneRecipe)
+		r.Get("/recipes/{id}/family", cookbookHandler.GetRecipeFamily)
+		r.Get("/recipes/{id}/parent-changes", cookbookHandler.GetRecipeParentChanges)
+		r.Get("/recipes/{id}/images", cookbookHandler.ListRecipeImages)
+		r.Post("/recipes/{id}/images", cookbookHandler.UploadRecipeImage)
+		r.Post("/recipes/{id}/steps/{step}/images", cookbookHandler.UploadRecipeStepImage)
+		r.Delete("/recipe-images/{id}", cookbookHandler.DeleteRecipeImage)
 		r.Post("/recipe-ingredients", cookbookHandler.BulkCreateRecipeIngredients)
+		r.Post("/recipe-ingredients/parse", cookbookHandler.ParseIngredientLines)
 		r.Patch("/recipe-ingredients/{id}", cookbookHandler.UpdateRecipeIngredient)
 		r.Delete("/recipe-ingredients/{id}", cookbookHandler.DeleteRecipeIngredient)
+		r.Post("/recipe-ingredients/{id}/restore", cookbookHandler.RestoreRecipeIngredient)
 
+		r.Get("/categories/tree", cookbookHandler.GetCategoryTree)
+		r.Get("/categories/{id}/booklet", cookbookHandler.GetCategoryBooklet)
 		r.Get("/categories", cookbookHandler.ListCategories)
 		r.Post("/categories", cookbookHandler.CreateCategory)
 		r.Patch("/categories/{id}", cookbookHandler.UpdateCategory)
 		r.Delete("/categories/{id}", cookbookHandler.DeleteCategory)
+		r.Post("/categories/{id}/restore", cookbookHandler.RestoreCategory)
 
 		r.Get("/ingredients", cookbookHandler.ListIngredients)
 		r.Post("/ingredients", cookbookHandler.CreateIngredient)
 		r.Patch("/ingredients/{id}", cookbookHandler.UpdateIngredient)
 		r.Delete("/ingredients/{id}", cookbookHandler.DeleteIngredient)
+		r.Post("/ingredients/{id}/restore", cookbookHandler.RestoreIngredient)
+		r.Get("/ingredients/{id}/unit-conversions", cookbookHandler.ListIngredientUnitConversions)
+		r.Post("/ingredients/{id}/unit-conversions", cookbookHandler.UpsertIngredientUnitConversion)
+		r.Delete("/ingredient-unit-conversions/{id}", cookbookHandler.DeleteIngredientUnitConversion)
+		r.Get("/ingredients/{id}/prices", cookbookHandler.ListIngredientPrices)
+		r.Post("/ingredients/{id}/prices", cookbookHandler.CreateIngredientPrice)
+		r.Delete("/ingredient-prices/{id}", cookbookHandler.DeleteIngredientPrice)
+		r.Post("/ingredients/{id}/merge", cookbookHandler.MergeIngredients)
+		r.Get("/ingredients/{id}/aliases", cookbookHandler.ListIngredientAliases)
+		r.Post("/ingredients/{id}/aliases", cookbookHandler.CreateIngredientAlias)
+		r.Delete("/ingredient-aliases/{id}", cookbookHandler.DeleteIngredientAlias)
+		r.Get("/ingredients/{id}/substitutions", cookbookHandler.ListIngredientSubstitutions)
+		r.Post("/ingredients/{id}/substitutions", cookbookHandler.CreateIngredientSubstitution)
+		r.Patch("/ingredient-substitutions/{id}", cookbookHandler.UpdateIngredientSubstitution)
+		r.Delete("/ingredient-substitutions/{id}", cookbookHandler.DeleteIngredientSubstitution)
+
+		r.Get("/tags", cookbookHandler.ListTags)
+		r.Post("/tags", cookbookHandler.CreateTag)
+		r.Patch("/tags/{id}", cookbookHandler.UpdateTag)
+		r.Delete("/tags/{id}", cookbookHandler.DeleteTag)
+
+		r.Get("/equipment", cookbookHandler.ListEquipment)
+		r.Post("/equipment", cookbookHandler.CreateEquipment)
+		r.Patch("/equipment/{id}", cookbookHandler.UpdateEquipment)
+		r.Delete("/equipment/{id}", cookbookHandler.DeleteEquipment)
+
+		r.Get("/collections", cookbookHandler.ListCollections)
+		r.Post("/collections", cookbookHandler.CreateCollection)
+		r.Get("/collections/{id}", cookbookHandler.GetCollection)
+		r.Patch("/collections/{id}", cookbookHandler.UpdateCollection)
+		r.Delete("/collections/{id}", cookbookHandler.DeleteCollection)
+		r.Post("/collections/{id}/cover", cookbookHandler.UploadCollectionCover)
+		r.Get("/collections/{id}/export", cookbookHandler.ExportCollection)
+		r.Post("/collections/{id}/recipes", cookbookHandler.AddCollectionRecipe)
+		r.Put("/collections/{id}/recipes/order", cookbookHandler.ReorderCollectionRecipes)
+		r.Delete("/collections/{id}/recipes/{recipe_id}", cookbookHandler.RemoveCollectionRecipe)
+
+		r.Get("/pantry", cookbookHandler.ListPantryItems)
+		r.Put("/pantry/{ingredient_id}/threshold", cookbookHandler.SetLowStockThreshold)
+		r.Get("/pantry/{ingredient_id}/movements", cookbookHandler.ListPantryMovements)
+		r.Post("/pantry/{ingredient_id}/movements", cookbookHandler.RecordPantryMovement)
+
+		r.Get("/ingredient-kinds", cookbookHandler.ListIngredientKinds)
+		r.Post("/ingredient-kinds", cookbookHandler.CreateIngredientKind)
+		r.Patch("/ingredient-kinds/{id}", cookbookHandler.UpdateIngredientKind)
+		r.Delete("/ingredient-kinds/{id}", cookbookHandler.DeleteIngredientKind)
 
 		r.Get("/ingredient-units", cookbookHandler.ListIngredientUnits)
 		r.Post("/ingredient-units", cookbookHandler.CreateIngredientUnit)
 		r.Patch("/ingredient-units/{id}", cookbookHandler.UpdateIngredientUnit)
 		r.Delete("/ingredient-units/{id}", cookbookHandler.DeleteIngredientUnit)
+		r.Post("/ingredient-units/{id}/restore", cookbookHandler.RestoreIngredientUnit)
+
+		r.Get("/media/*", cookbookHandler.ServeMedia)
+
+		r.Get("/trash", cookbookHandler.ListTrash)
+
+		r.Get("/reports/recipe-cost-increases", cookbookHandler.ListRecipeCostIncreases)
+
+		r.Get("/meal-plan", mealPlanHandler.GetMealPlan)
+		r.Get("/meal-plan/shopping-list", mealPlanHandler.GetShoppingList)
+		r.Post("/meal-plan/entries", mealPlanHandler.CreateMealPlanEntry)
+		r.Patch("/meal-plan/entries/{id}", mealPlanHandler.UpdateMealPlanEntry)
+		r.Delete("/meal-plan/entries/{id}", mealPlanHandler.DeleteMealPlanEntry)
 	})
 
 	port := config.RestPort()
@@ -10,11 +10,11 @@ require (
 	github.com/lib/pq v1.10.9
 	github.com/stretchr/testify v1.7.5
 	github.com/subosito/gotenv v1.6.0
+	golang.org/x/text v0.12.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/text v0.12.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
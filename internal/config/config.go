@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -13,6 +14,17 @@ import (
 
 const defaultRestPort = "8080"
 
+const (
+	renamePolicyPropagate = "propagate"
+	renamePolicySnapshot  = "snapshot"
+	defaultRenamePolicy   = renamePolicyPropagate
+)
+
+const (
+	defaultMediaDir      = "media"
+	defaultMediaMaxBytes = 5 << 20
+)
+
 func RestPort() string {
 	port := os.Getenv("REST_PORT")
 	if port == "" {
@@ -22,6 +34,58 @@ func RestPort() string {
 	return port
 }
 
+// RenamePolicy tells whether renames of ingredients and recipes are propagated to the recipes using them
+// ("propagate") or recipes keep the name at the time they were written ("snapshot"), any other policy is an error
+func RenamePolicy() (string, error) {
+	policy := os.Getenv("COOKBOOK_RENAME_POLICY")
+	if policy == "" {
+		policy = defaultRenamePolicy
+	}
+
+	if policy != renamePolicyPropagate && policy != renamePolicySnapshot {
+		return "", fmt.Errorf("unknown COOKBOOK_RENAME_POLICY %q, expected %q or %q", policy, renamePolicyPropagate, renamePolicySnapshot)
+	}
+
+	return policy, nil
+}
+
+// MediaDir is the directory uploaded images are stored in
+func MediaDir() string {
+	dir := os.Getenv("COOKBOOK_MEDIA_DIR")
+	if dir == "" {
+		dir = defaultMediaDir
+	}
+
+	return dir
+}
+
+// MediaMaxBytes is the largest image that can be uploaded
+func MediaMaxBytes() int64 {
+	maxBytes, err := strconv.ParseInt(os.Getenv("COOKBOOK_MEDIA_MAX_BYTES"), 10, 64)
+	if err != nil || maxBytes <= 0 {
+		maxBytes = defaultMediaMaxBytes
+	}
+
+	return maxBytes
+}
+
+// TemplatesDir is the directory of the recipe card templates overriding the embedded ones, none when empty
+func TemplatesDir() string {
+	return os.Getenv("COOKBOOK_TEMPLATES_DIR")
+}
+
+// Reviewers are the comma separated names allowed to approve, reject and publish recipes
+func Reviewers() []string {
+	var reviewers []string
+	for _, name := range strings.Split(os.Getenv("COOKBOOK_REVIEWERS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			reviewers = append(reviewers, name)
+		}
+	}
+
+	return reviewers
+}
+
 func BuildPostgres() (*sqlx.DB, error) {
 	dataSourceURL := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", os.Getenv("POSTGRES_HOST"), os.Getenv("POSTGRES_PORT"), os.Getenv("POSTGRES_USER"), os.Getenv("POSTGRES_PASSWORD"), os.Getenv("POSTGRES_DB"), os.Getenv("POSTGRES_SSLMODE"))
 
@@ -41,6 +41,19 @@ func WithError(w http.ResponseWriter, code int, err error) {
 	respond(w, code, Base{Error: &BaseError{Message: errMsg}})
 }
 
+// WithErrorData sends an error response along with a JSON object describing the error
+func WithErrorData(w http.ResponseWriter, code int, err error, jsonPayload interface{}) {
+	errMsg := err.Error()
+	respond(w, code, Base{Data: &jsonPayload, Error: &BaseError{Message: errMsg}})
+}
+
+// WithBody sends a response as is with the given content type, for the responses that are not wrapped in Base
+func WithBody(w http.ResponseWriter, code int, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
 func respond(w http.ResponseWriter, code int, payload interface{}) {
 	response, _ := json.Marshal(payload)
 	w.Header().Set("Content-Type", "application/json")
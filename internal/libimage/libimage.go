@@ -0,0 +1,124 @@
+package libimage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	_ "image/gif"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+)
+
+// extensions maps the supported content types to their file extension
+var extensions = map[string]string{
+	ContentTypeJPEG: ".jpg",
+	ContentTypePNG:  ".png",
+	ContentTypeGIF:  ".gif",
+}
+
+// Sniff returns the content type of an image from its first bytes, ok is false when it is not a supported image
+func Sniff(content []byte) (contentType string, ok bool) {
+	contentType = http.DetectContentType(content)
+	_, ok = extensions[contentType]
+
+	return contentType, ok
+}
+
+// Extension returns the file extension of a supported content type
+func Extension(contentType string) string {
+	return extensions[contentType]
+}
+
+// Decode decodes a JPEG, PNG or GIF image, only the first frame of an animated GIF is read
+func Decode(content []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(content))
+	return img, err
+}
+
+// Thumbnail scales img down so that its longest side is at most maxSide, each target pixel is the average
+// of the source pixels it covers. Images that already fit are returned as is
+func Thumbnail(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	if maxSide <= 0 || (srcW <= maxSide && srcH <= maxSide) {
+		return img
+	}
+
+	dstW, dstH := maxSide, maxSide
+	if srcW > srcH {
+		dstH = max(1, srcH*maxSide/srcW)
+	} else {
+		dstW = max(1, srcW*maxSide/srcH)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < dstH; y++ {
+		y0 := bounds.Min.Y + y*srcH/dstH
+		y1 := bounds.Min.Y + max((y+1)*srcH/dstH, y*srcH/dstH+1)
+
+		for x := 0; x < dstW; x++ {
+			x0 := bounds.Min.X + x*srcW/dstW
+			x1 := bounds.Min.X + max((x+1)*srcW/dstW, x*srcW/dstW+1)
+
+			dst.SetNRGBA(x, y, average(img, x0, y0, x1, y1))
+		}
+	}
+
+	return dst
+}
+
+// Encode encodes img in the given content type, GIF thumbnails are encoded as PNG so the returned
+// content type may differ from the requested one
+func Encode(img image.Image, contentType string) (content []byte, encodedType string, err error) {
+	var buf bytes.Buffer
+
+	switch contentType {
+	case ContentTypeJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	default:
+		contentType = ContentTypePNG
+		err = png.Encode(&buf, img)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), contentType, nil
+}
+
+// average returns the mean color of the source rectangle [x0, x1) x [y0, y1)
+func average(img image.Image, x0, y0, x1, y1 int) color.NRGBA {
+	var r, g, b, a, n uint64
+
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			r += uint64(c.R)
+			g += uint64(c.G)
+			b += uint64(c.B)
+			a += uint64(c.A)
+			n++
+		}
+	}
+
+	return color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
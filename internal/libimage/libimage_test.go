@@ -0,0 +1,42 @@
+package libimage_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libimage"
+)
+
+func TestSniff(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	content, _, err := libimage.Encode(img, libimage.ContentTypePNG)
+	assert.NoError(t, err)
+
+	contentType, ok := libimage.Sniff(content)
+	assert.True(t, ok)
+	assert.Equal(t, libimage.ContentTypePNG, contentType)
+	assert.Equal(t, ".png", libimage.Extension(contentType))
+
+	_, ok = libimage.Sniff([]byte("<html><body>not an image</body></html>"))
+	assert.False(t, ok)
+}
+
+func TestThumbnail(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+
+	thumb := libimage.Thumbnail(img, 100)
+
+	assert.Equal(t, image.Rect(0, 0, 100, 50), thumb.Bounds())
+	assert.Equal(t, color.NRGBA{R: 200, G: 100, B: 50, A: 255}, thumb.At(40, 20))
+
+	small := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+	assert.Equal(t, small, libimage.Thumbnail(small, 100))
+}
@@ -0,0 +1,305 @@
+package libingredient
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Line holds an ingredient line split into its parts, e.g. "2-3 siung bawang putih, cincang halus"
+// has an Amount of 2, an AmountMax of 3, the siung Unit, the bawang putih Name and the cincang halus Notes.
+// Denominator is the denominator of an amount written as a fraction, ToTaste is set for a line without an amount
+// like "garam secukupnya" and Optional for a line like "daun bawang (opsional)"
+type Line struct {
+	Amount      float64
+	AmountMax   float64
+	Denominator int
+	ToTaste     bool
+	Optional    bool
+	Unit        string
+	UnitText    string
+	Name        string
+	Notes       string
+}
+
+// HasAmount returns true when the line starts with an amount
+func (l Line) HasAmount() bool {
+	return l.Amount > 0
+}
+
+// IsRange returns true when the amount is a range like "2-3"
+func (l Line) IsRange() bool {
+	return l.AmountMax > l.Amount
+}
+
+const amountPattern = `\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?`
+
+var (
+	// leadingAmountPattern matches the amount or the range of amounts a line starts with
+	leadingAmountPattern = regexp.MustCompile(`(?i)^(` + amountPattern + `)(?:\s*(?:-|–|sampai|hingga|s/d|to|or|atau)\s*(` + amountPattern + `))?(?:\s+|$)`)
+
+	// glued numbers and units like "200g" are split into "200 g"
+	gluedUnitPattern = regexp.MustCompile(`(\d)([\p{L}])`)
+
+	// vulgarFractions are written out so that "1½" reads as "1 1/2"
+	vulgarFractions = strings.NewReplacer("½", " 1/2", "¼", " 1/4", "¾", " 3/4", "⅓", " 1/3", "⅔", " 2/3", "⅛", " 1/8")
+)
+
+// wordAmounts are amounts written as words
+var wordAmounts = map[string]float64{
+	"setengah":   0.5,
+	"seperempat": 0.25,
+	"satu":       1,
+	"sebuah":     1,
+	"dua":        2,
+	"tiga":       3,
+	"empat":      4,
+	"lima":       5,
+	"half":       0.5,
+	"a":          1,
+	"an":         1,
+	"one":        1,
+	"two":        2,
+	"three":      3,
+}
+
+// wordDenominators are the denominators of the word amounts that are fractions
+var wordDenominators = map[string]int{
+	"setengah":   2,
+	"seperempat": 4,
+	"half":       2,
+}
+
+var (
+	// toTastePhrases mark a line without an amount as to taste
+	toTastePhrases = []string{"secukupnya", "sesuai selera", "sckp", "to taste", "as needed"}
+
+	// optionalPhrases mark a line as optional
+	optionalPhrases = []string{"opsional", "optional", "bila suka", "jika suka", "kalau suka", "if desired"}
+)
+
+// units maps the Indonesian and English ways to write a unit to its canonical name,
+// the canonical names are the ones of the ingredient units table where there is one
+var units = map[string]string{}
+
+func init() {
+	for canonical, aliases := range map[string][]string{
+		"gram":    {"g", "gr", "grm", "gram", "grams", "gramme"},
+		"kg":      {"kg", "kilo", "kilogram", "kilograms"},
+		"ons":     {"ons"},
+		"ml":      {"ml", "mililiter", "milliliter", "millilitre", "cc"},
+		"liter":   {"l", "ltr", "liter", "litre", "liters", "litres"},
+		"sdm":     {"sdm", "sendok makan", "tbsp", "tbs", "tablespoon", "tablespoons"},
+		"sdt":     {"sdt", "sendok teh", "tsp", "teaspoon", "teaspoons"},
+		"siung":   {"siung", "clove", "cloves"},
+		"butir":   {"butir", "btr"},
+		"buah":    {"buah", "bh", "piece", "pieces", "pcs", "pc"},
+		"helai":   {"helai", "strand", "strands"},
+		"lembar":  {"lembar", "lbr", "leaf", "leaves", "sheet", "sheets"},
+		"batang":  {"batang", "btg", "stalk", "stalks", "stick", "sticks"},
+		"ruas":    {"ruas", "knob", "knobs"},
+		"ikat":    {"ikat", "bunch", "bunches"},
+		"cangkir": {"cangkir", "cup", "cups"},
+		"gelas":   {"gelas", "glass", "glasses"},
+		"mangkuk": {"mangkuk", "mangkok", "bowl", "bowls"},
+		"piring":  {"piring", "plate", "plates"},
+		"potong":  {"potong", "ptg"},
+		"iris":    {"iris", "slice", "slices"},
+		"bungkus": {"bungkus", "bks", "pack", "packs", "packet", "packets"},
+		"kaleng":  {"kaleng", "can", "cans", "tin", "tins"},
+		"jumput":  {"jumput", "sejumput", "pinch", "pinches"},
+		"oz":      {"oz", "ounce", "ounces"},
+		"lb":      {"lb", "lbs", "pound", "pounds"},
+	} {
+		for _, alias := range aliases {
+			units[alias] = canonical
+		}
+	}
+}
+
+// CanonicalUnit returns the canonical name of a unit written in Indonesian or English, e.g. "sdm" for "tbsp"
+func CanonicalUnit(text string) (string, bool) {
+	canonical, ok := units[strings.Trim(strings.ToLower(strings.TrimSpace(text)), ".")]
+	return canonical, ok
+}
+
+// Parse splits an ingredient line into its amount, unit, name and notes. A line without an amount has no unit,
+// and the word after the amount is only taken as the unit when it is a known unit
+func Parse(line string) Line {
+	var res Line
+
+	rest := strings.TrimSpace(vulgarFractions.Replace(line))
+	rest = gluedUnitPattern.ReplaceAllString(rest, "$1 $2")
+
+	if m := leadingAmountPattern.FindStringSubmatchIndex(rest); m != nil {
+		res.Amount, _ = ParseAmount(rest[m[2]:m[3]])
+		res.Denominator = amountDenominator(rest[m[2]:m[3]])
+		if m[4] >= 0 {
+			res.AmountMax, _ = ParseAmount(rest[m[4]:m[5]])
+			if res.Denominator == 0 {
+				res.Denominator = amountDenominator(rest[m[4]:m[5]])
+			}
+		}
+
+		rest = rest[m[1]:]
+	} else if words := strings.Fields(rest); len(words) > 1 {
+		if amount, ok := wordAmounts[strings.ToLower(words[0])]; ok {
+			res.Amount = amount
+			res.Denominator = wordDenominators[strings.ToLower(words[0])]
+			rest = strings.Join(words[1:], " ")
+		}
+	}
+
+	rest, res.Notes = splitNotes(rest)
+	rest, res.Notes, res.Optional = cutPhrases(rest, res.Notes, optionalPhrases)
+
+	// an amount with "sesuai selera" is only a hint to adjust it, a line without an amount is to taste
+	if !res.HasAmount() {
+		rest, res.Notes, res.ToTaste = cutPhrases(rest, res.Notes, toTastePhrases)
+	}
+
+	words := strings.Fields(rest)
+
+	if res.HasAmount() {
+		for _, n := range []int{2, 1} {
+			if len(words) <= n {
+				continue
+			}
+
+			text := strings.Join(words[:n], " ")
+			if canonical, ok := CanonicalUnit(text); ok {
+				res.Unit = canonical
+				res.UnitText = text
+				words = words[n:]
+				break
+			}
+		}
+	}
+
+	if len(words) > 1 && strings.EqualFold(words[0], "of") {
+		words = words[1:]
+	}
+
+	res.Name = strings.Join(words, " ")
+
+	return res
+}
+
+// ParseAmount reads a number, a decimal with a point or a comma, a fraction or a mixed number like "1 1/2"
+func ParseAmount(s string) (float64, bool) {
+	s = strings.TrimSpace(vulgarFractions.Replace(s))
+	if s == "" {
+		return 0, false
+	}
+
+	var total float64
+
+	for _, part := range strings.Fields(s) {
+		if num, den, ok := strings.Cut(part, "/"); ok {
+			n, err1 := strconv.ParseFloat(num, 64)
+			d, err2 := strconv.ParseFloat(den, 64)
+			if err1 != nil || err2 != nil || d == 0 {
+				return 0, false
+			}
+
+			total += n / d
+			continue
+		}
+
+		n, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+
+		total += n
+	}
+
+	return total, true
+}
+
+// amountDenominator returns the denominator of the fraction of an amount like "1 1/3", or 0 when there is none
+func amountDenominator(s string) int {
+	for _, part := range strings.Fields(s) {
+		if _, den, ok := strings.Cut(part, "/"); ok {
+			d, err := strconv.Atoi(den)
+			if err == nil && d > 1 {
+				return d
+			}
+		}
+	}
+
+	return 0
+}
+
+// cutPhrases removes the phrases from the end or the start of the name and from the notes, where a phrase has to be
+// a whole note, and reports whether one was found
+func cutPhrases(name, notes string, phrases []string) (string, string, bool) {
+	found := false
+
+	for _, phrase := range phrases {
+		lower := strings.ToLower(name)
+
+		switch {
+		case lower == phrase:
+			// a line that is only the phrase has nothing else to name it
+		case strings.HasSuffix(lower, " "+phrase):
+			name = strings.TrimSpace(name[:len(name)-len(phrase)])
+			found = true
+		case strings.HasPrefix(lower, phrase+" "):
+			name = strings.TrimSpace(name[len(phrase):])
+			found = true
+		}
+	}
+
+	var kept []string
+	for _, note := range strings.Split(notes, ", ") {
+		if note == "" {
+			continue
+		}
+
+		if containsPhrase(phrases, strings.ToLower(note)) {
+			found = true
+			continue
+		}
+
+		kept = append(kept, note)
+	}
+
+	return name, strings.Join(kept, ", "), found
+}
+
+func containsPhrase(phrases []string, s string) bool {
+	for _, phrase := range phrases {
+		if s == phrase {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitNotes moves the text after the first comma and the text in parentheses to the notes
+func splitNotes(s string) (string, string) {
+	var notes []string
+
+	if i := strings.Index(s, "("); i >= 0 {
+		if j := strings.Index(s[i:], ")"); j >= 0 {
+			notes = append(notes, strings.TrimSpace(s[i+1:i+j]))
+			s = s[:i] + " " + s[i+j+1:]
+		}
+	}
+
+	if i := strings.Index(s, ","); i >= 0 {
+		notes = append(notes, strings.TrimSpace(s[i+1:]))
+		s = s[:i]
+	}
+
+	var res []string
+	for _, note := range notes {
+		if note != "" {
+			res = append(res, note)
+		}
+	}
+
+	return strings.TrimSpace(s), strings.Join(res, ", ")
+}
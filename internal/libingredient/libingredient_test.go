@@ -0,0 +1,45 @@
+package libingredient_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libingredient"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want libingredient.Line
+	}{
+		{"2 siung bawang putih, cincang halus", libingredient.Line{Amount: 2, Unit: "siung", UnitText: "siung", Name: "bawang putih", Notes: "cincang halus"}},
+		{"1/2 sdt garam", libingredient.Line{Amount: 0.5, Denominator: 2, Unit: "sdt", UnitText: "sdt", Name: "garam"}},
+		{"1½ sendok makan kecap manis", libingredient.Line{Amount: 1.5, Denominator: 2, Unit: "sdm", UnitText: "sendok makan", Name: "kecap manis"}},
+		{"2-3 buah cabai rawit (sesuai selera)", libingredient.Line{Amount: 2, AmountMax: 3, Unit: "buah", UnitText: "buah", Name: "cabai rawit", Notes: "sesuai selera"}},
+		{"1,5 kg daging sapi", libingredient.Line{Amount: 1.5, Unit: "kg", UnitText: "kg", Name: "daging sapi"}},
+		{"200g tepung terigu", libingredient.Line{Amount: 200, Unit: "gram", UnitText: "g", Name: "tepung terigu"}},
+		{"2 cups of flour", libingredient.Line{Amount: 2, Unit: "cangkir", UnitText: "cups", Name: "flour"}},
+		{"setengah buah jeruk nipis", libingredient.Line{Amount: 0.5, Denominator: 2, Unit: "buah", UnitText: "buah", Name: "jeruk nipis"}},
+		{"3 telur", libingredient.Line{Amount: 3, Name: "telur"}},
+		{"garam", libingredient.Line{Name: "garam"}},
+		{"garam secukupnya", libingredient.Line{ToTaste: true, Name: "garam"}},
+		{"merica bubuk, sesuai selera", libingredient.Line{ToTaste: true, Name: "merica bubuk"}},
+		{"1/3 cangkir santan", libingredient.Line{Amount: 1.0 / 3, Denominator: 3, Unit: "cangkir", UnitText: "cangkir", Name: "santan"}},
+		{"2 batang daun bawang, iris (opsional)", libingredient.Line{Amount: 2, Optional: true, Unit: "batang", UnitText: "batang", Name: "daun bawang", Notes: "iris"}},
+		{"bawang goreng bila suka", libingredient.Line{Optional: true, Name: "bawang goreng"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, libingredient.Parse(tt.line), tt.line)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	amount, ok := libingredient.ParseAmount("1 1/2")
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, amount)
+
+	_, ok = libingredient.ParseAmount("1/0")
+	assert.False(t, ok)
+}
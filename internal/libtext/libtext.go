@@ -0,0 +1,144 @@
+package libtext
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// minTokenSimilarity is the minimum similarity for two words to be considered the same word
+const minTokenSimilarity = 0.75
+
+// Fold lowercases a text and strips its diacritics, e.g. "Crème Brûlée" becomes "creme brulee".
+func Fold(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = s
+	}
+
+	return strings.ToLower(strings.TrimSpace(folded))
+}
+
+// Normalize folds a text and collapses punctuation and spaces into single spaces,
+// e.g. "Bawang  Putih, segar" becomes "bawang putih segar".
+func Normalize(s string) string {
+	return strings.Join(Tokens(s), " ")
+}
+
+// Tokens splits a folded text into its alphanumeric words.
+func Tokens(s string) []string {
+	return strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Similarity scores how similar two texts are between 0 and 1.
+// It takes the best of the edit distance ratio and the word overlap, where a text fully
+// contained in the other (e.g. "bawang putih" in "bawang putih segar") scores 0.9.
+func Similarity(a, b string) float64 {
+	na, nb := Normalize(a), Normalize(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+
+	if na == nb {
+		return 1
+	}
+
+	score := levenshteinRatio(na, nb)
+
+	if overlap := tokenOverlap(strings.Fields(na), strings.Fields(nb)) * 0.9; overlap > score {
+		score = overlap
+	}
+
+	return score
+}
+
+func levenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// tokenOverlap scores the share of words of the shorter text found in the other one,
+// where near-identical words (e.g. "telor" and "telur") count by their similarity.
+func tokenOverlap(a, b []string) float64 {
+	setA, setB := tokenSet(a), tokenSet(b)
+	if len(setB) < len(setA) {
+		setA, setB = setB, setA
+	}
+
+	if len(setA) == 0 {
+		return 0
+	}
+
+	var total float64
+	for ta := range setA {
+		var best float64
+		for tb := range setB {
+			if ratio := levenshteinRatio(ta, tb); ratio > best {
+				best = ratio
+			}
+		}
+
+		if best >= minTokenSimilarity {
+			total += best
+		}
+	}
+
+	return total / float64(len(setA))
+}
+
+func tokenSet(tokens []string) map[string]bool {
+	set := map[string]bool{}
+	for _, t := range tokens {
+		set[t] = true
+	}
+
+	return set
+}
+
+func minInt(values ...int) int {
+	res := values[0]
+	for _, v := range values[1:] {
+		if v < res {
+			res = v
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,24 @@
+package libtext_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libtext"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "creme brulee", libtext.Fold(" Crème Brûlée "))
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "bawang putih segar", libtext.Normalize("Bawang  Putih, segar"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, float64(1), libtext.Similarity("Bawang merah", "bawang  MERAH"))
+	assert.InDelta(t, 0.9, libtext.Similarity("Bawang putih", "Bawang putih, segar"), 0.001)
+	assert.Greater(t, libtext.Similarity("Telor", "Telur"), 0.7)
+	assert.Less(t, libtext.Similarity("Bawang merah", "Bawang putih"), 0.7)
+}
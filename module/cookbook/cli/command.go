@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// NutritionUsecase defines the contract for nutrition usecase dependency
+type NutritionUsecase interface {
+	MatchNutritionEntries(ctx context.Context, entries []usecase.FoodCompositionEntry) (entity.NutritionImportMatches, error)
+	ApplyNutritionEntries(ctx context.Context, params []usecase.IngredientNutritionParams) (int64, error)
+}
+
+// RecipeUsecase defines the contract for recipe usecase dependency
+type RecipeUsecase interface {
+	CheckRecipeIngredientNames(ctx context.Context) (entity.RecipeIngredientNameDrifts, error)
+	RepairRecipeIngredientNames(ctx context.Context, actor string) (int64, error)
+}
+
+// TrashUsecase defines the contract for trash usecase dependency
+type TrashUsecase interface {
+	PurgeTrash(ctx context.Context, retentionDays int) (entity.TrashPurgeResult, error)
+}
+
+// CookbookCommand is our command line object
+type CookbookCommand struct {
+	nutritionUsecase NutritionUsecase
+	recipeUsecase    RecipeUsecase
+	trashUsecase     TrashUsecase
+	out              io.Writer
+}
+
+// NewCookbookCommand instantiates CookbookCommand
+func NewCookbookCommand(nutritionUsecase NutritionUsecase, recipeUsecase RecipeUsecase, trashUsecase TrashUsecase) *CookbookCommand {
+	return &CookbookCommand{
+		nutritionUsecase: nutritionUsecase,
+		recipeUsecase:    recipeUsecase,
+		trashUsecase:     trashUsecase,
+		out:              os.Stdout,
+	}
+}
@@ -0,0 +1,353 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+const defaultBaseUnitName = "g"
+
+// reportHeader is the header of the review report, the reviewer fills the accept column with "yes"
+// and may change the ingredient_id before feeding the report back with -accept
+var reportHeader = []string{"accept", "status", "entry_name", "ingredient_id", "ingredient_name", "score", "alternatives"}
+
+// FoodCompositionRecord is a food composition dataset entry with nutrition values per 100 base units
+type FoodCompositionRecord struct {
+	Name         string     `json:"name"`
+	BaseUnitName string     `json:"base_unit_name"`
+	Kcal         null.Float `json:"kcal"`
+	Protein      null.Float `json:"protein_g"`
+	Fat          null.Float `json:"fat_g"`
+	Carbs        null.Float `json:"carbs_g"`
+	Fiber        null.Float `json:"fiber_g"`
+	Sodium       null.Float `json:"sodium_mg"`
+}
+
+// ImportNutrition imports a local food composition dataset into the ingredients nutrition data.
+// Without -apply it only writes the review report.
+func (c *CookbookCommand) ImportNutrition(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import-nutrition", flag.ContinueOnError)
+	file := fs.String("file", "", "food composition dataset, a .csv or .json file")
+	format := fs.String("format", "", "dataset format, csv or json (default: file extension)")
+	reportPath := fs.String("report", "nutrition-review.csv", "path of the review report to write")
+	acceptPath := fs.String("accept", "", "reviewed report whose rows with accept=yes are applied")
+	apply := fs.Bool("apply", false, "apply matched and accepted mappings")
+	actor := fs.String("actor", "import-nutrition", "actor recorded as updated_by")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	// the report is written before anything is applied, it must not overwrite the reviewed report being applied
+	if *acceptPath != "" && samePath(*acceptPath, *reportPath) {
+		return fmt.Errorf("-accept and -report are the same file %s, write the new report elsewhere", *reportPath)
+	}
+
+	accepted := map[string]uint64{}
+	if *acceptPath != "" {
+		var err error
+
+		accepted, err = readAcceptedMappings(*acceptPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	entries, err := readFoodComposition(*file, *format)
+	if err != nil {
+		return err
+	}
+
+	matches, err := c.nutritionUsecase.MatchNutritionEntries(ctx, entries)
+	if err != nil {
+		return err
+	}
+
+	if err := writeNutritionReport(*reportPath, matches); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, m := range matches {
+		counts[m.Status]++
+	}
+
+	_, _ = fmt.Fprintf(c.out, "%d entries: %d matched, %d ambiguous, %d unmatched, report written to %s\n",
+		len(matches), counts[entity.NutritionImportStatusMatched], counts[entity.NutritionImportStatusAmbiguous], counts[entity.NutritionImportStatusUnmatched], *reportPath)
+
+	if !*apply {
+		return nil
+	}
+
+	params := acceptedNutritionParams(matches, accepted, *acceptPath != "", *actor)
+
+	applied, err := c.nutritionUsecase.ApplyNutritionEntries(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.out, "applied nutrition data to %d ingredients\n", applied)
+
+	return nil
+}
+
+// acceptedNutritionParams builds the updates from reviewed mappings, or from the automatic matches when there is no review
+func acceptedNutritionParams(matches entity.NutritionImportMatches, accepted map[string]uint64, reviewed bool, actor string) []usecase.IngredientNutritionParams {
+	var params []usecase.IngredientNutritionParams
+	applied := map[uint64]bool{}
+
+	for _, m := range matches {
+		var ingredientID uint64
+
+		if reviewed {
+			ingredientID = accepted[m.EntryName]
+		} else if m.Status == entity.NutritionImportStatusMatched {
+			ingredientID = m.Candidates[0].IngredientID
+		}
+
+		if ingredientID == 0 || applied[ingredientID] {
+			continue
+		}
+
+		applied[ingredientID] = true
+		params = append(params, usecase.IngredientNutritionParams{
+			IngredientID: ingredientID,
+			BaseUnitName: m.BaseUnitName,
+			Nutrition:    m.Nutrition,
+			Actor:        actor,
+		})
+	}
+
+	return params
+}
+
+func readFoodComposition(path, format string) ([]usecase.FoodCompositionEntry, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []FoodCompositionRecord
+
+	switch format {
+	case "json":
+		err = json.NewDecoder(f).Decode(&records)
+	case "csv":
+		records, err = readFoodCompositionCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported dataset format %q", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []usecase.FoodCompositionEntry
+	for _, r := range records {
+		if strings.TrimSpace(r.Name) == "" {
+			continue
+		}
+
+		baseUnitName := r.BaseUnitName
+		if baseUnitName == "" {
+			baseUnitName = defaultBaseUnitName
+		}
+
+		entries = append(entries, usecase.FoodCompositionEntry{
+			Name:         strings.TrimSpace(r.Name),
+			BaseUnitName: baseUnitName,
+			Nutrition: entity.Nutrition{
+				Kcal:    r.Kcal,
+				Protein: r.Protein,
+				Fat:     r.Fat,
+				Carbs:   r.Carbs,
+				Fiber:   r.Fiber,
+				Sodium:  r.Sodium,
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// readFoodCompositionCSV reads a CSV whose header uses the same column names as the JSON format
+func readFoodCompositionCSV(r io.Reader) ([]FoodCompositionRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("dataset has no name column")
+	}
+
+	value := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	number := func(row []string, column string) (null.Float, error) {
+		raw := strings.ReplaceAll(value(row, column), ",", ".")
+		if raw == "" || raw == "-" {
+			return null.Float{}, nil
+		}
+
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return null.Float{}, fmt.Errorf("invalid %s %q", column, raw)
+		}
+
+		return null.FloatFrom(f), nil
+	}
+
+	var records []FoodCompositionRecord
+	for line, row := range rows[1:] {
+		record := FoodCompositionRecord{
+			Name:         value(row, "name"),
+			BaseUnitName: value(row, "base_unit_name"),
+		}
+
+		for column, dest := range map[string]*null.Float{
+			"kcal":      &record.Kcal,
+			"protein_g": &record.Protein,
+			"fat_g":     &record.Fat,
+			"carbs_g":   &record.Carbs,
+			"fiber_g":   &record.Fiber,
+			"sodium_mg": &record.Sodium,
+		} {
+			if *dest, err = number(row, column); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line+2, err)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func writeNutritionReport(path string, matches entity.NutritionImportMatches) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(reportHeader); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		row := []string{"", m.Status, m.EntryName, "", "", "", ""}
+
+		if len(m.Candidates) > 0 {
+			best := m.Candidates[0]
+			row[3] = strconv.FormatUint(best.IngredientID, 10)
+			row[4] = best.IngredientName
+			row[5] = strconv.FormatFloat(best.Score, 'f', 2, 64)
+
+			var alternatives []string
+			for _, alt := range m.Candidates[1:] {
+				alternatives = append(alternatives, fmt.Sprintf("%d:%s:%.2f", alt.IngredientID, alt.IngredientName, alt.Score))
+			}
+			row[6] = strings.Join(alternatives, "; ")
+		}
+
+		if m.Status == entity.NutritionImportStatusMatched {
+			row[0] = "yes"
+		}
+
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// readAcceptedMappings reads a reviewed report and returns the ingredient ID of every accepted entry
+func readAcceptedMappings(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]uint64{}
+	for line, row := range rows {
+		if line == 0 || len(row) < len(reportHeader) {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(row[0])) {
+		case "yes", "y", "true":
+		default:
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSpace(row[3]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid ingredient_id %q", line+1, row[3])
+		}
+
+		res[row[2]] = id
+	}
+
+	return res, nil
+}
+
+// samePath returns true when both paths name the same file, through links too when it exists
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA == nil && errB == nil && absA == absB {
+		return true
+	}
+
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
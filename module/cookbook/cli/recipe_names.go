@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+// CheckNames lists recipe ingredients whose denormalized name differs from their ingredient or sub-recipe.
+// With -repair the names are rewritten, which is refused under the snapshot rename policy.
+func (c *CookbookCommand) CheckNames(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("check-names", flag.ContinueOnError)
+	repair := fs.Bool("repair", false, "rewrite the drifted names")
+	actor := fs.String("actor", "check-names", "actor recorded as updated_by")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	drifts, err := c.recipeUsecase.CheckRecipeIngredientNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range drifts {
+		_, _ = fmt.Fprintf(c.out, "recipe %d %q, recipe ingredient %d: %q should be %q\n",
+			d.RecipeID, d.RecipeName, d.RecipeIngredientID, d.IngredientName, d.ExpectedName)
+	}
+
+	_, _ = fmt.Fprintf(c.out, "%d drifted names\n", len(drifts))
+
+	if !*repair || len(drifts) == 0 {
+		return nil
+	}
+
+	repaired, err := c.recipeUsecase.RepairRecipeIngredientNames(ctx, *actor)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.out, "%d names repaired\n", repaired)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+const defaultTrashRetentionDays = 30
+
+// PurgeTrash permanently removes the records soft-deleted more than -retention-days ago.
+// It is meant to run periodically, e.g. from cron.
+func (c *CookbookCommand) PurgeTrash(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	retentionDays := fs.Int("retention-days", defaultTrashRetentionDays, "keep records deleted within this many days")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *retentionDays < 0 {
+		return fmt.Errorf("-retention-days cannot be negative")
+	}
+
+	res, err := c.trashUsecase.PurgeTrash(ctx, *retentionDays)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.out, "purged %d recipes, %d recipe ingredients, %d recipe images, %d categories, %d ingredients and %d ingredient units\n",
+		res.Recipes, res.RecipeIngredients, res.RecipeImages, res.Categories, res.Ingredients, res.IngredientUnits)
+
+	return nil
+}
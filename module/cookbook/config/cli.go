@@ -0,0 +1,35 @@
+package config
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	cookbookCli "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/cli"
+	cookbookFilesystemRepo "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/filesystem"
+	cookbookPostgresRepo "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/postgres"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func RegisterCookbookCommand(db *sqlx.DB, renamePolicy, mediaDir string) *cookbookCli.CookbookCommand {
+	categoryRepo := cookbookPostgresRepo.NewCategoryPostgresRepository(db)
+
+	ingredientRepo := cookbookPostgresRepo.NewIngredientPostgresRepository(db)
+	ingredientUnitRepo := cookbookPostgresRepo.NewIngredientUnitPostgresRepository(db)
+
+	recipeRepo := cookbookPostgresRepo.NewRecipePostgresRepository(db)
+	recipeIngredientRepo := cookbookPostgresRepo.NewRecipeIngredientPostgresRepository(db)
+
+	ingredientSubstitutionRepo := cookbookPostgresRepo.NewIngredientSubstitutionPostgresRepository(db)
+
+	tagRepo := cookbookPostgresRepo.NewTagPostgresRepository(db)
+	equipmentRepo := cookbookPostgresRepo.NewEquipmentPostgresRepository(db)
+
+	trashRepo := cookbookPostgresRepo.NewTrashPostgresRepository(db)
+
+	mediaStorage := cookbookFilesystemRepo.NewMediaLocalStorage(mediaDir)
+
+	nutritionUc := usecase.NewNutritionUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+	recipeUc := usecase.NewRecipeUsecase(recipeRepo, recipeIngredientRepo, ingredientRepo, ingredientUnitRepo, ingredientSubstitutionRepo, categoryRepo, tagRepo, equipmentRepo, usecase.RenamePolicy(renamePolicy), nil)
+	trashUc := usecase.NewTrashUsecase(trashRepo, ingredientRepo, mediaStorage)
+
+	return cookbookCli.NewCookbookCommand(nutritionUc, recipeUc, trashUc)
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	cookbookPostgresRepo "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/postgres"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// RecipeReader lets the other modules read the recipes of the cookbook without reaching into its internals
+type RecipeReader struct {
+	recipeUc *usecase.RecipeUsecase
+}
+
+// RegisterRecipeReader instantiates RecipeReader
+func RegisterRecipeReader(db *sqlx.DB, renamePolicy string, reviewers []string) *RecipeReader {
+	categoryRepo := cookbookPostgresRepo.NewCategoryPostgresRepository(db)
+
+	ingredientRepo := cookbookPostgresRepo.NewIngredientPostgresRepository(db)
+	ingredientUnitRepo := cookbookPostgresRepo.NewIngredientUnitPostgresRepository(db)
+
+	recipeRepo := cookbookPostgresRepo.NewRecipePostgresRepository(db)
+	recipeIngredientRepo := cookbookPostgresRepo.NewRecipeIngredientPostgresRepository(db)
+
+	ingredientSubstitutionRepo := cookbookPostgresRepo.NewIngredientSubstitutionPostgresRepository(db)
+
+	tagRepo := cookbookPostgresRepo.NewTagPostgresRepository(db)
+	equipmentRepo := cookbookPostgresRepo.NewEquipmentPostgresRepository(db)
+
+	recipeUc := usecase.NewRecipeUsecase(recipeRepo, recipeIngredientRepo, ingredientRepo, ingredientUnitRepo, ingredientSubstitutionRepo, categoryRepo, tagRepo, equipmentRepo, usecase.RenamePolicy(renamePolicy), reviewers)
+
+	return &RecipeReader{recipeUc: recipeUc}
+}
+
+// GetRecipeSummary retrieves the summary of a recipe with its sub-recipes expanded into the flattened ingredients,
+// the ID of a deleted or missing recipe, or of a draft the viewer cannot see, is 0
+func (r *RecipeReader) GetRecipeSummary(ctx context.Context, id uint64, viewer string) (entity.RecipeSummary, error) {
+	return r.recipeUc.GetRecipeSummary(ctx, id, usecase.RecipeSummaryOptions{ExpandSubRecipes: true, Viewer: viewer})
+}
+
+// ListRecipeNames retrieves the names of recipes by their IDs, deleted recipes and drafts the viewer cannot see are left out
+func (r *RecipeReader) ListRecipeNames(ctx context.Context, ids []uint64, viewer string) (map[uint64]string, error) {
+	return r.recipeUc.ListRecipeNames(ctx, ids, viewer)
+}
@@ -2,24 +2,56 @@ package config
 
 import (
 	"github.com/jmoiron/sqlx"
+	cookbookFilesystemRepo "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/filesystem"
 	cookbookPostgresRepo "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/postgres"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 	cookbookRest "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/rest"
 )
 
-func RegisterCookbookHandler(db *sqlx.DB) *cookbookRest.CookbookHandler {
+func RegisterCookbookHandler(db *sqlx.DB, renamePolicy, mediaDir string, mediaMaxBytes int64, templatesDir string, reviewers []string) (*cookbookRest.CookbookHandler, error) {
 	categoryRepo := cookbookPostgresRepo.NewCategoryPostgresRepository(db)
 
 	ingredientRepo := cookbookPostgresRepo.NewIngredientPostgresRepository(db)
 	ingredientUnitRepo := cookbookPostgresRepo.NewIngredientUnitPostgresRepository(db)
+	ingredientKindRepo := cookbookPostgresRepo.NewIngredientKindPostgresRepository(db)
 
 	recipeRepo := cookbookPostgresRepo.NewRecipePostgresRepository(db)
 	recipeIngredientRepo := cookbookPostgresRepo.NewRecipeIngredientPostgresRepository(db)
 
+	ingredientPriceRepo := cookbookPostgresRepo.NewIngredientPricePostgresRepository(db)
+	ingredientSubstitutionRepo := cookbookPostgresRepo.NewIngredientSubstitutionPostgresRepository(db)
+
+	tagRepo := cookbookPostgresRepo.NewTagPostgresRepository(db)
+	equipmentRepo := cookbookPostgresRepo.NewEquipmentPostgresRepository(db)
+
+	collectionRepo := cookbookPostgresRepo.NewCollectionPostgresRepository(db)
+	pantryRepo := cookbookPostgresRepo.NewPantryPostgresRepository(db)
+	cookLogRepo := cookbookPostgresRepo.NewCookLogPostgresRepository(db)
+
+	trashRepo := cookbookPostgresRepo.NewTrashPostgresRepository(db)
+
+	recipeImageRepo := cookbookPostgresRepo.NewRecipeImagePostgresRepository(db)
+	mediaStorage := cookbookFilesystemRepo.NewMediaLocalStorage(mediaDir)
+
 	cookbookUc := usecase.NewCategoryUsecase(categoryRepo)
-	ingredientUc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo)
+	ingredientUc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicy(renamePolicy))
+
+	recipeUc := usecase.NewRecipeUsecase(recipeRepo, recipeIngredientRepo, ingredientRepo, ingredientUnitRepo, ingredientSubstitutionRepo, categoryRepo, tagRepo, equipmentRepo, usecase.RenamePolicy(renamePolicy), reviewers)
+	nutritionUc := usecase.NewNutritionUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, reviewers)
+	costUc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, reviewers)
+	substitutionUc := usecase.NewSubstitutionUsecase(recipeRepo, ingredientSubstitutionRepo, reviewers)
+	trashUc := usecase.NewTrashUsecase(trashRepo, ingredientRepo, mediaStorage)
+	tagUc := usecase.NewTagUsecase(tagRepo)
+	mediaUc := usecase.NewMediaUsecase(recipeImageRepo, mediaStorage, mediaMaxBytes)
+	equipmentUc := usecase.NewEquipmentUsecase(equipmentRepo)
+	collectionUc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, mediaMaxBytes, reviewers)
+	pantryUc := usecase.NewPantryUsecase(pantryRepo, recipeRepo, ingredientRepo, ingredientUnitRepo, reviewers)
+	cookLogUc := usecase.NewCookLogUsecase(cookLogRepo, recipeRepo, mediaStorage, mediaMaxBytes, reviewers)
 
-	recipeUc := usecase.NewRecipeUsecase(recipeRepo, recipeIngredientRepo)
+	cardTemplates, err := cookbookRest.NewRecipeCardTemplates(templatesDir)
+	if err != nil {
+		return nil, err
+	}
 
-	return cookbookRest.NewCookbookHandler(cookbookUc, ingredientUc, recipeUc)
+	return cookbookRest.NewCookbookHandler(cookbookUc, ingredientUc, recipeUc, nutritionUc, costUc, substitutionUc, trashUc, tagUc, mediaUc, equipmentUc, collectionUc, pantryUc, cookLogUc, cardTemplates), nil
 }
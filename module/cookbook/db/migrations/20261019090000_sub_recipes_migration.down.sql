@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_recipe_ingredients_sub_recipe_id_is_deleted;
+
+ALTER TABLE recipe_ingredients
+    DROP CONSTRAINT IF EXISTS chk_recipe_ingredients_ingredient_or_sub_recipe,
+    DROP COLUMN IF EXISTS sub_recipe_id;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS servings,
+    DROP COLUMN IF EXISTS yield_amount,
+    DROP COLUMN IF EXISTS yield_unit_name;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE recipes
+    ADD COLUMN servings         int             NOT NULL DEFAULT 1,
+    ADD COLUMN yield_amount     decimal         NULL,
+    ADD COLUMN yield_unit_name  varchar(64)     NULL;
+
+ALTER TABLE recipe_ingredients
+    ALTER COLUMN ingredient_id DROP NOT NULL,
+    ADD COLUMN sub_recipe_id    bigint          NULL REFERENCES recipes,
+    ADD CONSTRAINT chk_recipe_ingredients_ingredient_or_sub_recipe CHECK ((ingredient_id IS NULL) <> (sub_recipe_id IS NULL));
+
+CREATE INDEX idx_recipe_ingredients_sub_recipe_id_is_deleted ON recipe_ingredients(sub_recipe_id, is_deleted);
+
+COMMIT;
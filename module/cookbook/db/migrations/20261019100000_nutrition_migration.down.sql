@@ -0,0 +1,20 @@
+BEGIN;
+
+DROP TABLE IF EXISTS ingredient_unit_conversions;
+
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS base_unit_name,
+    DROP COLUMN IF EXISTS kcal,
+    DROP COLUMN IF EXISTS protein_g,
+    DROP COLUMN IF EXISTS fat_g,
+    DROP COLUMN IF EXISTS carbs_g,
+    DROP COLUMN IF EXISTS fiber_g,
+    DROP COLUMN IF EXISTS sodium_mg;
+
+DELETE FROM ingredient_units WHERE name IN ('gram', 'kg', 'ml', 'liter', 'sdm', 'sdt') AND created_by = 'Naufal';
+
+ALTER TABLE ingredient_units
+    DROP COLUMN IF EXISTS base_unit_name,
+    DROP COLUMN IF EXISTS base_factor;
+
+COMMIT;
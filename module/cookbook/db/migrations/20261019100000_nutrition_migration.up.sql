@@ -0,0 +1,40 @@
+BEGIN;
+
+ALTER TABLE ingredient_units
+    ADD COLUMN base_unit_name   varchar(16)     NULL,
+    ADD COLUMN base_factor      decimal         NULL;
+
+INSERT INTO ingredient_units (name, base_unit_name, base_factor, created_by)
+VALUES
+    ('gram', 'g', 1, 'Naufal'),
+    ('kg', 'g', 1000, 'Naufal'),
+    ('ml', 'ml', 1, 'Naufal'),
+    ('liter', 'ml', 1000, 'Naufal'),
+    ('sdm', 'ml', 15, 'Naufal'),
+    ('sdt', 'ml', 5, 'Naufal');
+
+-- nutrition values are per 100 base units of the ingredient, e.g. per 100 g
+ALTER TABLE ingredients
+    ADD COLUMN base_unit_name   varchar(16)     NULL,
+    ADD COLUMN kcal             decimal         NULL,
+    ADD COLUMN protein_g        decimal         NULL,
+    ADD COLUMN fat_g            decimal         NULL,
+    ADD COLUMN carbs_g          decimal         NULL,
+    ADD COLUMN fiber_g          decimal         NULL,
+    ADD COLUMN sodium_mg        decimal         NULL;
+
+CREATE TABLE IF NOT EXISTS ingredient_unit_conversions (
+    id                  bigserial       PRIMARY KEY,
+    ingredient_id       int             NOT NULL REFERENCES ingredients,
+    ingredient_unit_id  int             NOT NULL REFERENCES ingredient_units,
+    base_amount         decimal         NOT NULL,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    updated_at          timestamp       NULL,
+    updated_by          varchar(64)     NULL,
+    is_deleted          boolean         NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX idx_ingredient_unit_conversions_ingredient_id_ingredient_unit_id ON ingredient_unit_conversions(ingredient_id, ingredient_unit_id);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS ingredient_prices (
+    id                  bigserial       PRIMARY KEY,
+    ingredient_id       int             NOT NULL REFERENCES ingredients,
+    ingredient_unit_id  int             NOT NULL REFERENCES ingredient_units,
+    quantity            decimal         NOT NULL DEFAULT 1,
+    price               decimal         NOT NULL,
+    effective_at        date            NOT NULL DEFAULT CURRENT_DATE,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    updated_at          timestamp       NULL,
+    updated_by          varchar(64)     NULL,
+    is_deleted          boolean         NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_ingredient_prices_ingredient_id_effective_at_is_deleted ON ingredient_prices(ingredient_id, effective_at, is_deleted);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_ingredients_allergens;
+
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS allergens,
+    DROP COLUMN IF EXISTS diets;
+
+COMMIT;
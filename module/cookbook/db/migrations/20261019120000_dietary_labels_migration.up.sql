@@ -0,0 +1,11 @@
+BEGIN;
+
+-- allergens and diets are free of duplicates and validated by the service,
+-- e.g. allergens = '{peanut,soy}' and diets = '{vegetarian,vegan,halal}'
+ALTER TABLE ingredients
+    ADD COLUMN allergens    varchar(32)[]   NOT NULL DEFAULT '{}',
+    ADD COLUMN diets        varchar(32)[]   NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_ingredients_allergens ON ingredients USING GIN (allergens);
+
+COMMIT;
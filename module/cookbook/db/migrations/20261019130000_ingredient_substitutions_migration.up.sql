@@ -0,0 +1,22 @@
+BEGIN;
+
+-- an amount of ingredient_id can be replaced by ratio times the amount of substitute_ingredient_id,
+-- in ingredient_unit_name or in the unit of the recipe when it is empty
+CREATE TABLE IF NOT EXISTS ingredient_substitutions (
+    id                          bigserial       PRIMARY KEY,
+    ingredient_id               int             NOT NULL REFERENCES ingredients,
+    substitute_ingredient_id    int             NOT NULL REFERENCES ingredients,
+    ratio                       decimal         NOT NULL DEFAULT 1,
+    ingredient_unit_name        varchar(16)     NULL,
+    notes                       text            NULL,
+    created_at                  timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by                  varchar(64)     NOT NULL,
+    updated_at                  timestamp       NULL,
+    updated_by                  varchar(64)     NULL,
+    is_deleted                  boolean         NOT NULL DEFAULT FALSE,
+    CONSTRAINT ingredient_substitutions_different_ingredients CHECK (ingredient_id <> substitute_ingredient_id)
+);
+
+CREATE UNIQUE INDEX idx_ingredient_substitutions_ingredient_id_substitute_ingredient_id ON ingredient_substitutions(ingredient_id, substitute_ingredient_id) WHERE is_deleted = false;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE IF EXISTS ingredient_aliases;
+
+DROP INDEX IF EXISTS idx_ingredients_normalized_name;
+
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS normalized_name;
+
+COMMIT;
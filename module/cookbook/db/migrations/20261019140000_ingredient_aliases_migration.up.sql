@@ -0,0 +1,27 @@
+BEGIN;
+
+-- normalized_name is the lowercased name without diacritics and punctuation, e.g. "Bawang  Merah," becomes "bawang merah".
+-- It is written by the service, the backfill below does not strip diacritics
+ALTER TABLE ingredients
+    ADD COLUMN normalized_name  varchar(128)    NULL;
+
+UPDATE ingredients SET normalized_name = btrim(regexp_replace(lower(name), '[^[:alnum:]]+', ' ', 'g'));
+
+CREATE INDEX idx_ingredients_normalized_name ON ingredients(normalized_name);
+
+CREATE TABLE IF NOT EXISTS ingredient_aliases (
+    id                  bigserial       PRIMARY KEY,
+    ingredient_id       int             NOT NULL REFERENCES ingredients,
+    name                varchar(128)    NOT NULL,
+    normalized_name     varchar(128)    NOT NULL,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    updated_at          timestamp       NULL,
+    updated_by          varchar(64)     NULL,
+    is_deleted          boolean         NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX idx_ingredient_aliases_normalized_name ON ingredient_aliases(normalized_name) WHERE is_deleted = false;
+CREATE INDEX idx_ingredient_aliases_ingredient_id ON ingredient_aliases(ingredient_id);
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_recipe_ingredients_deleted_at;
+DROP INDEX IF EXISTS idx_recipes_deleted_at;
+DROP INDEX IF EXISTS idx_ingredient_units_deleted_at;
+DROP INDEX IF EXISTS idx_ingredients_deleted_at;
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+
+ALTER TABLE recipe_ingredients
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE ingredient_units
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
@@ -0,0 +1,40 @@
+BEGIN;
+
+-- deleted_at is when a row was soft-deleted, it drives the trash retention.
+-- Rows deleted before this migration take their last update time
+ALTER TABLE categories
+    ADD COLUMN deleted_at   timestamp   NULL;
+
+ALTER TABLE ingredients
+    ADD COLUMN deleted_at   timestamp   NULL;
+
+ALTER TABLE ingredient_units
+    ADD COLUMN deleted_at   timestamp   NULL;
+
+ALTER TABLE recipes
+    ADD COLUMN deleted_at   timestamp   NULL;
+
+ALTER TABLE recipe_ingredients
+    ADD COLUMN deleted_at   timestamp   NULL;
+
+UPDATE categories SET deleted_at = coalesce(updated_at, created_at) WHERE is_deleted = true;
+UPDATE ingredients SET deleted_at = coalesce(updated_at, created_at) WHERE is_deleted = true;
+UPDATE ingredient_units SET deleted_at = coalesce(updated_at, created_at) WHERE is_deleted = true;
+UPDATE recipes SET deleted_at = coalesce(updated_at, created_at) WHERE is_deleted = true;
+UPDATE recipe_ingredients SET deleted_at = coalesce(updated_at, created_at) WHERE is_deleted = true;
+
+-- ingredient rows of recipes deleted before this migration are deleted along with their recipe
+UPDATE recipe_ingredients ri
+SET is_deleted = true, deleted_at = r.deleted_at, updated_at = r.deleted_at, updated_by = r.updated_by
+FROM recipes r
+WHERE r.id = ri.recipe_id
+AND r.is_deleted = true
+AND ri.is_deleted = false;
+
+CREATE INDEX idx_categories_deleted_at ON categories(deleted_at) WHERE is_deleted = true;
+CREATE INDEX idx_ingredients_deleted_at ON ingredients(deleted_at) WHERE is_deleted = true;
+CREATE INDEX idx_ingredient_units_deleted_at ON ingredient_units(deleted_at) WHERE is_deleted = true;
+CREATE INDEX idx_recipes_deleted_at ON recipes(deleted_at) WHERE is_deleted = true;
+CREATE INDEX idx_recipe_ingredients_deleted_at ON recipe_ingredients(deleted_at) WHERE is_deleted = true;
+
+COMMIT;
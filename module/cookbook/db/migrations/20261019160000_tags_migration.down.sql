@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_recipes_cuisine;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS cuisine;
+
+DROP TABLE IF EXISTS recipe_tags;
+
+DROP TABLE IF EXISTS tags;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tags (
+    id                  bigserial       PRIMARY KEY,
+    name                varchar(64)     NOT NULL,
+    normalized_name     varchar(64)     NOT NULL,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    updated_at          timestamp       NULL,
+    updated_by          varchar(64)     NULL,
+    is_deleted          boolean         NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX idx_tags_normalized_name ON tags(normalized_name) WHERE is_deleted = false;
+
+CREATE TABLE IF NOT EXISTS recipe_tags (
+    recipe_id           int             NOT NULL REFERENCES recipes,
+    tag_id              bigint          NOT NULL REFERENCES tags,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    PRIMARY KEY (recipe_id, tag_id)
+);
+
+CREATE INDEX idx_recipe_tags_tag_id ON recipe_tags(tag_id);
+
+-- cuisine is a free text region of a recipe, e.g. "Padang" or "Jawa Tengah"
+ALTER TABLE recipes
+    ADD COLUMN cuisine  varchar(64)     NULL;
+
+CREATE INDEX idx_recipes_cuisine ON recipes(lower(cuisine));
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- parent_id makes categories a tree, e.g. "Main Course > Rice Dishes > Fried Rice". Root categories have no parent
+ALTER TABLE categories
+    ADD COLUMN parent_id    int     NULL REFERENCES categories;
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id) WHERE is_deleted = false;
+
+COMMIT;
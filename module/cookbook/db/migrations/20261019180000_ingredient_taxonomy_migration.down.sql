@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_ingredients_parent_id;
+DROP INDEX IF EXISTS idx_ingredients_kind_id;
+
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS kind_id;
+
+DROP TABLE IF EXISTS ingredient_kinds;
+
+COMMIT;
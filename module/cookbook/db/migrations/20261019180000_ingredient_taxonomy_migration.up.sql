@@ -0,0 +1,36 @@
+BEGIN;
+
+-- ingredient_kinds classify ingredients, e.g. "Produce > Bumbu dapur". aisle is the store aisle used to group shopping items,
+-- a kind without aisle uses the aisle of its nearest parent
+CREATE TABLE IF NOT EXISTS ingredient_kinds (
+    id          serial      PRIMARY KEY,
+    name        varchar(64) NOT NULL,
+    parent_id   int         NULL REFERENCES ingredient_kinds,
+    aisle       varchar(64) NULL,
+    created_at  timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by  varchar(64) NOT NULL,
+    updated_at  timestamp   NULL,
+    updated_by  varchar(64) NULL,
+    is_deleted  boolean     NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_ingredient_kinds_parent_id ON ingredient_kinds(parent_id) WHERE is_deleted = false;
+
+INSERT INTO ingredient_kinds (name, aisle, created_by)
+VALUES
+    ('Produce', 'Sayur & buah', 'Naufal'),
+    ('Protein', 'Daging & ikan', 'Naufal'),
+    ('Spice', 'Bumbu', 'Naufal'),
+    ('Dairy', 'Susu & telur', 'Naufal'),
+    ('Grain', 'Beras & tepung', 'Naufal'),
+    ('Oil & sauce', 'Minyak & saus', 'Naufal');
+
+-- kind_id classifies an ingredient, parent_id nests it under a more generic ingredient, e.g. "Bawang merah" under "Bawang"
+ALTER TABLE ingredients
+    ADD COLUMN kind_id      int     NULL REFERENCES ingredient_kinds,
+    ADD COLUMN parent_id    int     NULL REFERENCES ingredients;
+
+CREATE INDEX idx_ingredients_kind_id ON ingredients(kind_id) WHERE is_deleted = false;
+CREATE INDEX idx_ingredients_parent_id ON ingredients(parent_id) WHERE is_deleted = false;
+
+COMMIT;
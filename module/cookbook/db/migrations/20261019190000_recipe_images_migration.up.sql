@@ -0,0 +1,22 @@
+BEGIN;
+
+-- step is null for a photo of the whole recipe and the 1-based step number for a step photo,
+-- the files themselves live in the media storage under file_key and thumbnail_key
+CREATE TABLE IF NOT EXISTS recipe_images (
+    id                  bigserial       PRIMARY KEY,
+    recipe_id           int             NOT NULL REFERENCES recipes,
+    step                int             NULL CHECK (step > 0),
+    caption             varchar(255)    NULL,
+    file_key            varchar(255)    NOT NULL,
+    thumbnail_key       varchar(255)    NOT NULL,
+    content_type        varchar(64)     NOT NULL,
+    size_bytes          bigint          NOT NULL,
+    width               int             NOT NULL,
+    height              int             NOT NULL,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL
+);
+
+CREATE INDEX idx_recipe_images_recipe_id ON recipe_images(recipe_id, step);
+
+COMMIT;
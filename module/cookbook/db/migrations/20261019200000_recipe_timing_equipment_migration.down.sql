@@ -0,0 +1,15 @@
+BEGIN;
+
+DROP TABLE IF EXISTS recipe_equipment;
+
+DROP TABLE IF EXISTS equipment;
+
+DROP INDEX IF EXISTS idx_recipes_total_minutes;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS total_minutes,
+    DROP COLUMN IF EXISTS prep_minutes,
+    DROP COLUMN IF EXISTS cook_minutes,
+    DROP COLUMN IF EXISTS difficulty;
+
+COMMIT;
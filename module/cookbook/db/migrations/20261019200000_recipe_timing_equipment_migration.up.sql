@@ -0,0 +1,45 @@
+BEGIN;
+
+-- total_minutes is derived from the prep and cook times so that it never drifts from them
+ALTER TABLE recipes
+    ADD COLUMN prep_minutes     int             NULL CHECK (prep_minutes >= 0),
+    ADD COLUMN cook_minutes     int             NULL CHECK (cook_minutes >= 0),
+    ADD COLUMN total_minutes    int             GENERATED ALWAYS AS (
+        CASE WHEN prep_minutes IS NULL AND cook_minutes IS NULL THEN NULL
+        ELSE coalesce(prep_minutes, 0) + coalesce(cook_minutes, 0) END
+    ) STORED,
+    ADD COLUMN difficulty       varchar(16)     NULL CHECK (difficulty IN ('easy', 'medium', 'hard'));
+
+CREATE INDEX idx_recipes_total_minutes ON recipes(total_minutes);
+
+CREATE TABLE IF NOT EXISTS equipment (
+    id          serial      PRIMARY KEY,
+    name        varchar(64) NOT NULL,
+    created_at  timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by  varchar(64) NOT NULL,
+    updated_at  timestamp   NULL,
+    updated_by  varchar(64) NULL,
+    is_deleted  boolean     NOT NULL DEFAULT FALSE
+);
+
+INSERT INTO equipment (name, created_by)
+VALUES
+    ('wajan', 'Naufal'),
+    ('oven', 'Naufal'),
+    ('blender', 'Naufal'),
+    ('panci', 'Naufal'),
+    ('kukusan', 'Naufal');
+
+CREATE INDEX idx_equipment_is_deleted ON equipment(is_deleted);
+
+CREATE TABLE IF NOT EXISTS recipe_equipment (
+    recipe_id           int             NOT NULL REFERENCES recipes,
+    equipment_id        int             NOT NULL REFERENCES equipment,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    PRIMARY KEY (recipe_id, equipment_id)
+);
+
+CREATE INDEX idx_recipe_equipment_equipment_id ON recipe_equipment(equipment_id);
+
+COMMIT;
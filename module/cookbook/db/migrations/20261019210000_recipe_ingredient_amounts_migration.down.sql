@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE recipe_ingredients
+    DROP CONSTRAINT IF EXISTS chk_recipe_ingredients_amount_denominator,
+    DROP CONSTRAINT IF EXISTS chk_recipe_ingredients_amount_range,
+    DROP COLUMN IF EXISTS optional,
+    DROP COLUMN IF EXISTS amount_denominator,
+    DROP COLUMN IF EXISTS amount_max;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- a NULL amount means "to taste", amount_max is the upper end of a range like "2-3 buah"
+-- and amount_denominator keeps the fraction an amount was written as, e.g. 3 for "1/3"
+ALTER TABLE recipe_ingredients
+    ADD COLUMN amount_max           decimal     NULL,
+    ADD COLUMN amount_denominator   smallint    NULL,
+    ADD COLUMN optional             boolean     NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT chk_recipe_ingredients_amount_range CHECK (amount_max IS NULL OR (amount IS NOT NULL AND amount_max >= amount)),
+    ADD CONSTRAINT chk_recipe_ingredients_amount_denominator CHECK (amount_denominator IS NULL OR amount_denominator > 1);
+
+COMMIT;
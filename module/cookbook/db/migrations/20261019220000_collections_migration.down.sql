@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS collection_recipes;
+DROP TABLE IF EXISTS collections;
+
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS collections (
+    id                      serial          PRIMARY KEY,
+    name                    varchar(128)    NOT NULL,
+    description             text            NULL,
+    cover_file_key          varchar(255)    NULL,
+    cover_thumbnail_key     varchar(255)    NULL,
+    created_at              timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by              varchar(64)     NOT NULL,
+    updated_at              timestamp       NULL,
+    updated_by              varchar(64)     NULL,
+    is_deleted              boolean         NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_collections_is_deleted ON collections(is_deleted);
+
+-- a deleted recipe keeps its row, so that the collection can flag the gap it leaves and show it again once restored
+CREATE TABLE IF NOT EXISTS collection_recipes (
+    collection_id       int             NOT NULL REFERENCES collections,
+    recipe_id           int             NOT NULL REFERENCES recipes,
+    position            int             NOT NULL,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL,
+    PRIMARY KEY (collection_id, recipe_id)
+);
+
+CREATE INDEX idx_collection_recipes_recipe_id ON collection_recipes(recipe_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_pantry_movements_append_only ON pantry_movements;
+DROP FUNCTION IF EXISTS reject_pantry_movement_change();
+DROP TABLE IF EXISTS pantry_movements;
+DROP TABLE IF EXISTS pantry_items;
+
+COMMIT;
@@ -0,0 +1,40 @@
+BEGIN;
+
+-- quantity is kept in the base unit of the ingredient and is the balance_after of its latest movement
+CREATE TABLE IF NOT EXISTS pantry_items (
+    ingredient_id           int             PRIMARY KEY REFERENCES ingredients,
+    quantity                decimal         NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    low_stock_threshold     decimal         NULL CHECK (low_stock_threshold >= 0),
+    updated_at              timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_by              varchar(64)     NOT NULL
+);
+
+-- recipe_id has no foreign key so that purging a cooked recipe keeps the ledger intact
+CREATE TABLE IF NOT EXISTS pantry_movements (
+    id                  serial          PRIMARY KEY,
+    ingredient_id       int             NOT NULL REFERENCES ingredients,
+    kind                varchar(16)     NOT NULL CHECK (kind IN ('purchase', 'cook', 'waste', 'adjustment')),
+    amount              decimal         NOT NULL,
+    unit_name           varchar(16)     NOT NULL,
+    base_amount         decimal         NOT NULL,
+    balance_after       decimal         NOT NULL CHECK (balance_after >= 0),
+    recipe_id           int             NULL,
+    notes               text            NULL,
+    created_at          timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by          varchar(64)     NOT NULL
+);
+
+CREATE INDEX idx_pantry_movements_ingredient_id ON pantry_movements(ingredient_id, id);
+
+-- the ledger is append-only, a mistake is corrected with an adjustment
+CREATE OR REPLACE FUNCTION reject_pantry_movement_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pantry_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pantry_movements_append_only
+    BEFORE UPDATE OR DELETE ON pantry_movements
+    FOR EACH ROW EXECUTE FUNCTION reject_pantry_movement_change();
+
+COMMIT;
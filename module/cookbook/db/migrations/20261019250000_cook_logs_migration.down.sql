@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TABLE IF EXISTS cook_logs;
+
+DROP INDEX IF EXISTS idx_recipes_average_rating;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS average_rating,
+    DROP COLUMN IF EXISTS rating_sum,
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS cook_count;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+-- the aggregates are kept by every cook log write, so listing recipes by rating never scans the logs
+ALTER TABLE recipes
+    ADD COLUMN cook_count       int             NOT NULL DEFAULT 0 CHECK (cook_count >= 0),
+    ADD COLUMN rating_count     int             NOT NULL DEFAULT 0 CHECK (rating_count >= 0),
+    ADD COLUMN rating_sum       int             NOT NULL DEFAULT 0 CHECK (rating_sum >= 0),
+    ADD COLUMN average_rating   decimal         GENERATED ALWAYS AS (
+        CASE WHEN rating_count = 0 THEN NULL ELSE rating_sum::decimal / rating_count END
+    ) STORED;
+
+CREATE INDEX idx_recipes_average_rating ON recipes(average_rating);
+
+-- cook logs are deleted for good rather than flagged with is_deleted and kept in the trash, like recipe_images:
+-- deleting a log removes its photo from the media storage and takes it out of the recipe aggregates at once,
+-- so a restored log would have lost its photo. They are still purged with their recipe
+CREATE TABLE IF NOT EXISTS cook_logs (
+    id                      serial          PRIMARY KEY,
+    recipe_id               int             NOT NULL REFERENCES recipes,
+    cooked_on               date            NOT NULL,
+    servings                int             NOT NULL CHECK (servings > 0),
+    rating                  smallint        NULL CHECK (rating BETWEEN 1 AND 5),
+    notes                   text            NULL,
+    photo_file_key          varchar(255)    NULL,
+    photo_thumbnail_key     varchar(255)    NULL,
+    created_at              timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by              varchar(64)     NOT NULL,
+    updated_at              timestamp       NULL,
+    updated_by              varchar(64)     NULL
+);
+
+CREATE INDEX idx_cook_logs_recipe_id ON cook_logs(recipe_id, cooked_on);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS recipe_status_changes;
+
+DROP INDEX IF EXISTS idx_recipes_status;
+
+ALTER TABLE recipes DROP COLUMN IF EXISTS status;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+-- recipes written before the workflow stay live, new recipes start as drafts
+ALTER TABLE recipes
+    ADD COLUMN status   varchar(16)     NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived'));
+
+ALTER TABLE recipes ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX idx_recipes_status ON recipes(status);
+
+CREATE TABLE IF NOT EXISTS recipe_status_changes (
+    id                      serial          PRIMARY KEY,
+    recipe_id               int             NOT NULL REFERENCES recipes,
+    action                  varchar(16)     NOT NULL,
+    from_status             varchar(16)     NOT NULL,
+    to_status               varchar(16)     NOT NULL,
+    comment                 text            NULL,
+    created_at              timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by              varchar(64)     NOT NULL
+);
+
+CREATE INDEX idx_recipe_status_changes_recipe_id ON recipe_status_changes(recipe_id, created_at);
+
+COMMIT;
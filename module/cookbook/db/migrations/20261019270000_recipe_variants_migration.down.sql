@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TABLE IF EXISTS recipe_fork_snapshot_ingredients;
+
+DROP TABLE IF EXISTS recipe_fork_snapshots;
+
+DROP INDEX IF EXISTS idx_recipes_variant_of_id;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS forked_at,
+    DROP COLUMN IF EXISTS variant_of_id;
+
+COMMIT;
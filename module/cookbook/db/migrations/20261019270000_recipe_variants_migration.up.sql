@@ -0,0 +1,43 @@
+BEGIN;
+
+-- a variant links to the recipe it was cloned from, the link never changes so the family tree has no cycles
+ALTER TABLE recipes
+    ADD COLUMN variant_of_id    int             NULL REFERENCES recipes,
+    ADD COLUMN forked_at        timestamp       NULL;
+
+CREATE INDEX idx_recipes_variant_of_id ON recipes(variant_of_id);
+
+-- the parent as it was when a variant was forked, the ingredients keep no foreign keys as they are history
+CREATE TABLE IF NOT EXISTS recipe_fork_snapshots (
+    recipe_id               int             PRIMARY KEY REFERENCES recipes,
+    name                    varchar(64)     NOT NULL,
+    description             varchar(255)    NULL,
+    category_id             int             NOT NULL,
+    servings                int             NOT NULL,
+    yield_amount            decimal         NULL,
+    yield_unit_name         varchar(64)     NULL,
+    cuisine                 varchar(64)     NULL,
+    prep_minutes            int             NULL,
+    cook_minutes            int             NULL,
+    difficulty              varchar(16)     NULL,
+    created_at              timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS recipe_fork_snapshot_ingredients (
+    id                      bigserial       PRIMARY KEY,
+    recipe_id               int             NOT NULL REFERENCES recipe_fork_snapshots,
+    ingredient_id           int             NULL,
+    sub_recipe_id           bigint          NULL,
+    ingredient_name         varchar(64)     NOT NULL,
+    ingredient_unit_name    varchar(64)     NOT NULL,
+    amount                  decimal         NULL,
+    amount_max              decimal         NULL,
+    amount_denominator      smallint        NULL,
+    optional                boolean         NOT NULL DEFAULT FALSE,
+    notes                   varchar(255)    NULL,
+    ordering_index          int             NOT NULL
+);
+
+CREATE INDEX idx_recipe_fork_snapshot_ingredients_recipe_id ON recipe_fork_snapshot_ingredients(recipe_id);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_ingredients_normalized_name;
+
+CREATE INDEX idx_ingredients_normalized_name ON ingredients(normalized_name);
+
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+
+-- folds like libtext.Normalize: lowercased, decomposed so that diacritics are stripped, e.g. "Crème  Brûlée," becomes
+-- "creme brulee", then punctuation and spaces collapsed. Only the rows still holding the first backfill are rewritten,
+-- the names written by the service are already folded
+UPDATE ingredients
+SET normalized_name = btrim(regexp_replace(regexp_replace(normalize(lower(name), NFD), '[\u0300-\u036f]+', '', 'g'), '[^[:alnum:]]+', ' ', 'g'))
+WHERE normalized_name IS NOT DISTINCT FROM btrim(regexp_replace(lower(name), '[^[:alnum:]]+', ' ', 'g'));
+
+-- aliases copied from merged ingredients may hold the first backfill too, an alias whose folded name is already
+-- taken by an older alias keeps its name as it is
+WITH folded AS (
+    SELECT id, btrim(regexp_replace(regexp_replace(normalize(lower(name), NFD), '[\u0300-\u036f]+', '', 'g'), '[^[:alnum:]]+', ' ', 'g')) AS normalized_name
+    FROM ingredient_aliases
+    WHERE is_deleted = false
+)
+UPDATE ingredient_aliases a SET normalized_name = f.normalized_name
+FROM folded f
+WHERE a.id = f.id
+AND a.normalized_name <> f.normalized_name
+AND NOT EXISTS (
+    select 1 from folded o
+    where o.normalized_name = f.normalized_name and o.id < f.id
+);
+
+-- live ingredients that turn out to share a name keep it on the oldest one only, so that the unique index can be built.
+-- The others are left without a normalized name until they are merged into it
+UPDATE ingredients i SET normalized_name = NULL
+WHERE i.is_deleted = false
+AND EXISTS (
+    select 1 from ingredients o
+    where o.is_deleted = false and o.normalized_name = i.normalized_name and o.id < i.id
+);
+
+DROP INDEX IF EXISTS idx_ingredients_normalized_name;
+
+CREATE UNIQUE INDEX idx_ingredients_normalized_name ON ingredients(normalized_name) WHERE NOT is_deleted;
+
+COMMIT;
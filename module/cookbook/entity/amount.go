@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"math"
+	"strconv"
+)
+
+// amountTolerance is how close an amount has to be to a fraction to be written as that fraction
+const amountTolerance = 1e-4
+
+// commonDenominators are tried when a fractional amount, e.g. a scaled one, no longer fits its own denominator
+var commonDenominators = []int64{2, 3, 4, 8}
+
+// FormatAmount writes an amount for display. An amount written as a fraction, which has a denominator,
+// is written as a fraction or a mixed number like "1 1/2" when it still is one, any other amount is written
+// with at most two decimals
+func FormatAmount(value float64, denominator int64) string {
+	whole := math.Floor(value)
+	frac := value - whole
+
+	if frac < amountTolerance || 1-frac < amountTolerance {
+		return strconv.FormatFloat(math.Round(value), 'f', -1, 64)
+	}
+
+	if denominator > 1 {
+		for _, d := range append([]int64{denominator}, commonDenominators...) {
+			n := int64(math.Round(frac * float64(d)))
+			if n == 0 || n >= d || math.Abs(frac-float64(n)/float64(d)) > amountTolerance {
+				continue
+			}
+
+			g := gcd(n, d)
+			text := strconv.FormatInt(n/g, 10) + "/" + strconv.FormatInt(d/g, 10)
+			if whole > 0 {
+				text = strconv.FormatFloat(whole, 'f', -1, 64) + " " + text
+			}
+
+			return text
+		}
+	}
+
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
@@ -8,13 +8,23 @@ import (
 // Categories is the plural form of Category
 type Categories []*Category
 
-// Category holds our category entity
+// Category holds our category entity, a root category has no ParentID
 type Category struct {
 	ID        uint64
 	Name      string
+	ParentID  uint64
 	CreatedAt time.Time
 	CreatedBy string
 	UpdatedAt null.Time
 	UpdatedBy null.String
 	IsDeleted bool
 }
+
+// CategoryNodes is the plural form of CategoryNode
+type CategoryNodes []*CategoryNode
+
+// CategoryNode is a category of the category tree with its sub-categories
+type CategoryNode struct {
+	Category
+	Children CategoryNodes
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// Collections is the plural form of Collection
+type Collections []*Collection
+
+// Collection is a user-curated cookbook, e.g. "Ramadan Menu 2026", holding an ordered list of recipes.
+// CoverFileKey and CoverThumbnailKey locate its cover image in the media storage, RecipeCount counts its live recipes
+// that anybody can see, or those the viewer can see in a collection detail
+type Collection struct {
+	ID                uint64
+	Name              string
+	Description       null.String
+	CoverFileKey      null.String
+	CoverThumbnailKey null.String
+	RecipeCount       int
+	CreatedAt         time.Time
+	CreatedBy         string
+	UpdatedAt         null.Time
+	UpdatedBy         null.String
+	IsDeleted         bool
+}
+
+// CollectionRecipes is the plural form of CollectionRecipe
+type CollectionRecipes []*CollectionRecipe
+
+// CollectionRecipe is a recipe at its position in a collection. A deleted recipe stays in the collection but is hidden,
+// the visible recipe after it has GapBefore set so that the gap is flagged. RecipeStatus and RecipeCreatedBy tell
+// whether the viewer can see the recipe
+type CollectionRecipe struct {
+	CollectionID    uint64
+	RecipeID        uint64
+	RecipeName      string
+	RecipeStatus    string
+	RecipeCreatedBy string
+	Position        int
+	GapBefore       bool
+	IsRecipeDeleted bool
+	CreatedAt       time.Time
+	CreatedBy       string
+}
+
+// CollectionDetail holds a collection with the recipes the viewer can see in order. HiddenRecipes counts its deleted
+// recipes and the drafts of others, TrailingGap flags hidden recipes after the last visible one
+type CollectionDetail struct {
+	Collection    *Collection
+	Recipes       CollectionRecipes
+	HiddenRecipes int
+	TrailingGap   bool
+}
+
+// CollectionExport holds a whole collection as a single document, its recipes in the order of the collection
+type CollectionExport struct {
+	Collection    *Collection
+	Recipes       []CollectionExportRecipe
+	HiddenRecipes int
+	TrailingGap   bool
+}
+
+// CollectionExportRecipe is a recipe of an exported collection, GapBefore flags deleted recipes hidden before it
+type CollectionExportRecipe struct {
+	Summary   RecipeSummary
+	GapBefore bool
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// CookLogs is the plural form of CookLog
+type CookLogs []*CookLog
+
+// CookLog is an "I cooked this" event of a recipe. Rating is from MinRating to MaxRating and can be left out,
+// PhotoFileKey and PhotoThumbnailKey locate its optional photo in the media storage
+type CookLog struct {
+	ID                uint64
+	RecipeID          uint64
+	CookedOn          time.Time
+	Servings          int
+	Rating            null.Int
+	Notes             null.String
+	PhotoFileKey      null.String
+	PhotoThumbnailKey null.String
+	CreatedAt         time.Time
+	CreatedBy         string
+	UpdatedAt         null.Time
+	UpdatedBy         null.String
+}
@@ -0,0 +1,46 @@
+package entity
+
+const (
+	AllergenPeanut    = "peanut"
+	AllergenTreeNut   = "tree_nut"
+	AllergenShellfish = "shellfish"
+	AllergenFish      = "fish"
+	AllergenGluten    = "gluten"
+	AllergenDairy     = "dairy"
+	AllergenEgg       = "egg"
+	AllergenSoy       = "soy"
+	AllergenSesame    = "sesame"
+)
+
+const (
+	DietVegetarian = "vegetarian"
+	DietVegan      = "vegan"
+	DietHalal      = "halal"
+)
+
+// Allergens are the allergens an ingredient can be tagged with
+var Allergens = []string{
+	AllergenPeanut,
+	AllergenTreeNut,
+	AllergenShellfish,
+	AllergenFish,
+	AllergenGluten,
+	AllergenDairy,
+	AllergenEgg,
+	AllergenSoy,
+	AllergenSesame,
+}
+
+// Diets are the dietary flags an ingredient can be tagged with
+var Diets = []string{
+	DietVegetarian,
+	DietVegan,
+	DietHalal,
+}
+
+// DietaryLabels holds labels of a recipe computed from its ingredients.
+// Allergens is the union of the ingredient allergens and Diets is the intersection of the ingredient diets
+type DietaryLabels struct {
+	Allergens []string
+	Diets     []string
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// Equipments is the plural form of Equipment
+type Equipments []*Equipment
+
+// Equipment holds a kitchen tool a recipe needs, e.g. "wajan" or "oven"
+type Equipment struct {
+	ID        uint64
+	Name      string
+	CreatedAt time.Time
+	CreatedBy string
+	UpdatedAt null.Time
+	UpdatedBy null.String
+	IsDeleted bool
+}
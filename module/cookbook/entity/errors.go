@@ -3,8 +3,70 @@ package entity
 import "github.com/tlab-backend-test-naufal/cookbook-management/internal/liberr"
 
 var (
-	ErrCategoryNotFound       = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_CATEGORY-NOT-FOUND", "Category is not found")
-	ErrIngredientNotFound     = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-NOT-FOUND", "Ingredient is not found")
-	ErrIngredientUnitNotFound = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-UNIT-NOT-FOUND", "Ingredient unit is not found")
-	ErrRecipeNotFound         = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-UNIT-NOT-FOUND", "Recipe is not found")
+	ErrCategoryNotFound                 = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_CATEGORY-NOT-FOUND", "Category is not found")
+	ErrIngredientNotFound               = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-NOT-FOUND", "Ingredient is not found")
+	ErrIngredientUnitNotFound           = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-UNIT-NOT-FOUND", "Ingredient unit is not found")
+	ErrRecipeNotFound                   = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-UNIT-NOT-FOUND", "Recipe is not found")
+	ErrRecipeIngredientNotFound         = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-INGREDIENT-NOT-FOUND", "Recipe ingredient is not found")
+	ErrRecipeCycle                      = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-CYCLE", "Recipe cannot include itself as a sub-recipe")
+	ErrIngredientUnitConversionNotFound = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-UNIT-CONVERSION-NOT-FOUND", "Ingredient unit conversion is not found")
+	ErrIngredientPriceNotFound          = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-PRICE-NOT-FOUND", "Ingredient price is not found")
+	ErrIngredientSubstitutionNotFound   = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-SUBSTITUTION-NOT-FOUND", "Ingredient substitution is not found")
+	ErrIngredientNameConflict           = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-NAME-CONFLICT", "Ingredient name or alias is already used")
+	ErrIngredientAliasNotFound          = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-ALIAS-NOT-FOUND", "Ingredient alias is not found")
+	ErrInvalidIngredientMerge           = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-INGREDIENT-MERGE", "Duplicates must be other existing ingredients")
+	ErrInvalidAllergen                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-ALLERGEN", "Allergen is not supported")
+	ErrInvalidDiet                      = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-DIET", "Diet is not supported")
+	ErrCategoryInUse                    = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_CATEGORY-IN-USE", "Category is still used by recipes")
+	ErrIngredientInUse                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-IN-USE", "Ingredient is still used by recipes")
+	ErrRecipeInUse                      = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-IN-USE", "Recipe is still used as a sub-recipe by recipes")
+	ErrIngredientUnitInUse              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-UNIT-IN-USE", "Ingredient unit is still used by recipes")
+	ErrInvalidReassign                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-REASSIGN", "Reassign target must be another existing row")
+	ErrInvalidTrashType                 = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-TRASH-TYPE", "Trash type is not supported")
+	ErrTrashItemNotFound                = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_TRASH-ITEM-NOT-FOUND", "Deleted record is not found")
+	ErrTrashSubRecipeDeleted            = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_TRASH-SUB-RECIPE-DELETED", "Record uses a deleted sub-recipe, restore it first")
+	ErrTrashParentDeleted               = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_TRASH-PARENT-DELETED", "Record belongs to a deleted recipe, category or parent ingredient, restore it first")
+	ErrTagNotFound                      = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_TAG-NOT-FOUND", "Tag is not found")
+	ErrTagNameConflict                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_TAG-NAME-CONFLICT", "Tag name is already used")
+	ErrCategoryCycle                    = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_CATEGORY-CYCLE", "Category cannot be its own parent or ancestor")
+	ErrCategoryHasChildren              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_CATEGORY-HAS-CHILDREN", "Category still has sub-categories")
+	ErrIngredientKindNotFound           = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-KIND-NOT-FOUND", "Ingredient kind is not found")
+	ErrIngredientKindInUse              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-KIND-IN-USE", "Ingredient kind still has ingredients or sub-kinds")
+	ErrIngredientKindCycle              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-KIND-CYCLE", "Ingredient kind cannot be its own parent or ancestor")
+	ErrIngredientCycle                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-CYCLE", "Ingredient cannot be its own parent or ancestor")
+	ErrRecipeImageNotFound              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-IMAGE-NOT-FOUND", "Recipe image is not found")
+	ErrInvalidRecipeStep                = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RECIPE-STEP", "Recipe step must be a positive number")
+	ErrUnsupportedMediaType             = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_UNSUPPORTED-MEDIA-TYPE", "Image must be a JPEG, PNG or GIF")
+	ErrMediaTooLarge                    = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_MEDIA-TOO-LARGE", "Image exceeds the upload size limit")
+	ErrMediaNotFound                    = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_MEDIA-NOT-FOUND", "Media file is not found")
+	ErrEquipmentNotFound                = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_EQUIPMENT-NOT-FOUND", "Equipment is not found")
+	ErrInvalidDifficulty                = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-DIFFICULTY", "Difficulty must be easy, medium or hard")
+	ErrInvalidDuration                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-DURATION", "Durations cannot be negative")
+	ErrInvalidRecipeDocument            = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RECIPE-DOCUMENT", "Document does not contain a schema.org Recipe")
+	ErrIngredientLineNotMatched         = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-LINE-NOT-MATCHED", "Ingredient line does not clearly match an ingredient, check it with the parse endpoint")
+	ErrInvalidAmount                    = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-AMOUNT", "Amount must not be negative, a range must not end below its start, and an amount to taste or a sub-recipe amount cannot be a range")
+	ErrCollectionNotFound               = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_COLLECTION-NOT-FOUND", "Collection is not found")
+	ErrCollectionRecipeNotFound         = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_COLLECTION-RECIPE-NOT-FOUND", "Recipe is not in the collection")
+	ErrCollectionRecipeExists           = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_COLLECTION-RECIPE-EXISTS", "Recipe is already in the collection")
+	ErrInvalidCollectionOrder           = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-COLLECTION-ORDER", "Order must list every visible recipe of the collection exactly once")
+	ErrInvalidPantryMovement            = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-PANTRY-MOVEMENT", "Movement must be a purchase or waste of a positive amount, or an adjustment of a non-zero amount")
+	ErrInsufficientStock                = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INSUFFICIENT-STOCK", "Not enough of the ingredient is on hand")
+	ErrPantryUnitNotConvertible         = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_PANTRY-UNIT-NOT-CONVERTIBLE", "Amount cannot be converted to the base unit of the ingredient")
+	ErrInvalidLowStockThreshold         = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-LOW-STOCK-THRESHOLD", "Low-stock threshold must not be negative")
+	ErrInvalidCookMultiplier            = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-COOK-MULTIPLIER", "Multiplier must be a positive number")
+	ErrCookLogNotFound                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_COOK-LOG-NOT-FOUND", "Cook log is not found")
+	ErrInvalidRating                    = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RATING", "Rating must be from 1 to 5")
+	ErrInvalidServings                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-SERVINGS", "Servings must be a positive number")
+	ErrInvalidRecipeSort                = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RECIPE-SORT", "Sort must be one of id, rating or cook_count, prefixed with - to sort descending")
+	ErrInvalidRecipeAction              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RECIPE-ACTION", "Action must be one of submit, approve, reject, publish or archive")
+	ErrInvalidRecipeTransition          = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RECIPE-TRANSITION", "Action is not allowed in the current status of the recipe")
+	ErrRecipeTransitionForbidden        = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-TRANSITION-FORBIDDEN", "Action is reserved to reviewers, or to the author and reviewers")
+	ErrReviewCommentRequired            = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_REVIEW-COMMENT-REQUIRED", "Rejecting a recipe requires a comment")
+	ErrInvalidRecipeStatus              = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-RECIPE-STATUS", "Status must be one of draft, in_review, published or archived")
+	ErrRecipeStatusFilterForbidden      = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-STATUS-FILTER-FORBIDDEN", "Listing drafts and recipes in review requires an actor")
+	ErrRecipeNotVariant                 = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-NOT-VARIANT", "Recipe is not a variant of another recipe")
+	ErrIngredientMergeUnitMismatch      = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INGREDIENT-MERGE-UNIT-MISMATCH", "Duplicates must have the same base unit as the ingredient")
+	ErrRecipeIngredientNameSnapshot     = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_RECIPE-INGREDIENT-NAME-SNAPSHOT", "Names cannot be repaired under the snapshot rename policy, recipes keep the names they were written with")
+	ErrInvalidCookedOn                  = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_INVALID-COOKED-ON", "Cooked on cannot be after today")
+	ErrSubRecipeUnitMismatch            = liberr.NewErrorDetails("COOKBOOK_COOKBOOK-MANAGEMENT_SUB-RECIPE-UNIT-MISMATCH", "Sub-recipe amount must be in servings or the sub-recipe yield unit")
 )
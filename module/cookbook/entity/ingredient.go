@@ -9,13 +9,22 @@ import (
 // Ingredients is the plural form of Ingredient
 type Ingredients []*Ingredient
 
-// Ingredient holds our ingredient entity
+// Ingredient holds our ingredient entity. KindID classifies it and ParentID nests it under a more generic ingredient,
+// Aisle is the aisle of its kind or of the nearest parent kind having one
 type Ingredient struct {
-	ID        uint64
-	Name      string
-	CreatedAt time.Time
-	CreatedBy string
-	UpdatedAt null.Time
-	UpdatedBy null.String
-	IsDeleted bool
+	ID           uint64
+	Name         string
+	KindID       uint64
+	ParentID     uint64
+	Aisle        null.String
+	Aliases      []string
+	BaseUnitName null.String
+	Nutrition    Nutrition
+	Allergens    []string
+	Diets        []string
+	CreatedAt    time.Time
+	CreatedBy    string
+	UpdatedAt    null.Time
+	UpdatedBy    null.String
+	IsDeleted    bool
 }
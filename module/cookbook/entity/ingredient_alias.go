@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// IngredientAliases is the plural form of IngredientAlias
+type IngredientAliases []*IngredientAlias
+
+// IngredientAlias holds another name of an ingredient, e.g. "Telor" for "Telur"
+type IngredientAlias struct {
+	ID             uint64
+	IngredientID   uint64
+	Name           string
+	NormalizedName string
+	CreatedAt      time.Time
+	CreatedBy      string
+	UpdatedAt      null.Time
+	UpdatedBy      null.String
+	IsDeleted      bool
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// IngredientKinds is the plural form of IngredientKind
+type IngredientKinds []*IngredientKind
+
+// IngredientKind classifies ingredients, e.g. produce, protein or spice. Kinds can be nested under a ParentID,
+// and Aisle is the store aisle of the kind's ingredients
+type IngredientKind struct {
+	ID        uint64
+	Name      string
+	ParentID  uint64
+	Aisle     null.String
+	CreatedAt time.Time
+	CreatedBy string
+	UpdatedAt null.Time
+	UpdatedBy null.String
+	IsDeleted bool
+}
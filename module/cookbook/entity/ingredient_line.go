@@ -0,0 +1,30 @@
+package entity
+
+// ParsedIngredientLines is the plural form of ParsedIngredientLine
+type ParsedIngredientLines []*ParsedIngredientLine
+
+// ParsedIngredientLine holds a free-text ingredient line with its candidate readings, best candidate first
+type ParsedIngredientLine struct {
+	Line       string
+	Candidates ParsedIngredientCandidates
+}
+
+// ParsedIngredientCandidates is the plural form of ParsedIngredientCandidate
+type ParsedIngredientCandidates []*ParsedIngredientCandidate
+
+// ParsedIngredientCandidate is a reading of an ingredient line matched to an ingredient. AmountMax is the upper end
+// of a range, AmountDenominator the denominator of an amount written as a fraction, and UnitMatched is false when
+// the unit is not one of the ingredient units. Confidence is between 0 and 1
+type ParsedIngredientCandidate struct {
+	Amount             float64
+	AmountMax          float64
+	AmountDenominator  int
+	ToTaste            bool
+	Optional           bool
+	IngredientID       uint64
+	IngredientName     string
+	IngredientUnitName string
+	UnitMatched        bool
+	Notes              string
+	Confidence         float64
+}
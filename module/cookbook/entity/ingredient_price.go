@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// IngredientPrices is the plural form of IngredientPrice
+type IngredientPrices []*IngredientPrice
+
+// IngredientPrice holds the price of a quantity of an ingredient from its effective date, e.g. 35000 per 1 kg
+type IngredientPrice struct {
+	ID                 uint64
+	IngredientID       uint64
+	IngredientUnitID   uint64
+	IngredientUnitName string
+	Quantity           float64
+	Price              float64
+	EffectiveAt        time.Time
+	CreatedAt          time.Time
+	CreatedBy          string
+	UpdatedAt          null.Time
+	UpdatedBy          null.String
+	IsDeleted          bool
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// IngredientSubstitutions is the plural form of IngredientSubstitution
+type IngredientSubstitutions []*IngredientSubstitution
+
+// IngredientSubstitution holds a substitute of an ingredient, e.g. 1 ikat sayur cesim can be replaced by 1 ikat pakcoy.
+// An amount of the ingredient is replaced by Ratio times the amount of the substitute,
+// in IngredientUnitName or in the recipe unit when it is empty
+type IngredientSubstitution struct {
+	ID                       uint64
+	IngredientID             uint64
+	IngredientName           string
+	SubstituteIngredientID   uint64
+	SubstituteIngredientName string
+	Ratio                    float64
+	IngredientUnitName       null.String
+	Notes                    null.String
+	CreatedAt                time.Time
+	CreatedBy                string
+	UpdatedAt                null.Time
+	UpdatedBy                null.String
+	IsDeleted                bool
+}
+
+// SubstitutionSuggestions is the plural form of SubstitutionSuggestion
+type SubstitutionSuggestions []*SubstitutionSuggestion
+
+// SubstitutionSuggestion holds the substitutes of a recipe ingredient that is missing from the pantry
+type SubstitutionSuggestion struct {
+	IngredientID       uint64
+	IngredientName     string
+	IngredientUnitName string
+	Amount             null.Float
+	Substitutes        SubstituteOptions
+}
+
+// SubstituteOptions is the plural form of SubstituteOption
+type SubstituteOptions []*SubstituteOption
+
+// SubstituteOption is a substitute with the amount needed by the recipe
+type SubstituteOption struct {
+	Substitution       *IngredientSubstitution
+	IngredientUnitName string
+	Amount             null.Float
+	IsAvailable        bool
+}
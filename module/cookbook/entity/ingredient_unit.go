@@ -10,11 +10,13 @@ type IngredientUnits []*IngredientUnit
 
 // IngredientUnit holds our ingredient unit entity
 type IngredientUnit struct {
-	ID        uint64
-	Name      string
-	CreatedAt time.Time
-	CreatedBy string
-	UpdatedAt null.Time
-	UpdatedBy null.String
-	IsDeleted bool
+	ID           uint64
+	Name         string
+	BaseUnitName null.String
+	BaseFactor   null.Float
+	CreatedAt    time.Time
+	CreatedBy    string
+	UpdatedAt    null.Time
+	UpdatedBy    null.String
+	IsDeleted    bool
 }
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// IngredientUnitConversions is the plural form of IngredientUnitConversion
+type IngredientUnitConversions []*IngredientUnitConversion
+
+// IngredientUnitConversion holds how many base units of an ingredient one unit is, e.g. 1 siung bawang putih is 5 g
+type IngredientUnitConversion struct {
+	ID                 uint64
+	IngredientID       uint64
+	IngredientUnitID   uint64
+	IngredientUnitName string
+	BaseAmount         float64
+	CreatedAt          time.Time
+	CreatedBy          string
+	UpdatedAt          null.Time
+	UpdatedBy          null.String
+	IsDeleted          bool
+}
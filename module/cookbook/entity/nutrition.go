@@ -0,0 +1,91 @@
+package entity
+
+import "github.com/guregu/null"
+
+const (
+	NutritionStatusOK                = "ok"
+	NutritionStatusPartialNutrition  = "partial_nutrition"
+	NutritionStatusMissingNutrition  = "missing_nutrition"
+	NutritionStatusUnconvertibleUnit = "unconvertible_unit"
+	NutritionStatusToTaste           = "to_taste"
+)
+
+// Nutrition holds nutrition values of an ingredient per 100 of its base unit, e.g. per 100 g
+type Nutrition struct {
+	Kcal    null.Float
+	Protein null.Float
+	Fat     null.Float
+	Carbs   null.Float
+	Fiber   null.Float
+	Sodium  null.Float
+}
+
+// IsEmpty returns true when no nutrition value is known
+func (n Nutrition) IsEmpty() bool {
+	return !n.Kcal.Valid && !n.Protein.Valid && !n.Fat.Valid && !n.Carbs.Valid && !n.Fiber.Valid && !n.Sodium.Valid
+}
+
+// IsComplete returns true when every nutrition value is known
+func (n Nutrition) IsComplete() bool {
+	return n.Kcal.Valid && n.Protein.Valid && n.Fat.Valid && n.Carbs.Valid && n.Fiber.Valid && n.Sodium.Valid
+}
+
+// NutritionFacts holds computed nutrition values, Sodium is in mg and the others except Kcal are in g
+type NutritionFacts struct {
+	Kcal    float64
+	Protein float64
+	Fat     float64
+	Carbs   float64
+	Fiber   float64
+	Sodium  float64
+}
+
+// Add sums two nutrition facts
+func (n NutritionFacts) Add(o NutritionFacts) NutritionFacts {
+	return NutritionFacts{
+		Kcal:    n.Kcal + o.Kcal,
+		Protein: n.Protein + o.Protein,
+		Fat:     n.Fat + o.Fat,
+		Carbs:   n.Carbs + o.Carbs,
+		Fiber:   n.Fiber + o.Fiber,
+		Sodium:  n.Sodium + o.Sodium,
+	}
+}
+
+// Scale multiplies every nutrition value by factor
+func (n NutritionFacts) Scale(factor float64) NutritionFacts {
+	return NutritionFacts{
+		Kcal:    n.Kcal * factor,
+		Protein: n.Protein * factor,
+		Fat:     n.Fat * factor,
+		Carbs:   n.Carbs * factor,
+		Fiber:   n.Fiber * factor,
+		Sodium:  n.Sodium * factor,
+	}
+}
+
+// RecipeIngredientNutritions is the plural form of RecipeIngredientNutrition
+type RecipeIngredientNutritions []*RecipeIngredientNutrition
+
+// RecipeIngredientNutrition holds the nutrition contribution of a recipe ingredient, the lower end of a range
+// is used and an ingredient to taste does not add any
+type RecipeIngredientNutrition struct {
+	IngredientID       uint64
+	IngredientName     string
+	IngredientUnitName string
+	Amount             null.Float
+	BaseAmount         null.Float
+	BaseUnitName       null.String
+	Facts              NutritionFacts
+	Status             string
+}
+
+// RecipeNutrition holds nutrition totals of a recipe
+type RecipeNutrition struct {
+	RecipeID    uint64
+	Servings    int
+	Total       NutritionFacts
+	PerServing  NutritionFacts
+	IsComplete  bool
+	Ingredients RecipeIngredientNutritions
+}
@@ -0,0 +1,26 @@
+package entity
+
+const (
+	NutritionImportStatusMatched   = "matched"
+	NutritionImportStatusAmbiguous = "ambiguous"
+	NutritionImportStatusUnmatched = "unmatched"
+)
+
+// NutritionImportCandidate is an ingredient that may correspond to a food composition entry
+type NutritionImportCandidate struct {
+	IngredientID   uint64
+	IngredientName string
+	Score          float64
+}
+
+// NutritionImportMatches is the plural form of NutritionImportMatch
+type NutritionImportMatches []*NutritionImportMatch
+
+// NutritionImportMatch holds a food composition entry with its candidate ingredients, best candidate first
+type NutritionImportMatch struct {
+	EntryName    string
+	BaseUnitName string
+	Nutrition    Nutrition
+	Status       string
+	Candidates   []NutritionImportCandidate
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+const (
+	PantryMovementPurchase   = "purchase"
+	PantryMovementCook       = "cook"
+	PantryMovementWaste      = "waste"
+	PantryMovementAdjustment = "adjustment"
+)
+
+const (
+	PantrySkipToTaste            = "to_taste"
+	PantrySkipOptional           = "optional"
+	PantrySkipUnitNotConvertible = "unit_not_convertible"
+	PantrySkipNotStocked         = "not_stocked"
+)
+
+// PantryItems is the plural form of PantryItem
+type PantryItems []*PantryItem
+
+// PantryItem holds the quantity of an ingredient on hand in the base unit of the ingredient.
+// The quantity is the balance after the latest movement of the ingredient
+type PantryItem struct {
+	IngredientID      uint64
+	IngredientName    string
+	BaseUnitName      string
+	Quantity          float64
+	LowStockThreshold null.Float
+	UpdatedAt         time.Time
+	UpdatedBy         string
+}
+
+// IsLowStock returns true when the quantity is at or below the low-stock threshold
+func (p PantryItem) IsLowStock() bool {
+	return p.LowStockThreshold.Valid && p.Quantity <= p.LowStockThreshold.Float64
+}
+
+// PantryMovements is the plural form of PantryMovement
+type PantryMovements []*PantryMovement
+
+// PantryMovement is an entry of the append-only stock ledger. Amount and UnitName are what was recorded,
+// BaseAmount is the signed change in the base unit and BalanceAfter the quantity on hand after it
+type PantryMovement struct {
+	ID             uint64
+	IngredientID   uint64
+	IngredientName string
+	Kind           string
+	Amount         float64
+	UnitName       string
+	BaseAmount     float64
+	BalanceAfter   float64
+	RecipeID       null.Int
+	Notes          null.String
+	CreatedAt      time.Time
+	CreatedBy      string
+}
+
+// PantryCook is the outcome of cooking a recipe from the pantry. Shortages are the ingredients there was not enough of,
+// they are used up, and Skipped are the ingredients that did not change the stock
+type PantryCook struct {
+	RecipeID   uint64
+	Multiplier float64
+	Movements  PantryMovements
+	Shortages  []PantryShortage
+	Skipped    []PantrySkip
+	LowStock   PantryItems
+}
+
+// PantryShortage holds how much of an ingredient a recipe needed and how much was on hand, in its base unit
+type PantryShortage struct {
+	IngredientID   uint64
+	IngredientName string
+	BaseUnitName   string
+	Needed         float64
+	OnHand         float64
+}
+
+// PantrySkip is an ingredient of a cooked recipe left untouched, Reason is one of the PantrySkip constants
+type PantrySkip struct {
+	IngredientID   uint64
+	IngredientName string
+	Reason         string
+}
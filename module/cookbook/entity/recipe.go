@@ -1,29 +1,79 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"github.com/guregu/null"
 )
 
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// Difficulties are the supported recipe difficulty levels
+var Difficulties = []string{
+	DifficultyEasy,
+	DifficultyMedium,
+	DifficultyHard,
+}
+
 // Recipes is the plural form of Recipe
 type Recipes []*Recipe
 
-// Recipe holds our recipe entity
+// Recipe holds our recipe entity, TotalMinutes is the sum of the prep and cook times.
+// CookCount, RatingCount and AverageRating aggregate the cook logs of the recipe and Status is one of RecipeStatuses.
+// VariantOfID is the recipe it was cloned from as a variant at ForkedAt
 type Recipe struct {
-	ID          uint64
-	Name        string
-	Description string
-	CategoryID  uint64
-	CreatedAt   time.Time
-	CreatedBy   string
-	UpdatedAt   null.Time
-	UpdatedBy   null.String
-	IsDeleted   bool
+	ID            uint64
+	Name          string
+	Description   string
+	CategoryID    uint64
+	Servings      int
+	YieldAmount   null.Float
+	YieldUnitName null.String
+	Cuisine       null.String
+	PrepMinutes   null.Int
+	CookMinutes   null.Int
+	TotalMinutes  null.Int
+	Difficulty    null.String
+	Status        string
+	VariantOfID   null.Int
+	ForkedAt      null.Time
+	CookCount     int
+	RatingCount   int
+	AverageRating null.Float
+	CreatedAt     time.Time
+	CreatedBy     string
+	UpdatedAt     null.Time
+	UpdatedBy     null.String
+	IsDeleted     bool
+}
+
+// Steps returns the non-empty lines of the description, recipes have no separate steps
+func (r Recipe) Steps() []string {
+	var res []string
+
+	for _, line := range strings.Split(r.Description, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			res = append(res, line)
+		}
+	}
+
+	return res
 }
 
-// RecipeSummary is a summary of a recipe with its ingredients
+// RecipeSummary is a summary of a recipe with its ingredients.
+// FlattenedIngredients is only filled when sub-recipes are expanded and Substitutions holds the applied substitutions
 type RecipeSummary struct {
 	Recipe
-	Ingredients RecipeIngredients
+	Ingredients          RecipeIngredients
+	FlattenedIngredients RecipeIngredients
+	DietaryLabels        DietaryLabels
+	Substitutions        IngredientSubstitutions
+	Tags                 Tags
+	Images               RecipeImages
+	Equipment            Equipments
 }
@@ -0,0 +1,7 @@
+package entity
+
+// RecipeBooklet holds the recipes of a category, printed together as a single booklet
+type RecipeBooklet struct {
+	Category *Category
+	Recipes  []RecipeSummary
+}
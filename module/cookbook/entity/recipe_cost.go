@@ -0,0 +1,62 @@
+package entity
+
+import "github.com/guregu/null"
+
+const (
+	CostStatusOK                = "ok"
+	CostStatusMissingPrice      = "missing_price"
+	CostStatusUnconvertibleUnit = "unconvertible_unit"
+	CostStatusToTaste           = "to_taste"
+)
+
+// RecipeIngredientCosts is the plural form of RecipeIngredientCost
+type RecipeIngredientCosts []*RecipeIngredientCost
+
+// RecipeIngredientCost holds the cost of a recipe ingredient, the lower end of a range is used
+// and an ingredient to taste costs nothing
+type RecipeIngredientCost struct {
+	IngredientID       uint64
+	IngredientName     string
+	IngredientUnitName string
+	Amount             null.Float
+	Cost               null.Float
+	Status             string
+}
+
+// RecipeCost holds the cost of a recipe for a number of servings
+type RecipeCost struct {
+	RecipeID    uint64
+	RecipeName  string
+	Servings    int
+	Total       float64
+	PerServing  float64
+	IsComplete  bool
+	Ingredients RecipeIngredientCosts
+}
+
+// RecipeCostIncreases is the plural form of RecipeCostIncrease
+type RecipeCostIncreases []*RecipeCostIncrease
+
+// RecipeCostIncrease holds the cost change of a recipe over a period
+type RecipeCostIncrease struct {
+	RecipeID        uint64
+	RecipeName      string
+	FromCost        float64
+	ToCost          float64
+	IncreasePercent float64
+	IsComplete      bool
+}
+
+// RecipeCostIncreaseReport holds the recipes whose cost rose over a period. Skipped are the recipes that could not be
+// costed, e.g. with a sub-recipe used in a unit it does not yield, they are left out of the increases
+type RecipeCostIncreaseReport struct {
+	Increases RecipeCostIncreases
+	Skipped   []RecipeCostSkip
+}
+
+// RecipeCostSkip holds a recipe left out of a cost report and the reason why
+type RecipeCostSkip struct {
+	RecipeID   uint64
+	RecipeName string
+	Reason     string
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// RecipeImages is the plural form of RecipeImage
+type RecipeImages []*RecipeImage
+
+// RecipeImage is a photo of a recipe, or of one of its steps when Step is set.
+// FileKey and ThumbnailKey locate the original and the thumbnail in the media storage
+type RecipeImage struct {
+	ID           uint64
+	RecipeID     uint64
+	Step         null.Int
+	Caption      null.String
+	FileKey      string
+	ThumbnailKey string
+	ContentType  string
+	SizeBytes    int64
+	Width        int
+	Height       int
+	CreatedAt    time.Time
+	CreatedBy    string
+}
+
+// MediaFile describes a stored media file
+type MediaFile struct {
+	Key         string
+	ContentType string
+	SizeBytes   int64
+	ModifiedAt  time.Time
+}
@@ -0,0 +1,25 @@
+package entity
+
+const (
+	ImportIssueIngredientNotFound = "ingredient_not_found"
+	ImportIssueUnitNotFound       = "unit_not_found"
+	ImportIssueAmbiguous          = "ambiguous_ingredient"
+)
+
+// RecipeImport holds the result of a recipe import
+type RecipeImport struct {
+	Recipe      *Recipe
+	Ingredients int
+	Unmatched   RecipeImportIssues
+}
+
+// RecipeImportIssues is the plural form of RecipeImportIssue
+type RecipeImportIssues []*RecipeImportIssue
+
+// RecipeImportIssue holds an ingredient line that could not be fully matched.
+// A line whose ingredient is not found or is ambiguous is left out of the recipe, a line whose unit
+// is not found is kept with the unit as written
+type RecipeImportIssue struct {
+	Line   string
+	Reason string
+}
@@ -9,14 +9,19 @@ import (
 // RecipeIngredients is the plural form of RecipeIngredient
 type RecipeIngredients []*RecipeIngredient
 
-// RecipeIngredient holds our recipe entity
+// RecipeIngredient holds our recipe entity. An Amount that is not set means "to taste", an AmountMax makes the amount
+// a range like "2-3 buah", and the AmountDenominator keeps the fraction the amount was written as, e.g. 3 for "1/3"
 type RecipeIngredient struct {
 	ID                 uint64
 	RecipeID           uint64
 	IngredientID       uint64
+	SubRecipeID        uint64
 	IngredientName     string
 	IngredientUnitName string
-	Amount             float64
+	Amount             null.Float
+	AmountMax          null.Float
+	AmountDenominator  null.Int
+	Optional           bool
 	OrderingIndex      int
 	Notes              string
 	CreatedAt          time.Time
@@ -25,3 +30,83 @@ type RecipeIngredient struct {
 	UpdatedBy          null.String
 	IsDeleted          bool
 }
+
+// IsToTaste returns true when the recipe ingredient has no amount
+func (ri RecipeIngredient) IsToTaste() bool {
+	return !ri.Amount.Valid
+}
+
+// IsRange returns true when the amount is a range like "2-3"
+func (ri RecipeIngredient) IsRange() bool {
+	return ri.Amount.Valid && ri.AmountMax.Valid && ri.AmountMax.Float64 > ri.Amount.Float64
+}
+
+// AmountText writes the amount for display, e.g. "1 1/2" or "2-3", and is empty for a recipe ingredient to taste
+func (ri RecipeIngredient) AmountText() string {
+	if ri.IsToTaste() {
+		return ""
+	}
+
+	text := FormatAmount(ri.Amount.Float64, ri.AmountDenominator.Int64)
+	if ri.IsRange() {
+		text += "-" + FormatAmount(ri.AmountMax.Float64, ri.AmountDenominator.Int64)
+	}
+
+	return text
+}
+
+// Scale returns a copy of the recipe ingredient with its amounts multiplied by factor, an amount to taste stays to taste
+func (ri RecipeIngredient) Scale(factor float64) *RecipeIngredient {
+	ri.Amount = null.NewFloat(ri.Amount.Float64*factor, ri.Amount.Valid)
+	ri.AmountMax = null.NewFloat(ri.AmountMax.Float64*factor, ri.AmountMax.Valid)
+
+	return &ri
+}
+
+// AddAmount returns a copy of the recipe ingredient with the amount of another row of the same ingredient and unit added.
+// The lower end of a range counts as the amount of a row without one, the fraction is kept unless the rows were written
+// with different ones, and an amount to taste stays to taste
+func (ri RecipeIngredient) AddAmount(other RecipeIngredient) *RecipeIngredient {
+	if ri.IsToTaste() {
+		return &ri
+	}
+
+	if ri.IsRange() || other.IsRange() {
+		ri.AmountMax = null.FloatFrom(ri.rangeEnd() + other.rangeEnd())
+	}
+
+	ri.Amount = null.FloatFrom(ri.Amount.Float64 + other.Amount.Float64)
+
+	switch {
+	case !ri.AmountDenominator.Valid:
+		ri.AmountDenominator = other.AmountDenominator
+	case other.AmountDenominator.Valid && ri.AmountDenominator != other.AmountDenominator:
+		ri.AmountDenominator = null.Int{}
+	}
+
+	return &ri
+}
+
+// rangeEnd returns the upper end of the amount
+func (ri RecipeIngredient) rangeEnd() float64 {
+	if ri.IsRange() {
+		return ri.AmountMax.Float64
+	}
+
+	return ri.Amount.Float64
+}
+
+// RecipeIngredientNameDrifts is the plural form of RecipeIngredientNameDrift
+type RecipeIngredientNameDrifts []*RecipeIngredientNameDrift
+
+// RecipeIngredientNameDrift is a recipe ingredient whose denormalized name differs from
+// the current name of its ingredient or sub-recipe
+type RecipeIngredientNameDrift struct {
+	RecipeIngredientID uint64
+	RecipeID           uint64
+	RecipeName         string
+	IngredientID       uint64
+	SubRecipeID        uint64
+	IngredientName     string
+	ExpectedName       string
+}
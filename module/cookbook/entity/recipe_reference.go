@@ -0,0 +1,36 @@
+package entity
+
+import "github.com/tlab-backend-test-naufal/cookbook-management/internal/liberr"
+
+// RecipeReferences is the plural form of RecipeReference
+type RecipeReferences []*RecipeReference
+
+// RecipeReference is a live recipe referencing a master data row
+type RecipeReference struct {
+	RecipeID   uint64
+	RecipeName string
+}
+
+// ReferenceConflictError is returned when a master data row cannot be deleted because live recipes still reference it
+type ReferenceConflictError struct {
+	Err     *liberr.ErrorDetails
+	Recipes RecipeReferences
+}
+
+// NewReferenceConflictError instantiates ReferenceConflictError
+func NewReferenceConflictError(err *liberr.ErrorDetails, recipes RecipeReferences) *ReferenceConflictError {
+	return &ReferenceConflictError{
+		Err:     err,
+		Recipes: recipes,
+	}
+}
+
+// Error is used to implement the Golang `error` interface.
+func (e *ReferenceConflictError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error details, so errors.Is matches them
+func (e *ReferenceConflictError) Unwrap() error {
+	return e.Err
+}
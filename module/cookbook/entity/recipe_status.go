@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+const (
+	RecipeStatusDraft     = "draft"
+	RecipeStatusInReview  = "in_review"
+	RecipeStatusPublished = "published"
+	RecipeStatusArchived  = "archived"
+)
+
+// RecipeStatuses lists the workflow states of a recipe
+var RecipeStatuses = []string{RecipeStatusDraft, RecipeStatusInReview, RecipeStatusPublished, RecipeStatusArchived}
+
+const (
+	RecipeActionSubmit  = "submit"
+	RecipeActionApprove = "approve"
+	RecipeActionReject  = "reject"
+	RecipeActionPublish = "publish"
+	RecipeActionArchive = "archive"
+)
+
+// RecipeTransition moves a recipe from one of the From states to the To state. A transition for reviewers only
+// cannot be made by the author, the others can be made by the author or a reviewer
+type RecipeTransition struct {
+	Action          string
+	From            []string
+	To              string
+	ReviewerOnly    bool
+	CommentRequired bool
+}
+
+// RecipeTransitions lists the allowed transitions of the recipe workflow. Approving a recipe in review publishes it,
+// rejecting it sends it back to draft with a comment, and publishing brings an archived recipe back
+var RecipeTransitions = []RecipeTransition{
+	{Action: RecipeActionSubmit, From: []string{RecipeStatusDraft}, To: RecipeStatusInReview},
+	{Action: RecipeActionApprove, From: []string{RecipeStatusInReview}, To: RecipeStatusPublished, ReviewerOnly: true},
+	{Action: RecipeActionReject, From: []string{RecipeStatusInReview}, To: RecipeStatusDraft, ReviewerOnly: true, CommentRequired: true},
+	{Action: RecipeActionPublish, From: []string{RecipeStatusArchived}, To: RecipeStatusPublished, ReviewerOnly: true},
+	{Action: RecipeActionArchive, From: []string{RecipeStatusPublished}, To: RecipeStatusArchived},
+}
+
+// IsUnpublishedDraft returns true when a recipe is a draft or in review, such a recipe is only visible to its author and reviewers
+func IsUnpublishedDraft(status string) bool {
+	return status == RecipeStatusDraft || status == RecipeStatusInReview
+}
+
+// RecipeStatusChanges is the plural form of RecipeStatusChange
+type RecipeStatusChanges []*RecipeStatusChange
+
+// RecipeStatusChange is a transition made on a recipe, with the comment of the reviewer
+type RecipeStatusChange struct {
+	ID         uint64
+	RecipeID   uint64
+	Action     string
+	FromStatus string
+	ToStatus   string
+	Comment    null.String
+	CreatedAt  time.Time
+	CreatedBy  string
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+const (
+	RecipeIngredientAdded   = "added"
+	RecipeIngredientRemoved = "removed"
+	RecipeIngredientChanged = "changed"
+)
+
+// RecipeFamily is a recipe with the variants cloned from it, recursively
+type RecipeFamily struct {
+	Recipe   *Recipe
+	Variants []*RecipeFamily
+}
+
+// RecipeParentChanges is what changed in the parent of a variant since the variant was forked from it
+type RecipeParentChanges struct {
+	RecipeID    uint64
+	ParentID    uint64
+	ForkedAt    time.Time
+	Fields      []RecipeFieldChange
+	Steps       []RecipeStepChange
+	Ingredients []RecipeIngredientChange
+}
+
+// RecipeFieldChange is a recipe field whose value changed, Before and After are null when the field is empty
+type RecipeFieldChange struct {
+	Field  string
+	Before null.String
+	After  null.String
+}
+
+// RecipeStepChange is a step that changed at a position, Before is null for an added step and After for a removed one
+type RecipeStepChange struct {
+	Step   int
+	Before null.String
+	After  null.String
+}
+
+// RecipeIngredientChange is an ingredient added, removed or changed, Before is nil for an added ingredient
+// and After for a removed one
+type RecipeIngredientChange struct {
+	Change string
+	Before *RecipeIngredient
+	After  *RecipeIngredient
+}
+
+// HasChanges returns true when the parent changed since the fork
+func (c RecipeParentChanges) HasChanges() bool {
+	return len(c.Fields) > 0 || len(c.Steps) > 0 || len(c.Ingredients) > 0
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// Tags is the plural form of Tag
+type Tags []*Tag
+
+// Tag holds a free label of recipes, e.g. "pedas" or "breakfast"
+type Tag struct {
+	ID             uint64
+	Name           string
+	NormalizedName string
+	CreatedAt      time.Time
+	CreatedBy      string
+	UpdatedAt      null.Time
+	UpdatedBy      null.String
+	IsDeleted      bool
+}
+
+// FacetCounts is the plural form of FacetCount
+type FacetCounts []*FacetCount
+
+// FacetCount is the number of recipes having a tag or a category
+type FacetCount struct {
+	ID    uint64
+	Name  string
+	Count int
+}
+
+// RecipeFacets holds the recipe counts per tag and per category of a recipe filter
+type RecipeFacets struct {
+	Tags       FacetCounts
+	Categories FacetCounts
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"github.com/guregu/null"
+)
+
+const (
+	TrashTypeRecipe           = "recipe"
+	TrashTypeRecipeIngredient = "recipe_ingredient"
+	TrashTypeCategory         = "category"
+	TrashTypeIngredient       = "ingredient"
+	TrashTypeIngredientUnit   = "ingredient_unit"
+)
+
+// TrashTypes are the kinds of soft-deleted records that can be listed and restored
+var TrashTypes = []string{
+	TrashTypeRecipe,
+	TrashTypeRecipeIngredient,
+	TrashTypeCategory,
+	TrashTypeIngredient,
+	TrashTypeIngredientUnit,
+}
+
+// TrashItems is the plural form of TrashItem
+type TrashItems []*TrashItem
+
+// TrashItem is a soft-deleted record. ParentID is the recipe of a recipe ingredient or the category of a recipe
+type TrashItem struct {
+	Type      string
+	ID        uint64
+	Name      string
+	ParentID  uint64
+	DeletedAt null.Time
+	DeletedBy null.String
+}
+
+// TrashPurgeResult holds the number of rows permanently removed per type.
+// MediaKeys are the stored files of the removed recipe images
+type TrashPurgeResult struct {
+	Recipes           int64
+	RecipeIngredients int64
+	RecipeImages      int64
+	Categories        int64
+	Ingredients       int64
+	IngredientUnits   int64
+	MediaKeys         []string
+}
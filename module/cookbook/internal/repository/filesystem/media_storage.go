@@ -0,0 +1,116 @@
+package filesystem_repo
+
+import (
+	"context"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// MediaLocalStorage is the local filesystem implementation for MediaStorage interface
+type MediaLocalStorage struct {
+	root string
+}
+
+// NewMediaLocalStorage instantiates MediaLocalStorage, files are stored under the root directory
+func NewMediaLocalStorage(root string) *MediaLocalStorage {
+	return &MediaLocalStorage{root: root}
+}
+
+// Save writes a file, it is written to a temporary file first so that a file is never read half written
+func (s *MediaLocalStorage) Save(_ context.Context, key string, content []byte) error {
+	name, ok := s.path(key)
+	if !ok {
+		return entity.ErrMediaNotFound
+	}
+
+	err := os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(content)
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Open opens a file for reading
+func (s *MediaLocalStorage) Open(_ context.Context, key string) (io.ReadSeekCloser, entity.MediaFile, error) {
+	name, ok := s.path(key)
+	if !ok {
+		return nil, entity.MediaFile{}, entity.ErrMediaNotFound
+	}
+
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, entity.MediaFile{}, entity.ErrMediaNotFound
+	}
+
+	if err != nil {
+		return nil, entity.MediaFile{}, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, entity.MediaFile{}, err
+	}
+
+	if info.IsDir() {
+		_ = file.Close()
+		return nil, entity.MediaFile{}, entity.ErrMediaNotFound
+	}
+
+	return file, entity.MediaFile{
+		Key:         key,
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		SizeBytes:   info.Size(),
+		ModifiedAt:  info.ModTime(),
+	}, nil
+}
+
+// Delete removes a file
+func (s *MediaLocalStorage) Delete(_ context.Context, key string) error {
+	name, ok := s.path(key)
+	if !ok {
+		return entity.ErrMediaNotFound
+	}
+
+	err := os.Remove(name)
+	if os.IsNotExist(err) {
+		return entity.ErrMediaNotFound
+	}
+
+	return err
+}
+
+// path maps a key to a file under the root, keys escaping the root are rejected
+func (s *MediaLocalStorage) path(key string) (string, bool) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "\\") {
+		return "", false
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), true
+}
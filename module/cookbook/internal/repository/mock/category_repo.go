@@ -52,17 +52,32 @@ func (mr *MockCategoryRepositoryMockRecorder) Create(ctx, params interface{}) *g
 }
 
 // Delete mocks base method.
-func (m *MockCategoryRepository) Delete(ctx context.Context, id uint64) error {
+func (m *MockCategoryRepository) Delete(ctx context.Context, id uint64, params usecase.DeleteParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, params)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCategoryRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) Delete(ctx, id, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), ctx, id, params)
+}
+
+// Get mocks base method.
+func (m *MockCategoryRepository) Get(ctx context.Context, id uint64) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCategoryRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCategoryRepository)(nil).Get), ctx, id)
 }
 
 // List mocks base method.
@@ -80,6 +95,66 @@ func (mr *MockCategoryRepositoryMockRecorder) List(ctx, limit, offset interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCategoryRepository)(nil).List), ctx, limit, offset)
 }
 
+// ListAncestorIDs mocks base method.
+func (m *MockCategoryRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAncestorIDs", ctx, id)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAncestorIDs indicates an expected call of ListAncestorIDs.
+func (mr *MockCategoryRepositoryMockRecorder) ListAncestorIDs(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAncestorIDs", reflect.TypeOf((*MockCategoryRepository)(nil).ListAncestorIDs), ctx, id)
+}
+
+// ListChildren mocks base method.
+func (m *MockCategoryRepository) ListChildren(ctx context.Context, id uint64) (entity.Categories, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChildren", ctx, id)
+	ret0, _ := ret[0].(entity.Categories)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChildren indicates an expected call of ListChildren.
+func (mr *MockCategoryRepositoryMockRecorder) ListChildren(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChildren", reflect.TypeOf((*MockCategoryRepository)(nil).ListChildren), ctx, id)
+}
+
+// ListReferencingRecipes mocks base method.
+func (m *MockCategoryRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReferencingRecipes", ctx, id)
+	ret0, _ := ret[0].(entity.RecipeReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReferencingRecipes indicates an expected call of ListReferencingRecipes.
+func (mr *MockCategoryRepositoryMockRecorder) ListReferencingRecipes(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReferencingRecipes", reflect.TypeOf((*MockCategoryRepository)(nil).ListReferencingRecipes), ctx, id)
+}
+
+// ListSubtree mocks base method.
+func (m *MockCategoryRepository) ListSubtree(ctx context.Context, rootID uint64) (entity.Categories, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubtree", ctx, rootID)
+	ret0, _ := ret[0].(entity.Categories)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubtree indicates an expected call of ListSubtree.
+func (mr *MockCategoryRepositoryMockRecorder) ListSubtree(ctx, rootID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubtree", reflect.TypeOf((*MockCategoryRepository)(nil).ListSubtree), ctx, rootID)
+}
+
 // Update mocks base method.
 func (m *MockCategoryRepository) Update(ctx context.Context, id uint64, params usecase.CategoryParams) (*entity.Category, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: collection_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockCollectionRepository is a mock of CollectionRepository interface.
+type MockCollectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionRepositoryMockRecorder
+}
+
+// MockCollectionRepositoryMockRecorder is the mock recorder for MockCollectionRepository.
+type MockCollectionRepositoryMockRecorder struct {
+	mock *MockCollectionRepository
+}
+
+// NewMockCollectionRepository creates a new mock instance.
+func NewMockCollectionRepository(ctrl *gomock.Controller) *MockCollectionRepository {
+	mock := &MockCollectionRepository{ctrl: ctrl}
+	mock.recorder = &MockCollectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionRepository) EXPECT() *MockCollectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRecipe mocks base method.
+func (m *MockCollectionRepository) AddRecipe(ctx context.Context, id, recipeID uint64, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipe", ctx, id, recipeID, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipe indicates an expected call of AddRecipe.
+func (mr *MockCollectionRepositoryMockRecorder) AddRecipe(ctx, id, recipeID, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipe", reflect.TypeOf((*MockCollectionRepository)(nil).AddRecipe), ctx, id, recipeID, actor)
+}
+
+// Create mocks base method.
+func (m *MockCollectionRepository) Create(ctx context.Context, params usecase.CollectionParams) (*entity.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCollectionRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCollectionRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockCollectionRepository) Delete(ctx context.Context, id uint64, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCollectionRepositoryMockRecorder) Delete(ctx, id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCollectionRepository)(nil).Delete), ctx, id, actor)
+}
+
+// Get mocks base method.
+func (m *MockCollectionRepository) Get(ctx context.Context, id uint64) (*entity.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCollectionRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCollectionRepository)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockCollectionRepository) List(ctx context.Context, limit, offset int) (entity.Collections, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].(entity.Collections)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCollectionRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCollectionRepository)(nil).List), ctx, limit, offset)
+}
+
+// ListRecipes mocks base method.
+func (m *MockCollectionRepository) ListRecipes(ctx context.Context, id uint64) (entity.CollectionRecipes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipes", ctx, id)
+	ret0, _ := ret[0].(entity.CollectionRecipes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipes indicates an expected call of ListRecipes.
+func (mr *MockCollectionRepositoryMockRecorder) ListRecipes(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipes", reflect.TypeOf((*MockCollectionRepository)(nil).ListRecipes), ctx, id)
+}
+
+// RemoveRecipe mocks base method.
+func (m *MockCollectionRepository) RemoveRecipe(ctx context.Context, id, recipeID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRecipe", ctx, id, recipeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRecipe indicates an expected call of RemoveRecipe.
+func (mr *MockCollectionRepositoryMockRecorder) RemoveRecipe(ctx, id, recipeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRecipe", reflect.TypeOf((*MockCollectionRepository)(nil).RemoveRecipe), ctx, id, recipeID)
+}
+
+// SetPositions mocks base method.
+func (m *MockCollectionRepository) SetPositions(ctx context.Context, id uint64, recipeIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPositions", ctx, id, recipeIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPositions indicates an expected call of SetPositions.
+func (mr *MockCollectionRepositoryMockRecorder) SetPositions(ctx, id, recipeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPositions", reflect.TypeOf((*MockCollectionRepository)(nil).SetPositions), ctx, id, recipeIDs)
+}
+
+// Update mocks base method.
+func (m *MockCollectionRepository) Update(ctx context.Context, id uint64, params usecase.CollectionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCollectionRepositoryMockRecorder) Update(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCollectionRepository)(nil).Update), ctx, id, params)
+}
+
+// UpdateCover mocks base method.
+func (m *MockCollectionRepository) UpdateCover(ctx context.Context, id uint64, params usecase.CollectionCoverParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCover", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCover indicates an expected call of UpdateCover.
+func (mr *MockCollectionRepositoryMockRecorder) UpdateCover(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCover", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateCover), ctx, id, params)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cook_log_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockCookLogRepository is a mock of CookLogRepository interface.
+type MockCookLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCookLogRepositoryMockRecorder
+}
+
+// MockCookLogRepositoryMockRecorder is the mock recorder for MockCookLogRepository.
+type MockCookLogRepositoryMockRecorder struct {
+	mock *MockCookLogRepository
+}
+
+// NewMockCookLogRepository creates a new mock instance.
+func NewMockCookLogRepository(ctrl *gomock.Controller) *MockCookLogRepository {
+	mock := &MockCookLogRepository{ctrl: ctrl}
+	mock.recorder = &MockCookLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCookLogRepository) EXPECT() *MockCookLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCookLogRepository) Create(ctx context.Context, params usecase.CookLogParams) (*entity.CookLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.CookLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCookLogRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCookLogRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockCookLogRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCookLogRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCookLogRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCookLogRepository) Get(ctx context.Context, id uint64) (*entity.CookLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.CookLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCookLogRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCookLogRepository)(nil).Get), ctx, id)
+}
+
+// ListByRecipeID mocks base method.
+func (m *MockCookLogRepository) ListByRecipeID(ctx context.Context, recipeID uint64, limit, offset int) (entity.CookLogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRecipeID", ctx, recipeID, limit, offset)
+	ret0, _ := ret[0].(entity.CookLogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRecipeID indicates an expected call of ListByRecipeID.
+func (mr *MockCookLogRepositoryMockRecorder) ListByRecipeID(ctx, recipeID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRecipeID", reflect.TypeOf((*MockCookLogRepository)(nil).ListByRecipeID), ctx, recipeID, limit, offset)
+}
+
+// UpdatePhoto mocks base method.
+func (m *MockCookLogRepository) UpdatePhoto(ctx context.Context, id uint64, params usecase.CookLogPhotoParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhoto", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhoto indicates an expected call of UpdatePhoto.
+func (mr *MockCookLogRepositoryMockRecorder) UpdatePhoto(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhoto", reflect.TypeOf((*MockCookLogRepository)(nil).UpdatePhoto), ctx, id, params)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: equipment_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockEquipmentRepository is a mock of EquipmentRepository interface.
+type MockEquipmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEquipmentRepositoryMockRecorder
+}
+
+// MockEquipmentRepositoryMockRecorder is the mock recorder for MockEquipmentRepository.
+type MockEquipmentRepositoryMockRecorder struct {
+	mock *MockEquipmentRepository
+}
+
+// NewMockEquipmentRepository creates a new mock instance.
+func NewMockEquipmentRepository(ctrl *gomock.Controller) *MockEquipmentRepository {
+	mock := &MockEquipmentRepository{ctrl: ctrl}
+	mock.recorder = &MockEquipmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEquipmentRepository) EXPECT() *MockEquipmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEquipmentRepository) Create(ctx context.Context, params usecase.EquipmentParams) (*entity.Equipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.Equipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEquipmentRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEquipmentRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockEquipmentRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEquipmentRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEquipmentRepository)(nil).Delete), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockEquipmentRepository) List(ctx context.Context, limit, offset int) (entity.Equipments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].(entity.Equipments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEquipmentRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEquipmentRepository)(nil).List), ctx, limit, offset)
+}
+
+// ListByIDs mocks base method.
+func (m *MockEquipmentRepository) ListByIDs(ctx context.Context, ids []uint64) (entity.Equipments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByIDs", ctx, ids)
+	ret0, _ := ret[0].(entity.Equipments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByIDs indicates an expected call of ListByIDs.
+func (mr *MockEquipmentRepositoryMockRecorder) ListByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByIDs", reflect.TypeOf((*MockEquipmentRepository)(nil).ListByIDs), ctx, ids)
+}
+
+// Update mocks base method.
+func (m *MockEquipmentRepository) Update(ctx context.Context, id uint64, params usecase.EquipmentParams) (*entity.Equipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(*entity.Equipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEquipmentRepositoryMockRecorder) Update(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEquipmentRepository)(nil).Update), ctx, id, params)
+}
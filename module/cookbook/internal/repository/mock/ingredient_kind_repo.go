@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingredient_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockIngredientKindRepository is a mock of IngredientKindRepository interface.
+type MockIngredientKindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngredientKindRepositoryMockRecorder
+}
+
+// MockIngredientKindRepositoryMockRecorder is the mock recorder for MockIngredientKindRepository.
+type MockIngredientKindRepositoryMockRecorder struct {
+	mock *MockIngredientKindRepository
+}
+
+// NewMockIngredientKindRepository creates a new mock instance.
+func NewMockIngredientKindRepository(ctrl *gomock.Controller) *MockIngredientKindRepository {
+	mock := &MockIngredientKindRepository{ctrl: ctrl}
+	mock.recorder = &MockIngredientKindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngredientKindRepository) EXPECT() *MockIngredientKindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountReferences mocks base method.
+func (m *MockIngredientKindRepository) CountReferences(ctx context.Context, id uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReferences", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReferences indicates an expected call of CountReferences.
+func (mr *MockIngredientKindRepositoryMockRecorder) CountReferences(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReferences", reflect.TypeOf((*MockIngredientKindRepository)(nil).CountReferences), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockIngredientKindRepository) Create(ctx context.Context, params usecase.IngredientKindParams) (*entity.IngredientKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.IngredientKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIngredientKindRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIngredientKindRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockIngredientKindRepository) Delete(ctx context.Context, id uint64, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIngredientKindRepositoryMockRecorder) Delete(ctx, id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientKindRepository)(nil).Delete), ctx, id, actor)
+}
+
+// Get mocks base method.
+func (m *MockIngredientKindRepository) Get(ctx context.Context, id uint64) (*entity.IngredientKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.IngredientKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngredientKindRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngredientKindRepository)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockIngredientKindRepository) List(ctx context.Context, limit, offset int) (entity.IngredientKinds, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].(entity.IngredientKinds)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIngredientKindRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngredientKindRepository)(nil).List), ctx, limit, offset)
+}
+
+// ListAncestorIDs mocks base method.
+func (m *MockIngredientKindRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAncestorIDs", ctx, id)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAncestorIDs indicates an expected call of ListAncestorIDs.
+func (mr *MockIngredientKindRepositoryMockRecorder) ListAncestorIDs(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAncestorIDs", reflect.TypeOf((*MockIngredientKindRepository)(nil).ListAncestorIDs), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockIngredientKindRepository) Update(ctx context.Context, id uint64, params usecase.IngredientKindParams) (*entity.IngredientKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(*entity.IngredientKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIngredientKindRepositoryMockRecorder) Update(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngredientKindRepository)(nil).Update), ctx, id, params)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cost_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockIngredientPriceRepository is a mock of IngredientPriceRepository interface.
+type MockIngredientPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngredientPriceRepositoryMockRecorder
+}
+
+// MockIngredientPriceRepositoryMockRecorder is the mock recorder for MockIngredientPriceRepository.
+type MockIngredientPriceRepositoryMockRecorder struct {
+	mock *MockIngredientPriceRepository
+}
+
+// NewMockIngredientPriceRepository creates a new mock instance.
+func NewMockIngredientPriceRepository(ctrl *gomock.Controller) *MockIngredientPriceRepository {
+	mock := &MockIngredientPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockIngredientPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngredientPriceRepository) EXPECT() *MockIngredientPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIngredientPriceRepository) Create(ctx context.Context, params usecase.IngredientPriceParams) (*entity.IngredientPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.IngredientPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIngredientPriceRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIngredientPriceRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockIngredientPriceRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIngredientPriceRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientPriceRepository)(nil).Delete), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockIngredientPriceRepository) List(ctx context.Context, ingredientID uint64, limit, offset int) (entity.IngredientPrices, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, ingredientID, limit, offset)
+	ret0, _ := ret[0].(entity.IngredientPrices)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIngredientPriceRepositoryMockRecorder) List(ctx, ingredientID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngredientPriceRepository)(nil).List), ctx, ingredientID, limit, offset)
+}
+
+// ListEffective mocks base method.
+func (m *MockIngredientPriceRepository) ListEffective(ctx context.Context, ingredientIDs []uint64, at time.Time) (entity.IngredientPrices, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEffective", ctx, ingredientIDs, at)
+	ret0, _ := ret[0].(entity.IngredientPrices)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEffective indicates an expected call of ListEffective.
+func (mr *MockIngredientPriceRepositoryMockRecorder) ListEffective(ctx, ingredientIDs, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEffective", reflect.TypeOf((*MockIngredientPriceRepository)(nil).ListEffective), ctx, ingredientIDs, at)
+}
@@ -36,6 +36,21 @@ func (m *MockIngredientRepository) EXPECT() *MockIngredientRepositoryMockRecorde
 	return m.recorder
 }
 
+// BulkUpdateNutrition mocks base method.
+func (m *MockIngredientRepository) BulkUpdateNutrition(ctx context.Context, params []usecase.IngredientNutritionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateNutrition", ctx, params)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateNutrition indicates an expected call of BulkUpdateNutrition.
+func (mr *MockIngredientRepositoryMockRecorder) BulkUpdateNutrition(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateNutrition", reflect.TypeOf((*MockIngredientRepository)(nil).BulkUpdateNutrition), ctx, params)
+}
+
 // Create mocks base method.
 func (m *MockIngredientRepository) Create(ctx context.Context, params usecase.IngredientParams) (*entity.Ingredient, error) {
 	m.ctrl.T.Helper()
@@ -51,33 +66,166 @@ func (mr *MockIngredientRepositoryMockRecorder) Create(ctx, params interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIngredientRepository)(nil).Create), ctx, params)
 }
 
+// CreateAlias mocks base method.
+func (m *MockIngredientRepository) CreateAlias(ctx context.Context, params usecase.IngredientAliasParams) (*entity.IngredientAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlias", ctx, params)
+	ret0, _ := ret[0].(*entity.IngredientAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlias indicates an expected call of CreateAlias.
+func (mr *MockIngredientRepositoryMockRecorder) CreateAlias(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlias", reflect.TypeOf((*MockIngredientRepository)(nil).CreateAlias), ctx, params)
+}
+
 // Delete mocks base method.
-func (m *MockIngredientRepository) Delete(ctx context.Context, id uint64) error {
+func (m *MockIngredientRepository) Delete(ctx context.Context, id uint64, params usecase.DeleteParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, params)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIngredientRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockIngredientRepositoryMockRecorder) Delete(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientRepository)(nil).Delete), ctx, id, params)
+}
+
+// DeleteAlias mocks base method.
+func (m *MockIngredientRepository) DeleteAlias(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlias", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlias indicates an expected call of DeleteAlias.
+func (mr *MockIngredientRepositoryMockRecorder) DeleteAlias(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlias", reflect.TypeOf((*MockIngredientRepository)(nil).DeleteAlias), ctx, id)
 }
 
 // List mocks base method.
-func (m *MockIngredientRepository) List(ctx context.Context, limit, offset int) (entity.Ingredients, error) {
+func (m *MockIngredientRepository) List(ctx context.Context, filter usecase.ListIngredientsFilter, limit, offset int) (entity.Ingredients, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
 	ret0, _ := ret[0].(entity.Ingredients)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockIngredientRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+func (mr *MockIngredientRepositoryMockRecorder) List(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngredientRepository)(nil).List), ctx, filter, limit, offset)
+}
+
+// ListAliases mocks base method.
+func (m *MockIngredientRepository) ListAliases(ctx context.Context, ingredientID uint64) (entity.IngredientAliases, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliases", ctx, ingredientID)
+	ret0, _ := ret[0].(entity.IngredientAliases)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAliases indicates an expected call of ListAliases.
+func (mr *MockIngredientRepositoryMockRecorder) ListAliases(ctx, ingredientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliases", reflect.TypeOf((*MockIngredientRepository)(nil).ListAliases), ctx, ingredientID)
+}
+
+// ListAllByIDs mocks base method.
+func (m *MockIngredientRepository) ListAllByIDs(ctx context.Context, ids []uint64) (entity.Ingredients, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllByIDs", ctx, ids)
+	ret0, _ := ret[0].(entity.Ingredients)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllByIDs indicates an expected call of ListAllByIDs.
+func (mr *MockIngredientRepositoryMockRecorder) ListAllByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllByIDs", reflect.TypeOf((*MockIngredientRepository)(nil).ListAllByIDs), ctx, ids)
+}
+
+// ListAncestorIDs mocks base method.
+func (m *MockIngredientRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAncestorIDs", ctx, id)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAncestorIDs indicates an expected call of ListAncestorIDs.
+func (mr *MockIngredientRepositoryMockRecorder) ListAncestorIDs(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAncestorIDs", reflect.TypeOf((*MockIngredientRepository)(nil).ListAncestorIDs), ctx, id)
+}
+
+// ListByIDs mocks base method.
+func (m *MockIngredientRepository) ListByIDs(ctx context.Context, ids []uint64) (entity.Ingredients, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByIDs", ctx, ids)
+	ret0, _ := ret[0].(entity.Ingredients)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByIDs indicates an expected call of ListByIDs.
+func (mr *MockIngredientRepositoryMockRecorder) ListByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByIDs", reflect.TypeOf((*MockIngredientRepository)(nil).ListByIDs), ctx, ids)
+}
+
+// ListByNormalizedNames mocks base method.
+func (m *MockIngredientRepository) ListByNormalizedNames(ctx context.Context, normalizedNames []string) (entity.Ingredients, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByNormalizedNames", ctx, normalizedNames)
+	ret0, _ := ret[0].(entity.Ingredients)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByNormalizedNames indicates an expected call of ListByNormalizedNames.
+func (mr *MockIngredientRepositoryMockRecorder) ListByNormalizedNames(ctx, normalizedNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByNormalizedNames", reflect.TypeOf((*MockIngredientRepository)(nil).ListByNormalizedNames), ctx, normalizedNames)
+}
+
+// ListReferencingRecipes mocks base method.
+func (m *MockIngredientRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReferencingRecipes", ctx, id)
+	ret0, _ := ret[0].(entity.RecipeReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReferencingRecipes indicates an expected call of ListReferencingRecipes.
+func (mr *MockIngredientRepositoryMockRecorder) ListReferencingRecipes(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReferencingRecipes", reflect.TypeOf((*MockIngredientRepository)(nil).ListReferencingRecipes), ctx, id)
+}
+
+// Merge mocks base method.
+func (m *MockIngredientRepository) Merge(ctx context.Context, params usecase.IngredientMergeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockIngredientRepositoryMockRecorder) Merge(ctx, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngredientRepository)(nil).List), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockIngredientRepository)(nil).Merge), ctx, params)
 }
 
 // Update mocks base method.
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: substitution_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockIngredientSubstitutionRepository is a mock of IngredientSubstitutionRepository interface.
+type MockIngredientSubstitutionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngredientSubstitutionRepositoryMockRecorder
+}
+
+// MockIngredientSubstitutionRepositoryMockRecorder is the mock recorder for MockIngredientSubstitutionRepository.
+type MockIngredientSubstitutionRepositoryMockRecorder struct {
+	mock *MockIngredientSubstitutionRepository
+}
+
+// NewMockIngredientSubstitutionRepository creates a new mock instance.
+func NewMockIngredientSubstitutionRepository(ctrl *gomock.Controller) *MockIngredientSubstitutionRepository {
+	mock := &MockIngredientSubstitutionRepository{ctrl: ctrl}
+	mock.recorder = &MockIngredientSubstitutionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngredientSubstitutionRepository) EXPECT() *MockIngredientSubstitutionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIngredientSubstitutionRepository) Create(ctx context.Context, params usecase.IngredientSubstitutionParams) (*entity.IngredientSubstitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.IngredientSubstitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIngredientSubstitutionRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIngredientSubstitutionRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockIngredientSubstitutionRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIngredientSubstitutionRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientSubstitutionRepository)(nil).Delete), ctx, id)
+}
+
+// ListByIngredientIDs mocks base method.
+func (m *MockIngredientSubstitutionRepository) ListByIngredientIDs(ctx context.Context, ingredientIDs []uint64) (entity.IngredientSubstitutions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByIngredientIDs", ctx, ingredientIDs)
+	ret0, _ := ret[0].(entity.IngredientSubstitutions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByIngredientIDs indicates an expected call of ListByIngredientIDs.
+func (mr *MockIngredientSubstitutionRepositoryMockRecorder) ListByIngredientIDs(ctx, ingredientIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByIngredientIDs", reflect.TypeOf((*MockIngredientSubstitutionRepository)(nil).ListByIngredientIDs), ctx, ingredientIDs)
+}
+
+// Update mocks base method.
+func (m *MockIngredientSubstitutionRepository) Update(ctx context.Context, id uint64, params usecase.IngredientSubstitutionParams) (*entity.IngredientSubstitution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(*entity.IngredientSubstitution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIngredientSubstitutionRepositoryMockRecorder) Update(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngredientSubstitutionRepository)(nil).Update), ctx, id, params)
+}
@@ -52,17 +52,46 @@ func (mr *MockIngredientUnitRepositoryMockRecorder) Create(ctx, params interface
 }
 
 // Delete mocks base method.
-func (m *MockIngredientUnitRepository) Delete(ctx context.Context, id uint64) error {
+func (m *MockIngredientUnitRepository) Delete(ctx context.Context, id uint64, params usecase.DeleteParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, params)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIngredientUnitRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockIngredientUnitRepositoryMockRecorder) Delete(ctx, id, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientUnitRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngredientUnitRepository)(nil).Delete), ctx, id, params)
+}
+
+// DeleteConversion mocks base method.
+func (m *MockIngredientUnitRepository) DeleteConversion(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConversion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConversion indicates an expected call of DeleteConversion.
+func (mr *MockIngredientUnitRepositoryMockRecorder) DeleteConversion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConversion", reflect.TypeOf((*MockIngredientUnitRepository)(nil).DeleteConversion), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockIngredientUnitRepository) Get(ctx context.Context, id uint64) (*entity.IngredientUnit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.IngredientUnit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngredientUnitRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngredientUnitRepository)(nil).Get), ctx, id)
 }
 
 // List mocks base method.
@@ -80,6 +109,51 @@ func (mr *MockIngredientUnitRepositoryMockRecorder) List(ctx, limit, offset inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngredientUnitRepository)(nil).List), ctx, limit, offset)
 }
 
+// ListByNames mocks base method.
+func (m *MockIngredientUnitRepository) ListByNames(ctx context.Context, names []string) (entity.IngredientUnits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByNames", ctx, names)
+	ret0, _ := ret[0].(entity.IngredientUnits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByNames indicates an expected call of ListByNames.
+func (mr *MockIngredientUnitRepositoryMockRecorder) ListByNames(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByNames", reflect.TypeOf((*MockIngredientUnitRepository)(nil).ListByNames), ctx, names)
+}
+
+// ListConversions mocks base method.
+func (m *MockIngredientUnitRepository) ListConversions(ctx context.Context, ingredientIDs []uint64) (entity.IngredientUnitConversions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversions", ctx, ingredientIDs)
+	ret0, _ := ret[0].(entity.IngredientUnitConversions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConversions indicates an expected call of ListConversions.
+func (mr *MockIngredientUnitRepositoryMockRecorder) ListConversions(ctx, ingredientIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversions", reflect.TypeOf((*MockIngredientUnitRepository)(nil).ListConversions), ctx, ingredientIDs)
+}
+
+// ListReferencingRecipes mocks base method.
+func (m *MockIngredientUnitRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReferencingRecipes", ctx, id)
+	ret0, _ := ret[0].(entity.RecipeReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReferencingRecipes indicates an expected call of ListReferencingRecipes.
+func (mr *MockIngredientUnitRepositoryMockRecorder) ListReferencingRecipes(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReferencingRecipes", reflect.TypeOf((*MockIngredientUnitRepository)(nil).ListReferencingRecipes), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockIngredientUnitRepository) Update(ctx context.Context, id uint64, params usecase.IngredientUnitParams) (*entity.IngredientUnit, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +168,18 @@ func (mr *MockIngredientUnitRepositoryMockRecorder) Update(ctx, id, params inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngredientUnitRepository)(nil).Update), ctx, id, params)
 }
+
+// UpsertConversion mocks base method.
+func (m *MockIngredientUnitRepository) UpsertConversion(ctx context.Context, params usecase.IngredientUnitConversionParams) (*entity.IngredientUnitConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertConversion", ctx, params)
+	ret0, _ := ret[0].(*entity.IngredientUnitConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertConversion indicates an expected call of UpsertConversion.
+func (mr *MockIngredientUnitRepositoryMockRecorder) UpsertConversion(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertConversion", reflect.TypeOf((*MockIngredientUnitRepository)(nil).UpsertConversion), ctx, params)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pantry_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockPantryRepository is a mock of PantryRepository interface.
+type MockPantryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPantryRepositoryMockRecorder
+}
+
+// MockPantryRepositoryMockRecorder is the mock recorder for MockPantryRepository.
+type MockPantryRepositoryMockRecorder struct {
+	mock *MockPantryRepository
+}
+
+// NewMockPantryRepository creates a new mock instance.
+func NewMockPantryRepository(ctrl *gomock.Controller) *MockPantryRepository {
+	mock := &MockPantryRepository{ctrl: ctrl}
+	mock.recorder = &MockPantryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPantryRepository) EXPECT() *MockPantryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetItems mocks base method.
+func (m *MockPantryRepository) GetItems(ctx context.Context, ingredientIDs []uint64) (entity.PantryItems, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, ingredientIDs)
+	ret0, _ := ret[0].(entity.PantryItems)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockPantryRepositoryMockRecorder) GetItems(ctx, ingredientIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockPantryRepository)(nil).GetItems), ctx, ingredientIDs)
+}
+
+// ListItems mocks base method.
+func (m *MockPantryRepository) ListItems(ctx context.Context, filter usecase.ListPantryItemsFilter, limit, offset int) (entity.PantryItems, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, filter, limit, offset)
+	ret0, _ := ret[0].(entity.PantryItems)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockPantryRepositoryMockRecorder) ListItems(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockPantryRepository)(nil).ListItems), ctx, filter, limit, offset)
+}
+
+// ListMovements mocks base method.
+func (m *MockPantryRepository) ListMovements(ctx context.Context, ingredientID uint64, limit, offset int) (entity.PantryMovements, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, ingredientID, limit, offset)
+	ret0, _ := ret[0].(entity.PantryMovements)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockPantryRepositoryMockRecorder) ListMovements(ctx, ingredientID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockPantryRepository)(nil).ListMovements), ctx, ingredientID, limit, offset)
+}
+
+// RecordMovements mocks base method.
+func (m *MockPantryRepository) RecordMovements(ctx context.Context, params []usecase.PantryMovementParams) (entity.PantryMovements, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMovements", ctx, params)
+	ret0, _ := ret[0].(entity.PantryMovements)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMovements indicates an expected call of RecordMovements.
+func (mr *MockPantryRepositoryMockRecorder) RecordMovements(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMovements", reflect.TypeOf((*MockPantryRepository)(nil).RecordMovements), ctx, params)
+}
+
+// SetThreshold mocks base method.
+func (m *MockPantryRepository) SetThreshold(ctx context.Context, params usecase.PantryThresholdParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreshold", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetThreshold indicates an expected call of SetThreshold.
+func (mr *MockPantryRepositoryMockRecorder) SetThreshold(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreshold", reflect.TypeOf((*MockPantryRepository)(nil).SetThreshold), ctx, params)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: media_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// MockRecipeImageRepository is a mock of RecipeImageRepository interface.
+type MockRecipeImageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeImageRepositoryMockRecorder
+}
+
+// MockRecipeImageRepositoryMockRecorder is the mock recorder for MockRecipeImageRepository.
+type MockRecipeImageRepositoryMockRecorder struct {
+	mock *MockRecipeImageRepository
+}
+
+// NewMockRecipeImageRepository creates a new mock instance.
+func NewMockRecipeImageRepository(ctrl *gomock.Controller) *MockRecipeImageRepository {
+	mock := &MockRecipeImageRepository{ctrl: ctrl}
+	mock.recorder = &MockRecipeImageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeImageRepository) EXPECT() *MockRecipeImageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRecipeImageRepository) Create(ctx context.Context, params usecase.RecipeImageParams) (*entity.RecipeImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity.RecipeImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRecipeImageRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRecipeImageRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockRecipeImageRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecipeImageRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecipeImageRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockRecipeImageRepository) Get(ctx context.Context, id uint64) (*entity.RecipeImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.RecipeImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRecipeImageRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecipeImageRepository)(nil).Get), ctx, id)
+}
+
+// ListByRecipeID mocks base method.
+func (m *MockRecipeImageRepository) ListByRecipeID(ctx context.Context, recipeID uint64) (entity.RecipeImages, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRecipeID", ctx, recipeID)
+	ret0, _ := ret[0].(entity.RecipeImages)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRecipeID indicates an expected call of ListByRecipeID.
+func (mr *MockRecipeImageRepositoryMockRecorder) ListByRecipeID(ctx, recipeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRecipeID", reflect.TypeOf((*MockRecipeImageRepository)(nil).ListByRecipeID), ctx, recipeID)
+}
@@ -64,6 +64,51 @@ func (mr *MockRecipeIngredientRepositoryMockRecorder) Delete(ctx, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecipeIngredientRepository)(nil).Delete), ctx, id)
 }
 
+// Get mocks base method.
+func (m *MockRecipeIngredientRepository) Get(ctx context.Context, id uint64) (*entity.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRecipeIngredientRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecipeIngredientRepository)(nil).Get), ctx, id)
+}
+
+// ListNameDrifts mocks base method.
+func (m *MockRecipeIngredientRepository) ListNameDrifts(ctx context.Context) (entity.RecipeIngredientNameDrifts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNameDrifts", ctx)
+	ret0, _ := ret[0].(entity.RecipeIngredientNameDrifts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNameDrifts indicates an expected call of ListNameDrifts.
+func (mr *MockRecipeIngredientRepositoryMockRecorder) ListNameDrifts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNameDrifts", reflect.TypeOf((*MockRecipeIngredientRepository)(nil).ListNameDrifts), ctx)
+}
+
+// RepairNameDrifts mocks base method.
+func (m *MockRecipeIngredientRepository) RepairNameDrifts(ctx context.Context, actor string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairNameDrifts", ctx, actor)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairNameDrifts indicates an expected call of RepairNameDrifts.
+func (mr *MockRecipeIngredientRepositoryMockRecorder) RepairNameDrifts(ctx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairNameDrifts", reflect.TypeOf((*MockRecipeIngredientRepository)(nil).RepairNameDrifts), ctx, actor)
+}
+
 // Update mocks base method.
 func (m *MockRecipeIngredientRepository) Update(ctx context.Context, id uint64, params usecase.RecipeIngredientParams) (*entity.RecipeIngredient, error) {
 	m.ctrl.T.Helper()
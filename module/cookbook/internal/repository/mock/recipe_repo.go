@@ -36,6 +36,21 @@ func (m *MockRecipeRepository) EXPECT() *MockRecipeRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Clone mocks base method.
+func (m *MockRecipeRepository) Clone(ctx context.Context, id uint64, params usecase.CloneRecipeParams) (*entity.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, id, params)
+	ret0, _ := ret[0].(*entity.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockRecipeRepositoryMockRecorder) Clone(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockRecipeRepository)(nil).Clone), ctx, id, params)
+}
+
 // Create mocks base method.
 func (m *MockRecipeRepository) Create(ctx context.Context, params usecase.CreateRecipeParams) (*entity.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +80,21 @@ func (mr *MockRecipeRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecipeRepository)(nil).Delete), ctx, id)
 }
 
+// GetForkSnapshot mocks base method.
+func (m *MockRecipeRepository) GetForkSnapshot(ctx context.Context, id uint64) (entity.RecipeSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForkSnapshot", ctx, id)
+	ret0, _ := ret[0].(entity.RecipeSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForkSnapshot indicates an expected call of GetForkSnapshot.
+func (mr *MockRecipeRepositoryMockRecorder) GetForkSnapshot(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForkSnapshot", reflect.TypeOf((*MockRecipeRepository)(nil).GetForkSnapshot), ctx, id)
+}
+
 // GetSummary mocks base method.
 func (m *MockRecipeRepository) GetSummary(ctx context.Context, id uint64) (entity.RecipeSummary, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +125,96 @@ func (mr *MockRecipeRepositoryMockRecorder) List(ctx, filter, limit, offset inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRecipeRepository)(nil).List), ctx, filter, limit, offset)
 }
 
+// ListByIDs mocks base method.
+func (m *MockRecipeRepository) ListByIDs(ctx context.Context, ids []uint64) (entity.Recipes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByIDs", ctx, ids)
+	ret0, _ := ret[0].(entity.Recipes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByIDs indicates an expected call of ListByIDs.
+func (mr *MockRecipeRepositoryMockRecorder) ListByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByIDs", reflect.TypeOf((*MockRecipeRepository)(nil).ListByIDs), ctx, ids)
+}
+
+// ListFacets mocks base method.
+func (m *MockRecipeRepository) ListFacets(ctx context.Context, filter usecase.ListRecipesFiter) (entity.RecipeFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFacets", ctx, filter)
+	ret0, _ := ret[0].(entity.RecipeFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFacets indicates an expected call of ListFacets.
+func (mr *MockRecipeRepositoryMockRecorder) ListFacets(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFacets", reflect.TypeOf((*MockRecipeRepository)(nil).ListFacets), ctx, filter)
+}
+
+// ListFamily mocks base method.
+func (m *MockRecipeRepository) ListFamily(ctx context.Context, id uint64) (entity.Recipes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFamily", ctx, id)
+	ret0, _ := ret[0].(entity.Recipes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFamily indicates an expected call of ListFamily.
+func (mr *MockRecipeRepositoryMockRecorder) ListFamily(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFamily", reflect.TypeOf((*MockRecipeRepository)(nil).ListFamily), ctx, id)
+}
+
+// ListReferencingRecipes mocks base method.
+func (m *MockRecipeRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReferencingRecipes", ctx, id)
+	ret0, _ := ret[0].(entity.RecipeReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReferencingRecipes indicates an expected call of ListReferencingRecipes.
+func (mr *MockRecipeRepositoryMockRecorder) ListReferencingRecipes(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReferencingRecipes", reflect.TypeOf((*MockRecipeRepository)(nil).ListReferencingRecipes), ctx, id)
+}
+
+// ListStatusChanges mocks base method.
+func (m *MockRecipeRepository) ListStatusChanges(ctx context.Context, id uint64) (entity.RecipeStatusChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusChanges", ctx, id)
+	ret0, _ := ret[0].(entity.RecipeStatusChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusChanges indicates an expected call of ListStatusChanges.
+func (mr *MockRecipeRepositoryMockRecorder) ListStatusChanges(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusChanges", reflect.TypeOf((*MockRecipeRepository)(nil).ListStatusChanges), ctx, id)
+}
+
+// ListSubRecipeIDs mocks base method.
+func (m *MockRecipeRepository) ListSubRecipeIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubRecipeIDs", ctx, id)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubRecipeIDs indicates an expected call of ListSubRecipeIDs.
+func (mr *MockRecipeRepositoryMockRecorder) ListSubRecipeIDs(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubRecipeIDs", reflect.TypeOf((*MockRecipeRepository)(nil).ListSubRecipeIDs), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockRecipeRepository) Update(ctx context.Context, id uint64, params usecase.RecipeParams) (*entity.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -109,3 +229,17 @@ func (mr *MockRecipeRepositoryMockRecorder) Update(ctx, id, params interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRecipeRepository)(nil).Update), ctx, id, params)
 }
+
+// UpdateStatus mocks base method.
+func (m *MockRecipeRepository) UpdateStatus(ctx context.Context, id uint64, params usecase.RecipeStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRecipeRepositoryMockRecorder) UpdateStatus(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRecipeRepository)(nil).UpdateStatus), ctx, id, params)
+}
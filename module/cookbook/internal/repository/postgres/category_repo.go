@@ -26,17 +26,20 @@ func NewCategoryPostgresRepository(db *sqlx.DB) *CategoryPostgresRepository {
 type categoryDto struct {
 	ID        uint64      `db:"id"`
 	Name      string      `db:"name"`
+	ParentID  null.Int    `db:"parent_id"`
 	CreatedAt time.Time   `db:"created_at"`
 	CreatedBy string      `db:"created_by"`
 	UpdatedAt null.Time   `db:"updated_at"`
 	UpdatedBy null.String `db:"updated_by"`
 	IsDeleted bool        `db:"is_deleted"`
+	DeletedAt null.Time   `db:"deleted_at"`
 }
 
 func (c categoryDto) toEntity() *entity.Category {
 	return &entity.Category{
 		ID:        c.ID,
 		Name:      c.Name,
+		ParentID:  uint64(c.ParentID.Int64),
 		CreatedAt: c.CreatedAt,
 		CreatedBy: c.CreatedBy,
 		UpdatedAt: c.UpdatedAt,
@@ -46,7 +49,7 @@ func (c categoryDto) toEntity() *entity.Category {
 }
 
 const selectCategoryQuery = `
-select id, name, created_at, created_by, updated_at, updated_by from categories
+select id, name, parent_id, created_at, created_by, updated_at, updated_by from categories
 where is_deleted = false
 limit $1 offset $2;
 `
@@ -68,15 +71,15 @@ func (r *CategoryPostgresRepository) List(ctx context.Context, limit, offset int
 }
 
 const insertCategoryQuery = `
-INSERT INTO categories (name, created_at, created_by)
-VALUES ($1, $2, $3) RETURNING id
+INSERT INTO categories (name, parent_id, created_at, created_by)
+VALUES ($1, $2, $3, $4) RETURNING id
 `
 
 // Create creates a new category
 func (r *CategoryPostgresRepository) Create(ctx context.Context, params usecase.CategoryParams) (*entity.Category, error) {
 	dto := categoryDtoForCreate(params)
 
-	err := r.db.QueryRowxContext(ctx, insertCategoryQuery, dto.Name, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	err := r.db.QueryRowxContext(ctx, insertCategoryQuery, dto.Name, dto.ParentID, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +87,7 @@ func (r *CategoryPostgresRepository) Create(ctx context.Context, params usecase.
 	return &entity.Category{
 		ID:        dto.ID,
 		Name:      dto.Name,
+		ParentID:  uint64(dto.ParentID.Int64),
 		CreatedAt: dto.CreatedAt,
 		CreatedBy: dto.CreatedBy,
 		UpdatedAt: dto.UpdatedAt,
@@ -107,6 +111,7 @@ func (r *CategoryPostgresRepository) Update(ctx context.Context, id uint64, para
 	return &entity.Category{
 		ID:        dto.ID,
 		Name:      dto.Name,
+		ParentID:  uint64(dto.ParentID.Int64),
 		CreatedAt: dto.CreatedAt,
 		UpdatedAt: dto.UpdatedAt,
 		CreatedBy: dto.CreatedBy,
@@ -114,21 +119,179 @@ func (r *CategoryPostgresRepository) Update(ctx context.Context, id uint64, para
 	}, nil
 }
 
-// Delete deletes a category by its ID
-func (r *CategoryPostgresRepository) Delete(ctx context.Context, id uint64) error {
-	dto, query := categoryDtoForDelete(id)
+const selectCategoryByIDQuery = `
+select id, name, parent_id, created_at, created_by, updated_at, updated_by from categories
+where is_deleted = false
+and id = $1;
+`
 
-	_, err := r.db.NamedExecContext(ctx, query, &dto)
+// Get retrieves a category by its ID
+func (r *CategoryPostgresRepository) Get(ctx context.Context, id uint64) (*entity.Category, error) {
+	var dto categoryDto
+
+	err := r.db.GetContext(ctx, &dto, selectCategoryByIDQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrCategoryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// ListReferencingRecipes lists the live recipes of a category
+func (r *CategoryPostgresRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	return selectRecipeReferences(ctx, r.db, selectCategoryRecipeReferencesQuery, id)
+}
+
+const reassignCategoryRecipesQuery = `
+UPDATE recipes SET category_id = $1, updated_at = $2, updated_by = $3
+WHERE category_id = $4 AND is_deleted = false
+`
+
+const reassignCategoryChildrenQuery = `
+UPDATE categories SET parent_id = $1, updated_at = $2, updated_by = $3
+WHERE parent_id = $4 AND is_deleted = false
+`
+
+// Delete deletes a category by its ID, its recipes and sub-categories are moved to params.ReassignTo first when it is set
+func (r *CategoryPostgresRepository) Delete(ctx context.Context, id uint64, params usecase.DeleteParams) error {
+	dto, query := categoryDtoForDelete(id, params.Actor)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if params.ReassignTo != 0 {
+		_, err = tx.ExecContext(ctx, reassignCategoryRecipesQuery, params.ReassignTo, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, reassignCategoryChildrenQuery, params.ReassignTo, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
 	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
 		return entity.ErrCategoryNotFound
 	}
 
-	return err
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+const selectCategoryChildrenQuery = `
+select id, name, parent_id, created_at, created_by, updated_at, updated_by from categories
+where is_deleted = false
+and parent_id = $1
+order by name, id;
+`
+
+// ListChildren lists the direct sub-categories of a category
+func (r *CategoryPostgresRepository) ListChildren(ctx context.Context, id uint64) (res entity.Categories, err error) {
+	var dtos []categoryDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectCategoryChildrenQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+// selectCategoryAncestorIDsQuery walks up the parents of a category, union stops on a cycle in corrupted data
+const selectCategoryAncestorIDsQuery = `
+with recursive ancestors(id, parent_id) as (
+    select c.id, c.parent_id from categories c where c.id = $1
+    union
+    select c.id, c.parent_id from categories c
+    join ancestors a on c.id = a.parent_id
+)
+select id from ancestors where id <> $1;
+`
+
+// ListAncestorIDs lists the IDs of the parent, grandparent and so on of a category
+func (r *CategoryPostgresRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	var ids []uint64
+
+	err := r.db.SelectContext(ctx, &ids, selectCategoryAncestorIDsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// categoryDescendantIDsQuery lists a category and its live descendants, $1 is the top category
+const categoryDescendantIDsQuery = `
+with recursive descendants(id) as (
+    select c.id from categories c where c.id = $1 and c.is_deleted = false
+    union
+    select c.id from categories c
+    join descendants d on c.parent_id = d.id
+    where c.is_deleted = false
+)`
+
+const selectCategorySubtreeQuery = categoryDescendantIDsQuery + `
+select c.id, c.name, c.parent_id, c.created_at, c.created_by, c.updated_at, c.updated_by from categories c
+join descendants d on d.id = c.id
+order by c.name, c.id;
+`
+
+const selectCategoryForestQuery = `
+select id, name, parent_id, created_at, created_by, updated_at, updated_by from categories
+where is_deleted = false
+order by name, id;
+`
+
+// ListSubtree lists a category with all of its descendants, or every live category when rootID is 0.
+// Only the requested subtree is read
+func (r *CategoryPostgresRepository) ListSubtree(ctx context.Context, rootID uint64) (res entity.Categories, err error) {
+	var dtos []categoryDto
+
+	if rootID == 0 {
+		err = r.db.SelectContext(ctx, &dtos, selectCategoryForestQuery)
+	} else {
+		err = r.db.SelectContext(ctx, &dtos, selectCategorySubtreeQuery, rootID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
 }
 
 func categoryDtoForCreate(params usecase.CategoryParams) categoryDto {
+	var parentID uint64
+	if params.ParentID != nil {
+		parentID = *params.ParentID
+	}
+
 	return categoryDto{
 		Name:      params.Name,
+		ParentID:  nullableID(parentID),
 		CreatedAt: time.Now(),
 		CreatedBy: params.Actor,
 	}
@@ -144,9 +307,15 @@ func categoryDtoForUpdate(id uint64, params usecase.CategoryParams, isDeleted *b
 		dto.Name = params.Name
 	}
 
+	if params.ParentID != nil {
+		qb.WriteString("parent_id = :parent_id, ")
+		dto.ParentID = nullableID(*params.ParentID)
+	}
+
 	if isDeleted != nil {
-		qb.WriteString("is_deleted = :is_deleted, ")
+		qb.WriteString("is_deleted = :is_deleted, deleted_at = :deleted_at, ")
 		dto.IsDeleted = *isDeleted
+		dto.DeletedAt = null.NewTime(time.Now(), *isDeleted)
 	}
 
 	qb.WriteString("updated_at = :updated_at, ")
@@ -161,7 +330,7 @@ func categoryDtoForUpdate(id uint64, params usecase.CategoryParams, isDeleted *b
 	return dto, qb.String()
 }
 
-func categoryDtoForDelete(id uint64) (dto categoryDto, query string) {
+func categoryDtoForDelete(id uint64, actor string) (dto categoryDto, query string) {
 	isDeleted := true
-	return categoryDtoForUpdate(id, usecase.CategoryParams{}, &isDeleted)
+	return categoryDtoForUpdate(id, usecase.CategoryParams{Actor: actor}, &isDeleted)
 }
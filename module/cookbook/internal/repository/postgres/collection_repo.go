@@ -0,0 +1,329 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// CollectionPostgresRepository is the PostgreSQL implementation for CollectionRepository interface
+type CollectionPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewCollectionPostgresRepository instantiates CollectionPostgresRepository
+func NewCollectionPostgresRepository(db *sqlx.DB) *CollectionPostgresRepository {
+	return &CollectionPostgresRepository{db: db}
+}
+
+type collectionDto struct {
+	ID                uint64      `db:"id"`
+	Name              string      `db:"name"`
+	Description       null.String `db:"description"`
+	CoverFileKey      null.String `db:"cover_file_key"`
+	CoverThumbnailKey null.String `db:"cover_thumbnail_key"`
+	RecipeCount       int         `db:"recipe_count"`
+	CreatedAt         time.Time   `db:"created_at"`
+	CreatedBy         string      `db:"created_by"`
+	UpdatedAt         null.Time   `db:"updated_at"`
+	UpdatedBy         null.String `db:"updated_by"`
+	IsDeleted         bool        `db:"is_deleted"`
+}
+
+func (c collectionDto) toEntity() *entity.Collection {
+	return &entity.Collection{
+		ID:                c.ID,
+		Name:              c.Name,
+		Description:       c.Description,
+		CoverFileKey:      c.CoverFileKey,
+		CoverThumbnailKey: c.CoverThumbnailKey,
+		RecipeCount:       c.RecipeCount,
+		CreatedAt:         c.CreatedAt,
+		CreatedBy:         c.CreatedBy,
+		UpdatedAt:         c.UpdatedAt,
+		UpdatedBy:         c.UpdatedBy,
+		IsDeleted:         c.IsDeleted,
+	}
+}
+
+type collectionRecipeDto struct {
+	CollectionID    uint64    `db:"collection_id"`
+	RecipeID        uint64    `db:"recipe_id"`
+	RecipeName      string    `db:"recipe_name"`
+	RecipeStatus    string    `db:"recipe_status"`
+	RecipeCreatedBy string    `db:"recipe_created_by"`
+	Position        int       `db:"position"`
+	IsRecipeDeleted bool      `db:"is_recipe_deleted"`
+	CreatedAt       time.Time `db:"created_at"`
+	CreatedBy       string    `db:"created_by"`
+}
+
+func (c collectionRecipeDto) toEntity() *entity.CollectionRecipe {
+	return &entity.CollectionRecipe{
+		CollectionID:    c.CollectionID,
+		RecipeID:        c.RecipeID,
+		RecipeName:      c.RecipeName,
+		RecipeStatus:    c.RecipeStatus,
+		RecipeCreatedBy: c.RecipeCreatedBy,
+		Position:        c.Position,
+		IsRecipeDeleted: c.IsRecipeDeleted,
+		CreatedAt:       c.CreatedAt,
+		CreatedBy:       c.CreatedBy,
+	}
+}
+
+// selectCollectionColumns counts the live recipes that anybody can see only, the deleted ones and the drafts are hidden
+// from a collection. The usecase counts the drafts the viewer can see in a collection detail
+const selectCollectionColumns = `
+select c.id, c.name, c.description, c.cover_file_key, c.cover_thumbnail_key,
+(select count(*) from collection_recipes cr join recipes r on r.id = cr.recipe_id
+where cr.collection_id = c.id and r.is_deleted = false and r.status not in ('draft', 'in_review')) as recipe_count,
+c.created_at, c.created_by, c.updated_at, c.updated_by, c.is_deleted
+from collections c`
+
+const selectCollectionsQuery = selectCollectionColumns + `
+where c.is_deleted = false
+order by c.name, c.id
+limit $1 offset $2;
+`
+
+// List retrieves a list of collections with offset and limit
+func (r *CollectionPostgresRepository) List(ctx context.Context, limit, offset int) (res entity.Collections, err error) {
+	var dtos []collectionDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectCollectionsQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectCollectionByIDQuery = selectCollectionColumns + `
+where c.id = $1 and c.is_deleted = false;
+`
+
+// Get retrieves a collection by its ID
+func (r *CollectionPostgresRepository) Get(ctx context.Context, id uint64) (*entity.Collection, error) {
+	var dto collectionDto
+
+	err := r.db.GetContext(ctx, &dto, selectCollectionByIDQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrCollectionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const insertCollectionQuery = `
+INSERT INTO collections (name, description, created_at, created_by)
+VALUES ($1, $2, $3, $4) RETURNING id
+`
+
+// Create creates a new collection
+func (r *CollectionPostgresRepository) Create(ctx context.Context, params usecase.CollectionParams) (*entity.Collection, error) {
+	dto := collectionDto{
+		Name:        params.Name,
+		Description: null.NewString(params.Description, params.Description != ""),
+		CreatedAt:   time.Now(),
+		CreatedBy:   params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertCollectionQuery, dto.Name, dto.Description, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates the name and description of a collection by its ID
+func (r *CollectionPostgresRepository) Update(ctx context.Context, id uint64, params usecase.CollectionParams) error {
+	dto, query := collectionDtoForUpdate(id, params, nil)
+
+	return r.execCollectionUpdate(ctx, query, &dto)
+}
+
+const updateCollectionCoverQuery = `
+UPDATE collections SET cover_file_key = :cover_file_key, cover_thumbnail_key = :cover_thumbnail_key,
+updated_at = :updated_at, updated_by = :updated_by
+WHERE id = :id AND is_deleted = false
+`
+
+// UpdateCover records the stored cover image of a collection
+func (r *CollectionPostgresRepository) UpdateCover(ctx context.Context, id uint64, params usecase.CollectionCoverParams) error {
+	dto := collectionDto{
+		ID:                id,
+		CoverFileKey:      null.StringFrom(params.FileKey),
+		CoverThumbnailKey: null.StringFrom(params.ThumbnailKey),
+		UpdatedAt:         null.TimeFrom(time.Now()),
+		UpdatedBy:         null.StringFrom(params.Actor),
+	}
+
+	return r.execCollectionUpdate(ctx, updateCollectionCoverQuery, &dto)
+}
+
+// Delete soft-deletes a collection by its ID
+func (r *CollectionPostgresRepository) Delete(ctx context.Context, id uint64, actor string) error {
+	isDeleted := true
+	dto, query := collectionDtoForUpdate(id, usecase.CollectionParams{Actor: actor}, &isDeleted)
+
+	return r.execCollectionUpdate(ctx, query, &dto)
+}
+
+func (r *CollectionPostgresRepository) execCollectionUpdate(ctx context.Context, query string, dto *collectionDto) error {
+	result, err := r.db.NamedExecContext(ctx, query, dto)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+// selectCollectionRecipesQuery selects every recipe of a collection including the deleted ones and the drafts,
+// the usecase hides those the viewer cannot see and flags the gaps they leave
+const selectCollectionRecipesQuery = `
+select cr.collection_id, cr.recipe_id, r.name as recipe_name, r.status as recipe_status, r.created_by as recipe_created_by,
+cr.position, r.is_deleted as is_recipe_deleted,
+cr.created_at, cr.created_by
+from collection_recipes cr
+join recipes r on r.id = cr.recipe_id
+where cr.collection_id = $1
+order by cr.position, cr.created_at;
+`
+
+// ListRecipes retrieves the recipes of a collection by position
+func (r *CollectionPostgresRepository) ListRecipes(ctx context.Context, id uint64) (res entity.CollectionRecipes, err error) {
+	var dtos []collectionRecipeDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectCollectionRecipesQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+// insertCollectionRecipeQuery appends a recipe after the last one, it only inserts when the recipe exists and is not deleted
+const insertCollectionRecipeQuery = `
+INSERT INTO collection_recipes (collection_id, recipe_id, position, created_at, created_by)
+SELECT $1, r.id, (select coalesce(max(position), 0) + 1 from collection_recipes where collection_id = $1), $3, $4
+FROM recipes r
+WHERE r.id = $2 AND r.is_deleted = false
+ON CONFLICT (collection_id, recipe_id) DO NOTHING
+`
+
+// AddRecipe appends a recipe to a collection
+func (r *CollectionPostgresRepository) AddRecipe(ctx context.Context, id, recipeID uint64, actor string) error {
+	result, err := r.db.ExecContext(ctx, insertCollectionRecipeQuery, id, recipeID, time.Now(), actor)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrRecipeNotFound
+	}
+
+	return nil
+}
+
+const deleteCollectionRecipeQuery = `
+DELETE FROM collection_recipes WHERE collection_id = $1 AND recipe_id = $2
+`
+
+// RemoveRecipe removes a recipe from a collection
+func (r *CollectionPostgresRepository) RemoveRecipe(ctx context.Context, id, recipeID uint64) error {
+	result, err := r.db.ExecContext(ctx, deleteCollectionRecipeQuery, id, recipeID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrCollectionRecipeNotFound
+	}
+
+	return nil
+}
+
+const updateCollectionRecipePositionsQuery = `
+UPDATE collection_recipes cr SET position = o.position
+FROM unnest($2::bigint[]) WITH ORDINALITY AS o(recipe_id, position)
+WHERE cr.collection_id = $1 AND cr.recipe_id = o.recipe_id
+`
+
+// SetPositions numbers the recipes of a collection in the order of recipeIDs
+func (r *CollectionPostgresRepository) SetPositions(ctx context.Context, id uint64, recipeIDs []uint64) error {
+	_, err := r.db.ExecContext(ctx, updateCollectionRecipePositionsQuery, id, pq.Array(recipeIDs))
+	return err
+}
+
+func collectionDtoForUpdate(id uint64, params usecase.CollectionParams, isDeleted *bool) (dto collectionDto, query string) {
+	var qb strings.Builder
+
+	qb.WriteString("UPDATE collections SET ")
+
+	if params.Name != "" {
+		qb.WriteString("name = :name, ")
+		dto.Name = params.Name
+	}
+
+	if params.Description != "" {
+		qb.WriteString("description = :description, ")
+		dto.Description = null.StringFrom(params.Description)
+	}
+
+	if isDeleted != nil {
+		qb.WriteString("is_deleted = :is_deleted, ")
+		dto.IsDeleted = *isDeleted
+	}
+
+	qb.WriteString("updated_at = :updated_at, ")
+	dto.UpdatedAt = null.TimeFrom(time.Now())
+
+	qb.WriteString("updated_by = :updated_by ")
+	dto.UpdatedBy = null.StringFrom(params.Actor)
+
+	qb.WriteString("WHERE id = :id AND is_deleted = false")
+	dto.ID = id
+
+	return dto, qb.String()
+}
@@ -0,0 +1,220 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// CookLogPostgresRepository is the PostgreSQL implementation for CookLogRepository interface
+type CookLogPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewCookLogPostgresRepository instantiates CookLogPostgresRepository
+func NewCookLogPostgresRepository(db *sqlx.DB) *CookLogPostgresRepository {
+	return &CookLogPostgresRepository{db: db}
+}
+
+type cookLogDto struct {
+	ID                uint64      `db:"id"`
+	RecipeID          uint64      `db:"recipe_id"`
+	CookedOn          time.Time   `db:"cooked_on"`
+	Servings          int         `db:"servings"`
+	Rating            null.Int    `db:"rating"`
+	Notes             null.String `db:"notes"`
+	PhotoFileKey      null.String `db:"photo_file_key"`
+	PhotoThumbnailKey null.String `db:"photo_thumbnail_key"`
+	CreatedAt         time.Time   `db:"created_at"`
+	CreatedBy         string      `db:"created_by"`
+	UpdatedAt         null.Time   `db:"updated_at"`
+	UpdatedBy         null.String `db:"updated_by"`
+}
+
+func (c cookLogDto) toEntity() *entity.CookLog {
+	return &entity.CookLog{
+		ID:                c.ID,
+		RecipeID:          c.RecipeID,
+		CookedOn:          c.CookedOn,
+		Servings:          c.Servings,
+		Rating:            c.Rating,
+		Notes:             c.Notes,
+		PhotoFileKey:      c.PhotoFileKey,
+		PhotoThumbnailKey: c.PhotoThumbnailKey,
+		CreatedAt:         c.CreatedAt,
+		CreatedBy:         c.CreatedBy,
+		UpdatedAt:         c.UpdatedAt,
+		UpdatedBy:         c.UpdatedBy,
+	}
+}
+
+const selectCookLogColumns = `
+select id, recipe_id, cooked_on, servings, rating, notes, photo_file_key, photo_thumbnail_key, created_at, created_by, updated_at, updated_by
+from cook_logs`
+
+const selectCookLogByIDQuery = selectCookLogColumns + `
+where id = $1;
+`
+
+// Get retrieves a cook log by its ID
+func (r *CookLogPostgresRepository) Get(ctx context.Context, id uint64) (*entity.CookLog, error) {
+	var dto cookLogDto
+
+	err := r.db.GetContext(ctx, &dto, selectCookLogByIDQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrCookLogNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const selectCookLogsByRecipeIDQuery = selectCookLogColumns + `
+where recipe_id = $1
+order by cooked_on desc, id desc
+limit $2 offset $3;
+`
+
+// ListByRecipeID retrieves the cook logs of a recipe, latest first
+func (r *CookLogPostgresRepository) ListByRecipeID(ctx context.Context, recipeID uint64, limit, offset int) (res entity.CookLogs, err error) {
+	var dtos []cookLogDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectCookLogsByRecipeIDQuery, recipeID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertCookLogQuery = `
+INSERT INTO cook_logs (recipe_id, cooked_on, servings, rating, notes, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+// addRecipeCookLogQuery counts a cook log in the aggregates of its recipe, $2 is its rating or null
+const addRecipeCookLogQuery = `
+UPDATE recipes SET
+    cook_count = cook_count + 1,
+    rating_count = rating_count + (CASE WHEN $2::int IS NULL THEN 0 ELSE 1 END),
+    rating_sum = rating_sum + coalesce($2::int, 0)
+WHERE id = $1
+`
+
+// Create records a cook log and counts it in the aggregates of its recipe
+func (r *CookLogPostgresRepository) Create(ctx context.Context, params usecase.CookLogParams) (*entity.CookLog, error) {
+	dto := cookLogDto{
+		RecipeID:  params.RecipeID,
+		CookedOn:  params.CookedOn,
+		Servings:  params.Servings,
+		Rating:    params.Rating,
+		Notes:     null.NewString(params.Notes, params.Notes != ""),
+		CreatedAt: time.Now(),
+		CreatedBy: params.Actor,
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowxContext(ctx, insertCookLogQuery, dto.RecipeID, dto.CookedOn, dto.Servings, dto.Rating, dto.Notes,
+		dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, addRecipeCookLogQuery, dto.RecipeID, dto.Rating)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const updateCookLogPhotoQuery = `
+UPDATE cook_logs SET photo_file_key = $1, photo_thumbnail_key = $2, updated_at = $3, updated_by = $4
+WHERE id = $5
+`
+
+// UpdatePhoto records the stored photo of a cook log
+func (r *CookLogPostgresRepository) UpdatePhoto(ctx context.Context, id uint64, params usecase.CookLogPhotoParams) error {
+	result, err := r.db.ExecContext(ctx, updateCookLogPhotoQuery, params.FileKey, params.ThumbnailKey, time.Now(), params.Actor, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrCookLogNotFound
+	}
+
+	return nil
+}
+
+const deleteCookLogQuery = `
+DELETE FROM cook_logs WHERE id = $1
+RETURNING recipe_id, rating
+`
+
+// removeRecipeCookLogQuery takes a cook log out of the aggregates of its recipe, $2 is its rating or null
+const removeRecipeCookLogQuery = `
+UPDATE recipes SET
+    cook_count = cook_count - 1,
+    rating_count = rating_count - (CASE WHEN $2::int IS NULL THEN 0 ELSE 1 END),
+    rating_sum = rating_sum - coalesce($2::int, 0)
+WHERE id = $1
+`
+
+// Delete permanently deletes a cook log by its ID and takes it out of the aggregates of its recipe
+func (r *CookLogPostgresRepository) Delete(ctx context.Context, id uint64) error {
+	var dto cookLogDto
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowxContext(ctx, deleteCookLogQuery, id).Scan(&dto.RecipeID, &dto.Rating)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return entity.ErrCookLogNotFound
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, removeRecipeCookLogQuery, dto.RecipeID, dto.Rating)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
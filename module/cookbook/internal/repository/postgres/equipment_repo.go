@@ -0,0 +1,196 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// EquipmentPostgresRepository is the PostgreSQL implementation for EquipmentRepository interface
+type EquipmentPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewEquipmentPostgresRepository instantiates EquipmentPostgresRepository
+func NewEquipmentPostgresRepository(db *sqlx.DB) *EquipmentPostgresRepository {
+	return &EquipmentPostgresRepository{db: db}
+}
+
+type equipmentDto struct {
+	ID        uint64      `db:"id"`
+	Name      string      `db:"name"`
+	CreatedAt time.Time   `db:"created_at"`
+	CreatedBy string      `db:"created_by"`
+	UpdatedAt null.Time   `db:"updated_at"`
+	UpdatedBy null.String `db:"updated_by"`
+	IsDeleted bool        `db:"is_deleted"`
+}
+
+func (c equipmentDto) toEntity() *entity.Equipment {
+	return &entity.Equipment{
+		ID:        c.ID,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt,
+		CreatedBy: c.CreatedBy,
+		UpdatedAt: c.UpdatedAt,
+		UpdatedBy: c.UpdatedBy,
+		IsDeleted: c.IsDeleted,
+	}
+}
+
+const selectEquipmentColumns = `
+select id, name, created_at, created_by, updated_at, updated_by, is_deleted
+from equipment`
+
+const selectEquipmentQuery = selectEquipmentColumns + `
+where is_deleted = false
+order by name, id
+limit $1 offset $2;
+`
+
+// List retrieves a list of equipment with offset and limit
+func (r *EquipmentPostgresRepository) List(ctx context.Context, limit, offset int) (res entity.Equipments, err error) {
+	var dtos []equipmentDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectEquipmentQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectEquipmentByIDsQuery = selectEquipmentColumns + `
+where is_deleted = false
+and id in (?);
+`
+
+// ListByIDs retrieves equipment by their IDs
+func (r *EquipmentPostgresRepository) ListByIDs(ctx context.Context, ids []uint64) (res entity.Equipments, err error) {
+	var dtos []equipmentDto
+
+	query, args, err := sqlx.In(selectEquipmentByIDsQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertEquipmentQuery = `
+INSERT INTO equipment (name, created_at, created_by)
+VALUES ($1, $2, $3) RETURNING id
+`
+
+// Create creates a new equipment
+func (r *EquipmentPostgresRepository) Create(ctx context.Context, params usecase.EquipmentParams) (*entity.Equipment, error) {
+	dto := equipmentDto{
+		Name:      params.Name,
+		CreatedAt: time.Now(),
+		CreatedBy: params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertEquipmentQuery, dto.Name, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates an equipment by its ID
+func (r *EquipmentPostgresRepository) Update(ctx context.Context, id uint64, params usecase.EquipmentParams) (*entity.Equipment, error) {
+	dto, query := equipmentDtoForUpdate(id, params, nil)
+
+	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrEquipmentNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const deleteRecipeEquipmentOfEquipmentQuery = `
+DELETE FROM recipe_equipment WHERE equipment_id = $1
+`
+
+// Delete deletes an equipment by its ID and removes it from its recipes
+func (r *EquipmentPostgresRepository) Delete(ctx context.Context, id uint64) error {
+	isDeleted := true
+	dto, query := equipmentDtoForUpdate(id, usecase.EquipmentParams{}, &isDeleted)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return entity.ErrEquipmentNotFound
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRecipeEquipmentOfEquipmentQuery, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func equipmentDtoForUpdate(id uint64, params usecase.EquipmentParams, isDeleted *bool) (dto equipmentDto, query string) {
+	var qb strings.Builder
+
+	qb.WriteString("UPDATE equipment SET ")
+
+	if params.Name != "" {
+		qb.WriteString("name = :name, ")
+		dto.Name = params.Name
+	}
+
+	if isDeleted != nil {
+		qb.WriteString("is_deleted = :is_deleted, ")
+		dto.IsDeleted = *isDeleted
+	}
+
+	qb.WriteString("updated_at = :updated_at, ")
+	dto.UpdatedAt = null.TimeFrom(time.Now())
+
+	qb.WriteString("updated_by = :updated_by ")
+	dto.UpdatedBy = null.StringFrom(params.Actor)
+
+	qb.WriteString("WHERE id = :id")
+	dto.ID = id
+
+	return dto, qb.String()
+}
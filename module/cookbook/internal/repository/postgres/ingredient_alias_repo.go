@@ -0,0 +1,334 @@
+package postgres_repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type ingredientAliasDto struct {
+	ID             uint64      `db:"id"`
+	IngredientID   uint64      `db:"ingredient_id"`
+	Name           string      `db:"name"`
+	NormalizedName string      `db:"normalized_name"`
+	CreatedAt      time.Time   `db:"created_at"`
+	CreatedBy      string      `db:"created_by"`
+	UpdatedAt      null.Time   `db:"updated_at"`
+	UpdatedBy      null.String `db:"updated_by"`
+	IsDeleted      bool        `db:"is_deleted"`
+}
+
+func (c ingredientAliasDto) toEntity() *entity.IngredientAlias {
+	return &entity.IngredientAlias{
+		ID:             c.ID,
+		IngredientID:   c.IngredientID,
+		Name:           c.Name,
+		NormalizedName: c.NormalizedName,
+		CreatedAt:      c.CreatedAt,
+		CreatedBy:      c.CreatedBy,
+		UpdatedAt:      c.UpdatedAt,
+		UpdatedBy:      c.UpdatedBy,
+		IsDeleted:      c.IsDeleted,
+	}
+}
+
+const selectIngredientAliasesQuery = `
+select id, ingredient_id, name, normalized_name, created_at, created_by, updated_at, updated_by, is_deleted
+from ingredient_aliases
+where is_deleted = false
+and ingredient_id = $1
+order by id;
+`
+
+// ListAliases retrieves the aliases of an ingredient
+func (r *IngredientPostgresRepository) ListAliases(ctx context.Context, ingredientID uint64) (res entity.IngredientAliases, err error) {
+	var dtos []ingredientAliasDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectIngredientAliasesQuery, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertIngredientAliasQuery = `
+INSERT INTO ingredient_aliases (ingredient_id, name, normalized_name, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5) RETURNING id
+`
+
+// CreateAlias creates a new ingredient alias
+func (r *IngredientPostgresRepository) CreateAlias(ctx context.Context, params usecase.IngredientAliasParams) (*entity.IngredientAlias, error) {
+	dto := ingredientAliasDto{
+		IngredientID:   params.IngredientID,
+		Name:           params.Name,
+		NormalizedName: params.NormalizedName,
+		CreatedAt:      time.Now(),
+		CreatedBy:      params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertIngredientAliasQuery, dto.IngredientID, dto.Name, dto.NormalizedName, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if isUniqueViolation(err, ingredientAliasNormalizedNameIndex) {
+		return nil, entity.ErrIngredientNameConflict
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const deleteIngredientAliasQuery = `
+UPDATE ingredient_aliases SET is_deleted = true, updated_at = $2
+WHERE id = $1
+AND is_deleted = false
+`
+
+// DeleteAlias deletes an ingredient alias by its ID
+func (r *IngredientPostgresRepository) DeleteAlias(ctx context.Context, id uint64) error {
+	res, err := r.db.ExecContext(ctx, deleteIngredientAliasQuery, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return entity.ErrIngredientAliasNotFound
+	}
+
+	return nil
+}
+
+const mergeRecipeIngredientsQuery = `
+UPDATE recipe_ingredients SET
+    ingredient_id = ?,
+    ingredient_name = (select name from ingredients where id = ?),
+    updated_at = ?,
+    updated_by = ?
+WHERE ingredient_id in (?)
+`
+
+const mergeIngredientPricesQuery = `
+UPDATE ingredient_prices SET ingredient_id = ?, updated_at = ?, updated_by = ?
+WHERE ingredient_id in (?)
+`
+
+const mergeIngredientAliasesQuery = `
+UPDATE ingredient_aliases SET ingredient_id = ?, updated_at = ?, updated_by = ?
+WHERE ingredient_id in (?)
+AND is_deleted = false
+`
+
+// names of the duplicates become aliases unless they are the same as the surviving name or an existing alias
+const insertMergedIngredientAliasesQuery = `
+INSERT INTO ingredient_aliases (ingredient_id, name, normalized_name, created_at, created_by)
+SELECT DISTINCT ON (i.normalized_name) ?, i.name, i.normalized_name, ?, ?
+FROM ingredients i
+WHERE i.id in (?)
+AND i.normalized_name IS NOT NULL
+AND i.normalized_name IS DISTINCT FROM (select normalized_name from ingredients where id = ?)
+AND NOT EXISTS (select 1 from ingredient_aliases a where a.is_deleted = false and a.normalized_name = i.normalized_name)
+ORDER BY i.normalized_name, i.id
+`
+
+// deleteMergedIngredientSubstitutionsQuery deletes the substitutions of the duplicates that would replace the ingredient
+// by itself once merged, or that the ingredient or an earlier duplicate already has
+const deleteMergedIngredientSubstitutionsQuery = `
+WITH merged AS (
+    SELECT id,
+        ingredient_id = ANY($2::bigint[]) OR substitute_ingredient_id = ANY($2::bigint[]) AS is_moved,
+        CASE WHEN ingredient_id = ANY($2::bigint[]) THEN $1::int ELSE ingredient_id END AS merged_ingredient_id,
+        CASE WHEN substitute_ingredient_id = ANY($2::bigint[]) THEN $1::int ELSE substitute_ingredient_id END AS merged_substitute_id
+    FROM ingredient_substitutions
+    WHERE is_deleted = false
+)
+UPDATE ingredient_substitutions s SET is_deleted = true, updated_at = $3, updated_by = $4
+FROM merged m
+WHERE s.id = m.id
+AND m.is_moved
+AND (m.merged_ingredient_id = m.merged_substitute_id OR EXISTS (
+    SELECT 1 FROM merged o
+    WHERE o.merged_ingredient_id = m.merged_ingredient_id
+    AND o.merged_substitute_id = m.merged_substitute_id
+    AND o.id <> m.id
+    AND (NOT o.is_moved OR o.id < m.id)
+))
+`
+
+const mergeIngredientSubstitutionsQuery = `
+UPDATE ingredient_substitutions SET
+    ingredient_id = CASE WHEN ingredient_id = ANY($2::bigint[]) THEN $1::int ELSE ingredient_id END,
+    substitute_ingredient_id = CASE WHEN substitute_ingredient_id = ANY($2::bigint[]) THEN $1::int ELSE substitute_ingredient_id END,
+    updated_at = $3,
+    updated_by = $4
+WHERE is_deleted = false
+AND (ingredient_id = ANY($2::bigint[]) OR substitute_ingredient_id = ANY($2::bigint[]))
+`
+
+// restoreMergedIngredientUnitConversionsQuery writes a conversion of the duplicates over a deleted conversion of the
+// ingredient in the same unit, the unique index covers deleted conversions too
+const restoreMergedIngredientUnitConversionsQuery = `
+UPDATE ingredient_unit_conversions c SET base_amount = d.base_amount, is_deleted = false, updated_at = $3, updated_by = $4
+FROM (
+    SELECT DISTINCT ON (ingredient_unit_id) ingredient_unit_id, base_amount
+    FROM ingredient_unit_conversions
+    WHERE ingredient_id = ANY($2::bigint[])
+    AND is_deleted = false
+    ORDER BY ingredient_unit_id, id
+) d
+WHERE c.ingredient_id = $1
+AND c.ingredient_unit_id = d.ingredient_unit_id
+AND c.is_deleted = true
+`
+
+// deleteMergedIngredientUnitConversionsQuery deletes the conversions of the duplicates in a unit the ingredient
+// or an earlier duplicate already converts
+const deleteMergedIngredientUnitConversionsQuery = `
+UPDATE ingredient_unit_conversions c SET is_deleted = true, updated_at = $3, updated_by = $4
+WHERE c.ingredient_id = ANY($2::bigint[])
+AND c.is_deleted = false
+AND (
+    EXISTS (select 1 from ingredient_unit_conversions o where o.ingredient_id = $1 and o.ingredient_unit_id = c.ingredient_unit_id)
+    OR EXISTS (
+        select 1 from ingredient_unit_conversions o
+        where o.ingredient_id = ANY($2::bigint[]) and o.is_deleted = false and o.ingredient_unit_id = c.ingredient_unit_id and o.id < c.id
+    )
+)
+`
+
+const mergeIngredientUnitConversionsQuery = `
+UPDATE ingredient_unit_conversions SET ingredient_id = $1, updated_at = $3, updated_by = $4
+WHERE ingredient_id = ANY($2::bigint[])
+AND is_deleted = false
+`
+
+// mergeIngredientParentQuery moves the ingredient out from under a duplicate, to the parent of the duplicate
+const mergeIngredientParentQuery = `
+UPDATE ingredients SET parent_id = (select d.parent_id from ingredients d where d.id = ingredients.parent_id), updated_at = ?, updated_by = ?
+WHERE id = ? AND parent_id in (?)
+`
+
+// mergeIngredientBaseUnitQuery gives the ingredient the base unit of the duplicates when it has none, the usecase checks
+// that the duplicates have only one
+const mergeIngredientBaseUnitQuery = `
+UPDATE ingredients SET base_unit_name = (
+    select d.base_unit_name from ingredients d where d.id in (?) and d.base_unit_name is not null limit 1
+), updated_at = ?, updated_by = ?
+WHERE id = ? AND base_unit_name IS NULL
+`
+
+const mergeIngredientChildrenQuery = `
+UPDATE ingredients SET parent_id = ?, updated_at = ?, updated_by = ?
+WHERE parent_id in (?) AND id <> ? AND is_deleted = false
+`
+
+const deleteMergedIngredientsQuery = `
+UPDATE ingredients SET is_deleted = true, deleted_at = ?, updated_at = ?, updated_by = ?
+WHERE id in (?)
+`
+
+// Merge moves recipe ingredients, prices, aliases, substitutions, unit conversions, pantry stock and child ingredients
+// of the duplicates to the ingredient, along with their base unit when it has none, adds the duplicate names as aliases
+// and deletes the duplicates in a single transaction.
+// Substitutions that would become duplicates or replace the ingredient by itself, and conversions in a unit the
+// ingredient already converts, are deleted instead of moved
+func (r *IngredientPostgresRepository) Merge(ctx context.Context, params usecase.IngredientMergeParams) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	err = execIn(ctx, tx, mergeRecipeIngredientsQuery, params.IngredientID, params.IngredientID, now, params.Actor, params.DuplicateIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = execIn(ctx, tx, mergeIngredientPricesQuery, params.IngredientID, now, params.Actor, params.DuplicateIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = execIn(ctx, tx, mergeIngredientAliasesQuery, params.IngredientID, now, params.Actor, params.DuplicateIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = execIn(ctx, tx, insertMergedIngredientAliasesQuery, params.IngredientID, now, params.Actor, params.DuplicateIDs, params.IngredientID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	queries := []string{
+		deleteMergedIngredientSubstitutionsQuery,
+		mergeIngredientSubstitutionsQuery,
+		restoreMergedIngredientUnitConversionsQuery,
+		deleteMergedIngredientUnitConversionsQuery,
+		mergeIngredientUnitConversionsQuery,
+	}
+	for _, query := range queries {
+		_, err = tx.ExecContext(ctx, query, params.IngredientID, pq.Array(params.DuplicateIDs), now, params.Actor)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	err = execIn(ctx, tx, mergeIngredientParentQuery, now, params.Actor, params.IngredientID, params.DuplicateIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = execIn(ctx, tx, mergeIngredientChildrenQuery, params.IngredientID, now, params.Actor, params.DuplicateIDs, params.IngredientID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = execIn(ctx, tx, mergeIngredientBaseUnitQuery, params.DuplicateIDs, now, params.Actor, params.IngredientID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = mergePantryItems(ctx, tx, params.IngredientID, params.DuplicateIDs, now, params.Actor)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = execIn(ctx, tx, deleteMergedIngredientsQuery, now, now, params.Actor, params.DuplicateIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// execIn expands slice arguments of a query with IN clauses and executes it in a transaction
+func execIn(ctx context.Context, tx *sqlx.Tx, query string, args ...interface{}) error {
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), args...)
+	return err
+}
@@ -0,0 +1,158 @@
+package postgres_repo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	postgres_repo "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/postgres"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// testDB connects to the migrated database of COOKBOOK_TEST_DATABASE_URL, the test is skipped without it
+func testDB(t *testing.T) *sqlx.DB {
+	dsn := os.Getenv("COOKBOOK_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("COOKBOOK_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func insertID(t *testing.T, db *sqlx.DB, query string, args ...interface{}) uint64 {
+	var id uint64
+	require.NoError(t, db.QueryRowx(query+" RETURNING id", args...).Scan(&id))
+
+	return id
+}
+
+func TestIngredientPostgresRepository_Merge(t *testing.T) {
+	db := testDB(t)
+	repo := postgres_repo.NewIngredientPostgresRepository(db)
+	suffix := time.Now().Format("150405.000000")
+
+	var ingredientIDs, unitIDs []uint64
+	for _, name := range []string{"Telur", "Telor", "Telur ayam", "Kecap", "Garam"} {
+		ingredientIDs = append(ingredientIDs, insertID(t, db, "INSERT INTO ingredients (name, created_by) VALUES ($1, 'test')", name+" "+suffix))
+	}
+	for _, name := range []string{"butir", "kotak", "rak"} {
+		unitIDs = append(unitIDs, insertID(t, db, "INSERT INTO ingredient_units (name, created_by) VALUES ($1, 'test')", name+" "+suffix))
+	}
+
+	t.Cleanup(func() {
+		ids := pq.Array(ingredientIDs)
+
+		// the ledger is append-only, its trigger is only lifted inside the transaction deleting the test movements
+		tx := db.MustBegin()
+		_, _ = tx.Exec("ALTER TABLE pantry_movements DISABLE TRIGGER trg_pantry_movements_append_only")
+		_, _ = tx.Exec("DELETE FROM pantry_movements WHERE ingredient_id = ANY($1::bigint[])", ids)
+		_, _ = tx.Exec("ALTER TABLE pantry_movements ENABLE TRIGGER trg_pantry_movements_append_only")
+		_ = tx.Commit()
+
+		_, _ = db.Exec("DELETE FROM pantry_items WHERE ingredient_id = ANY($1::bigint[])", ids)
+		_, _ = db.Exec("DELETE FROM ingredient_substitutions WHERE ingredient_id = ANY($1::bigint[]) OR substitute_ingredient_id = ANY($1::bigint[])", ids)
+		_, _ = db.Exec("DELETE FROM ingredient_unit_conversions WHERE ingredient_id = ANY($1::bigint[])", ids)
+		_, _ = db.Exec("DELETE FROM ingredient_aliases WHERE ingredient_id = ANY($1::bigint[])", ids)
+		_, _ = db.Exec("DELETE FROM ingredients WHERE id = ANY($1::bigint[])", ids)
+		_, _ = db.Exec("DELETE FROM ingredient_units WHERE id = ANY($1::bigint[])", pq.Array(unitIDs))
+	})
+
+	telur, telor, telurAyam, kecap, garam := ingredientIDs[0], ingredientIDs[1], ingredientIDs[2], ingredientIDs[3], ingredientIDs[4]
+	butir, kotak, rak := unitIDs[0], unitIDs[1], unitIDs[2]
+
+	insertSubstitution := "INSERT INTO ingredient_substitutions (ingredient_id, substitute_ingredient_id, created_by) VALUES ($1, $2, 'test')"
+	kept := insertID(t, db, insertSubstitution, telur, kecap)
+	insertID(t, db, insertSubstitution, telor, kecap) // the ingredient already has it
+	insertID(t, db, insertSubstitution, telor, telur) // would replace the ingredient by itself
+	moved := insertID(t, db, insertSubstitution, telurAyam, garam)
+	insertID(t, db, insertSubstitution, telor, garam) // an earlier duplicate already has it
+	substitute := insertID(t, db, insertSubstitution, kecap, telurAyam)
+
+	insertConversion := "INSERT INTO ingredient_unit_conversions (ingredient_id, ingredient_unit_id, base_amount, is_deleted, created_by) VALUES ($1, $2, $3, $4, 'test')"
+	insertID(t, db, insertConversion, telur, butir, 50, false)
+	insertID(t, db, insertConversion, telor, butir, 60, false)
+	insertID(t, db, insertConversion, telor, kotak, 600, false)
+	insertID(t, db, insertConversion, telurAyam, kotak, 700, false)
+	insertID(t, db, insertConversion, telur, rak, 1000, true)
+	insertID(t, db, insertConversion, telurAyam, rak, 1500, false)
+
+	insertPantryItem := "INSERT INTO pantry_items (ingredient_id, quantity, low_stock_threshold, updated_by) VALUES ($1, $2, $3, 'test')"
+	_, err := db.Exec(insertPantryItem, telur, 5, nil)
+	require.NoError(t, err)
+	_, err = db.Exec(insertPantryItem, telor, 3, 2)
+	require.NoError(t, err)
+
+	err = repo.Merge(context.Background(), usecase.IngredientMergeParams{
+		IngredientID: telur,
+		DuplicateIDs: []uint64{telor, telurAyam},
+		Actor:        "test",
+	})
+	require.NoError(t, err)
+
+	var substitutions []struct {
+		ID                     uint64 `db:"id"`
+		IngredientID           uint64 `db:"ingredient_id"`
+		SubstituteIngredientID uint64 `db:"substitute_ingredient_id"`
+	}
+	err = db.Select(&substitutions, `
+		SELECT id, ingredient_id, substitute_ingredient_id FROM ingredient_substitutions
+		WHERE is_deleted = false AND (ingredient_id = ANY($1::bigint[]) OR substitute_ingredient_id = ANY($1::bigint[]))
+		ORDER BY id`, pq.Array(ingredientIDs))
+	require.NoError(t, err)
+
+	var got [][3]uint64
+	for _, s := range substitutions {
+		got = append(got, [3]uint64{s.ID, s.IngredientID, s.SubstituteIngredientID})
+	}
+
+	// the duplicate, the self-replacing and the repeated substitutions are deleted, the others are repointed
+	assert.Equal(t, [][3]uint64{{kept, telur, kecap}, {moved, telur, garam}, {substitute, kecap, telur}}, got)
+
+	var conversions []struct {
+		IngredientID     uint64  `db:"ingredient_id"`
+		IngredientUnitID uint64  `db:"ingredient_unit_id"`
+		BaseAmount       float64 `db:"base_amount"`
+	}
+	err = db.Select(&conversions, `
+		SELECT ingredient_id, ingredient_unit_id, base_amount FROM ingredient_unit_conversions
+		WHERE is_deleted = false AND ingredient_id = ANY($1::bigint[])
+		ORDER BY ingredient_unit_id`, pq.Array(ingredientIDs))
+	require.NoError(t, err)
+
+	// the ingredient keeps its own conversion, takes the first one of the duplicates and gets a deleted one back
+	require.Len(t, conversions, 3)
+	assert.Equal(t, []uint64{telur, butir}, []uint64{conversions[0].IngredientID, conversions[0].IngredientUnitID})
+	assert.Equal(t, 50.0, conversions[0].BaseAmount)
+	assert.Equal(t, []uint64{telur, kotak}, []uint64{conversions[1].IngredientID, conversions[1].IngredientUnitID})
+	assert.Equal(t, 600.0, conversions[1].BaseAmount)
+	assert.Equal(t, []uint64{telur, rak}, []uint64{conversions[2].IngredientID, conversions[2].IngredientUnitID})
+	assert.Equal(t, 1500.0, conversions[2].BaseAmount)
+
+	// the stock of the duplicate is moved through the ledger and the ingredient takes its threshold
+	var item struct {
+		Quantity          float64 `db:"quantity"`
+		LowStockThreshold float64 `db:"low_stock_threshold"`
+	}
+	require.NoError(t, db.Get(&item, "SELECT quantity, low_stock_threshold FROM pantry_items WHERE ingredient_id = $1", telur))
+	assert.Equal(t, 8.0, item.Quantity)
+	assert.Equal(t, 2.0, item.LowStockThreshold)
+
+	var duplicateItems int
+	require.NoError(t, db.Get(&duplicateItems, "SELECT count(*) FROM pantry_items WHERE ingredient_id = $1", telor))
+	assert.Zero(t, duplicateItems)
+
+	var balances []float64
+	require.NoError(t, db.Select(&balances, "SELECT balance_after FROM pantry_movements WHERE ingredient_id = ANY($1::bigint[]) ORDER BY id", pq.Array([]uint64{telur, telor})))
+	assert.Equal(t, []float64{0, 8}, balances)
+}
@@ -0,0 +1,253 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// IngredientKindPostgresRepository is the PostgreSQL implementation for IngredientKindRepository interface
+type IngredientKindPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewIngredientKindPostgresRepository instantiates IngredientKindPostgresRepository
+func NewIngredientKindPostgresRepository(db *sqlx.DB) *IngredientKindPostgresRepository {
+	return &IngredientKindPostgresRepository{db: db}
+}
+
+type ingredientKindDto struct {
+	ID        uint64      `db:"id"`
+	Name      string      `db:"name"`
+	ParentID  null.Int    `db:"parent_id"`
+	Aisle     null.String `db:"aisle"`
+	CreatedAt time.Time   `db:"created_at"`
+	CreatedBy string      `db:"created_by"`
+	UpdatedAt null.Time   `db:"updated_at"`
+	UpdatedBy null.String `db:"updated_by"`
+	IsDeleted bool        `db:"is_deleted"`
+}
+
+func (c ingredientKindDto) toEntity() *entity.IngredientKind {
+	return &entity.IngredientKind{
+		ID:        c.ID,
+		Name:      c.Name,
+		ParentID:  uint64(c.ParentID.Int64),
+		Aisle:     c.Aisle,
+		CreatedAt: c.CreatedAt,
+		CreatedBy: c.CreatedBy,
+		UpdatedAt: c.UpdatedAt,
+		UpdatedBy: c.UpdatedBy,
+		IsDeleted: c.IsDeleted,
+	}
+}
+
+const selectIngredientKindColumns = `
+select id, name, parent_id, aisle, created_at, created_by, updated_at, updated_by, is_deleted
+from ingredient_kinds`
+
+const selectIngredientKindQuery = selectIngredientKindColumns + `
+where is_deleted = false
+order by id
+limit $1 offset $2;
+`
+
+// List retrieves a list of ingredient kinds with offset and limit
+func (r *IngredientKindPostgresRepository) List(ctx context.Context, limit, offset int) (res entity.IngredientKinds, err error) {
+	var dtos []ingredientKindDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectIngredientKindQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectIngredientKindByIDQuery = selectIngredientKindColumns + `
+where is_deleted = false
+and id = $1;
+`
+
+// Get retrieves an ingredient kind by its ID
+func (r *IngredientKindPostgresRepository) Get(ctx context.Context, id uint64) (*entity.IngredientKind, error) {
+	var dto ingredientKindDto
+
+	err := r.db.GetContext(ctx, &dto, selectIngredientKindByIDQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrIngredientKindNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// selectIngredientKindAncestorIDsQuery walks up the parents of a kind, union stops on a cycle in corrupted data
+const selectIngredientKindAncestorIDsQuery = `
+with recursive ancestors(id, parent_id) as (
+    select k.id, k.parent_id from ingredient_kinds k where k.id = $1
+    union
+    select k.id, k.parent_id from ingredient_kinds k
+    join ancestors a on k.id = a.parent_id
+)
+select id from ancestors where id <> $1;
+`
+
+// ListAncestorIDs lists the IDs of the parent, grandparent and so on of a kind
+func (r *IngredientKindPostgresRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	var ids []uint64
+
+	err := r.db.SelectContext(ctx, &ids, selectIngredientKindAncestorIDsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+const countIngredientKindReferencesQuery = `
+select (select count(*) from ingredients where kind_id = $1 and is_deleted = false)
+     + (select count(*) from ingredient_kinds where parent_id = $1 and is_deleted = false);
+`
+
+// CountReferences counts the live ingredients and sub-kinds of a kind
+func (r *IngredientKindPostgresRepository) CountReferences(ctx context.Context, id uint64) (int, error) {
+	var count int
+
+	err := r.db.GetContext(ctx, &count, countIngredientKindReferencesQuery, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+const insertIngredientKindQuery = `
+INSERT INTO ingredient_kinds (name, parent_id, aisle, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5) RETURNING id
+`
+
+// Create creates a new ingredient kind
+func (r *IngredientKindPostgresRepository) Create(ctx context.Context, params usecase.IngredientKindParams) (*entity.IngredientKind, error) {
+	var parentID uint64
+	if params.ParentID != nil {
+		parentID = *params.ParentID
+	}
+
+	dto := ingredientKindDto{
+		Name:      params.Name,
+		ParentID:  nullableID(parentID),
+		Aisle:     null.NewString(params.Aisle, params.Aisle != ""),
+		CreatedAt: time.Now(),
+		CreatedBy: params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertIngredientKindQuery, dto.Name, dto.ParentID, dto.Aisle, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates an ingredient kind by its ID
+func (r *IngredientKindPostgresRepository) Update(ctx context.Context, id uint64, params usecase.IngredientKindParams) (*entity.IngredientKind, error) {
+	dto, query := ingredientKindDtoForUpdate(id, params, nil)
+
+	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrIngredientKindNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// clearDeletedIngredientsKindQuery unlinks the deleted ingredients of a kind so they do not point to a deleted kind when restored
+const clearDeletedIngredientsKindQuery = `
+UPDATE ingredients SET kind_id = NULL WHERE kind_id = $1 AND is_deleted = true
+`
+
+// Delete deletes an ingredient kind by its ID
+func (r *IngredientKindPostgresRepository) Delete(ctx context.Context, id uint64, actor string) error {
+	isDeleted := true
+	dto, query := ingredientKindDtoForUpdate(id, usecase.IngredientKindParams{Actor: actor}, &isDeleted)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, clearDeletedIngredientsKindQuery, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return entity.ErrIngredientKindNotFound
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func ingredientKindDtoForUpdate(id uint64, params usecase.IngredientKindParams, isDeleted *bool) (dto ingredientKindDto, query string) {
+	var qb strings.Builder
+
+	qb.WriteString("UPDATE ingredient_kinds SET ")
+
+	if params.Name != "" {
+		qb.WriteString("name = :name, ")
+		dto.Name = params.Name
+	}
+
+	if params.ParentID != nil {
+		qb.WriteString("parent_id = :parent_id, ")
+		dto.ParentID = nullableID(*params.ParentID)
+	}
+
+	if params.Aisle != "" {
+		qb.WriteString("aisle = :aisle, ")
+		dto.Aisle = null.StringFrom(params.Aisle)
+	}
+
+	if isDeleted != nil {
+		qb.WriteString("is_deleted = :is_deleted, ")
+		dto.IsDeleted = *isDeleted
+	}
+
+	qb.WriteString("updated_at = :updated_at, ")
+	dto.UpdatedAt = null.TimeFrom(time.Now())
+
+	qb.WriteString("updated_by = :updated_by ")
+	dto.UpdatedBy = null.StringFrom(params.Actor)
+
+	qb.WriteString("WHERE id = :id")
+	dto.ID = id
+
+	return dto, qb.String()
+}
@@ -0,0 +1,181 @@
+package postgres_repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// IngredientPricePostgresRepository is the PostgreSQL implementation for IngredientPriceRepository interface
+type IngredientPricePostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewIngredientPricePostgresRepository instantiates IngredientPricePostgresRepository
+func NewIngredientPricePostgresRepository(db *sqlx.DB) *IngredientPricePostgresRepository {
+	return &IngredientPricePostgresRepository{db: db}
+}
+
+type ingredientPriceDto struct {
+	ID                 uint64      `db:"id"`
+	IngredientID       uint64      `db:"ingredient_id"`
+	IngredientUnitID   uint64      `db:"ingredient_unit_id"`
+	IngredientUnitName string      `db:"ingredient_unit_name"`
+	Quantity           float64     `db:"quantity"`
+	Price              float64     `db:"price"`
+	EffectiveAt        time.Time   `db:"effective_at"`
+	CreatedAt          time.Time   `db:"created_at"`
+	CreatedBy          string      `db:"created_by"`
+	UpdatedAt          null.Time   `db:"updated_at"`
+	UpdatedBy          null.String `db:"updated_by"`
+	IsDeleted          bool        `db:"is_deleted"`
+}
+
+func (c ingredientPriceDto) toEntity() *entity.IngredientPrice {
+	return &entity.IngredientPrice{
+		ID:                 c.ID,
+		IngredientID:       c.IngredientID,
+		IngredientUnitID:   c.IngredientUnitID,
+		IngredientUnitName: c.IngredientUnitName,
+		Quantity:           c.Quantity,
+		Price:              c.Price,
+		EffectiveAt:        c.EffectiveAt,
+		CreatedAt:          c.CreatedAt,
+		CreatedBy:          c.CreatedBy,
+		UpdatedAt:          c.UpdatedAt,
+		UpdatedBy:          c.UpdatedBy,
+		IsDeleted:          c.IsDeleted,
+	}
+}
+
+const selectIngredientPriceColumns = `
+select
+       p.id,
+       p.ingredient_id,
+       p.ingredient_unit_id,
+       iu.name as ingredient_unit_name,
+       p.quantity,
+       p.price,
+       p.effective_at,
+       p.created_at,
+       p.created_by,
+       p.updated_at,
+       p.updated_by,
+       p.is_deleted
+from ingredient_prices p
+join ingredient_units iu on iu.id = p.ingredient_unit_id`
+
+const selectIngredientPriceQuery = selectIngredientPriceColumns + `
+where p.is_deleted = false
+and p.ingredient_id = $1
+order by p.effective_at desc, p.id desc
+limit $2 offset $3;
+`
+
+// List retrieves the price history of an ingredient, latest first
+func (r *IngredientPricePostgresRepository) List(ctx context.Context, ingredientID uint64, limit, offset int) (res entity.IngredientPrices, err error) {
+	var dtos []ingredientPriceDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectIngredientPriceQuery, ingredientID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectEffectiveIngredientPriceQuery = `
+select distinct on (p.ingredient_id)
+       p.id,
+       p.ingredient_id,
+       p.ingredient_unit_id,
+       iu.name as ingredient_unit_name,
+       p.quantity,
+       p.price,
+       p.effective_at,
+       p.created_at,
+       p.created_by,
+       p.updated_at,
+       p.updated_by,
+       p.is_deleted
+from ingredient_prices p
+join ingredient_units iu on iu.id = p.ingredient_unit_id
+where p.is_deleted = false
+and p.effective_at <= ?
+and p.ingredient_id in (?)
+order by p.ingredient_id, p.effective_at desc, p.id desc;
+`
+
+// ListEffective retrieves the latest price of each ingredient that is effective at the given time
+func (r *IngredientPricePostgresRepository) ListEffective(ctx context.Context, ingredientIDs []uint64, at time.Time) (res entity.IngredientPrices, err error) {
+	var dtos []ingredientPriceDto
+
+	query, args, err := sqlx.In(selectEffectiveIngredientPriceQuery, at, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertIngredientPriceQuery = `
+INSERT INTO ingredient_prices (ingredient_id, ingredient_unit_id, quantity, price, effective_at, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+// Create creates a new ingredient price
+func (r *IngredientPricePostgresRepository) Create(ctx context.Context, params usecase.IngredientPriceParams) (*entity.IngredientPrice, error) {
+	dto := ingredientPriceDto{
+		IngredientID:     params.IngredientID,
+		IngredientUnitID: params.IngredientUnitID,
+		Quantity:         params.Quantity,
+		Price:            params.Price,
+		EffectiveAt:      params.EffectiveAt,
+		CreatedAt:        time.Now(),
+		CreatedBy:        params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertIngredientPriceQuery, dto.IngredientID, dto.IngredientUnitID, dto.Quantity, dto.Price, dto.EffectiveAt, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const deleteIngredientPriceQuery = `
+UPDATE ingredient_prices SET is_deleted = true, updated_at = $2
+WHERE id = $1
+`
+
+// Delete deletes an ingredient price by its ID
+func (r *IngredientPricePostgresRepository) Delete(ctx context.Context, id uint64) error {
+	res, err := r.db.ExecContext(ctx, deleteIngredientPriceQuery, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return entity.ErrIngredientPriceNotFound
+	}
+
+	return nil
+}
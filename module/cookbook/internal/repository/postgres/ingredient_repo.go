@@ -3,14 +3,25 @@ package postgres_repo
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 	"strings"
 	"time"
 )
 
+const (
+	// uniqueViolationCode is the PostgreSQL error code of a unique index violation
+	uniqueViolationCode = "23505"
+
+	ingredientNormalizedNameIndex      = "idx_ingredients_normalized_name"
+	ingredientAliasNormalizedNameIndex = "idx_ingredient_aliases_normalized_name"
+)
+
 // IngredientPostgresRepository is the PostgreSQL implementation for IngredientRepository interface
 type IngredientPostgresRepository struct {
 	db *sqlx.DB
@@ -22,19 +33,49 @@ func NewIngredientPostgresRepository(db *sqlx.DB) *IngredientPostgresRepository
 }
 
 type ingredientDto struct {
-	ID        uint64      `db:"id"`
-	Name      string      `db:"name"`
-	CreatedAt time.Time   `db:"created_at"`
-	CreatedBy string      `db:"created_by"`
-	UpdatedAt null.Time   `db:"updated_at"`
-	UpdatedBy null.String `db:"updated_by"`
-	IsDeleted bool        `db:"is_deleted"`
+	ID             uint64         `db:"id"`
+	Name           string         `db:"name"`
+	NormalizedName null.String    `db:"normalized_name"`
+	KindID         null.Int       `db:"kind_id"`
+	ParentID       null.Int       `db:"parent_id"`
+	Aisle          null.String    `db:"aisle"`
+	Aliases        pq.StringArray `db:"aliases"`
+	BaseUnitName   null.String    `db:"base_unit_name"`
+	Kcal           null.Float     `db:"kcal"`
+	Protein        null.Float     `db:"protein_g"`
+	Fat            null.Float     `db:"fat_g"`
+	Carbs          null.Float     `db:"carbs_g"`
+	Fiber          null.Float     `db:"fiber_g"`
+	Sodium         null.Float     `db:"sodium_mg"`
+	Allergens      pq.StringArray `db:"allergens"`
+	Diets          pq.StringArray `db:"diets"`
+	CreatedAt      time.Time      `db:"created_at"`
+	CreatedBy      string         `db:"created_by"`
+	UpdatedAt      null.Time      `db:"updated_at"`
+	UpdatedBy      null.String    `db:"updated_by"`
+	IsDeleted      bool           `db:"is_deleted"`
+	DeletedAt      null.Time      `db:"deleted_at"`
 }
 
 func (c ingredientDto) toEntity() *entity.Ingredient {
 	return &entity.Ingredient{
-		ID:        c.ID,
-		Name:      c.Name,
+		ID:           c.ID,
+		Name:         c.Name,
+		KindID:       uint64(c.KindID.Int64),
+		ParentID:     uint64(c.ParentID.Int64),
+		Aisle:        c.Aisle,
+		Aliases:      c.Aliases,
+		BaseUnitName: c.BaseUnitName,
+		Nutrition: entity.Nutrition{
+			Kcal:    c.Kcal,
+			Protein: c.Protein,
+			Fat:     c.Fat,
+			Carbs:   c.Carbs,
+			Fiber:   c.Fiber,
+			Sodium:  c.Sodium,
+		},
+		Allergens: c.Allergens,
+		Diets:     c.Diets,
 		CreatedAt: c.CreatedAt,
 		CreatedBy: c.CreatedBy,
 		UpdatedAt: c.UpdatedAt,
@@ -43,17 +84,144 @@ func (c ingredientDto) toEntity() *entity.Ingredient {
 	}
 }
 
-const selectIngredientQuery = `
-select id, name, created_at, created_by, updated_at, updated_by, is_deleted from ingredients
+// ingredientAisleColumn walks up the kinds of an ingredient until one has an aisle
+const ingredientAisleColumn = `
+       (with recursive kind_path(id, parent_id, aisle, depth) as (
+           select k.id, k.parent_id, k.aisle, 0 from ingredient_kinds k where k.id = ingredients.kind_id
+           union all
+           select k.id, k.parent_id, k.aisle, p.depth + 1 from ingredient_kinds k
+           join kind_path p on k.id = p.parent_id
+           where p.aisle is null and p.depth < 32
+       ) select aisle from kind_path where aisle is not null order by depth limit 1) as aisle,`
+
+const selectIngredientColumns = `
+select id, name, normalized_name, kind_id, parent_id, base_unit_name, kcal, protein_g, fat_g, carbs_g, fiber_g, sodium_mg, allergens, diets,
+       array(select a.name from ingredient_aliases a where a.ingredient_id = ingredients.id and a.is_deleted = false order by a.id) as aliases,` + ingredientAisleColumn + `
+       created_at, created_by, updated_at, updated_by, is_deleted
+from ingredients`
+
+const selectIngredientQuery = selectIngredientColumns + `
+where is_deleted = false`
+
+// ingredientKindDescendantIDsQuery lists a kind and its live sub-kinds, $1 is the top kind
+const ingredientKindDescendantIDsQuery = `
+with recursive kind_descendants(id) as (
+    select k.id from ingredient_kinds k where k.id = $1 and k.is_deleted = false
+    union
+    select k.id from ingredient_kinds k
+    join kind_descendants d on k.parent_id = d.id
+    where k.is_deleted = false
+)
+select id from kind_descendants`
+
+// ingredientDescendantIDsQuery lists an ingredient and its live child ingredients at any depth, $1 is the top ingredient
+const ingredientDescendantIDsQuery = `
+with recursive ingredient_descendants(id) as (
+    select i.id from ingredients i where i.id = $1
+    union
+    select i.id from ingredients i
+    join ingredient_descendants d on i.parent_id = d.id
+    where i.is_deleted = false
+)
+select id from ingredient_descendants`
+
+// List retrieves a list of ingredients with filter, offset and limit
+func (r *IngredientPostgresRepository) List(ctx context.Context, filter usecase.ListIngredientsFilter, limit, offset int) (res entity.Ingredients, err error) {
+	var dtos []ingredientDto
+
+	query := selectIngredientQuery
+
+	var args []interface{}
+
+	if filter.KindID > 0 {
+		args = append(args, filter.KindID)
+		query += "\nand kind_id in (" + ingredientKindDescendantIDsQuery + ")"
+	}
+
+	args = append(args, limit, offset)
+	query += fmt.Sprintf("\norder by id\nlimit $%d offset $%d;", len(args)-1, len(args))
+
+	err = r.db.SelectContext(ctx, &dtos, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectIngredientsByIDsQuery = selectIngredientColumns + `
 where is_deleted = false
-limit $1 offset $2;
+and id in (?);
 `
 
-// List retrieves a list of ingredients with offset and limit
-func (r *IngredientPostgresRepository) List(ctx context.Context, limit, offset int) (res entity.Ingredients, err error) {
+// ListByIDs retrieves ingredients by their IDs
+func (r *IngredientPostgresRepository) ListByIDs(ctx context.Context, ids []uint64) (res entity.Ingredients, err error) {
 	var dtos []ingredientDto
 
-	err = r.db.SelectContext(ctx, &dtos, selectIngredientQuery, limit, offset)
+	query, args, err := sqlx.In(selectIngredientsByIDsQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectAllIngredientsByIDsQuery = selectIngredientColumns + `
+where id in (?);
+`
+
+// ListAllByIDs retrieves ingredients by their IDs, deleted ones included
+func (r *IngredientPostgresRepository) ListAllByIDs(ctx context.Context, ids []uint64) (res entity.Ingredients, err error) {
+	var dtos []ingredientDto
+
+	query, args, err := sqlx.In(selectAllIngredientsByIDsQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectIngredientsByNormalizedNamesQuery = selectIngredientColumns + `
+where is_deleted = false
+and (
+    normalized_name in (?)
+    or id in (select a.ingredient_id from ingredient_aliases a where a.is_deleted = false and a.normalized_name in (?))
+);
+`
+
+// ListByNormalizedNames retrieves ingredients whose normalized name or one of their aliases matches
+func (r *IngredientPostgresRepository) ListByNormalizedNames(ctx context.Context, normalizedNames []string) (res entity.Ingredients, err error) {
+	var dtos []ingredientDto
+
+	query, args, err := sqlx.In(selectIngredientsByNormalizedNamesQuery, normalizedNames, normalizedNames)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,69 +234,229 @@ func (r *IngredientPostgresRepository) List(ctx context.Context, limit, offset i
 }
 
 const insertIngredientQuery = `
-INSERT INTO ingredients (name, created_at, created_by)
-VALUES ($1, $2, $3) RETURNING id
+INSERT INTO ingredients (name, normalized_name, kind_id, parent_id, base_unit_name, kcal, protein_g, fat_g, carbs_g, fiber_g, sodium_mg, allergens, diets, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id
 `
 
 // Create creates a new ingredient
 func (r *IngredientPostgresRepository) Create(ctx context.Context, params usecase.IngredientParams) (*entity.Ingredient, error) {
 	dto := ingredientDtoForCreate(params)
 
-	err := r.db.QueryRowxContext(ctx, insertIngredientQuery, dto.Name, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	err := r.db.QueryRowxContext(ctx, insertIngredientQuery, dto.Name, dto.NormalizedName, dto.KindID, dto.ParentID, dto.BaseUnitName, dto.Kcal, dto.Protein, dto.Fat, dto.Carbs, dto.Fiber, dto.Sodium, dto.Allergens, dto.Diets, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if isUniqueViolation(err, ingredientNormalizedNameIndex) {
+		return nil, entity.ErrIngredientNameConflict
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	return &entity.Ingredient{
-		ID:        dto.ID,
-		Name:      dto.Name,
-		CreatedAt: dto.CreatedAt,
-		CreatedBy: dto.CreatedBy,
-		UpdatedAt: dto.UpdatedAt,
-		UpdatedBy: dto.UpdatedBy,
-	}, nil
+	return dto.toEntity(), nil
 }
 
+const propagateIngredientNameQuery = `
+	UPDATE recipe_ingredients SET ingredient_name = $1, updated_at = $2, updated_by = $3
+	WHERE ingredient_id = $4 AND ingredient_name IS DISTINCT FROM $1
+`
+
 // Update updates a ingredient by its ID
 func (r *IngredientPostgresRepository) Update(ctx context.Context, id uint64, params usecase.IngredientParams) (*entity.Ingredient, error) {
 	dto, query := ingredientDtoForUpdate(id, params, nil)
 
-	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
 	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
 		return nil, entity.ErrIngredientNotFound
 	}
 
+	if isUniqueViolation(err, ingredientNormalizedNameIndex) {
+		_ = tx.Rollback()
+		return nil, entity.ErrIngredientNameConflict
+	}
+
 	if err != nil {
+		_ = tx.Rollback()
 		return nil, err
 	}
 
-	return &entity.Ingredient{
-		ID:        dto.ID,
-		Name:      dto.Name,
-		CreatedAt: dto.CreatedAt,
-		UpdatedAt: dto.UpdatedAt,
-		CreatedBy: dto.CreatedBy,
-		UpdatedBy: dto.UpdatedBy,
-	}, nil
+	if params.PropagateName && params.Name != "" {
+		_, err = tx.ExecContext(ctx, propagateIngredientNameQuery, params.Name, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
 }
 
-// Delete deletes a ingredient by its ID
-func (r *IngredientPostgresRepository) Delete(ctx context.Context, id uint64) error {
-	dto, query := ingredientDtoForDelete(id)
+// ListReferencingRecipes lists the live recipes using an ingredient
+func (r *IngredientPostgresRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	return selectRecipeReferences(ctx, r.db, selectIngredientRecipeReferencesQuery, id)
+}
+
+const reassignIngredientRecipeIngredientsQuery = `
+UPDATE recipe_ingredients
+SET ingredient_id = $1, ingredient_name = (select name from ingredients where id = $1), updated_at = $2, updated_by = $3
+WHERE ingredient_id = $4 AND is_deleted = false
+`
+
+// liftIngredientChildrenQuery moves the child ingredients of an ingredient to its own parent
+const liftIngredientChildrenQuery = `
+UPDATE ingredients SET parent_id = (select parent_id from ingredients where id = $1), updated_at = $2, updated_by = $3
+WHERE parent_id = $1 AND is_deleted = false
+`
 
-	_, err := r.db.NamedExecContext(ctx, query, &dto)
+// Delete deletes a ingredient by its ID, its recipe references are moved to params.ReassignTo first when it is set.
+// Its child ingredients are moved to its own parent
+func (r *IngredientPostgresRepository) Delete(ctx context.Context, id uint64, params usecase.DeleteParams) error {
+	dto, query := ingredientDtoForDelete(id, params.Actor)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, liftIngredientChildrenQuery, id, dto.UpdatedAt, dto.UpdatedBy)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if params.ReassignTo != 0 {
+		_, err = tx.ExecContext(ctx, reassignIngredientRecipeIngredientsQuery, params.ReassignTo, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
 	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
 		return entity.ErrIngredientNotFound
 	}
 
-	return err
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// selectIngredientAncestorIDsQuery walks up the parents of an ingredient, union stops on a cycle in corrupted data
+const selectIngredientAncestorIDsQuery = `
+with recursive ancestors(id, parent_id) as (
+    select i.id, i.parent_id from ingredients i where i.id = $1
+    union
+    select i.id, i.parent_id from ingredients i
+    join ancestors a on i.id = a.parent_id
+)
+select id from ancestors where id <> $1;
+`
+
+// ListAncestorIDs lists the IDs of the parent, grandparent and so on of an ingredient
+func (r *IngredientPostgresRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	var ids []uint64
+
+	err := r.db.SelectContext(ctx, &ids, selectIngredientAncestorIDsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+const updateIngredientNutritionQuery = `
+UPDATE ingredients SET
+    base_unit_name = coalesce($2, base_unit_name),
+    kcal = $3,
+    protein_g = $4,
+    fat_g = $5,
+    carbs_g = $6,
+    fiber_g = $7,
+    sodium_mg = $8,
+    updated_at = $9,
+    updated_by = $10
+WHERE id = $1
+AND is_deleted = false
+`
+
+// BulkUpdateNutrition replaces nutrition data of many ingredients in a single transaction and returns how many
+// ingredients were updated, deleted ingredients are left as they are
+func (r *IngredientPostgresRepository) BulkUpdateNutrition(ctx context.Context, params []usecase.IngredientNutritionParams) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	var count int64
+
+	for _, p := range params {
+		n := p.Nutrition
+		baseUnitName := null.NewString(p.BaseUnitName, p.BaseUnitName != "")
+
+		result, err := tx.ExecContext(ctx, updateIngredientNutritionQuery, p.IngredientID, baseUnitName, n.Kcal, n.Protein, n.Fat, n.Carbs, n.Fiber, n.Sodium, now, p.Actor)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+
+		count += affected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
 }
 
 func ingredientDtoForCreate(params usecase.IngredientParams) ingredientDto {
+	var kindID, parentID uint64
+	if params.KindID != nil {
+		kindID = *params.KindID
+	}
+
+	if params.ParentID != nil {
+		parentID = *params.ParentID
+	}
+
 	return ingredientDto{
-		Name:      params.Name,
-		CreatedAt: time.Now(),
-		CreatedBy: params.Actor,
+		Name:           params.Name,
+		NormalizedName: null.NewString(params.NormalizedName, params.NormalizedName != ""),
+		KindID:         nullableID(kindID),
+		ParentID:       nullableID(parentID),
+		BaseUnitName:   null.NewString(params.BaseUnitName, params.BaseUnitName != ""),
+		Kcal:           params.Nutrition.Kcal,
+		Protein:        params.Nutrition.Protein,
+		Fat:            params.Nutrition.Fat,
+		Carbs:          params.Nutrition.Carbs,
+		Fiber:          params.Nutrition.Fiber,
+		Sodium:         params.Nutrition.Sodium,
+		Allergens:      pq.StringArray(nonNilStrings(params.Allergens)),
+		Diets:          pq.StringArray(nonNilStrings(params.Diets)),
+		CreatedAt:      time.Now(),
+		CreatedBy:      params.Actor,
 	}
 }
 
@@ -142,9 +470,70 @@ func ingredientDtoForUpdate(id uint64, params usecase.IngredientParams, isDelete
 		dto.Name = params.Name
 	}
 
+	if params.NormalizedName != "" {
+		qb.WriteString("normalized_name = :normalized_name, ")
+		dto.NormalizedName = null.StringFrom(params.NormalizedName)
+	}
+
+	if params.KindID != nil {
+		qb.WriteString("kind_id = :kind_id, ")
+		dto.KindID = nullableID(*params.KindID)
+	}
+
+	if params.ParentID != nil {
+		qb.WriteString("parent_id = :parent_id, ")
+		dto.ParentID = nullableID(*params.ParentID)
+	}
+
+	if params.BaseUnitName != "" {
+		qb.WriteString("base_unit_name = :base_unit_name, ")
+		dto.BaseUnitName = null.StringFrom(params.BaseUnitName)
+	}
+
+	if params.Nutrition.Kcal.Valid {
+		qb.WriteString("kcal = :kcal, ")
+		dto.Kcal = params.Nutrition.Kcal
+	}
+
+	if params.Nutrition.Protein.Valid {
+		qb.WriteString("protein_g = :protein_g, ")
+		dto.Protein = params.Nutrition.Protein
+	}
+
+	if params.Nutrition.Fat.Valid {
+		qb.WriteString("fat_g = :fat_g, ")
+		dto.Fat = params.Nutrition.Fat
+	}
+
+	if params.Nutrition.Carbs.Valid {
+		qb.WriteString("carbs_g = :carbs_g, ")
+		dto.Carbs = params.Nutrition.Carbs
+	}
+
+	if params.Nutrition.Fiber.Valid {
+		qb.WriteString("fiber_g = :fiber_g, ")
+		dto.Fiber = params.Nutrition.Fiber
+	}
+
+	if params.Nutrition.Sodium.Valid {
+		qb.WriteString("sodium_mg = :sodium_mg, ")
+		dto.Sodium = params.Nutrition.Sodium
+	}
+
+	if params.Allergens != nil {
+		qb.WriteString("allergens = :allergens, ")
+		dto.Allergens = params.Allergens
+	}
+
+	if params.Diets != nil {
+		qb.WriteString("diets = :diets, ")
+		dto.Diets = params.Diets
+	}
+
 	if isDeleted != nil {
-		qb.WriteString("is_deleted = :is_deleted, ")
+		qb.WriteString("is_deleted = :is_deleted, deleted_at = :deleted_at, ")
 		dto.IsDeleted = *isDeleted
+		dto.DeletedAt = null.NewTime(time.Now(), *isDeleted)
 	}
 
 	qb.WriteString("updated_at = :updated_at, ")
@@ -159,7 +548,22 @@ func ingredientDtoForUpdate(id uint64, params usecase.IngredientParams, isDelete
 	return dto, qb.String()
 }
 
-func ingredientDtoForDelete(id uint64) (dto ingredientDto, query string) {
+func ingredientDtoForDelete(id uint64, actor string) (dto ingredientDto, query string) {
 	isDeleted := true
-	return ingredientDtoForUpdate(id, usecase.IngredientParams{}, &isDeleted)
+	return ingredientDtoForUpdate(id, usecase.IngredientParams{Actor: actor}, &isDeleted)
+}
+
+// isUniqueViolation returns true when err is a violation of the unique index
+func isUniqueViolation(err error, index string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == index
+}
+
+// nonNilStrings returns an empty slice for nil so that it is stored as an empty array instead of NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
 }
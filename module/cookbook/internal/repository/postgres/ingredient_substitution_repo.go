@@ -0,0 +1,198 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// IngredientSubstitutionPostgresRepository is the PostgreSQL implementation for IngredientSubstitutionRepository interface
+type IngredientSubstitutionPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewIngredientSubstitutionPostgresRepository instantiates IngredientSubstitutionPostgresRepository
+func NewIngredientSubstitutionPostgresRepository(db *sqlx.DB) *IngredientSubstitutionPostgresRepository {
+	return &IngredientSubstitutionPostgresRepository{db: db}
+}
+
+type ingredientSubstitutionDto struct {
+	ID                       uint64      `db:"id"`
+	IngredientID             uint64      `db:"ingredient_id"`
+	IngredientName           string      `db:"ingredient_name"`
+	SubstituteIngredientID   uint64      `db:"substitute_ingredient_id"`
+	SubstituteIngredientName string      `db:"substitute_ingredient_name"`
+	Ratio                    float64     `db:"ratio"`
+	IngredientUnitName       null.String `db:"ingredient_unit_name"`
+	Notes                    null.String `db:"notes"`
+	CreatedAt                time.Time   `db:"created_at"`
+	CreatedBy                string      `db:"created_by"`
+	UpdatedAt                null.Time   `db:"updated_at"`
+	UpdatedBy                null.String `db:"updated_by"`
+	IsDeleted                bool        `db:"is_deleted"`
+}
+
+func (c ingredientSubstitutionDto) toEntity() *entity.IngredientSubstitution {
+	return &entity.IngredientSubstitution{
+		ID:                       c.ID,
+		IngredientID:             c.IngredientID,
+		IngredientName:           c.IngredientName,
+		SubstituteIngredientID:   c.SubstituteIngredientID,
+		SubstituteIngredientName: c.SubstituteIngredientName,
+		Ratio:                    c.Ratio,
+		IngredientUnitName:       c.IngredientUnitName,
+		Notes:                    c.Notes,
+		CreatedAt:                c.CreatedAt,
+		CreatedBy:                c.CreatedBy,
+		UpdatedAt:                c.UpdatedAt,
+		UpdatedBy:                c.UpdatedBy,
+		IsDeleted:                c.IsDeleted,
+	}
+}
+
+const selectIngredientSubstitutionsByIngredientIDsQuery = `
+select
+       s.id,
+       s.ingredient_id,
+       i.name as ingredient_name,
+       s.substitute_ingredient_id,
+       si.name as substitute_ingredient_name,
+       s.ratio,
+       s.ingredient_unit_name,
+       s.notes,
+       s.created_at,
+       s.created_by,
+       s.updated_at,
+       s.updated_by,
+       s.is_deleted
+from ingredient_substitutions s
+join ingredients i on i.id = s.ingredient_id
+join ingredients si on si.id = s.substitute_ingredient_id and si.is_deleted = false
+where s.is_deleted = false
+and s.ingredient_id in (?)
+order by s.ingredient_id, s.id;
+`
+
+// ListByIngredientIDs retrieves the substitutes of ingredients
+func (r *IngredientSubstitutionPostgresRepository) ListByIngredientIDs(ctx context.Context, ingredientIDs []uint64) (res entity.IngredientSubstitutions, err error) {
+	var dtos []ingredientSubstitutionDto
+
+	query, args, err := sqlx.In(selectIngredientSubstitutionsByIngredientIDsQuery, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertIngredientSubstitutionQuery = `
+INSERT INTO ingredient_substitutions (ingredient_id, substitute_ingredient_id, ratio, ingredient_unit_name, notes, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+// Create creates a new ingredient substitution
+func (r *IngredientSubstitutionPostgresRepository) Create(ctx context.Context, params usecase.IngredientSubstitutionParams) (*entity.IngredientSubstitution, error) {
+	dto := ingredientSubstitutionDto{
+		IngredientID:           params.IngredientID,
+		SubstituteIngredientID: params.SubstituteIngredientID,
+		Ratio:                  params.Ratio,
+		IngredientUnitName:     null.NewString(params.IngredientUnitName, params.IngredientUnitName != ""),
+		Notes:                  null.NewString(params.Notes, params.Notes != ""),
+		CreatedAt:              time.Now(),
+		CreatedBy:              params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertIngredientSubstitutionQuery, dto.IngredientID, dto.SubstituteIngredientID, dto.Ratio, dto.IngredientUnitName, dto.Notes, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates an ingredient substitution by its ID
+func (r *IngredientSubstitutionPostgresRepository) Update(ctx context.Context, id uint64, params usecase.IngredientSubstitutionParams) (*entity.IngredientSubstitution, error) {
+	dto, query := ingredientSubstitutionDtoForUpdate(id, params, nil)
+
+	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrIngredientSubstitutionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Delete deletes an ingredient substitution by its ID
+func (r *IngredientSubstitutionPostgresRepository) Delete(ctx context.Context, id uint64) error {
+	isDeleted := true
+	dto, query := ingredientSubstitutionDtoForUpdate(id, usecase.IngredientSubstitutionParams{}, &isDeleted)
+
+	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		return entity.ErrIngredientSubstitutionNotFound
+	}
+
+	return err
+}
+
+func ingredientSubstitutionDtoForUpdate(id uint64, params usecase.IngredientSubstitutionParams, isDeleted *bool) (dto ingredientSubstitutionDto, query string) {
+	var qb strings.Builder
+
+	qb.WriteString("UPDATE ingredient_substitutions SET ")
+
+	if params.SubstituteIngredientID != 0 {
+		qb.WriteString("substitute_ingredient_id = :substitute_ingredient_id, ")
+		dto.SubstituteIngredientID = params.SubstituteIngredientID
+	}
+
+	if params.Ratio > 0 {
+		qb.WriteString("ratio = :ratio, ")
+		dto.Ratio = params.Ratio
+	}
+
+	if params.IngredientUnitName != "" {
+		qb.WriteString("ingredient_unit_name = :ingredient_unit_name, ")
+		dto.IngredientUnitName = null.StringFrom(params.IngredientUnitName)
+	}
+
+	if params.Notes != "" {
+		qb.WriteString("notes = :notes, ")
+		dto.Notes = null.StringFrom(params.Notes)
+	}
+
+	if isDeleted != nil {
+		qb.WriteString("is_deleted = :is_deleted, ")
+		dto.IsDeleted = *isDeleted
+	}
+
+	qb.WriteString("updated_at = :updated_at, ")
+	dto.UpdatedAt = null.TimeFrom(time.Now())
+
+	qb.WriteString("updated_by = :updated_by ")
+	dto.UpdatedBy = null.StringFrom(params.Actor)
+
+	qb.WriteString("WHERE id = :id")
+	dto.ID = id
+
+	return dto, qb.String()
+}
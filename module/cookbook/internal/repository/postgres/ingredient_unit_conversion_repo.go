@@ -0,0 +1,129 @@
+package postgres_repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type ingredientUnitConversionDto struct {
+	ID                 uint64      `db:"id"`
+	IngredientID       uint64      `db:"ingredient_id"`
+	IngredientUnitID   uint64      `db:"ingredient_unit_id"`
+	IngredientUnitName string      `db:"ingredient_unit_name"`
+	BaseAmount         float64     `db:"base_amount"`
+	CreatedAt          time.Time   `db:"created_at"`
+	CreatedBy          string      `db:"created_by"`
+	UpdatedAt          null.Time   `db:"updated_at"`
+	UpdatedBy          null.String `db:"updated_by"`
+	IsDeleted          bool        `db:"is_deleted"`
+}
+
+func (c ingredientUnitConversionDto) toEntity() *entity.IngredientUnitConversion {
+	return &entity.IngredientUnitConversion{
+		ID:                 c.ID,
+		IngredientID:       c.IngredientID,
+		IngredientUnitID:   c.IngredientUnitID,
+		IngredientUnitName: c.IngredientUnitName,
+		BaseAmount:         c.BaseAmount,
+		CreatedAt:          c.CreatedAt,
+		CreatedBy:          c.CreatedBy,
+		UpdatedAt:          c.UpdatedAt,
+		UpdatedBy:          c.UpdatedBy,
+		IsDeleted:          c.IsDeleted,
+	}
+}
+
+const selectIngredientUnitConversionsQuery = `
+select
+       c.id,
+       c.ingredient_id,
+       c.ingredient_unit_id,
+       iu.name as ingredient_unit_name,
+       c.base_amount,
+       c.created_at,
+       c.created_by,
+       c.updated_at,
+       c.updated_by,
+       c.is_deleted
+from ingredient_unit_conversions c
+join ingredient_units iu on iu.id = c.ingredient_unit_id
+where c.is_deleted = false
+and iu.is_deleted = false
+and c.ingredient_id in (?);
+`
+
+// ListConversions retrieves unit conversions of the given ingredients
+func (r *IngredientUnitPostgresRepository) ListConversions(ctx context.Context, ingredientIDs []uint64) (res entity.IngredientUnitConversions, err error) {
+	var dtos []ingredientUnitConversionDto
+
+	if len(ingredientIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(selectIngredientUnitConversionsQuery, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const upsertIngredientUnitConversionQuery = `
+INSERT INTO ingredient_unit_conversions (ingredient_id, ingredient_unit_id, base_amount, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id, ingredient_unit_id)
+DO UPDATE SET base_amount = EXCLUDED.base_amount, is_deleted = false, updated_at = EXCLUDED.created_at, updated_by = EXCLUDED.created_by
+RETURNING id
+`
+
+// UpsertConversion creates or replaces the conversion of an ingredient unit
+func (r *IngredientUnitPostgresRepository) UpsertConversion(ctx context.Context, params usecase.IngredientUnitConversionParams) (*entity.IngredientUnitConversion, error) {
+	dto := ingredientUnitConversionDto{
+		IngredientID:     params.IngredientID,
+		IngredientUnitID: params.IngredientUnitID,
+		BaseAmount:       params.BaseAmount,
+		CreatedAt:        time.Now(),
+		CreatedBy:        params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, upsertIngredientUnitConversionQuery, dto.IngredientID, dto.IngredientUnitID, dto.BaseAmount, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const deleteIngredientUnitConversionQuery = `
+UPDATE ingredient_unit_conversions SET is_deleted = true, updated_at = $2
+WHERE id = $1
+`
+
+// DeleteConversion deletes an ingredient unit conversion by its ID
+func (r *IngredientUnitPostgresRepository) DeleteConversion(ctx context.Context, id uint64) error {
+	res, err := r.db.ExecContext(ctx, deleteIngredientUnitConversionQuery, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return entity.ErrIngredientUnitConversionNotFound
+	}
+
+	return nil
+}
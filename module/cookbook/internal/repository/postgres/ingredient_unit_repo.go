@@ -22,29 +22,37 @@ func NewIngredientUnitPostgresRepository(db *sqlx.DB) *IngredientUnitPostgresRep
 }
 
 type ingredientUnitDto struct {
-	ID        uint64      `db:"id"`
-	Name      string      `db:"name"`
-	CreatedAt time.Time   `db:"created_at"`
-	CreatedBy string      `db:"created_by"`
-	UpdatedAt null.Time   `db:"updated_at"`
-	UpdatedBy null.String `db:"updated_by"`
-	IsDeleted bool        `db:"is_deleted"`
+	ID           uint64      `db:"id"`
+	Name         string      `db:"name"`
+	BaseUnitName null.String `db:"base_unit_name"`
+	BaseFactor   null.Float  `db:"base_factor"`
+	CreatedAt    time.Time   `db:"created_at"`
+	CreatedBy    string      `db:"created_by"`
+	UpdatedAt    null.Time   `db:"updated_at"`
+	UpdatedBy    null.String `db:"updated_by"`
+	IsDeleted    bool        `db:"is_deleted"`
+	DeletedAt    null.Time   `db:"deleted_at"`
 }
 
 func (c ingredientUnitDto) toEntity() *entity.IngredientUnit {
 	return &entity.IngredientUnit{
-		ID:        c.ID,
-		Name:      c.Name,
-		CreatedAt: c.CreatedAt,
-		CreatedBy: c.CreatedBy,
-		UpdatedAt: c.UpdatedAt,
-		UpdatedBy: c.UpdatedBy,
-		IsDeleted: c.IsDeleted,
+		ID:           c.ID,
+		Name:         c.Name,
+		BaseUnitName: c.BaseUnitName,
+		BaseFactor:   c.BaseFactor,
+		CreatedAt:    c.CreatedAt,
+		CreatedBy:    c.CreatedBy,
+		UpdatedAt:    c.UpdatedAt,
+		UpdatedBy:    c.UpdatedBy,
+		IsDeleted:    c.IsDeleted,
 	}
 }
 
-const selectIngredientUnitQuery = `
-select id, name, created_at, created_by, updated_at, updated_by, is_deleted from ingredient_units
+const selectIngredientUnitColumns = `
+select id, name, base_unit_name, base_factor, created_at, created_by, updated_at, updated_by, is_deleted
+from ingredient_units`
+
+const selectIngredientUnitQuery = selectIngredientUnitColumns + `
 where is_deleted = false
 limit $1 offset $2;
 `
@@ -65,28 +73,56 @@ func (r *IngredientUnitPostgresRepository) List(ctx context.Context, limit, offs
 	return res, nil
 }
 
+const selectIngredientUnitsByNamesQuery = selectIngredientUnitColumns + `
+where is_deleted = false
+and lower(name) in (?);
+`
+
+// ListByNames retrieves ingredient units by their case-insensitive names
+func (r *IngredientUnitPostgresRepository) ListByNames(ctx context.Context, names []string) (res entity.IngredientUnits, err error) {
+	var dtos []ingredientUnitDto
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var lowerNames []string
+	for _, name := range names {
+		lowerNames = append(lowerNames, strings.ToLower(name))
+	}
+
+	query, args, err := sqlx.In(selectIngredientUnitsByNamesQuery, lowerNames)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
 const insertIngredientUnitQuery = `
-INSERT INTO ingredient_units (name, created_at, created_by)
-VALUES ($1, $2, $3) RETURNING id
+INSERT INTO ingredient_units (name, base_unit_name, base_factor, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5) RETURNING id
 `
 
 // Create creates a new ingredientUnit
 func (r *IngredientUnitPostgresRepository) Create(ctx context.Context, params usecase.IngredientUnitParams) (*entity.IngredientUnit, error) {
 	dto := ingredientUnitDtoForCreate(params)
 
-	err := r.db.QueryRowxContext(ctx, insertIngredientUnitQuery, dto.Name, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	err := r.db.QueryRowxContext(ctx, insertIngredientUnitQuery, dto.Name, dto.BaseUnitName, dto.BaseFactor, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &entity.IngredientUnit{
-		ID:        dto.ID,
-		Name:      dto.Name,
-		CreatedAt: dto.CreatedAt,
-		CreatedBy: dto.CreatedBy,
-		UpdatedAt: dto.UpdatedAt,
-		UpdatedBy: dto.UpdatedBy,
-	}, nil
+	return dto.toEntity(), nil
 }
 
 // Update updates a ingredientUnit by its ID
@@ -102,33 +138,93 @@ func (r *IngredientUnitPostgresRepository) Update(ctx context.Context, id uint64
 		return nil, err
 	}
 
-	return &entity.IngredientUnit{
-		ID:        dto.ID,
-		Name:      dto.Name,
-		CreatedAt: dto.CreatedAt,
-		UpdatedAt: dto.UpdatedAt,
-		CreatedBy: dto.CreatedBy,
-		UpdatedBy: dto.UpdatedBy,
-	}, nil
+	return dto.toEntity(), nil
 }
 
-// Delete deletes a ingredientUnit by its ID
-func (r *IngredientUnitPostgresRepository) Delete(ctx context.Context, id uint64) error {
-	dto, query := ingredientUnitDtoForDelete(id)
+const selectIngredientUnitByIDQuery = `
+select id, name, base_unit_name, base_factor, created_at, created_by, updated_at, updated_by, is_deleted
+from ingredient_units
+where is_deleted = false
+and id = $1;
+`
 
-	_, err := r.db.NamedExecContext(ctx, query, &dto)
+// Get retrieves an ingredient unit by its ID
+func (r *IngredientUnitPostgresRepository) Get(ctx context.Context, id uint64) (*entity.IngredientUnit, error) {
+	var dto ingredientUnitDto
+
+	err := r.db.GetContext(ctx, &dto, selectIngredientUnitByIDQuery, id)
 	if err == sql.ErrNoRows {
+		return nil, entity.ErrIngredientUnitNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// ListReferencingRecipes lists the live recipes using an ingredient unit in their ingredients or yield
+func (r *IngredientUnitPostgresRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	return selectRecipeReferences(ctx, r.db, selectIngredientUnitRecipeReferencesQuery, id)
+}
+
+const reassignIngredientUnitRecipeIngredientsQuery = `
+UPDATE recipe_ingredients
+SET ingredient_unit_name = (select name from ingredient_units where id = $1), updated_at = $2, updated_by = $3
+WHERE ingredient_unit_name = (select name from ingredient_units where id = $4) AND is_deleted = false
+`
+
+const reassignIngredientUnitRecipeYieldsQuery = `
+UPDATE recipes
+SET yield_unit_name = (select name from ingredient_units where id = $1), updated_at = $2, updated_by = $3
+WHERE yield_unit_name = (select name from ingredient_units where id = $4) AND is_deleted = false
+`
+
+// Delete deletes an ingredient unit by its ID, its recipe references are moved to params.ReassignTo first when it is set
+func (r *IngredientUnitPostgresRepository) Delete(ctx context.Context, id uint64, params usecase.DeleteParams) error {
+	dto, query := ingredientUnitDtoForDelete(id, params.Actor)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if params.ReassignTo != 0 {
+		_, err = tx.ExecContext(ctx, reassignIngredientUnitRecipeIngredientsQuery, params.ReassignTo, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, reassignIngredientUnitRecipeYieldsQuery, params.ReassignTo, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
 		return entity.ErrIngredientUnitNotFound
 	}
 
-	return err
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func ingredientUnitDtoForCreate(params usecase.IngredientUnitParams) ingredientUnitDto {
 	return ingredientUnitDto{
-		Name:      params.Name,
-		CreatedAt: time.Now(),
-		CreatedBy: params.Actor,
+		Name:         params.Name,
+		BaseUnitName: null.NewString(params.BaseUnitName, params.BaseUnitName != ""),
+		BaseFactor:   params.BaseFactor,
+		CreatedAt:    time.Now(),
+		CreatedBy:    params.Actor,
 	}
 }
 
@@ -142,9 +238,20 @@ func ingredientUnitDtoForUpdate(id uint64, params usecase.IngredientUnitParams,
 		dto.Name = params.Name
 	}
 
+	if params.BaseUnitName != "" {
+		qb.WriteString("base_unit_name = :base_unit_name, ")
+		dto.BaseUnitName = null.StringFrom(params.BaseUnitName)
+	}
+
+	if params.BaseFactor.Valid {
+		qb.WriteString("base_factor = :base_factor, ")
+		dto.BaseFactor = params.BaseFactor
+	}
+
 	if isDeleted != nil {
-		qb.WriteString("is_deleted = :is_deleted, ")
+		qb.WriteString("is_deleted = :is_deleted, deleted_at = :deleted_at, ")
 		dto.IsDeleted = *isDeleted
+		dto.DeletedAt = null.NewTime(time.Now(), *isDeleted)
 	}
 
 	qb.WriteString("updated_at = :updated_at, ")
@@ -159,7 +266,7 @@ func ingredientUnitDtoForUpdate(id uint64, params usecase.IngredientUnitParams,
 	return dto, qb.String()
 }
 
-func ingredientUnitDtoForDelete(id uint64) (dto ingredientUnitDto, query string) {
+func ingredientUnitDtoForDelete(id uint64, actor string) (dto ingredientUnitDto, query string) {
 	isDeleted := true
-	return ingredientUnitDtoForUpdate(id, usecase.IngredientUnitParams{}, &isDeleted)
+	return ingredientUnitDtoForUpdate(id, usecase.IngredientUnitParams{Actor: actor}, &isDeleted)
 }
@@ -0,0 +1,390 @@
+package postgres_repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// PantryPostgresRepository is the PostgreSQL implementation for PantryRepository interface
+type PantryPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPantryPostgresRepository instantiates PantryPostgresRepository
+func NewPantryPostgresRepository(db *sqlx.DB) *PantryPostgresRepository {
+	return &PantryPostgresRepository{db: db}
+}
+
+type pantryItemDto struct {
+	IngredientID      uint64      `db:"ingredient_id"`
+	IngredientName    string      `db:"ingredient_name"`
+	BaseUnitName      null.String `db:"base_unit_name"`
+	Quantity          float64     `db:"quantity"`
+	LowStockThreshold null.Float  `db:"low_stock_threshold"`
+	UpdatedAt         time.Time   `db:"updated_at"`
+	UpdatedBy         string      `db:"updated_by"`
+}
+
+func (c pantryItemDto) toEntity() *entity.PantryItem {
+	return &entity.PantryItem{
+		IngredientID:      c.IngredientID,
+		IngredientName:    c.IngredientName,
+		BaseUnitName:      c.BaseUnitName.String,
+		Quantity:          c.Quantity,
+		LowStockThreshold: c.LowStockThreshold,
+		UpdatedAt:         c.UpdatedAt,
+		UpdatedBy:         c.UpdatedBy,
+	}
+}
+
+type pantryMovementDto struct {
+	ID             uint64      `db:"id"`
+	IngredientID   uint64      `db:"ingredient_id"`
+	IngredientName string      `db:"ingredient_name"`
+	Kind           string      `db:"kind"`
+	Amount         float64     `db:"amount"`
+	UnitName       string      `db:"unit_name"`
+	BaseAmount     float64     `db:"base_amount"`
+	BalanceAfter   float64     `db:"balance_after"`
+	RecipeID       null.Int    `db:"recipe_id"`
+	Notes          null.String `db:"notes"`
+	CreatedAt      time.Time   `db:"created_at"`
+	CreatedBy      string      `db:"created_by"`
+}
+
+func (c pantryMovementDto) toEntity() *entity.PantryMovement {
+	return &entity.PantryMovement{
+		ID:             c.ID,
+		IngredientID:   c.IngredientID,
+		IngredientName: c.IngredientName,
+		Kind:           c.Kind,
+		Amount:         c.Amount,
+		UnitName:       c.UnitName,
+		BaseAmount:     c.BaseAmount,
+		BalanceAfter:   c.BalanceAfter,
+		RecipeID:       c.RecipeID,
+		Notes:          c.Notes,
+		CreatedAt:      c.CreatedAt,
+		CreatedBy:      c.CreatedBy,
+	}
+}
+
+const selectPantryItemColumns = `
+select
+       p.ingredient_id,
+       i.name as ingredient_name,
+       i.base_unit_name,
+       p.quantity,
+       p.low_stock_threshold,
+       p.updated_at,
+       p.updated_by
+from pantry_items p
+join ingredients i on i.id = p.ingredient_id`
+
+const selectPantryItemsQuery = selectPantryItemColumns + `
+where ($1 = false or (p.low_stock_threshold is not null and p.quantity <= p.low_stock_threshold))
+order by i.name, p.ingredient_id
+limit $2 offset $3;
+`
+
+// ListItems retrieves the pantry items by ingredient name, filter.LowStockOnly keeps the items at or below their threshold
+func (r *PantryPostgresRepository) ListItems(ctx context.Context, filter usecase.ListPantryItemsFilter, limit, offset int) (res entity.PantryItems, err error) {
+	var dtos []pantryItemDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectPantryItemsQuery, filter.LowStockOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectPantryItemsByIngredientIDsQuery = selectPantryItemColumns + `
+where p.ingredient_id in (?)
+order by p.ingredient_id;
+`
+
+// GetItems retrieves the pantry items of ingredients, ingredients never stocked have no item
+func (r *PantryPostgresRepository) GetItems(ctx context.Context, ingredientIDs []uint64) (res entity.PantryItems, err error) {
+	var dtos []pantryItemDto
+
+	query, args, err := sqlx.In(selectPantryItemsByIngredientIDsQuery, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const upsertPantryItemThresholdQuery = `
+INSERT INTO pantry_items (ingredient_id, low_stock_threshold, updated_at, updated_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (ingredient_id) DO UPDATE SET
+    low_stock_threshold = excluded.low_stock_threshold,
+    updated_at = excluded.updated_at,
+    updated_by = excluded.updated_by
+`
+
+// SetThreshold sets the low-stock threshold of an ingredient, the ingredient is stocked with nothing on hand when it has no item yet
+func (r *PantryPostgresRepository) SetThreshold(ctx context.Context, params usecase.PantryThresholdParams) error {
+	_, err := r.db.ExecContext(ctx, upsertPantryItemThresholdQuery, params.IngredientID, params.Threshold, time.Now(), params.Actor)
+
+	return err
+}
+
+const insertPantryItemQuery = `
+INSERT INTO pantry_items (ingredient_id, updated_at, updated_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (ingredient_id) DO NOTHING
+`
+
+// lockPantryItemsQuery locks the items in ingredient order so that concurrent movements cannot deadlock
+const lockPantryItemsQuery = `
+select ingredient_id, quantity from pantry_items
+where ingredient_id in (?)
+order by ingredient_id
+for update;
+`
+
+const insertPantryMovementQuery = `
+INSERT INTO pantry_movements (ingredient_id, kind, amount, unit_name, base_amount, balance_after, recipe_id, notes, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id
+`
+
+const updatePantryItemQuantityQuery = `
+UPDATE pantry_items SET quantity = $1, updated_at = $2, updated_by = $3
+WHERE ingredient_id = $4
+`
+
+// RecordMovements appends movements to the ledger and moves the quantities on hand in one transaction.
+// Nothing is recorded when a movement would take an ingredient below zero
+func (r *PantryPostgresRepository) RecordMovements(ctx context.Context, params []usecase.PantryMovementParams) (res entity.PantryMovements, err error) {
+	now := time.Now()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ingredientIDs []uint64
+	for _, p := range params {
+		_, err = tx.ExecContext(ctx, insertPantryItemQuery, p.IngredientID, now, p.Actor)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+
+		ingredientIDs = append(ingredientIDs, p.IngredientID)
+	}
+
+	query, args, err := sqlx.In(lockPantryItemsQuery, ingredientIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	var items []pantryItemDto
+
+	err = tx.SelectContext(ctx, &items, tx.Rebind(query), args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	quantityByID := map[uint64]float64{}
+	for _, item := range items {
+		quantityByID[item.IngredientID] = item.Quantity
+	}
+
+	for _, p := range params {
+		balance := quantityByID[p.IngredientID] + p.BaseAmount
+		if balance < 0 {
+			_ = tx.Rollback()
+			return nil, entity.ErrInsufficientStock
+		}
+
+		dto := pantryMovementDto{
+			IngredientID: p.IngredientID,
+			Kind:         p.Kind,
+			Amount:       p.Amount,
+			UnitName:     p.UnitName,
+			BaseAmount:   p.BaseAmount,
+			BalanceAfter: balance,
+			RecipeID:     null.NewInt(int64(p.RecipeID), p.RecipeID != 0),
+			Notes:        null.NewString(p.Notes, p.Notes != ""),
+			CreatedAt:    now,
+			CreatedBy:    p.Actor,
+		}
+
+		err = tx.QueryRowxContext(ctx, insertPantryMovementQuery, dto.IngredientID, dto.Kind, dto.Amount, dto.UnitName, dto.BaseAmount,
+			dto.BalanceAfter, dto.RecipeID, dto.Notes, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, updatePantryItemQuantityQuery, balance, now, p.Actor, p.IngredientID)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+
+		quantityByID[p.IngredientID] = balance
+		res = append(res, dto.toEntity())
+	}
+
+	return res, tx.Commit()
+}
+
+// lockMergedPantryItemsQuery locks the items of an ingredient and its duplicates, in ingredient order like lockPantryItemsQuery
+const lockMergedPantryItemsQuery = `
+select p.ingredient_id, i.base_unit_name, p.quantity, p.low_stock_threshold
+from pantry_items p
+join ingredients i on i.id = p.ingredient_id
+where p.ingredient_id in (?)
+order by p.ingredient_id
+for update of p;
+`
+
+const updateMergedPantryItemThresholdQuery = `
+UPDATE pantry_items SET low_stock_threshold = $1, updated_at = $2, updated_by = $3
+WHERE ingredient_id = $4 AND low_stock_threshold IS NULL
+`
+
+const deleteMergedPantryItemsQuery = `
+DELETE FROM pantry_items WHERE ingredient_id in (?)
+`
+
+// mergePantryItems moves the stock of the duplicates to the ingredient as adjustments on both ledgers, since the ledger
+// is append-only, then drops the items of the duplicates. The ingredient takes the threshold of the first duplicate
+// that has one when it has none
+func mergePantryItems(ctx context.Context, tx *sqlx.Tx, ingredientID uint64, duplicateIDs []uint64, at time.Time, actor string) error {
+	query, args, err := sqlx.In(lockMergedPantryItemsQuery, append([]uint64{ingredientID}, duplicateIDs...))
+	if err != nil {
+		return err
+	}
+
+	var items []pantryItemDto
+	err = tx.SelectContext(ctx, &items, tx.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+
+	var quantity float64
+	var threshold null.Float
+	var duplicates []pantryItemDto
+
+	for _, item := range items {
+		if item.IngredientID == ingredientID {
+			quantity = item.Quantity
+			continue
+		}
+
+		duplicates = append(duplicates, item)
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertPantryItemQuery, ingredientID, at, actor)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range duplicates {
+		if !threshold.Valid {
+			threshold = item.LowStockThreshold
+		}
+
+		if item.Quantity == 0 {
+			continue
+		}
+
+		quantity += item.Quantity
+
+		movements := []pantryMovementDto{
+			{IngredientID: item.IngredientID, BaseAmount: -item.Quantity, BalanceAfter: 0, Notes: null.StringFrom(fmt.Sprintf("merged into ingredient %d", ingredientID))},
+			{IngredientID: ingredientID, BaseAmount: item.Quantity, BalanceAfter: quantity, Notes: null.StringFrom(fmt.Sprintf("merged from ingredient %d", item.IngredientID))},
+		}
+
+		for _, m := range movements {
+			_, err = tx.ExecContext(ctx, insertPantryMovementQuery, m.IngredientID, entity.PantryMovementAdjustment, m.BaseAmount, item.BaseUnitName.String,
+				m.BaseAmount, m.BalanceAfter, m.RecipeID, m.Notes, at, actor)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, updatePantryItemQuantityQuery, quantity, at, actor, ingredientID)
+	if err != nil {
+		return err
+	}
+
+	if threshold.Valid {
+		_, err = tx.ExecContext(ctx, updateMergedPantryItemThresholdQuery, threshold, at, actor, ingredientID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return execIn(ctx, tx, deleteMergedPantryItemsQuery, duplicateIDs)
+}
+
+const selectPantryMovementsQuery = `
+select
+       m.id,
+       m.ingredient_id,
+       i.name as ingredient_name,
+       m.kind,
+       m.amount,
+       m.unit_name,
+       m.base_amount,
+       m.balance_after,
+       m.recipe_id,
+       m.notes,
+       m.created_at,
+       m.created_by
+from pantry_movements m
+join ingredients i on i.id = m.ingredient_id
+where m.ingredient_id = $1
+order by m.id desc
+limit $2 offset $3;
+`
+
+// ListMovements retrieves the ledger of an ingredient, latest first
+func (r *PantryPostgresRepository) ListMovements(ctx context.Context, ingredientID uint64, limit, offset int) (res entity.PantryMovements, err error) {
+	var dtos []pantryMovementDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectPantryMovementsQuery, ingredientID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
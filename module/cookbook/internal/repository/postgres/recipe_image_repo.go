@@ -0,0 +1,161 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// RecipeImagePostgresRepository is the PostgreSQL implementation for RecipeImageRepository interface
+type RecipeImagePostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewRecipeImagePostgresRepository instantiates RecipeImagePostgresRepository
+func NewRecipeImagePostgresRepository(db *sqlx.DB) *RecipeImagePostgresRepository {
+	return &RecipeImagePostgresRepository{db: db}
+}
+
+type recipeImageDto struct {
+	ID           uint64      `db:"id"`
+	RecipeID     uint64      `db:"recipe_id"`
+	Step         null.Int    `db:"step"`
+	Caption      null.String `db:"caption"`
+	FileKey      string      `db:"file_key"`
+	ThumbnailKey string      `db:"thumbnail_key"`
+	ContentType  string      `db:"content_type"`
+	SizeBytes    int64       `db:"size_bytes"`
+	Width        int         `db:"width"`
+	Height       int         `db:"height"`
+	CreatedAt    time.Time   `db:"created_at"`
+	CreatedBy    string      `db:"created_by"`
+}
+
+func (c recipeImageDto) toEntity() *entity.RecipeImage {
+	return &entity.RecipeImage{
+		ID:           c.ID,
+		RecipeID:     c.RecipeID,
+		Step:         c.Step,
+		Caption:      c.Caption,
+		FileKey:      c.FileKey,
+		ThumbnailKey: c.ThumbnailKey,
+		ContentType:  c.ContentType,
+		SizeBytes:    c.SizeBytes,
+		Width:        c.Width,
+		Height:       c.Height,
+		CreatedAt:    c.CreatedAt,
+		CreatedBy:    c.CreatedBy,
+	}
+}
+
+const selectRecipeImageColumns = `
+select id, recipe_id, step, caption, file_key, thumbnail_key, content_type, size_bytes, width, height, created_at, created_by
+from recipe_images`
+
+// insertRecipeImageQuery only inserts when the recipe exists and is not deleted
+const insertRecipeImageQuery = `
+INSERT INTO recipe_images (recipe_id, step, caption, file_key, thumbnail_key, content_type, size_bytes, width, height, created_at, created_by)
+SELECT r.id, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+FROM recipes r
+WHERE r.id = $1 AND r.is_deleted = false
+RETURNING id
+`
+
+// Create records a stored recipe image
+func (r *RecipeImagePostgresRepository) Create(ctx context.Context, params usecase.RecipeImageParams) (*entity.RecipeImage, error) {
+	dto := recipeImageDto{
+		RecipeID:     params.RecipeID,
+		Step:         params.Step,
+		Caption:      params.Caption,
+		FileKey:      params.FileKey,
+		ThumbnailKey: params.ThumbnailKey,
+		ContentType:  params.ContentType,
+		SizeBytes:    params.SizeBytes,
+		Width:        params.Width,
+		Height:       params.Height,
+		CreatedAt:    time.Now(),
+		CreatedBy:    params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertRecipeImageQuery, dto.RecipeID, dto.Step, dto.Caption, dto.FileKey, dto.ThumbnailKey,
+		dto.ContentType, dto.SizeBytes, dto.Width, dto.Height, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const selectRecipeImageByIDQuery = selectRecipeImageColumns + `
+where id = $1;
+`
+
+// Get retrieves a recipe image by its ID
+func (r *RecipeImagePostgresRepository) Get(ctx context.Context, id uint64) (*entity.RecipeImage, error) {
+	var dto recipeImageDto
+
+	err := r.db.GetContext(ctx, &dto, selectRecipeImageByIDQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRecipeImageNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const selectRecipeImagesByRecipeIDQuery = selectRecipeImageColumns + `
+where recipe_id = $1
+order by step nulls first, id;
+`
+
+// ListByRecipeID retrieves the images of a recipe, the recipe photos first and then the step photos by step
+func (r *RecipeImagePostgresRepository) ListByRecipeID(ctx context.Context, recipeID uint64) (res entity.RecipeImages, err error) {
+	var dtos []recipeImageDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectRecipeImagesByRecipeIDQuery, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const deleteRecipeImageQuery = `
+DELETE FROM recipe_images WHERE id = $1
+`
+
+// Delete permanently deletes a recipe image by its ID
+func (r *RecipeImagePostgresRepository) Delete(ctx context.Context, id uint64) error {
+	result, err := r.db.ExecContext(ctx, deleteRecipeImageQuery, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrRecipeImageNotFound
+	}
+
+	return nil
+}
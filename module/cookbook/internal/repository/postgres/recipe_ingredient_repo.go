@@ -15,10 +15,14 @@ import (
 type recipeIngredientDto struct {
 	ID                 uint64      `db:"id"`
 	RecipeID           uint64      `db:"recipe_id"`
-	IngredientID       uint64      `db:"ingredient_id"`
+	IngredientID       null.Int    `db:"ingredient_id"`
+	SubRecipeID        null.Int    `db:"sub_recipe_id"`
 	IngredientName     string      `db:"ingredient_name"`
 	IngredientUnitName string      `db:"ingredient_unit_name"`
-	Amount             float64     `db:"amount"`
+	Amount             null.Float  `db:"amount"`
+	AmountMax          null.Float  `db:"amount_max"`
+	AmountDenominator  null.Int    `db:"amount_denominator"`
+	Optional           bool        `db:"optional"`
 	OrderingIndex      int         `db:"ordering_index"`
 	Notes              string      `db:"notes"`
 	CreatedAt          time.Time   `db:"created_at"`
@@ -26,6 +30,29 @@ type recipeIngredientDto struct {
 	UpdatedAt          null.Time   `db:"updated_at"`
 	UpdatedBy          null.String `db:"updated_by"`
 	IsDeleted          bool        `db:"is_deleted"`
+	DeletedAt          null.Time   `db:"deleted_at"`
+}
+
+func (c recipeIngredientDto) toEntity() *entity.RecipeIngredient {
+	return &entity.RecipeIngredient{
+		ID:                 c.ID,
+		RecipeID:           c.RecipeID,
+		IngredientID:       uint64(c.IngredientID.Int64),
+		SubRecipeID:        uint64(c.SubRecipeID.Int64),
+		IngredientName:     c.IngredientName,
+		IngredientUnitName: c.IngredientUnitName,
+		Amount:             c.Amount,
+		AmountMax:          c.AmountMax,
+		AmountDenominator:  c.AmountDenominator,
+		Optional:           c.Optional,
+		OrderingIndex:      c.OrderingIndex,
+		Notes:              c.Notes,
+		CreatedAt:          c.CreatedAt,
+		CreatedBy:          c.CreatedBy,
+		UpdatedAt:          c.UpdatedAt,
+		UpdatedBy:          c.UpdatedBy,
+		IsDeleted:          c.IsDeleted,
+	}
 }
 
 // RecipeIngredientPostgresRepository is the PostgreSQL implementation for RecipeIngredientRepository interface
@@ -39,9 +66,10 @@ func NewRecipeIngredientPostgresRepository(db *sqlx.DB) *RecipeIngredientPostgre
 }
 
 const bulkInsertRecipeIngredientsQuery = `
-INSERT INTO recipe_ingredients (recipe_id, ingredient_id, ingredient_name, ingredient_unit_name, amount, ordering_index, notes, created_at, created_by)
+INSERT INTO recipe_ingredients (recipe_id, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max, amount_denominator, optional, ordering_index, notes, created_at, created_by)
 `
 
+// BulkCreate creates recipe ingredients of a recipe in a single statement
 func (r *RecipeIngredientPostgresRepository) BulkCreate(ctx context.Context, recipeID uint64, params usecase.BulkRecipeIngredientParams) error {
 	var args []interface{}
 	var count int
@@ -55,10 +83,14 @@ func (r *RecipeIngredientPostgresRepository) BulkCreate(ctx context.Context, rec
 		var argsTmp []interface{}
 
 		argsTmp = append(argsTmp, recipeID)
-		argsTmp = append(argsTmp, p.IngredientID)
+		argsTmp = append(argsTmp, nullableID(p.IngredientID))
+		argsTmp = append(argsTmp, nullableID(p.SubRecipeID))
 		argsTmp = append(argsTmp, p.IngredientName)
 		argsTmp = append(argsTmp, p.IngredientUnitName)
-		argsTmp = append(argsTmp, p.Amount)
+		argsTmp = append(argsTmp, null.NewFloat(p.Amount, !p.ToTaste))
+		argsTmp = append(argsTmp, null.NewFloat(p.AmountMax, p.AmountMax > 0))
+		argsTmp = append(argsTmp, null.NewInt(int64(p.AmountDenominator), p.AmountDenominator > 1))
+		argsTmp = append(argsTmp, p.Optional != nil && *p.Optional)
 		argsTmp = append(argsTmp, p.OrderingIndex)
 		argsTmp = append(argsTmp, p.Notes)
 		argsTmp = append(argsTmp, time.Now())
@@ -84,6 +116,31 @@ func (r *RecipeIngredientPostgresRepository) BulkCreate(ctx context.Context, rec
 	return err
 }
 
+const selectRecipeIngredientQuery = `
+select id, recipe_id, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max, amount_denominator, optional,
+       ordering_index, coalesce(notes, '') as notes, created_at, created_by, updated_at, updated_by, is_deleted
+from recipe_ingredients
+where is_deleted = false
+and id = $1;
+`
+
+// Get retrieves a recipe ingredient by its ID
+func (r *RecipeIngredientPostgresRepository) Get(ctx context.Context, id uint64) (*entity.RecipeIngredient, error) {
+	var dto recipeIngredientDto
+
+	err := r.db.GetContext(ctx, &dto, selectRecipeIngredientQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRecipeIngredientNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates a recipe ingredient by its ID
 func (r *RecipeIngredientPostgresRepository) Update(ctx context.Context, id uint64, params usecase.RecipeIngredientParams) (*entity.RecipeIngredient, error) {
 	dto, query := recipeIngredientDtoForUpdate(id, params, nil)
 
@@ -96,22 +153,10 @@ func (r *RecipeIngredientPostgresRepository) Update(ctx context.Context, id uint
 		return nil, err
 	}
 
-	return &entity.RecipeIngredient{
-		ID:                 dto.ID,
-		RecipeID:           dto.RecipeID,
-		IngredientID:       dto.IngredientID,
-		IngredientName:     dto.IngredientName,
-		IngredientUnitName: dto.IngredientUnitName,
-		Amount:             dto.Amount,
-		OrderingIndex:      dto.OrderingIndex,
-		Notes:              dto.Notes,
-		CreatedAt:          dto.CreatedAt,
-		CreatedBy:          dto.CreatedBy,
-		UpdatedAt:          dto.UpdatedAt,
-		UpdatedBy:          dto.UpdatedBy,
-	}, nil
+	return dto.toEntity(), nil
 }
 
+// Delete deletes a recipe ingredient by its ID
 func (r *RecipeIngredientPostgresRepository) Delete(ctx context.Context, id uint64) error {
 	dto, query := recipeIngredientDtoForDelete(id)
 
@@ -123,19 +168,108 @@ func (r *RecipeIngredientPostgresRepository) Delete(ctx context.Context, id uint
 	return err
 }
 
+type recipeIngredientNameDriftDto struct {
+	RecipeIngredientID uint64   `db:"recipe_ingredient_id"`
+	RecipeID           uint64   `db:"recipe_id"`
+	RecipeName         string   `db:"recipe_name"`
+	IngredientID       null.Int `db:"ingredient_id"`
+	SubRecipeID        null.Int `db:"sub_recipe_id"`
+	IngredientName     string   `db:"ingredient_name"`
+	ExpectedName       string   `db:"expected_name"`
+}
+
+func (c recipeIngredientNameDriftDto) toEntity() *entity.RecipeIngredientNameDrift {
+	return &entity.RecipeIngredientNameDrift{
+		RecipeIngredientID: c.RecipeIngredientID,
+		RecipeID:           c.RecipeID,
+		RecipeName:         c.RecipeName,
+		IngredientID:       uint64(c.IngredientID.Int64),
+		SubRecipeID:        uint64(c.SubRecipeID.Int64),
+		IngredientName:     c.IngredientName,
+		ExpectedName:       c.ExpectedName,
+	}
+}
+
+const selectRecipeIngredientNameDriftsQuery = `
+select ri.id as recipe_ingredient_id, ri.recipe_id, r.name as recipe_name, ri.ingredient_id, ri.sub_recipe_id,
+       ri.ingredient_name, coalesce(i.name, sr.name) as expected_name
+from recipe_ingredients ri
+join recipes r on r.id = ri.recipe_id
+left join ingredients i on i.id = ri.ingredient_id
+left join recipes sr on sr.id = ri.sub_recipe_id
+where ri.is_deleted = false
+and coalesce(i.name, sr.name) is not null
+and ri.ingredient_name is distinct from coalesce(i.name, sr.name)
+order by ri.recipe_id, ri.ordering_index;
+`
+
+// ListNameDrifts lists recipe ingredients whose name differs from their ingredient or sub-recipe
+func (r *RecipeIngredientPostgresRepository) ListNameDrifts(ctx context.Context) (res entity.RecipeIngredientNameDrifts, err error) {
+	var dtos []recipeIngredientNameDriftDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectRecipeIngredientNameDriftsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const repairRecipeIngredientNameDriftsQuery = `
+UPDATE recipe_ingredients ri
+SET ingredient_name = coalesce(i.name, sr.name), updated_at = $1, updated_by = $2
+FROM recipe_ingredients cur
+LEFT JOIN ingredients i ON i.id = cur.ingredient_id
+LEFT JOIN recipes sr ON sr.id = cur.sub_recipe_id
+WHERE cur.id = ri.id
+AND ri.is_deleted = false
+AND coalesce(i.name, sr.name) IS NOT NULL
+AND ri.ingredient_name IS DISTINCT FROM coalesce(i.name, sr.name)
+`
+
+// RepairNameDrifts rewrites the names of drifted recipe ingredients and returns how many rows were repaired
+func (r *RecipeIngredientPostgresRepository) RepairNameDrifts(ctx context.Context, actor string) (int64, error) {
+	result, err := r.db.ExecContext(ctx, repairRecipeIngredientNameDriftsQuery, time.Now(), actor)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func recipeIngredientDtoForUpdate(id uint64, params usecase.RecipeIngredientParams, isDeleted *bool) (dto recipeIngredientDto, query string) {
 	var qb strings.Builder
 
 	qb.WriteString("UPDATE recipe_ingredients SET ")
 
-	if params.Amount > 0 {
-		qb.WriteString("amount = :amount, ")
-		dto.Amount = params.Amount
+	// the amounts are replaced together, so that a range or a fraction never outlives its amount
+	switch {
+	case params.ToTaste:
+		qb.WriteString("amount = NULL, amount_max = NULL, amount_denominator = NULL, ")
+	case params.Amount > 0:
+		qb.WriteString("amount = :amount, amount_max = :amount_max, amount_denominator = :amount_denominator, ")
+		dto.Amount = null.FloatFrom(params.Amount)
+		dto.AmountMax = null.NewFloat(params.AmountMax, params.AmountMax > 0)
+		dto.AmountDenominator = null.NewInt(int64(params.AmountDenominator), params.AmountDenominator > 1)
+	}
+
+	if params.Optional != nil {
+		qb.WriteString("optional = :optional, ")
+		dto.Optional = *params.Optional
 	}
 
 	if params.IngredientID != 0 {
-		qb.WriteString("ingredient_id = :ingredient_id, ")
-		dto.IngredientID = params.IngredientID
+		qb.WriteString("ingredient_id = :ingredient_id, sub_recipe_id = NULL, ")
+		dto.IngredientID = nullableID(params.IngredientID)
+	}
+
+	if params.SubRecipeID != 0 {
+		qb.WriteString("sub_recipe_id = :sub_recipe_id, ingredient_id = NULL, ")
+		dto.SubRecipeID = nullableID(params.SubRecipeID)
 	}
 
 	if params.IngredientName != "" {
@@ -159,8 +293,9 @@ func recipeIngredientDtoForUpdate(id uint64, params usecase.RecipeIngredientPara
 	}
 
 	if isDeleted != nil {
-		qb.WriteString("is_deleted = :is_deleted, ")
+		qb.WriteString("is_deleted = :is_deleted, deleted_at = :deleted_at, ")
 		dto.IsDeleted = *isDeleted
+		dto.DeletedAt = null.NewTime(time.Now(), *isDeleted)
 	}
 
 	qb.WriteString("updated_at = :updated_at, ")
@@ -179,3 +314,8 @@ func recipeIngredientDtoForDelete(id uint64) (dto recipeIngredientDto, query str
 	isDeleted := true
 	return recipeIngredientDtoForUpdate(id, usecase.RecipeIngredientParams{}, &isDeleted)
 }
+
+// nullableID converts an optional ID, where zero means unset, into a nullable column value
+func nullableID(id uint64) null.Int {
+	return null.NewInt(int64(id), id != 0)
+}
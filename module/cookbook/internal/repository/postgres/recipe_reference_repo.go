@@ -0,0 +1,75 @@
+package postgres_repo
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type recipeReferenceDto struct {
+	RecipeID   uint64 `db:"recipe_id"`
+	RecipeName string `db:"recipe_name"`
+}
+
+func (c recipeReferenceDto) toEntity() *entity.RecipeReference {
+	return &entity.RecipeReference{
+		RecipeID:   c.RecipeID,
+		RecipeName: c.RecipeName,
+	}
+}
+
+const selectCategoryRecipeReferencesQuery = `
+select r.id as recipe_id, r.name as recipe_name
+from recipes r
+where r.is_deleted = false
+and r.category_id = $1
+order by r.id;
+`
+
+const selectIngredientRecipeReferencesQuery = `
+select distinct r.id as recipe_id, r.name as recipe_name
+from recipes r
+join recipe_ingredients ri on ri.recipe_id = r.id and ri.is_deleted = false
+where r.is_deleted = false
+and ri.ingredient_id = $1
+order by r.id;
+`
+
+const selectSubRecipeRecipeReferencesQuery = `
+select distinct r.id as recipe_id, r.name as recipe_name
+from recipes r
+join recipe_ingredients ri on ri.recipe_id = r.id and ri.is_deleted = false
+where r.is_deleted = false
+and ri.sub_recipe_id = $1
+order by r.id;
+`
+
+const selectIngredientUnitRecipeReferencesQuery = `
+with unit as (select name from ingredient_units where id = $1)
+select r.id as recipe_id, r.name as recipe_name
+from recipes r
+where r.is_deleted = false
+and (r.yield_unit_name = (select name from unit)
+    or exists (select 1 from recipe_ingredients ri
+               where ri.recipe_id = r.id and ri.is_deleted = false
+               and ri.ingredient_unit_name = (select name from unit)))
+order by r.id;
+`
+
+// selectRecipeReferences lists the live recipes returned by query
+func selectRecipeReferences(ctx context.Context, db *sqlx.DB, query string, args ...interface{}) (res entity.RecipeReferences, err error) {
+	var dtos []recipeReferenceDto
+
+	err = db.SelectContext(ctx, &dtos, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
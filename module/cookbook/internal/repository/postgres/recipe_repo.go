@@ -3,12 +3,14 @@ package postgres_repo
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 	"strings"
 	"time"
 
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 )
@@ -24,15 +26,30 @@ func NewRecipePostgresRepository(db *sqlx.DB) *RecipePostgresRepository {
 }
 
 type recipeDto struct {
-	ID          uint64      `db:"id"`
-	Name        string      `db:"name"`
-	Description string      `db:"description"`
-	CategoryID  uint64      `db:"category_id"`
-	CreatedAt   time.Time   `db:"created_at"`
-	CreatedBy   string      `db:"created_by"`
-	UpdatedAt   null.Time   `db:"updated_at"`
-	UpdatedBy   null.String `db:"updated_by"`
-	IsDeleted   bool        `db:"is_deleted"`
+	ID            uint64      `db:"id"`
+	Name          string      `db:"name"`
+	Description   string      `db:"description"`
+	CategoryID    uint64      `db:"category_id"`
+	Servings      int         `db:"servings"`
+	YieldAmount   null.Float  `db:"yield_amount"`
+	YieldUnitName null.String `db:"yield_unit_name"`
+	Cuisine       null.String `db:"cuisine"`
+	PrepMinutes   null.Int    `db:"prep_minutes"`
+	CookMinutes   null.Int    `db:"cook_minutes"`
+	TotalMinutes  null.Int    `db:"total_minutes"`
+	Difficulty    null.String `db:"difficulty"`
+	Status        string      `db:"status"`
+	VariantOfID   null.Int    `db:"variant_of_id"`
+	ForkedAt      null.Time   `db:"forked_at"`
+	CookCount     int         `db:"cook_count"`
+	RatingCount   int         `db:"rating_count"`
+	AverageRating null.Float  `db:"average_rating"`
+	CreatedAt     time.Time   `db:"created_at"`
+	CreatedBy     string      `db:"created_by"`
+	UpdatedAt     null.Time   `db:"updated_at"`
+	UpdatedBy     null.String `db:"updated_by"`
+	IsDeleted     bool        `db:"is_deleted"`
+	DeletedAt     null.Time   `db:"deleted_at"`
 }
 
 type recipeSummaryDto struct {
@@ -40,12 +57,31 @@ type recipeSummaryDto struct {
 	Name               string      `db:"name"`
 	Description        string      `db:"description"`
 	CategoryID         uint64      `db:"category_id"`
-	RecipeIngredientID uint64      `db:"recipe_ingredient_id"`
-	IngredientName     string      `db:"ingredient_name"`
-	IngredientUnitName string      `db:"ingredient_unit_name"`
-	Amount             float64     `db:"amount"`
-	Notes              string      `db:"notes"`
-	OrderingIndex      int         `db:"ordering_index"`
+	Servings           int         `db:"servings"`
+	YieldAmount        null.Float  `db:"yield_amount"`
+	YieldUnitName      null.String `db:"yield_unit_name"`
+	Cuisine            null.String `db:"cuisine"`
+	PrepMinutes        null.Int    `db:"prep_minutes"`
+	CookMinutes        null.Int    `db:"cook_minutes"`
+	TotalMinutes       null.Int    `db:"total_minutes"`
+	Difficulty         null.String `db:"difficulty"`
+	Status             string      `db:"status"`
+	VariantOfID        null.Int    `db:"variant_of_id"`
+	ForkedAt           null.Time   `db:"forked_at"`
+	CookCount          int         `db:"cook_count"`
+	RatingCount        int         `db:"rating_count"`
+	AverageRating      null.Float  `db:"average_rating"`
+	RecipeIngredientID null.Int    `db:"recipe_ingredient_id"`
+	IngredientID       null.Int    `db:"ingredient_id"`
+	SubRecipeID        null.Int    `db:"sub_recipe_id"`
+	IngredientName     null.String `db:"ingredient_name"`
+	IngredientUnitName null.String `db:"ingredient_unit_name"`
+	Amount             null.Float  `db:"amount"`
+	AmountMax          null.Float  `db:"amount_max"`
+	AmountDenominator  null.Int    `db:"amount_denominator"`
+	Optional           null.Bool   `db:"optional"`
+	Notes              null.String `db:"notes"`
+	OrderingIndex      null.Int    `db:"ordering_index"`
 	CreatedAt          time.Time   `db:"created_at"`
 	CreatedBy          string      `db:"created_by"`
 	UpdatedAt          null.Time   `db:"updated_at"`
@@ -55,15 +91,29 @@ type recipeSummaryDto struct {
 
 func (c recipeDto) toEntity() *entity.Recipe {
 	return &entity.Recipe{
-		ID:          c.ID,
-		Name:        c.Name,
-		Description: c.Description,
-		CategoryID:  c.CategoryID,
-		CreatedAt:   c.CreatedAt,
-		CreatedBy:   c.CreatedBy,
-		UpdatedAt:   c.UpdatedAt,
-		UpdatedBy:   c.UpdatedBy,
-		IsDeleted:   c.IsDeleted,
+		ID:            c.ID,
+		Name:          c.Name,
+		Description:   c.Description,
+		CategoryID:    c.CategoryID,
+		Servings:      c.Servings,
+		YieldAmount:   c.YieldAmount,
+		YieldUnitName: c.YieldUnitName,
+		Cuisine:       c.Cuisine,
+		PrepMinutes:   c.PrepMinutes,
+		CookMinutes:   c.CookMinutes,
+		TotalMinutes:  c.TotalMinutes,
+		Difficulty:    c.Difficulty,
+		Status:        c.Status,
+		VariantOfID:   c.VariantOfID,
+		ForkedAt:      c.ForkedAt,
+		CookCount:     c.CookCount,
+		RatingCount:   c.RatingCount,
+		AverageRating: c.AverageRating,
+		CreatedAt:     c.CreatedAt,
+		CreatedBy:     c.CreatedBy,
+		UpdatedAt:     c.UpdatedAt,
+		UpdatedBy:     c.UpdatedBy,
+		IsDeleted:     c.IsDeleted,
 	}
 }
 
@@ -73,106 +123,443 @@ select
        r.name,
        r.description,
        r.category_id,
+       r.servings,
+       r.yield_amount,
+       r.yield_unit_name,
+       r.cuisine,
+       r.prep_minutes,
+       r.cook_minutes,
+       r.total_minutes,
+       r.difficulty,
+       r.status,
+       r.variant_of_id,
+       r.forked_at,
+       r.cook_count,
+       r.rating_count,
+       r.average_rating,
        r.created_at,
        r.created_by,
        r.updated_at,
        r.updated_by
 from recipes r
-left join recipe_ingredients ri on r.id = ri.recipe_id
 where r.is_deleted = false`
 
-// List retrieves a list of categories with offset and limit
+// recipeIngredientTreesQuery lists the ingredient rows of every recipe together with the rows of its sub-recipes.
+// root_id is the recipe the rows belong to, directly or through sub-recipes
+const recipeIngredientTreesQuery = `
+with recursive recipe_ingredient_trees(root_id, ingredient_id, sub_recipe_id) as (
+    select ri.recipe_id, ri.ingredient_id, ri.sub_recipe_id
+    from recipe_ingredients ri
+    where ri.is_deleted = false
+    union
+    select t.root_id, ri.ingredient_id, ri.sub_recipe_id
+    from recipe_ingredients ri
+    join recipe_ingredient_trees t on ri.recipe_id = t.sub_recipe_id
+    where ri.is_deleted = false
+)`
+
+const selectRecipesByIDsQuery = selectRecipeQuery + `
+and r.id in (?);
+`
+
+// ListByIDs retrieves recipes by their IDs
+func (r *RecipePostgresRepository) ListByIDs(ctx context.Context, ids []uint64) (res entity.Recipes, err error) {
+	var dtos []recipeDto
+
+	query, args, err := sqlx.In(selectRecipesByIDsQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+// List retrieves a list of recipes with filter, offset and limit
 func (r *RecipePostgresRepository) List(ctx context.Context, filter usecase.ListRecipesFiter, limit, offset int) (res entity.Recipes, err error) {
 	var dtos []recipeDto
 
-	query := selectRecipeQuery
+	prefix, conditions, args := recipeFilterConditions(filter)
 
-	args := []interface{}{limit, offset}
+	args = append(args, limit, offset)
+	query := prefix + selectRecipeQuery + conditions + fmt.Sprintf("\norder by %s\nlimit $%d offset $%d;", recipeOrderBy[filter.Sort], len(args)-1, len(args))
 
-	if filter.CategoryID > 0 {
-		query += "\nand r.category_id = $3"
+	err = r.db.SelectContext(ctx, &dtos, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+// recipeOrderBy maps the sorts of the recipe list to their order by clause, unrated recipes come last either way
+var recipeOrderBy = map[string]string{
+	"":                              "r.id",
+	usecase.RecipeSortID:            "r.id",
+	usecase.RecipeSortIDDesc:        "r.id desc",
+	usecase.RecipeSortRating:        "r.average_rating nulls last, r.rating_count desc, r.id",
+	usecase.RecipeSortRatingDesc:    "r.average_rating desc nulls last, r.rating_count desc, r.id",
+	usecase.RecipeSortCookCount:     "r.cook_count, r.id",
+	usecase.RecipeSortCookCountDesc: "r.cook_count desc, r.id",
+}
+
+// recipeFilterConditions builds the conditions appended after "where r.is_deleted = false" for a filter,
+// prefix holds the CTE the conditions need and must be put in front of the whole query
+func recipeFilterConditions(filter usecase.ListRecipesFiter) (prefix string, conditions string, args []interface{}) {
+	var qb strings.Builder
+
+	if len(filter.ExcludeAllergens) > 0 || len(filter.Diets) > 0 {
+		prefix = recipeIngredientTreesQuery
+	}
+
+	if filter.CategoryID > 0 && filter.IncludeSubcategories {
+		args = append(args, filter.CategoryID)
+		qb.WriteString(fmt.Sprintf("\nand r.category_id in (%s\nselect id from descendants)", strings.Replace(categoryDescendantIDsQuery, "$1", fmt.Sprintf("$%d", len(args)), 1)))
+	} else if filter.CategoryID > 0 {
 		args = append(args, filter.CategoryID)
+		qb.WriteString(fmt.Sprintf("\nand r.category_id = $%d", len(args)))
 	}
 
+	// an ingredient matches the recipes using it or any of its child ingredients
 	if filter.IngredientID > 0 {
-		query += "\nand ri.ingredient_id = $4"
 		args = append(args, filter.IngredientID)
+		qb.WriteString(fmt.Sprintf("\nand exists (select 1 from recipe_ingredients ri where ri.recipe_id = r.id and ri.is_deleted = false and ri.ingredient_id in (%s))", strings.Replace(ingredientDescendantIDsQuery, "$1", fmt.Sprintf("$%d", len(args)), 1)))
 	}
 
-	query += "\nlimit $1 offset $2;"
+	if len(filter.ExcludeAllergens) > 0 {
+		args = append(args, pq.StringArray(filter.ExcludeAllergens))
+		qb.WriteString(fmt.Sprintf("\nand not exists (select 1 from recipe_ingredient_trees t join ingredients i on i.id = t.ingredient_id where t.root_id = r.id and i.allergens && $%d::varchar[])", len(args)))
+	}
 
-	err = r.db.SelectContext(ctx, &dtos, query, args...)
+	// a recipe fits a diet when it has ingredients and all of them are tagged with the diet, deleted ingredients
+	// still in the recipe count as in the allergen filter and the summary labels
+	if len(filter.Diets) > 0 {
+		args = append(args, pq.StringArray(filter.Diets))
+		qb.WriteString("\nand exists (select 1 from recipe_ingredient_trees t where t.root_id = r.id and t.ingredient_id is not null)")
+		qb.WriteString(fmt.Sprintf("\nand not exists (select 1 from recipe_ingredient_trees t left join ingredients i on i.id = t.ingredient_id where t.root_id = r.id and t.ingredient_id is not null and (i.id is null or not i.diets @> $%d::varchar[]))", len(args)))
+	}
+
+	// a recipe must carry every requested tag
+	if len(filter.TagIDs) > 0 {
+		args = append(args, pq.Array(filter.TagIDs), len(filter.TagIDs))
+		qb.WriteString(fmt.Sprintf("\nand r.id in (select rt.recipe_id from recipe_tags rt where rt.tag_id = any($%d::bigint[]) group by rt.recipe_id having count(distinct rt.tag_id) = $%d)", len(args)-1, len(args)))
+	}
+
+	if filter.Cuisine != "" {
+		args = append(args, filter.Cuisine)
+		qb.WriteString(fmt.Sprintf("\nand lower(r.cuisine) = lower($%d)", len(args)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		args = append(args, pq.StringArray(filter.Statuses))
+		qb.WriteString(fmt.Sprintf("\nand r.status = any($%d::varchar[])", len(args)))
+	}
+
+	// drafts and recipes in review are only listed to their author
+	if filter.DraftAuthor.Valid {
+		args = append(args, filter.DraftAuthor.String)
+		qb.WriteString(fmt.Sprintf("\nand (r.status not in ('draft', 'in_review') or lower(r.created_by) = lower($%d))", len(args)))
+	}
+
+	if filter.MinRating > 0 {
+		args = append(args, filter.MinRating)
+		qb.WriteString(fmt.Sprintf("\nand r.average_rating >= $%d", len(args)))
+	}
+
+	if filter.MaxTotalMinutes > 0 {
+		args = append(args, filter.MaxTotalMinutes)
+		qb.WriteString(fmt.Sprintf("\nand r.total_minutes <= $%d", len(args)))
+	}
+
+	// a recipe must need every requested equipment
+	if len(filter.EquipmentIDs) > 0 {
+		args = append(args, pq.Array(filter.EquipmentIDs), len(filter.EquipmentIDs))
+		qb.WriteString(fmt.Sprintf("\nand r.id in (select re.recipe_id from recipe_equipment re where re.equipment_id = any($%d::int[]) group by re.recipe_id having count(distinct re.equipment_id) = $%d)", len(args)-1, len(args)))
+	}
+
+	return prefix, qb.String(), args
+}
+
+const selectTagFacetsQuery = `
+select t.id, t.name, count(*) as count
+from recipe_tags rt
+join tags t on t.id = rt.tag_id and t.is_deleted = false
+join filtered_recipes fr on fr.id = rt.recipe_id
+group by t.id, t.name
+order by count desc, t.name;
+`
+
+const selectCategoryFacetsQuery = `
+select c.id, c.name, count(*) as count
+from filtered_recipes fr
+join categories c on c.id = fr.category_id and c.is_deleted = false
+group by c.id, c.name
+order by count desc, c.name;
+`
+
+type facetCountDto struct {
+	ID    uint64 `db:"id"`
+	Name  string `db:"name"`
+	Count int    `db:"count"`
+}
+
+// ListFacets counts the recipes matching a filter per tag and per category
+func (r *RecipePostgresRepository) ListFacets(ctx context.Context, filter usecase.ListRecipesFiter) (res entity.RecipeFacets, err error) {
+	res.Tags, err = r.listFacets(ctx, filter, selectTagFacetsQuery)
+	if err != nil {
+		return entity.RecipeFacets{}, err
+	}
+
+	res.Categories, err = r.listFacets(ctx, filter, selectCategoryFacetsQuery)
+	if err != nil {
+		return entity.RecipeFacets{}, err
+	}
+
+	return res, nil
+}
+
+func (r *RecipePostgresRepository) listFacets(ctx context.Context, filter usecase.ListRecipesFiter, facetQuery string) (res entity.FacetCounts, err error) {
+	var dtos []facetCountDto
+
+	prefix, conditions, args := recipeFilterConditions(filter)
+
+	// filtered_recipes is chained to the recipe ingredient trees CTE when the filter needs it
+	filtered := "filtered_recipes as (\nselect r.id, r.category_id from recipes r\nwhere r.is_deleted = false" + conditions + "\n)"
+	if prefix != "" {
+		filtered = prefix + ",\n" + filtered
+	} else {
+		filtered = "with " + filtered
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, filtered+facetQuery, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, dto := range dtos {
-		res = append(res, dto.toEntity())
+		res = append(res, &entity.FacetCount{
+			ID:    dto.ID,
+			Name:  dto.Name,
+			Count: dto.Count,
+		})
 	}
 
 	return res, nil
 }
 
 const insertRecipeQuery = `
-INSERT INTO recipes (name, description, category_id, created_at, created_by)
-VALUES ($1, $2, $3, $4, $5) RETURNING id
+INSERT INTO recipes (name, description, category_id, servings, yield_amount, yield_unit_name, cuisine, prep_minutes, cook_minutes, difficulty, status, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, total_minutes
 `
 
-// Create creates a new Recipe
+// Create creates a new Recipe with its tags and equipment
 func (r *RecipePostgresRepository) Create(ctx context.Context, params usecase.CreateRecipeParams) (*entity.Recipe, error) {
 	dto := recipeDtoForCreate(params)
 
-	err := r.db.QueryRowxContext(ctx, insertRecipeQuery, dto.Name, dto.Description, dto.CategoryID, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return &entity.Recipe{
-		ID:        dto.ID,
-		Name:      dto.Name,
-		CreatedAt: dto.CreatedAt,
-		CreatedBy: dto.CreatedBy,
-		UpdatedAt: dto.UpdatedAt,
-		UpdatedBy: dto.UpdatedBy,
-	}, nil
+	err = tx.QueryRowxContext(ctx, insertRecipeQuery, dto.Name, dto.Description, dto.CategoryID, dto.Servings, dto.YieldAmount, dto.YieldUnitName, dto.Cuisine,
+		dto.PrepMinutes, dto.CookMinutes, dto.Difficulty, dto.Status, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID, &dto.TotalMinutes)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	err = replaceRecipeTags(ctx, tx, dto.ID, params.TagIDs, dto.CreatedAt, params.Actor)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	err = replaceRecipeEquipment(ctx, tx, dto.ID, params.EquipmentIDs, dto.CreatedAt, params.Actor)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const deleteRecipeTagsOfRecipeQuery = `
+DELETE FROM recipe_tags WHERE recipe_id = $1
+`
+
+const insertRecipeTagsQuery = `
+INSERT INTO recipe_tags (recipe_id, tag_id, created_at, created_by)
+SELECT $1, unnest($2::bigint[]), $3, $4
+`
+
+// replaceRecipeTags replaces the tags of a recipe within a transaction
+func replaceRecipeTags(ctx context.Context, tx *sqlx.Tx, recipeID uint64, tagIDs []uint64, at time.Time, actor string) error {
+	_, err := tx.ExecContext(ctx, deleteRecipeTagsOfRecipeQuery, recipeID)
+	if err != nil {
+		return err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertRecipeTagsQuery, recipeID, pq.Array(tagIDs), at, actor)
+	return err
+}
+
+const deleteRecipeEquipmentOfRecipeQuery = `
+DELETE FROM recipe_equipment WHERE recipe_id = $1
+`
+
+const insertRecipeEquipmentQuery = `
+INSERT INTO recipe_equipment (recipe_id, equipment_id, created_at, created_by)
+SELECT $1, unnest($2::int[]), $3, $4
+`
+
+// replaceRecipeEquipment replaces the equipment of a recipe within a transaction
+func replaceRecipeEquipment(ctx context.Context, tx *sqlx.Tx, recipeID uint64, equipmentIDs []uint64, at time.Time, actor string) error {
+	_, err := tx.ExecContext(ctx, deleteRecipeEquipmentOfRecipeQuery, recipeID)
+	if err != nil {
+		return err
+	}
+
+	if len(equipmentIDs) == 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertRecipeEquipmentQuery, recipeID, pq.Array(equipmentIDs), at, actor)
+	return err
 }
 
+const propagateSubRecipeNameQuery = `
+	UPDATE recipe_ingredients SET ingredient_name = $1, updated_at = $2, updated_by = $3
+	WHERE sub_recipe_id = $4 AND ingredient_name IS DISTINCT FROM $1
+`
+
 // Update updates a Recipe by its ID
 func (r *RecipePostgresRepository) Update(ctx context.Context, id uint64, params usecase.RecipeParams) (*entity.Recipe, error) {
 	dto, query := recipeDtoForUpdate(id, params, nil)
 
-	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
 	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
 		return nil, entity.ErrRecipeNotFound
 	}
 
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if params.PropagateName && params.Name != "" {
+		_, err = tx.ExecContext(ctx, propagateSubRecipeNameQuery, params.Name, dto.UpdatedAt, dto.UpdatedBy, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if params.TagIDs != nil {
+		err = replaceRecipeTags(ctx, tx, id, params.TagIDs, dto.UpdatedAt.Time, params.Actor)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if params.EquipmentIDs != nil {
+		err = replaceRecipeEquipment(ctx, tx, id, params.EquipmentIDs, dto.UpdatedAt.Time, params.Actor)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
 	return &entity.Recipe{
-		ID:          dto.ID,
-		Name:        dto.Name,
-		Description: dto.Description,
-		CategoryID:  dto.CategoryID,
-		CreatedAt:   dto.CreatedAt,
-		CreatedBy:   dto.CreatedBy,
-		UpdatedAt:   dto.UpdatedAt,
-		UpdatedBy:   dto.UpdatedBy,
-		IsDeleted:   dto.IsDeleted,
+		ID:            dto.ID,
+		Name:          dto.Name,
+		Description:   dto.Description,
+		CategoryID:    dto.CategoryID,
+		Servings:      dto.Servings,
+		YieldAmount:   dto.YieldAmount,
+		YieldUnitName: dto.YieldUnitName,
+		Cuisine:       dto.Cuisine,
+		PrepMinutes:   dto.PrepMinutes,
+		CookMinutes:   dto.CookMinutes,
+		Difficulty:    dto.Difficulty,
+		CreatedAt:     dto.CreatedAt,
+		CreatedBy:     dto.CreatedBy,
+		UpdatedAt:     dto.UpdatedAt,
+		UpdatedBy:     dto.UpdatedBy,
+		IsDeleted:     dto.IsDeleted,
 	}, nil
 }
 
-// Delete deletes a Recipe by its ID
+const deleteRecipeIngredientsOfRecipeQuery = `
+UPDATE recipe_ingredients SET is_deleted = true, deleted_at = $1, updated_at = $2, updated_by = $3
+WHERE recipe_id = $4 AND is_deleted = false
+`
+
+// ListReferencingRecipes lists the live recipes using a recipe as a sub-recipe
+func (r *RecipePostgresRepository) ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error) {
+	return selectRecipeReferences(ctx, r.db, selectSubRecipeRecipeReferencesQuery, id)
+}
+
+// Delete deletes a Recipe by its ID along with its ingredient rows
 func (r *RecipePostgresRepository) Delete(ctx context.Context, id uint64) error {
 	dto, query := recipeDtoForDelete(id)
 
-	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
 	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
 		return entity.ErrRecipeNotFound
 	}
 
-	return err
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	// the ingredient rows share the deleted_at of the recipe, so restoring the recipe restores exactly them
+	_, err = tx.ExecContext(ctx, deleteRecipeIngredientsOfRecipeQuery, dto.DeletedAt, dto.UpdatedAt, dto.UpdatedBy, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 const selectRecipeSummaryQuery = `
@@ -181,10 +568,29 @@ select
        r.name,
        r.description,
        r.category_id,
+       r.servings,
+       r.yield_amount,
+       r.yield_unit_name,
+       r.cuisine,
+       r.prep_minutes,
+       r.cook_minutes,
+       r.total_minutes,
+       r.difficulty,
+       r.status,
+       r.variant_of_id,
+       r.forked_at,
+       r.cook_count,
+       r.rating_count,
+       r.average_rating,
        ri.id as recipe_ingredient_id,
+       ri.ingredient_id as ingredient_id,
+       ri.sub_recipe_id as sub_recipe_id,
        ri.ingredient_name as ingredient_name,
        ri.ingredient_unit_name as ingredient_unit_name,
        ri.amount as amount,
+       ri.amount_max as amount_max,
+       ri.amount_denominator as amount_denominator,
+       ri.optional as optional,
        ri.notes as notes,
        ri.ordering_index as ordering_index,
        r.created_at,
@@ -192,12 +598,13 @@ select
        r.updated_at,
        r.updated_by
 from recipes r
-left join recipe_ingredients ri on r.id = ri.recipe_id
+left join recipe_ingredients ri on r.id = ri.recipe_id and ri.is_deleted = false
 where r.is_deleted = false
 and r.id = $1
-order by ordering_index;
+order by ri.ordering_index;
 `
 
+// GetSummary retrieves a recipe with its ingredients sorted by ordering index
 func (r *RecipePostgresRepository) GetSummary(ctx context.Context, id uint64) (entity.RecipeSummary, error) {
 	var dtos []recipeSummaryDto
 
@@ -210,20 +617,83 @@ func (r *RecipePostgresRepository) GetSummary(ctx context.Context, id uint64) (e
 		return entity.RecipeSummary{}, nil
 	}
 
-	return constructRecipeSummary(dtos), nil
+	summary := constructRecipeSummary(dtos)
+
+	var tagDtos []tagDto
+
+	err = r.db.SelectContext(ctx, &tagDtos, selectTagsOfRecipeQuery, id)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	for _, dto := range tagDtos {
+		summary.Tags = append(summary.Tags, dto.toEntity())
+	}
+
+	var imageDtos []recipeImageDto
+
+	err = r.db.SelectContext(ctx, &imageDtos, selectRecipeImagesByRecipeIDQuery, id)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	for _, dto := range imageDtos {
+		summary.Images = append(summary.Images, dto.toEntity())
+	}
+
+	var equipmentDtos []equipmentDto
+
+	err = r.db.SelectContext(ctx, &equipmentDtos, selectEquipmentOfRecipeQuery, id)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	for _, dto := range equipmentDtos {
+		summary.Equipment = append(summary.Equipment, dto.toEntity())
+	}
+
+	return summary, nil
 }
 
+const selectTagsOfRecipeQuery = `
+select t.id, t.name, t.normalized_name, t.created_at, t.created_by, t.updated_at, t.updated_by, t.is_deleted
+from tags t
+join recipe_tags rt on rt.tag_id = t.id
+where t.is_deleted = false
+and rt.recipe_id = $1
+order by t.name;
+`
+
+const selectEquipmentOfRecipeQuery = `
+select e.id, e.name, e.created_at, e.created_by, e.updated_at, e.updated_by, e.is_deleted
+from equipment e
+join recipe_equipment re on re.equipment_id = e.id
+where e.is_deleted = false
+and re.recipe_id = $1
+order by e.name;
+`
+
 func constructRecipeSummary(dtos []recipeSummaryDto) entity.RecipeSummary {
 	var ingredients entity.RecipeIngredients
 
 	for _, dto := range dtos {
+		if !dto.RecipeIngredientID.Valid {
+			continue
+		}
+
 		ingredients = append(ingredients, &entity.RecipeIngredient{
-			ID:                 dto.ID,
-			IngredientName:     dto.IngredientName,
-			IngredientUnitName: dto.IngredientUnitName,
+			ID:                 uint64(dto.RecipeIngredientID.Int64),
+			RecipeID:           dto.ID,
+			IngredientID:       uint64(dto.IngredientID.Int64),
+			SubRecipeID:        uint64(dto.SubRecipeID.Int64),
+			IngredientName:     dto.IngredientName.String,
+			IngredientUnitName: dto.IngredientUnitName.String,
 			Amount:             dto.Amount,
-			OrderingIndex:      dto.OrderingIndex,
-			Notes:              dto.Notes,
+			AmountMax:          dto.AmountMax,
+			AmountDenominator:  dto.AmountDenominator,
+			Optional:           dto.Optional.Bool,
+			OrderingIndex:      int(dto.OrderingIndex.Int64),
+			Notes:              dto.Notes.String,
 			CreatedAt:          dto.CreatedAt,
 			CreatedBy:          dto.CreatedBy,
 			UpdatedAt:          dto.UpdatedAt,
@@ -234,15 +704,29 @@ func constructRecipeSummary(dtos []recipeSummaryDto) entity.RecipeSummary {
 
 	return entity.RecipeSummary{
 		Recipe: entity.Recipe{
-			ID:          dtos[0].ID,
-			Name:        dtos[0].Name,
-			Description: dtos[0].Description,
-			CategoryID:  dtos[0].CategoryID,
-			CreatedAt:   dtos[0].CreatedAt,
-			CreatedBy:   dtos[0].CreatedBy,
-			UpdatedAt:   dtos[0].UpdatedAt,
-			UpdatedBy:   dtos[0].UpdatedBy,
-			IsDeleted:   dtos[0].IsDeleted,
+			ID:            dtos[0].ID,
+			Name:          dtos[0].Name,
+			Description:   dtos[0].Description,
+			CategoryID:    dtos[0].CategoryID,
+			Servings:      dtos[0].Servings,
+			YieldAmount:   dtos[0].YieldAmount,
+			YieldUnitName: dtos[0].YieldUnitName,
+			Cuisine:       dtos[0].Cuisine,
+			PrepMinutes:   dtos[0].PrepMinutes,
+			CookMinutes:   dtos[0].CookMinutes,
+			TotalMinutes:  dtos[0].TotalMinutes,
+			Difficulty:    dtos[0].Difficulty,
+			Status:        dtos[0].Status,
+			VariantOfID:   dtos[0].VariantOfID,
+			ForkedAt:      dtos[0].ForkedAt,
+			CookCount:     dtos[0].CookCount,
+			RatingCount:   dtos[0].RatingCount,
+			AverageRating: dtos[0].AverageRating,
+			CreatedAt:     dtos[0].CreatedAt,
+			CreatedBy:     dtos[0].CreatedBy,
+			UpdatedAt:     dtos[0].UpdatedAt,
+			UpdatedBy:     dtos[0].UpdatedBy,
+			IsDeleted:     dtos[0].IsDeleted,
 		},
 		Ingredients: ingredients,
 	}
@@ -250,11 +734,19 @@ func constructRecipeSummary(dtos []recipeSummaryDto) entity.RecipeSummary {
 
 func recipeDtoForCreate(params usecase.CreateRecipeParams) recipeDto {
 	return recipeDto{
-		Name:        params.Name,
-		Description: params.Description,
-		CategoryID:  params.CategoryID,
-		CreatedAt:   time.Now(),
-		CreatedBy:   params.Actor,
+		Name:          params.Name,
+		Description:   params.Description,
+		CategoryID:    params.CategoryID,
+		Servings:      params.Servings,
+		YieldAmount:   null.NewFloat(params.YieldAmount, params.YieldAmount > 0),
+		YieldUnitName: null.NewString(params.YieldUnitName, params.YieldUnitName != ""),
+		Cuisine:       null.NewString(params.Cuisine, params.Cuisine != ""),
+		PrepMinutes:   null.NewInt(int64(params.PrepMinutes), params.PrepMinutes > 0),
+		CookMinutes:   null.NewInt(int64(params.CookMinutes), params.CookMinutes > 0),
+		Difficulty:    null.NewString(params.Difficulty, params.Difficulty != ""),
+		Status:        entity.RecipeStatusDraft,
+		CreatedAt:     time.Now(),
+		CreatedBy:     params.Actor,
 	}
 }
 
@@ -270,17 +762,53 @@ func recipeDtoForUpdate(id uint64, params usecase.RecipeParams, isDeleted *bool)
 
 	if params.Description != "" {
 		qb.WriteString("description = :description, ")
-		dto.Name = params.Description
+		dto.Description = params.Description
 	}
 
 	if params.CategoryID != 0 {
 		qb.WriteString("category_id = :category_id, ")
-		dto.Name = params.Description
+		dto.CategoryID = params.CategoryID
+	}
+
+	if params.Servings > 0 {
+		qb.WriteString("servings = :servings, ")
+		dto.Servings = params.Servings
+	}
+
+	if params.YieldAmount > 0 {
+		qb.WriteString("yield_amount = :yield_amount, ")
+		dto.YieldAmount = null.FloatFrom(params.YieldAmount)
+	}
+
+	if params.YieldUnitName != "" {
+		qb.WriteString("yield_unit_name = :yield_unit_name, ")
+		dto.YieldUnitName = null.StringFrom(params.YieldUnitName)
+	}
+
+	if params.Cuisine != "" {
+		qb.WriteString("cuisine = :cuisine, ")
+		dto.Cuisine = null.StringFrom(params.Cuisine)
+	}
+
+	if params.PrepMinutes > 0 {
+		qb.WriteString("prep_minutes = :prep_minutes, ")
+		dto.PrepMinutes = null.IntFrom(int64(params.PrepMinutes))
+	}
+
+	if params.CookMinutes > 0 {
+		qb.WriteString("cook_minutes = :cook_minutes, ")
+		dto.CookMinutes = null.IntFrom(int64(params.CookMinutes))
+	}
+
+	if params.Difficulty != "" {
+		qb.WriteString("difficulty = :difficulty, ")
+		dto.Difficulty = null.StringFrom(params.Difficulty)
 	}
 
 	if isDeleted != nil {
-		qb.WriteString("is_deleted = :is_deleted, ")
+		qb.WriteString("is_deleted = :is_deleted, deleted_at = :deleted_at, ")
 		dto.IsDeleted = *isDeleted
+		dto.DeletedAt = null.NewTime(time.Now(), *isDeleted)
 	}
 
 	qb.WriteString("updated_at = :updated_at, ")
@@ -299,3 +827,325 @@ func recipeDtoForDelete(id uint64) (dto recipeDto, query string) {
 	isDeleted := true
 	return recipeDtoForUpdate(id, usecase.RecipeParams{}, &isDeleted)
 }
+
+const selectSubRecipeIDsQuery = `
+with recursive sub_recipes(id) as (
+    select ri.sub_recipe_id
+    from recipe_ingredients ri
+    where ri.recipe_id = $1
+    and ri.sub_recipe_id is not null
+    and ri.is_deleted = false
+    union
+    select ri.sub_recipe_id
+    from recipe_ingredients ri
+    join sub_recipes sr on ri.recipe_id = sr.id
+    where ri.sub_recipe_id is not null
+    and ri.is_deleted = false
+)
+select id from sub_recipes;
+`
+
+// ListSubRecipeIDs retrieves IDs of all recipes used directly or transitively as sub-recipes of a recipe
+func (r *RecipePostgresRepository) ListSubRecipeIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	var ids []uint64
+
+	err := r.db.SelectContext(ctx, &ids, selectSubRecipeIDsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+const updateRecipeStatusQuery = `
+UPDATE recipes SET status = $1, updated_at = $2, updated_by = $3
+WHERE id = $4 AND status = $5 AND is_deleted = false
+`
+
+const insertRecipeStatusChangeQuery = `
+INSERT INTO recipe_status_changes (recipe_id, action, from_status, to_status, comment, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+// UpdateStatus moves a recipe to a new status and records the change in its history. The recipe must still be in
+// the From status, so that concurrent transitions cannot both apply
+func (r *RecipePostgresRepository) UpdateStatus(ctx context.Context, id uint64, params usecase.RecipeStatusParams) error {
+	now := time.Now()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, updateRecipeStatusQuery, params.To, now, params.Actor, id, params.From)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if count == 0 {
+		_ = tx.Rollback()
+		return entity.ErrInvalidRecipeTransition
+	}
+
+	_, err = tx.ExecContext(ctx, insertRecipeStatusChangeQuery, id, params.Action, params.From, params.To,
+		null.NewString(params.Comment, params.Comment != ""), now, params.Actor)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type recipeStatusChangeDto struct {
+	ID         uint64      `db:"id"`
+	RecipeID   uint64      `db:"recipe_id"`
+	Action     string      `db:"action"`
+	FromStatus string      `db:"from_status"`
+	ToStatus   string      `db:"to_status"`
+	Comment    null.String `db:"comment"`
+	CreatedAt  time.Time   `db:"created_at"`
+	CreatedBy  string      `db:"created_by"`
+}
+
+func (c recipeStatusChangeDto) toEntity() *entity.RecipeStatusChange {
+	return &entity.RecipeStatusChange{
+		ID:         c.ID,
+		RecipeID:   c.RecipeID,
+		Action:     c.Action,
+		FromStatus: c.FromStatus,
+		ToStatus:   c.ToStatus,
+		Comment:    c.Comment,
+		CreatedAt:  c.CreatedAt,
+		CreatedBy:  c.CreatedBy,
+	}
+}
+
+const selectRecipeStatusChangesQuery = `
+select id, recipe_id, action, from_status, to_status, comment, created_at, created_by
+from recipe_status_changes
+where recipe_id = $1
+order by created_at, id;
+`
+
+// ListStatusChanges retrieves the workflow history of a recipe, oldest first
+func (r *RecipePostgresRepository) ListStatusChanges(ctx context.Context, id uint64) (res entity.RecipeStatusChanges, err error) {
+	var dtos []recipeStatusChangeDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectRecipeStatusChangesQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertRecipeCloneQuery = `
+INSERT INTO recipes (name, description, category_id, servings, yield_amount, yield_unit_name, cuisine, prep_minutes, cook_minutes, difficulty, status,
+                     variant_of_id, forked_at, created_at, created_by)
+SELECT $2, description, category_id, servings, yield_amount, yield_unit_name, cuisine, prep_minutes, cook_minutes, difficulty, $3,
+       CASE WHEN $4::boolean THEN id END, CASE WHEN $4::boolean THEN $5::timestamp END, $5, $6
+FROM recipes
+WHERE id = $1 AND is_deleted = false
+RETURNING id, name, description, category_id, servings, yield_amount, yield_unit_name, cuisine, prep_minutes, cook_minutes, total_minutes, difficulty,
+          status, variant_of_id, forked_at, cook_count, rating_count, average_rating, created_at, created_by
+`
+
+const insertRecipeIngredientsCloneQuery = `
+INSERT INTO recipe_ingredients (recipe_id, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max, amount_denominator,
+                                optional, notes, ordering_index, created_at, created_by)
+SELECT $2, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max, amount_denominator,
+       optional, notes, ordering_index, $3, $4
+FROM recipe_ingredients
+WHERE recipe_id = $1 AND is_deleted = false
+`
+
+const insertRecipeTagsCloneQuery = `
+INSERT INTO recipe_tags (recipe_id, tag_id, created_at, created_by)
+SELECT $2, tag_id, $3, $4 FROM recipe_tags WHERE recipe_id = $1
+`
+
+const insertRecipeEquipmentCloneQuery = `
+INSERT INTO recipe_equipment (recipe_id, equipment_id, created_at, created_by)
+SELECT $2, equipment_id, $3, $4 FROM recipe_equipment WHERE recipe_id = $1
+`
+
+const insertRecipeForkSnapshotQuery = `
+INSERT INTO recipe_fork_snapshots (recipe_id, name, description, category_id, servings, yield_amount, yield_unit_name, cuisine, prep_minutes, cook_minutes,
+                                   difficulty, created_at)
+SELECT $2, name, description, category_id, servings, yield_amount, yield_unit_name, cuisine, prep_minutes, cook_minutes, difficulty, $3
+FROM recipes
+WHERE id = $1
+`
+
+const insertRecipeForkSnapshotIngredientsQuery = `
+INSERT INTO recipe_fork_snapshot_ingredients (recipe_id, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max,
+                                              amount_denominator, optional, notes, ordering_index)
+SELECT $2, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max, amount_denominator, optional, notes, ordering_index
+FROM recipe_ingredients
+WHERE recipe_id = $1 AND is_deleted = false
+`
+
+// Clone copies a recipe with its ingredients, tags and equipment, but none of its recipe or step images, into a new
+// draft in a single transaction.
+// A variant links to the recipe and keeps a snapshot of it, so that its later changes can be compared
+func (r *RecipePostgresRepository) Clone(ctx context.Context, id uint64, params usecase.CloneRecipeParams) (*entity.Recipe, error) {
+	var dto recipeDto
+	now := time.Now()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowxContext(ctx, insertRecipeCloneQuery, id, params.Name, entity.RecipeStatusDraft, params.Variant, now, params.Actor).StructScan(&dto)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	queries := []string{insertRecipeIngredientsCloneQuery, insertRecipeTagsCloneQuery, insertRecipeEquipmentCloneQuery}
+	for _, query := range queries {
+		_, err = tx.ExecContext(ctx, query, id, dto.ID, now, params.Actor)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if params.Variant {
+		for _, query := range []string{insertRecipeForkSnapshotQuery, insertRecipeForkSnapshotIngredientsQuery} {
+			_, err = tx.ExecContext(ctx, query, id, dto.ID, now)
+			if err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// selectRecipeFamilyQuery walks up the variant links of a recipe to the recipe they start from, then down to every
+// variant of it. Deleted recipes are kept so that their variants stay in the tree
+const selectRecipeFamilyQuery = `
+with recursive ancestors(id, variant_of_id) as (
+    select id, variant_of_id from recipes where id = $1
+    union
+    select r.id, r.variant_of_id from recipes r join ancestors a on r.id = a.variant_of_id
+), family(id) as (
+    select id from ancestors where variant_of_id is null
+    union
+    select r.id from recipes r join family f on r.variant_of_id = f.id
+)
+select
+       r.id,
+       r.name,
+       r.description,
+       r.category_id,
+       r.servings,
+       r.yield_amount,
+       r.yield_unit_name,
+       r.cuisine,
+       r.prep_minutes,
+       r.cook_minutes,
+       r.total_minutes,
+       r.difficulty,
+       r.status,
+       r.variant_of_id,
+       r.forked_at,
+       r.cook_count,
+       r.rating_count,
+       r.average_rating,
+       r.created_at,
+       r.created_by,
+       r.updated_at,
+       r.updated_by,
+       r.is_deleted
+from recipes r
+join family f on f.id = r.id
+order by r.id;
+`
+
+// ListFamily retrieves every recipe of the family tree of a recipe, deleted ones included
+func (r *RecipePostgresRepository) ListFamily(ctx context.Context, id uint64) (res entity.Recipes, err error) {
+	var dtos []recipeDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectRecipeFamilyQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectRecipeForkSnapshotQuery = `
+select r.variant_of_id as id, s.name, coalesce(s.description, '') as description, s.category_id, s.servings, s.yield_amount, s.yield_unit_name,
+       s.cuisine, s.prep_minutes, s.cook_minutes, s.difficulty, s.created_at
+from recipe_fork_snapshots s
+join recipes r on r.id = s.recipe_id
+where s.recipe_id = $1
+and r.variant_of_id is not null;
+`
+
+const selectRecipeForkSnapshotIngredientsQuery = `
+select id, ingredient_id, sub_recipe_id, ingredient_name, ingredient_unit_name, amount, amount_max, amount_denominator, optional,
+       coalesce(notes, '') as notes, ordering_index
+from recipe_fork_snapshot_ingredients
+where recipe_id = $1
+order by ordering_index, id;
+`
+
+// GetForkSnapshot retrieves the parent of a variant as it was when the variant was forked, its ID is the one of the parent
+func (r *RecipePostgresRepository) GetForkSnapshot(ctx context.Context, id uint64) (entity.RecipeSummary, error) {
+	var dto recipeDto
+
+	err := r.db.GetContext(ctx, &dto, selectRecipeForkSnapshotQuery, id)
+	if err == sql.ErrNoRows {
+		return entity.RecipeSummary{}, nil
+	}
+
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	var ingredientDtos []recipeIngredientDto
+
+	err = r.db.SelectContext(ctx, &ingredientDtos, selectRecipeForkSnapshotIngredientsQuery, id)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	summary := entity.RecipeSummary{Recipe: *dto.toEntity()}
+	for _, ingredientDto := range ingredientDtos {
+		ingredientDto.RecipeID = dto.ID
+		summary.Ingredients = append(summary.Ingredients, ingredientDto.toEntity())
+	}
+
+	return summary, nil
+}
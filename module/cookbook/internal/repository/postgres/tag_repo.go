@@ -0,0 +1,214 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// TagPostgresRepository is the PostgreSQL implementation for TagRepository interface
+type TagPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewTagPostgresRepository instantiates TagPostgresRepository
+func NewTagPostgresRepository(db *sqlx.DB) *TagPostgresRepository {
+	return &TagPostgresRepository{db: db}
+}
+
+type tagDto struct {
+	ID             uint64      `db:"id"`
+	Name           string      `db:"name"`
+	NormalizedName string      `db:"normalized_name"`
+	CreatedAt      time.Time   `db:"created_at"`
+	CreatedBy      string      `db:"created_by"`
+	UpdatedAt      null.Time   `db:"updated_at"`
+	UpdatedBy      null.String `db:"updated_by"`
+	IsDeleted      bool        `db:"is_deleted"`
+}
+
+func (c tagDto) toEntity() *entity.Tag {
+	return &entity.Tag{
+		ID:             c.ID,
+		Name:           c.Name,
+		NormalizedName: c.NormalizedName,
+		CreatedAt:      c.CreatedAt,
+		CreatedBy:      c.CreatedBy,
+		UpdatedAt:      c.UpdatedAt,
+		UpdatedBy:      c.UpdatedBy,
+		IsDeleted:      c.IsDeleted,
+	}
+}
+
+const selectTagColumns = `
+select id, name, normalized_name, created_at, created_by, updated_at, updated_by, is_deleted
+from tags`
+
+const selectTagQuery = selectTagColumns + `
+where is_deleted = false
+order by name, id
+limit $1 offset $2;
+`
+
+// List retrieves a list of tags with offset and limit
+func (r *TagPostgresRepository) List(ctx context.Context, limit, offset int) (res entity.Tags, err error) {
+	var dtos []tagDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectTagQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectTagsByIDsQuery = selectTagColumns + `
+where is_deleted = false
+and id in (?);
+`
+
+// ListByIDs retrieves tags by their IDs
+func (r *TagPostgresRepository) ListByIDs(ctx context.Context, ids []uint64) (res entity.Tags, err error) {
+	return r.listIn(ctx, selectTagsByIDsQuery, ids)
+}
+
+const selectTagsByNormalizedNamesQuery = selectTagColumns + `
+where is_deleted = false
+and normalized_name in (?);
+`
+
+// ListByNormalizedNames retrieves tags by their normalized names
+func (r *TagPostgresRepository) ListByNormalizedNames(ctx context.Context, normalizedNames []string) (res entity.Tags, err error) {
+	return r.listIn(ctx, selectTagsByNormalizedNamesQuery, normalizedNames)
+}
+
+func (r *TagPostgresRepository) listIn(ctx context.Context, query string, values interface{}) (res entity.Tags, err error) {
+	var dtos []tagDto
+
+	query, args, err := sqlx.In(query, values)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &dtos, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const insertTagQuery = `
+INSERT INTO tags (name, normalized_name, created_at, created_by)
+VALUES ($1, $2, $3, $4) RETURNING id
+`
+
+// Create creates a new tag
+func (r *TagPostgresRepository) Create(ctx context.Context, params usecase.TagParams) (*entity.Tag, error) {
+	dto := tagDto{
+		Name:           params.Name,
+		NormalizedName: params.NormalizedName,
+		CreatedAt:      time.Now(),
+		CreatedBy:      params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertTagQuery, dto.Name, dto.NormalizedName, dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates a tag by its ID
+func (r *TagPostgresRepository) Update(ctx context.Context, id uint64, params usecase.TagParams) (*entity.Tag, error) {
+	dto, query := tagDtoForUpdate(id, params, nil)
+
+	_, err := r.db.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrTagNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const deleteRecipeTagsOfTagQuery = `
+DELETE FROM recipe_tags WHERE tag_id = $1
+`
+
+// Delete deletes a tag by its ID and removes it from its recipes
+func (r *TagPostgresRepository) Delete(ctx context.Context, id uint64) error {
+	isDeleted := true
+	dto, query := tagDtoForUpdate(id, usecase.TagParams{}, &isDeleted)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExecContext(ctx, query, &dto)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return entity.ErrTagNotFound
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, deleteRecipeTagsOfTagQuery, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func tagDtoForUpdate(id uint64, params usecase.TagParams, isDeleted *bool) (dto tagDto, query string) {
+	var qb strings.Builder
+
+	qb.WriteString("UPDATE tags SET ")
+
+	if params.Name != "" {
+		qb.WriteString("name = :name, normalized_name = :normalized_name, ")
+		dto.Name = params.Name
+		dto.NormalizedName = params.NormalizedName
+	}
+
+	if isDeleted != nil {
+		qb.WriteString("is_deleted = :is_deleted, ")
+		dto.IsDeleted = *isDeleted
+	}
+
+	qb.WriteString("updated_at = :updated_at, ")
+	dto.UpdatedAt = null.TimeFrom(time.Now())
+
+	qb.WriteString("updated_by = :updated_by ")
+	dto.UpdatedBy = null.StringFrom(params.Actor)
+
+	qb.WriteString("WHERE id = :id")
+	dto.ID = id
+
+	return dto, qb.String()
+}
@@ -0,0 +1,483 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// TrashPostgresRepository is the PostgreSQL implementation for TrashRepository interface
+type TrashPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewTrashPostgresRepository instantiates TrashPostgresRepository
+func NewTrashPostgresRepository(db *sqlx.DB) *TrashPostgresRepository {
+	return &TrashPostgresRepository{db: db}
+}
+
+type trashItemDto struct {
+	ID        uint64      `db:"id"`
+	Name      string      `db:"name"`
+	ParentID  uint64      `db:"parent_id"`
+	DeletedAt null.Time   `db:"deleted_at"`
+	DeletedBy null.String `db:"deleted_by"`
+}
+
+func (c trashItemDto) toEntity(itemType string) *entity.TrashItem {
+	return &entity.TrashItem{
+		Type:      itemType,
+		ID:        c.ID,
+		Name:      c.Name,
+		ParentID:  c.ParentID,
+		DeletedAt: c.DeletedAt,
+		DeletedBy: c.DeletedBy,
+	}
+}
+
+// selectTrashQueries select the deleted rows of each trash type, they are completed with a condition
+var selectTrashQueries = map[string]string{
+	entity.TrashTypeRecipe: `
+select id, name, category_id as parent_id, deleted_at, updated_by as deleted_by from recipes
+where is_deleted = true`,
+	entity.TrashTypeRecipeIngredient: `
+select id, ingredient_name as name, recipe_id as parent_id, deleted_at, updated_by as deleted_by from recipe_ingredients
+where is_deleted = true`,
+	entity.TrashTypeCategory: `
+select id, name, coalesce(parent_id, 0) as parent_id, deleted_at, updated_by as deleted_by from categories
+where is_deleted = true`,
+	entity.TrashTypeIngredient: `
+select id, name, coalesce(parent_id, 0) as parent_id, deleted_at, updated_by as deleted_by from ingredients
+where is_deleted = true`,
+	entity.TrashTypeIngredientUnit: `
+select id, name, 0 as parent_id, deleted_at, updated_by as deleted_by from ingredient_units
+where is_deleted = true`,
+}
+
+// List retrieves the deleted rows of a type with offset and limit, the latest deleted first
+func (r *TrashPostgresRepository) List(ctx context.Context, itemType string, limit, offset int) (res entity.TrashItems, err error) {
+	query, ok := selectTrashQueries[itemType]
+	if !ok {
+		return nil, entity.ErrInvalidTrashType
+	}
+
+	var dtos []trashItemDto
+
+	err = r.db.SelectContext(ctx, &dtos, query+`
+order by deleted_at desc nulls last, id desc
+limit $1 offset $2;`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity(itemType))
+	}
+
+	return res, nil
+}
+
+// Get retrieves a deleted row of a type by its ID
+func (r *TrashPostgresRepository) Get(ctx context.Context, itemType string, id uint64) (*entity.TrashItem, error) {
+	query, ok := selectTrashQueries[itemType]
+	if !ok {
+		return nil, entity.ErrInvalidTrashType
+	}
+
+	var dto trashItemDto
+
+	err := r.db.GetContext(ctx, &dto, query+`
+and id = $1;`, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrTrashItemNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(itemType), nil
+}
+
+// restoreTrashQueries restore a deleted row of each trash type
+var restoreTrashQueries = map[string]string{
+	entity.TrashTypeRecipe: `
+UPDATE recipes SET is_deleted = false, deleted_at = NULL, updated_at = $1, updated_by = $2
+WHERE id = $3 AND is_deleted = true`,
+	entity.TrashTypeRecipeIngredient: `
+UPDATE recipe_ingredients SET is_deleted = false, deleted_at = NULL, updated_at = $1, updated_by = $2
+WHERE id = $3 AND is_deleted = true`,
+	entity.TrashTypeCategory: `
+UPDATE categories SET is_deleted = false, deleted_at = NULL, updated_at = $1, updated_by = $2
+WHERE id = $3 AND is_deleted = true`,
+	entity.TrashTypeIngredient: `
+UPDATE ingredients SET is_deleted = false, deleted_at = NULL, updated_at = $1, updated_by = $2
+WHERE id = $3 AND is_deleted = true`,
+	entity.TrashTypeIngredientUnit: `
+UPDATE ingredient_units SET is_deleted = false, deleted_at = NULL, updated_at = $1, updated_by = $2
+WHERE id = $3 AND is_deleted = true`,
+}
+
+// restoreRecipeIngredientsOfRecipeQuery restores the ingredient rows deleted along with a recipe, they share its deleted_at
+const restoreRecipeIngredientsOfRecipeQuery = `
+UPDATE recipe_ingredients ri SET is_deleted = false, deleted_at = NULL, updated_at = $1, updated_by = $2
+FROM recipes r
+WHERE r.id = $3 AND r.is_deleted = true
+AND ri.recipe_id = r.id AND ri.is_deleted = true AND ri.deleted_at = r.deleted_at
+`
+
+// selectRestoredDeletedSubRecipeQuery tells whether the rows restored with a recipe ($1) or a restored recipe
+// ingredient ($2) use a sub-recipe that is still deleted
+const selectRestoredDeletedSubRecipeQuery = `
+select exists (
+    select 1 from recipe_ingredients ri
+    join recipes s on s.id = ri.sub_recipe_id
+    where ri.is_deleted = false and s.is_deleted = true
+    and (ri.recipe_id = $1 or ri.id = $2)
+)`
+
+// Restore restores a deleted row of a type, a recipe is restored with the ingredient rows deleted along with it.
+// Rows using a sub-recipe that is still deleted are not restored
+func (r *TrashPostgresRepository) Restore(ctx context.Context, itemType string, id uint64, actor string) error {
+	query, ok := restoreTrashQueries[itemType]
+	if !ok {
+		return entity.ErrInvalidTrashType
+	}
+
+	now := time.Now()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if itemType == entity.TrashTypeRecipe {
+		_, err = tx.ExecContext(ctx, restoreRecipeIngredientsOfRecipeQuery, now, actor, id)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, query, now, actor, id)
+	if isUniqueViolation(err, ingredientNormalizedNameIndex) {
+		_ = tx.Rollback()
+		return entity.ErrIngredientNameConflict
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if affected == 0 {
+		_ = tx.Rollback()
+		return entity.ErrTrashItemNotFound
+	}
+
+	if itemType == entity.TrashTypeRecipe || itemType == entity.TrashTypeRecipeIngredient {
+		var recipeID, recipeIngredientID uint64
+		if itemType == entity.TrashTypeRecipe {
+			recipeID = id
+		} else {
+			recipeIngredientID = id
+		}
+
+		var usesDeletedSubRecipe bool
+		err = tx.GetContext(ctx, &usesDeletedSubRecipe, selectRestoredDeletedSubRecipeQuery, recipeID, recipeIngredientID)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if usesDeletedSubRecipe {
+			_ = tx.Rollback()
+			return entity.ErrTrashSubRecipeDeleted
+		}
+	}
+
+	return tx.Commit()
+}
+
+// purgeableRecipesQuery selects recipes deleted before $1 that no live recipe uses as a sub-recipe
+const purgeableRecipesQuery = `
+select r.id from recipes r
+where r.is_deleted = true and r.deleted_at < $1
+and not exists (select 1 from recipe_ingredients ri where ri.sub_recipe_id = r.id and ri.is_deleted = false)`
+
+// purgeableIngredientsQuery selects ingredients deleted before $1 that no live recipe uses and no ingredient has as parent,
+// an ingredient with pantry movements is kept for the append-only ledger
+const purgeableIngredientsQuery = `
+select i.id from ingredients i
+where i.is_deleted = true and i.deleted_at < $1
+and not exists (select 1 from recipe_ingredients ri where ri.ingredient_id = i.id and ri.is_deleted = false)
+and not exists (select 1 from ingredients ch where ch.parent_id = i.id)
+and not exists (select 1 from pantry_movements pm where pm.ingredient_id = i.id)`
+
+const purgeRecipeIngredientsQuery = `
+DELETE FROM recipe_ingredients
+WHERE (is_deleted = true AND deleted_at < $1)
+OR recipe_id IN (` + purgeableRecipesQuery + `)
+OR (is_deleted = true AND sub_recipe_id IN (` + purgeableRecipesQuery + `))
+`
+
+const purgeRecipeTagsQuery = `
+DELETE FROM recipe_tags WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+`
+
+const purgeRecipeEquipmentQuery = `
+DELETE FROM recipe_equipment WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+`
+
+const purgeCollectionRecipesQuery = `
+DELETE FROM collection_recipes WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+`
+
+// purgeRecipeForkSnapshot*Queries remove the snapshots of purged variants and of the variants of purged recipes,
+// which are unlinked from them by unlinkPurgedRecipeVariantsQuery
+const purgeRecipeForkSnapshotIngredientsQuery = `
+DELETE FROM recipe_fork_snapshot_ingredients
+WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+OR recipe_id IN (select v.id from recipes v where v.variant_of_id IN (` + purgeableRecipesQuery + `))
+`
+
+const purgeRecipeForkSnapshotsQuery = `
+DELETE FROM recipe_fork_snapshots
+WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+OR recipe_id IN (select v.id from recipes v where v.variant_of_id IN (` + purgeableRecipesQuery + `))
+`
+
+const unlinkPurgedRecipeVariantsQuery = `
+UPDATE recipes SET variant_of_id = NULL, forked_at = NULL WHERE variant_of_id IN (` + purgeableRecipesQuery + `)
+`
+
+const purgeRecipeStatusChangesQuery = `
+DELETE FROM recipe_status_changes WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+`
+
+const purgeCookLogsQuery = `
+DELETE FROM cook_logs WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+RETURNING photo_file_key, photo_thumbnail_key
+`
+
+const purgeRecipeImagesQuery = `
+DELETE FROM recipe_images WHERE recipe_id IN (` + purgeableRecipesQuery + `)
+RETURNING file_key, thumbnail_key
+`
+
+const purgeRecipesQuery = `
+DELETE FROM recipes WHERE id IN (` + purgeableRecipesQuery + `)
+`
+
+const purgeIngredientRecipeIngredientsQuery = `
+DELETE FROM recipe_ingredients WHERE is_deleted = true AND ingredient_id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientPricesQuery = `
+DELETE FROM ingredient_prices WHERE ingredient_id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientPantryItemsQuery = `
+DELETE FROM pantry_items WHERE ingredient_id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientUnitConversionsQuery = `
+DELETE FROM ingredient_unit_conversions WHERE ingredient_id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientAliasesQuery = `
+DELETE FROM ingredient_aliases WHERE ingredient_id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientSubstitutionsQuery = `
+DELETE FROM ingredient_substitutions
+WHERE ingredient_id IN (` + purgeableIngredientsQuery + `)
+OR substitute_ingredient_id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientsQuery = `
+DELETE FROM ingredients WHERE id IN (` + purgeableIngredientsQuery + `)
+`
+
+const purgeIngredientUnitsQuery = `
+DELETE FROM ingredient_units u
+WHERE u.is_deleted = true AND u.deleted_at < $1
+AND NOT EXISTS (select 1 from ingredient_prices p where p.ingredient_unit_id = u.id)
+AND NOT EXISTS (select 1 from ingredient_unit_conversions c where c.ingredient_unit_id = u.id)
+`
+
+const purgeCategoriesQuery = `
+DELETE FROM categories c
+WHERE c.is_deleted = true AND c.deleted_at < $1
+AND NOT EXISTS (select 1 from recipes r where r.category_id = c.id)
+AND NOT EXISTS (select 1 from categories ch where ch.parent_id = c.id)
+`
+
+// Purge permanently removes the rows deleted before a time in a single transaction.
+// Rows still used by live rows are kept, and deleted rows using a purged recipe or ingredient are removed with it
+func (r *TrashPostgresRepository) Purge(ctx context.Context, before time.Time) (res entity.TrashPurgeResult, err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+
+	res.RecipeIngredients, err = execCount(ctx, tx, purgeRecipeIngredientsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = execCount(ctx, tx, purgeRecipeTagsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = execCount(ctx, tx, purgeRecipeEquipmentQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = execCount(ctx, tx, purgeCollectionRecipesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = execCount(ctx, tx, purgeRecipeStatusChangesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	for _, query := range []string{purgeRecipeForkSnapshotIngredientsQuery, purgeRecipeForkSnapshotsQuery, unlinkPurgedRecipeVariantsQuery} {
+		_, err = execCount(ctx, tx, query, before)
+		if err != nil {
+			_ = tx.Rollback()
+			return res, err
+		}
+	}
+
+	var cookLogDtos []purgedCookLogDto
+
+	err = tx.SelectContext(ctx, &cookLogDtos, purgeCookLogsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	for _, dto := range cookLogDtos {
+		if dto.PhotoFileKey.Valid {
+			res.MediaKeys = append(res.MediaKeys, dto.PhotoFileKey.String, dto.PhotoThumbnailKey.String)
+		}
+	}
+
+	var imageDtos []purgedRecipeImageDto
+
+	err = tx.SelectContext(ctx, &imageDtos, purgeRecipeImagesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	res.RecipeImages = int64(len(imageDtos))
+	for _, dto := range imageDtos {
+		res.MediaKeys = append(res.MediaKeys, dto.FileKey, dto.ThumbnailKey)
+	}
+
+	res.Recipes, err = execCount(ctx, tx, purgeRecipesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	count, err := execCount(ctx, tx, purgeIngredientRecipeIngredientsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	res.RecipeIngredients += count
+
+	_, err = tx.ExecContext(ctx, purgeIngredientPricesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = tx.ExecContext(ctx, purgeIngredientPantryItemsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = tx.ExecContext(ctx, purgeIngredientUnitConversionsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = tx.ExecContext(ctx, purgeIngredientAliasesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	_, err = tx.ExecContext(ctx, purgeIngredientSubstitutionsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	res.Ingredients, err = execCount(ctx, tx, purgeIngredientsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	res.IngredientUnits, err = execCount(ctx, tx, purgeIngredientUnitsQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	res.Categories, err = execCount(ctx, tx, purgeCategoriesQuery, before)
+	if err != nil {
+		_ = tx.Rollback()
+		return res, err
+	}
+
+	return res, tx.Commit()
+}
+
+type purgedRecipeImageDto struct {
+	FileKey      string `db:"file_key"`
+	ThumbnailKey string `db:"thumbnail_key"`
+}
+
+type purgedCookLogDto struct {
+	PhotoFileKey      null.String `db:"photo_file_key"`
+	PhotoThumbnailKey null.String `db:"photo_thumbnail_key"`
+}
+
+// execCount executes a query in a transaction and returns the number of affected rows
+func execCount(ctx context.Context, tx *sqlx.Tx, query string, args ...interface{}) (int64, error) {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
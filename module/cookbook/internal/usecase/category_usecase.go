@@ -8,17 +8,24 @@ import (
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 )
 
+// CategoryParams holds the category fields, ParentID is left unchanged when nil and points to 0 for a root category
 type CategoryParams struct {
-	Name  string
-	Actor string
+	Name     string
+	ParentID *uint64
+	Actor    string
 }
 
 // CategoryRepository defines contract for ingredient repository dependency
 type CategoryRepository interface {
 	Create(ctx context.Context, params CategoryParams) (*entity.Category, error)
 	Update(ctx context.Context, id uint64, params CategoryParams) (*entity.Category, error)
-	Delete(ctx context.Context, id uint64) error
+	Delete(ctx context.Context, id uint64, params DeleteParams) error
+	Get(ctx context.Context, id uint64) (*entity.Category, error)
 	List(ctx context.Context, limit, offset int) (entity.Categories, error)
+	ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error)
+	ListChildren(ctx context.Context, id uint64) (entity.Categories, error)
+	ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error)
+	ListSubtree(ctx context.Context, rootID uint64) (entity.Categories, error)
 }
 
 // CategoryUsecase is our ingredient usecase object
@@ -35,17 +42,72 @@ func NewCategoryUsecase(categoryRepo CategoryRepository) *CategoryUsecase {
 
 // CreateCategory creates a new category
 func (u *CategoryUsecase) CreateCategory(ctx context.Context, params CategoryParams) (*entity.Category, error) {
+	err := u.validateParent(ctx, 0, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
 	return u.categoryRepo.Create(ctx, params)
 }
 
-// UpdateCategory updates a category
+// UpdateCategory updates a category, a category cannot be moved under itself or one of its descendants
 func (u *CategoryUsecase) UpdateCategory(ctx context.Context, id uint64, params CategoryParams) (*entity.Category, error) {
+	err := u.validateParent(ctx, id, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
 	return u.categoryRepo.Update(ctx, id, params)
 }
 
-// DeleteCategory deletes a category
-func (u *CategoryUsecase) DeleteCategory(ctx context.Context, id uint64) error {
-	return u.categoryRepo.Delete(ctx, id)
+// DeleteCategory deletes a category. A category used by recipes or with sub-categories is only deleted
+// when its recipes and sub-categories are reassigned to another category, outside of its own subtree
+func (u *CategoryUsecase) DeleteCategory(ctx context.Context, id uint64, params DeleteParams) error {
+	if params.ReassignTo == 0 {
+		recipes, err := u.categoryRepo.ListReferencingRecipes(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(recipes) > 0 {
+			return entity.NewReferenceConflictError(entity.ErrCategoryInUse, recipes)
+		}
+
+		children, err := u.categoryRepo.ListChildren(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(children) > 0 {
+			return entity.ErrCategoryHasChildren
+		}
+
+		return u.categoryRepo.Delete(ctx, id, params)
+	}
+
+	if params.ReassignTo == id {
+		return entity.ErrInvalidReassign
+	}
+
+	_, err := u.categoryRepo.Get(ctx, params.ReassignTo)
+	if err == entity.ErrCategoryNotFound {
+		return entity.ErrInvalidReassign
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ancestorIDs, err := u.categoryRepo.ListAncestorIDs(ctx, params.ReassignTo)
+	if err != nil {
+		return err
+	}
+
+	if containsID(ancestorIDs, id) {
+		return entity.ErrInvalidReassign
+	}
+
+	return u.categoryRepo.Delete(ctx, id, params)
 }
 
 // ListCategories retrieves a list of categories
@@ -63,3 +125,72 @@ func (u *CategoryUsecase) ListCategories(ctx context.Context, limit, offset int)
 
 	return u.categoryRepo.List(ctx, lim, ofs)
 }
+
+// GetCategoryTree retrieves the category tree under a category, or every root category when rootID is 0
+func (u *CategoryUsecase) GetCategoryTree(ctx context.Context, rootID uint64) (entity.CategoryNodes, error) {
+	categories, err := u.categoryRepo.ListSubtree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rootID != 0 && len(categories) == 0 {
+		return nil, entity.ErrCategoryNotFound
+	}
+
+	return buildCategoryTree(categories, rootID), nil
+}
+
+// validateParent checks that the parent of a category exists and is not the category or one of its descendants
+func (u *CategoryUsecase) validateParent(ctx context.Context, id uint64, parentID *uint64) error {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+
+	if *parentID == id {
+		return entity.ErrCategoryCycle
+	}
+
+	_, err := u.categoryRepo.Get(ctx, *parentID)
+	if err != nil {
+		return err
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	ancestorIDs, err := u.categoryRepo.ListAncestorIDs(ctx, *parentID)
+	if err != nil {
+		return err
+	}
+
+	if containsID(ancestorIDs, id) {
+		return entity.ErrCategoryCycle
+	}
+
+	return nil
+}
+
+// buildCategoryTree nests categories under their parents, rootID is the top of the tree or 0 for every root category
+func buildCategoryTree(categories entity.Categories, rootID uint64) entity.CategoryNodes {
+	nodes := make(map[uint64]*entity.CategoryNode, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = &entity.CategoryNode{Category: *c}
+	}
+
+	var roots entity.CategoryNodes
+
+	for _, c := range categories {
+		node := nodes[c.ID]
+
+		parent, ok := nodes[c.ParentID]
+		if c.ID == rootID || !ok {
+			roots = append(roots, node)
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots
+}
@@ -1,8 +1,11 @@
 package usecase_test
 
 import (
+	"context"
+	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 	"testing"
@@ -16,3 +19,69 @@ func TestNewCategoryUsecase(t *testing.T) {
 
 	assert.NotEmpty(t, uc)
 }
+
+func TestCategoryUsecase_DeleteCategory_Referenced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	categoryRepo := mock.NewMockCategoryRepository(ctrl)
+	uc := usecase.NewCategoryUsecase(categoryRepo)
+
+	categoryRepo.EXPECT().ListReferencingRecipes(gomock.Any(), uint64(2)).Return(entity.RecipeReferences{
+		{RecipeID: 7, RecipeName: "Nasi Goreng"},
+	}, nil)
+
+	err := uc.DeleteCategory(context.Background(), 2, usecase.DeleteParams{})
+
+	var conflict *entity.ReferenceConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.True(t, errors.Is(err, entity.ErrCategoryInUse))
+	assert.Len(t, conflict.Recipes, 1)
+
+	err = uc.DeleteCategory(context.Background(), 2, usecase.DeleteParams{ReassignTo: 2})
+	assert.Equal(t, entity.ErrInvalidReassign, err)
+
+	categoryRepo.EXPECT().Get(gomock.Any(), uint64(5)).Return(&entity.Category{ID: 5, Name: "Makanan Utama"}, nil)
+	categoryRepo.EXPECT().ListAncestorIDs(gomock.Any(), uint64(5)).Return(nil, nil)
+	categoryRepo.EXPECT().Delete(gomock.Any(), uint64(2), usecase.DeleteParams{ReassignTo: 5}).Return(nil)
+
+	err = uc.DeleteCategory(context.Background(), 2, usecase.DeleteParams{ReassignTo: 5})
+	assert.NoError(t, err)
+}
+
+func TestCategoryUsecase_UpdateCategory_RejectsCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	categoryRepo := mock.NewMockCategoryRepository(ctrl)
+	uc := usecase.NewCategoryUsecase(categoryRepo)
+
+	parentID := uint64(2)
+	_, err := uc.UpdateCategory(context.Background(), 2, usecase.CategoryParams{ParentID: &parentID})
+	assert.Equal(t, entity.ErrCategoryCycle, err)
+
+	// "Fried Rice" (3) is under "Rice Dishes" (2), which is under "Main Course" (1)
+	parentID = 3
+	categoryRepo.EXPECT().Get(gomock.Any(), uint64(3)).Return(&entity.Category{ID: 3, Name: "Fried Rice", ParentID: 2}, nil)
+	categoryRepo.EXPECT().ListAncestorIDs(gomock.Any(), uint64(3)).Return([]uint64{2, 1}, nil)
+
+	_, err = uc.UpdateCategory(context.Background(), 1, usecase.CategoryParams{ParentID: &parentID})
+	assert.Equal(t, entity.ErrCategoryCycle, err)
+}
+
+func TestCategoryUsecase_GetCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	categoryRepo := mock.NewMockCategoryRepository(ctrl)
+	uc := usecase.NewCategoryUsecase(categoryRepo)
+
+	categoryRepo.EXPECT().ListSubtree(gomock.Any(), uint64(2)).Return(entity.Categories{
+		{ID: 3, Name: "Fried Rice", ParentID: 2},
+		{ID: 2, Name: "Rice Dishes", ParentID: 1},
+	}, nil)
+
+	nodes, err := uc.GetCategoryTree(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 1)
+	assert.Equal(t, uint64(2), nodes[0].ID)
+	assert.Len(t, nodes[0].Children, 1)
+	assert.Equal(t, uint64(3), nodes[0].Children[0].ID)
+}
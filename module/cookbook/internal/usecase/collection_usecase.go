@@ -0,0 +1,319 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/collection_repo.go -source=collection_usecase.go -package=mock CollectionRepository
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// CollectionParams holds the fields of a collection, empty fields are left unchanged on update
+type CollectionParams struct {
+	Name        string
+	Description string
+	Actor       string
+}
+
+// CollectionCoverParams holds a stored cover image to be recorded
+type CollectionCoverParams struct {
+	FileKey      string
+	ThumbnailKey string
+	Actor        string
+}
+
+// UploadCollectionCoverParams holds an uploaded cover image of a collection
+type UploadCollectionCoverParams struct {
+	CollectionID uint64
+	Actor        string
+	Content      io.Reader
+}
+
+// CollectionRepository defines contract for collection repository dependency
+type CollectionRepository interface {
+	Create(ctx context.Context, params CollectionParams) (*entity.Collection, error)
+	Update(ctx context.Context, id uint64, params CollectionParams) error
+	UpdateCover(ctx context.Context, id uint64, params CollectionCoverParams) error
+	Delete(ctx context.Context, id uint64, actor string) error
+	Get(ctx context.Context, id uint64) (*entity.Collection, error)
+	List(ctx context.Context, limit, offset int) (entity.Collections, error)
+	ListRecipes(ctx context.Context, id uint64) (entity.CollectionRecipes, error)
+	AddRecipe(ctx context.Context, id, recipeID uint64, actor string) error
+	RemoveRecipe(ctx context.Context, id, recipeID uint64) error
+	SetPositions(ctx context.Context, id uint64, recipeIDs []uint64) error
+}
+
+// CollectionUsecase is our collection usecase object
+type CollectionUsecase struct {
+	collectionRepo CollectionRepository
+	recipeRepo     RecipeRepository
+	mediaStorage   MediaStorage
+	maxBytes       int64
+	reviewers      reviewerSet
+}
+
+// NewCollectionUsecase instantiates CollectionUsecase, cover uploads larger than maxBytes are rejected and
+// reviewers can export the recipes that are not published yet
+func NewCollectionUsecase(collectionRepo CollectionRepository, recipeRepo RecipeRepository, mediaStorage MediaStorage, maxBytes int64, reviewers []string) *CollectionUsecase {
+	return &CollectionUsecase{
+		collectionRepo: collectionRepo,
+		recipeRepo:     recipeRepo,
+		mediaStorage:   mediaStorage,
+		maxBytes:       maxBytes,
+		reviewers:      newReviewerSet(reviewers),
+	}
+}
+
+// CreateCollection creates a new, empty collection
+func (u *CollectionUsecase) CreateCollection(ctx context.Context, params CollectionParams) (*entity.Collection, error) {
+	return u.collectionRepo.Create(ctx, params)
+}
+
+// UpdateCollection updates the name and description of a collection
+func (u *CollectionUsecase) UpdateCollection(ctx context.Context, id uint64, params CollectionParams) (*entity.Collection, error) {
+	err := u.collectionRepo.Update(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.collectionRepo.Get(ctx, id)
+}
+
+// DeleteCollection deletes a collection, its recipes are left untouched
+func (u *CollectionUsecase) DeleteCollection(ctx context.Context, id uint64, actor string) error {
+	return u.collectionRepo.Delete(ctx, id, actor)
+}
+
+// ListCollections retrieves a list of collections
+func (u *CollectionUsecase) ListCollections(ctx context.Context, limit, offset int) (entity.Collections, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.collectionRepo.List(ctx, lim, ofs)
+}
+
+// GetCollection retrieves a collection with the recipes the viewer can see in order. Deleted recipes and the drafts of
+// others are hidden and the gaps they leave flagged, the recipe count only counts the visible recipes
+func (u *CollectionUsecase) GetCollection(ctx context.Context, id uint64, viewer string) (entity.CollectionDetail, error) {
+	collection, err := u.collectionRepo.Get(ctx, id)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	recipes, err := u.collectionRepo.ListRecipes(ctx, id)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	res := entity.CollectionDetail{
+		Collection: collection,
+		Recipes:    entity.CollectionRecipes{},
+	}
+
+	gap := false
+
+	for _, recipe := range recipes {
+		if !u.canViewCollectionRecipe(recipe, viewer) {
+			res.HiddenRecipes++
+			gap = true
+			continue
+		}
+
+		recipe.GapBefore = gap
+		gap = false
+
+		res.Recipes = append(res.Recipes, recipe)
+	}
+
+	res.TrailingGap = gap
+	res.Collection.RecipeCount = len(res.Recipes)
+
+	return res, nil
+}
+
+// AddCollectionRecipe appends a recipe to the end of a collection, the actor is the viewer of the collection returned
+func (u *CollectionUsecase) AddCollectionRecipe(ctx context.Context, id, recipeID uint64, actor string) (entity.CollectionDetail, error) {
+	recipes, err := u.listCollectionRecipes(ctx, id)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	for _, recipe := range recipes {
+		if recipe.RecipeID == recipeID {
+			return entity.CollectionDetail{}, entity.ErrCollectionRecipeExists
+		}
+	}
+
+	err = u.collectionRepo.AddRecipe(ctx, id, recipeID, actor)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	return u.GetCollection(ctx, id, actor)
+}
+
+// RemoveCollectionRecipe removes a recipe from a collection, a deleted recipe can be removed to close its gap
+func (u *CollectionUsecase) RemoveCollectionRecipe(ctx context.Context, id, recipeID uint64, viewer string) (entity.CollectionDetail, error) {
+	_, err := u.collectionRepo.Get(ctx, id)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	err = u.collectionRepo.RemoveRecipe(ctx, id, recipeID)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	return u.GetCollection(ctx, id, viewer)
+}
+
+// ReorderCollectionRecipes puts the recipes of a collection the viewer can see in the given order, recipeIDs lists each
+// of them exactly once. The hidden recipes keep their slots, so that a restored recipe shows up where it was
+func (u *CollectionUsecase) ReorderCollectionRecipes(ctx context.Context, id uint64, recipeIDs []uint64, viewer string) (entity.CollectionDetail, error) {
+	recipes, err := u.listCollectionRecipes(ctx, id)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	visible := map[uint64]bool{}
+	for _, recipe := range recipes {
+		if u.canViewCollectionRecipe(recipe, viewer) {
+			visible[recipe.RecipeID] = true
+		}
+	}
+
+	if len(recipeIDs) != len(visible) {
+		return entity.CollectionDetail{}, entity.ErrInvalidCollectionOrder
+	}
+
+	seen := map[uint64]bool{}
+	for _, recipeID := range recipeIDs {
+		if !visible[recipeID] || seen[recipeID] {
+			return entity.CollectionDetail{}, entity.ErrInvalidCollectionOrder
+		}
+
+		seen[recipeID] = true
+	}
+
+	order := make([]uint64, 0, len(recipes))
+	next := 0
+
+	for _, recipe := range recipes {
+		if !visible[recipe.RecipeID] {
+			order = append(order, recipe.RecipeID)
+			continue
+		}
+
+		order = append(order, recipeIDs[next])
+		next++
+	}
+
+	err = u.collectionRepo.SetPositions(ctx, id, order)
+	if err != nil {
+		return entity.CollectionDetail{}, err
+	}
+
+	return u.GetCollection(ctx, id, viewer)
+}
+
+// UploadCollectionCover stores the cover image of a collection along with a thumbnail, replacing the previous cover
+func (u *CollectionUsecase) UploadCollectionCover(ctx context.Context, params UploadCollectionCoverParams) (*entity.Collection, error) {
+	collection, err := u.collectionRepo.Get(ctx, params.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := storeImage(ctx, u.mediaStorage, u.maxBytes, fmt.Sprintf("collections/%d", params.CollectionID), params.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.collectionRepo.UpdateCover(ctx, params.CollectionID, CollectionCoverParams{
+		FileKey:      stored.FileKey,
+		ThumbnailKey: stored.ThumbnailKey,
+		Actor:        params.Actor,
+	})
+	if err != nil {
+		_ = u.mediaStorage.Delete(ctx, stored.FileKey)
+		_ = u.mediaStorage.Delete(ctx, stored.ThumbnailKey)
+		return nil, err
+	}
+
+	if collection.CoverFileKey.Valid {
+		err = deleteMediaFiles(ctx, u.mediaStorage, []string{collection.CoverFileKey.String, collection.CoverThumbnailKey.String})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return u.collectionRepo.Get(ctx, params.CollectionID)
+}
+
+// ExportCollection retrieves a whole collection with the summaries of the recipes the viewer can see in order,
+// so that it can be written as a single document
+func (u *CollectionUsecase) ExportCollection(ctx context.Context, id uint64, viewer string) (entity.CollectionExport, error) {
+	detail, err := u.GetCollection(ctx, id, viewer)
+	if err != nil {
+		return entity.CollectionExport{}, err
+	}
+
+	res := entity.CollectionExport{
+		Collection:    detail.Collection,
+		Recipes:       []entity.CollectionExportRecipe{},
+		HiddenRecipes: detail.HiddenRecipes,
+		TrailingGap:   detail.TrailingGap,
+	}
+
+	gap := false
+
+	for _, recipe := range detail.Recipes {
+		summary, err := u.recipeRepo.GetSummary(ctx, recipe.RecipeID)
+		if err != nil {
+			return entity.CollectionExport{}, err
+		}
+
+		// a recipe deleted or unpublished while the collection is exported is hidden like the others
+		if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, viewer) {
+			res.HiddenRecipes++
+			gap = true
+			continue
+		}
+
+		res.Recipes = append(res.Recipes, entity.CollectionExportRecipe{
+			Summary:   summary,
+			GapBefore: gap || recipe.GapBefore,
+		})
+
+		gap = false
+	}
+
+	res.TrailingGap = res.TrailingGap || gap
+
+	return res, nil
+}
+
+// listCollectionRecipes retrieves every recipe of a collection including the deleted ones, the collection must exist
+func (u *CollectionUsecase) listCollectionRecipes(ctx context.Context, id uint64) (entity.CollectionRecipes, error) {
+	_, err := u.collectionRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.collectionRepo.ListRecipes(ctx, id)
+}
+
+// canViewCollectionRecipe returns true when a recipe of a collection is live and the viewer can see it
+func (u *CollectionUsecase) canViewCollectionRecipe(recipe *entity.CollectionRecipe, viewer string) bool {
+	return !recipe.IsRecipeDeleted &&
+		u.reviewers.canViewRecipe(entity.Recipe{Status: recipe.RecipeStatus, CreatedBy: recipe.RecipeCreatedBy}, viewer)
+}
@@ -0,0 +1,223 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewCollectionUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	assert.NotEmpty(t, uc)
+}
+
+// collectionRecipes holds recipes 1 to 4 of collection 7, recipes 2 and 4 are deleted
+func collectionRecipes() entity.CollectionRecipes {
+	return entity.CollectionRecipes{
+		{CollectionID: 7, RecipeID: 1, RecipeName: "Kolak", Position: 1},
+		{CollectionID: 7, RecipeID: 2, RecipeName: "Kurma", Position: 2, IsRecipeDeleted: true},
+		{CollectionID: 7, RecipeID: 3, RecipeName: "Bakwan", Position: 3},
+		{CollectionID: 7, RecipeID: 4, RecipeName: "Es buah", Position: 4, IsRecipeDeleted: true},
+	}
+}
+
+func TestCollectionUsecase_GetCollection_HidesDeletedRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7, Name: "Ramadan Menu 2026"}, nil)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(collectionRecipes(), nil)
+
+	detail, err := uc.GetCollection(context.Background(), 7, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, detail.Recipes, 2)
+	assert.Equal(t, uint64(1), detail.Recipes[0].RecipeID)
+	assert.False(t, detail.Recipes[0].GapBefore)
+	assert.Equal(t, uint64(3), detail.Recipes[1].RecipeID)
+	assert.True(t, detail.Recipes[1].GapBefore)
+	assert.Equal(t, 2, detail.HiddenRecipes)
+	assert.True(t, detail.TrailingGap)
+}
+
+func TestCollectionUsecase_GetCollection_HidesDraftsOfOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, []string{"Chef"})
+
+	recipes := func() entity.CollectionRecipes {
+		return entity.CollectionRecipes{
+			{CollectionID: 7, RecipeID: 1, RecipeName: "Kolak", RecipeStatus: entity.RecipeStatusPublished, Position: 1},
+			{CollectionID: 7, RecipeID: 5, RecipeName: "Secret sambal", RecipeStatus: entity.RecipeStatusDraft, RecipeCreatedBy: "Rina", Position: 2},
+		}
+	}
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).DoAndReturn(func(context.Context, uint64) (*entity.Collection, error) {
+		return &entity.Collection{ID: 7, Name: "Ramadan Menu 2026", RecipeCount: 1}, nil
+	}).Times(3)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).DoAndReturn(func(context.Context, uint64) (entity.CollectionRecipes, error) {
+		return recipes(), nil
+	}).Times(3)
+
+	detail, err := uc.GetCollection(context.Background(), 7, "Naufal")
+
+	assert.NoError(t, err)
+	assert.Len(t, detail.Recipes, 1)
+	assert.Equal(t, "Kolak", detail.Recipes[0].RecipeName)
+	assert.Equal(t, 1, detail.Collection.RecipeCount)
+	assert.Equal(t, 1, detail.HiddenRecipes)
+	assert.True(t, detail.TrailingGap)
+
+	for _, viewer := range []string{"rina", "Chef"} {
+		detail, err = uc.GetCollection(context.Background(), 7, viewer)
+
+		assert.NoError(t, err)
+		assert.Len(t, detail.Recipes, 2)
+		assert.Equal(t, 2, detail.Collection.RecipeCount)
+	}
+}
+
+func TestCollectionUsecase_ReorderCollectionRecipes_KeepsDraftsOfOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	recipes := collectionRecipes()
+	recipes[0].RecipeStatus = entity.RecipeStatusDraft
+	recipes[0].RecipeCreatedBy = "Rina"
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7}, nil).Times(2)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(recipes, nil).Times(2)
+
+	// the draft of Rina is hidden from Naufal, it keeps its slot like the deleted recipes
+	collectionRepo.EXPECT().SetPositions(gomock.Any(), uint64(7), []uint64{1, 2, 3, 4}).Return(nil)
+
+	detail, err := uc.ReorderCollectionRecipes(context.Background(), 7, []uint64{3}, "Naufal")
+
+	assert.NoError(t, err)
+	assert.Len(t, detail.Recipes, 1)
+}
+
+func TestCollectionUsecase_AddCollectionRecipe_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7}, nil)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(collectionRecipes(), nil)
+
+	_, err := uc.AddCollectionRecipe(context.Background(), 7, 2, "Naufal")
+
+	assert.Equal(t, entity.ErrCollectionRecipeExists, err)
+}
+
+func TestCollectionUsecase_ReorderCollectionRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7}, nil).Times(2)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(collectionRecipes(), nil).Times(2)
+
+	// the deleted recipes keep their slots
+	collectionRepo.EXPECT().SetPositions(gomock.Any(), uint64(7), []uint64{3, 2, 1, 4}).Return(nil)
+
+	_, err := uc.ReorderCollectionRecipes(context.Background(), 7, []uint64{3, 1}, "")
+
+	assert.NoError(t, err)
+}
+
+func TestCollectionUsecase_ReorderCollectionRecipes_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7}, nil).Times(3)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(collectionRecipes(), nil).Times(3)
+
+	for _, order := range [][]uint64{{1}, {1, 1}, {1, 2}} {
+		_, err := uc.ReorderCollectionRecipes(context.Background(), 7, order, "")
+
+		assert.Equal(t, entity.ErrInvalidCollectionOrder, err)
+	}
+}
+
+func TestCollectionUsecase_ExportCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7, Name: "Ramadan Menu 2026"}, nil)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(collectionRecipes(), nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 1, Name: "Kolak"}}, nil)
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(3)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 3, Name: "Bakwan"}}, nil)
+
+	export, err := uc.ExportCollection(context.Background(), 7, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, export.Recipes, 2)
+	assert.Equal(t, "Kolak", export.Recipes[0].Summary.Name)
+	assert.True(t, export.Recipes[1].GapBefore)
+	assert.Equal(t, 2, export.HiddenRecipes)
+	assert.True(t, export.TrailingGap)
+}
+
+func TestCollectionUsecase_ExportCollection_HidesDrafts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	collectionRepo := mock.NewMockCollectionRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCollectionUsecase(collectionRepo, recipeRepo, mediaStorage, 1<<20, []string{"Sari"})
+
+	collectionRepo.EXPECT().Get(gomock.Any(), uint64(7)).Return(&entity.Collection{ID: 7, Name: "Ramadan Menu 2026"}, nil)
+	collectionRepo.EXPECT().ListRecipes(gomock.Any(), uint64(7)).Return(collectionRecipes(), nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Kolak", Status: entity.RecipeStatusDraft, CreatedBy: "Budi"},
+	}, nil)
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(3)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 3, Name: "Bakwan"}}, nil)
+
+	export, err := uc.ExportCollection(context.Background(), 7, "Andi")
+
+	assert.NoError(t, err)
+	assert.Len(t, export.Recipes, 1)
+	assert.Equal(t, "Bakwan", export.Recipes[0].Summary.Name)
+	assert.True(t, export.Recipes[0].GapBefore)
+	assert.Equal(t, 3, export.HiddenRecipes)
+}
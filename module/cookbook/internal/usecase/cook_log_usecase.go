@@ -0,0 +1,179 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/cook_log_repo.go -source=cook_log_usecase.go -package=mock CookLogRepository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// CookLogParams holds an "I cooked this" event, CookedOn defaults to today and Servings to the servings of the recipe
+type CookLogParams struct {
+	RecipeID uint64
+	CookedOn time.Time
+	Servings int
+	Rating   null.Int
+	Notes    string
+	Actor    string
+}
+
+// CookLogPhotoParams holds a stored cook log photo to be recorded
+type CookLogPhotoParams struct {
+	FileKey      string
+	ThumbnailKey string
+	Actor        string
+}
+
+// UploadCookLogPhotoParams holds an uploaded photo of a cook log
+type UploadCookLogPhotoParams struct {
+	CookLogID uint64
+	Actor     string
+	Content   io.Reader
+}
+
+// CookLogRepository defines contract for cook log repository dependency.
+// Create and Delete keep the cook count and rating aggregates of the recipe in the same transaction
+type CookLogRepository interface {
+	Create(ctx context.Context, params CookLogParams) (*entity.CookLog, error)
+	Get(ctx context.Context, id uint64) (*entity.CookLog, error)
+	ListByRecipeID(ctx context.Context, recipeID uint64, limit, offset int) (entity.CookLogs, error)
+	UpdatePhoto(ctx context.Context, id uint64, params CookLogPhotoParams) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// CookLogUsecase is our cook log usecase object
+type CookLogUsecase struct {
+	cookLogRepo  CookLogRepository
+	recipeRepo   RecipeRepository
+	mediaStorage MediaStorage
+	maxBytes     int64
+	reviewers    reviewerSet
+}
+
+// NewCookLogUsecase instantiates CookLogUsecase, photo uploads larger than maxBytes are rejected and
+// reviewers can log the recipes that are not published yet
+func NewCookLogUsecase(cookLogRepo CookLogRepository, recipeRepo RecipeRepository, mediaStorage MediaStorage, maxBytes int64, reviewers []string) *CookLogUsecase {
+	return &CookLogUsecase{
+		cookLogRepo:  cookLogRepo,
+		recipeRepo:   recipeRepo,
+		mediaStorage: mediaStorage,
+		maxBytes:     maxBytes,
+		reviewers:    newReviewerSet(reviewers),
+	}
+}
+
+// CreateCookLog records that a recipe was cooked, optionally with a rating. A recipe cannot be cooked after today
+func (u *CookLogUsecase) CreateCookLog(ctx context.Context, params CookLogParams) (*entity.CookLog, error) {
+	if params.Rating.Valid && (params.Rating.Int64 < entity.MinRating || params.Rating.Int64 > entity.MaxRating) {
+		return nil, entity.ErrInvalidRating
+	}
+
+	if params.Servings < 0 {
+		return nil, entity.ErrInvalidServings
+	}
+
+	now := time.Now()
+	if params.CookedOn.IsZero() {
+		params.CookedOn = now
+	}
+
+	params.CookedOn = time.Date(params.CookedOn.Year(), params.CookedOn.Month(), params.CookedOn.Day(), 0, 0, 0, 0, time.UTC)
+
+	if params.CookedOn.After(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return nil, entity.ErrInvalidCookedOn
+	}
+
+	summary, err := u.recipeRepo.GetSummary(ctx, params.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, params.Actor) {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	if params.Servings == 0 {
+		params.Servings = summary.Servings
+	}
+
+	if params.Servings <= 0 {
+		params.Servings = defaultServings
+	}
+
+	return u.cookLogRepo.Create(ctx, params)
+}
+
+// ListCookLogs retrieves the cook logs of a recipe, latest first
+func (u *CookLogUsecase) ListCookLogs(ctx context.Context, recipeID uint64, limit, offset int) (entity.CookLogs, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.cookLogRepo.ListByRecipeID(ctx, recipeID, lim, ofs)
+}
+
+// DeleteCookLog permanently removes a cook log along with its photo, the recipe aggregates no longer count it.
+// Unlike most rows cook logs never go to the trash, see the cook logs migration
+func (u *CookLogUsecase) DeleteCookLog(ctx context.Context, id uint64) error {
+	cookLog, err := u.cookLogRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = u.cookLogRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !cookLog.PhotoFileKey.Valid {
+		return nil
+	}
+
+	return deleteMediaFiles(ctx, u.mediaStorage, []string{cookLog.PhotoFileKey.String, cookLog.PhotoThumbnailKey.String})
+}
+
+// UploadCookLogPhoto stores the photo of a cook log along with a thumbnail, replacing the previous one
+func (u *CookLogUsecase) UploadCookLogPhoto(ctx context.Context, params UploadCookLogPhotoParams) (*entity.CookLog, error) {
+	cookLog, err := u.cookLogRepo.Get(ctx, params.CookLogID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := storeImage(ctx, u.mediaStorage, u.maxBytes, fmt.Sprintf("cook-logs/%d", params.CookLogID), params.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.cookLogRepo.UpdatePhoto(ctx, params.CookLogID, CookLogPhotoParams{
+		FileKey:      stored.FileKey,
+		ThumbnailKey: stored.ThumbnailKey,
+		Actor:        params.Actor,
+	})
+	if err != nil {
+		_ = u.mediaStorage.Delete(ctx, stored.FileKey)
+		_ = u.mediaStorage.Delete(ctx, stored.ThumbnailKey)
+		return nil, err
+	}
+
+	if cookLog.PhotoFileKey.Valid {
+		err = deleteMediaFiles(ctx, u.mediaStorage, []string{cookLog.PhotoFileKey.String, cookLog.PhotoThumbnailKey.String})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return u.cookLogRepo.Get(ctx, params.CookLogID)
+}
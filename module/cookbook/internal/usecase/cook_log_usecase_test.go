@@ -0,0 +1,117 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewCookLogUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cookLogRepo := mock.NewMockCookLogRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCookLogUsecase(cookLogRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestCookLogUsecase_CreateCookLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cookLogRepo := mock.NewMockCookLogRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCookLogUsecase(cookLogRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(3)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 3, Servings: 4}}, nil)
+
+	// the date is kept without its time and the servings default to the servings of the recipe
+	cookLogRepo.EXPECT().Create(gomock.Any(), usecase.CookLogParams{
+		RecipeID: 3,
+		CookedOn: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+		Servings: 4,
+		Rating:   null.IntFrom(5),
+		Actor:    "Naufal",
+	}).Return(&entity.CookLog{ID: 1, RecipeID: 3, Servings: 4, Rating: null.IntFrom(5)}, nil)
+
+	cookLog, err := uc.CreateCookLog(context.Background(), usecase.CookLogParams{
+		RecipeID: 3,
+		CookedOn: time.Date(2026, time.October, 18, 19, 30, 0, 0, time.UTC),
+		Rating:   null.IntFrom(5),
+		Actor:    "Naufal",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), cookLog.ID)
+}
+
+func TestCookLogUsecase_CreateCookLog_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cookLogRepo := mock.NewMockCookLogRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCookLogUsecase(cookLogRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	for _, rating := range []int64{0, 6} {
+		_, err := uc.CreateCookLog(context.Background(), usecase.CookLogParams{RecipeID: 3, Rating: null.IntFrom(rating)})
+		assert.Equal(t, entity.ErrInvalidRating, err)
+	}
+
+	_, err := uc.CreateCookLog(context.Background(), usecase.CookLogParams{RecipeID: 3, CookedOn: time.Now().AddDate(0, 0, 2)})
+	assert.Equal(t, entity.ErrInvalidCookedOn, err)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(4)).Return(entity.RecipeSummary{}, nil)
+
+	_, err = uc.CreateCookLog(context.Background(), usecase.CookLogParams{RecipeID: 4})
+	assert.Equal(t, entity.ErrRecipeNotFound, err)
+}
+
+func TestCookLogUsecase_CreateCookLog_HidesDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cookLogRepo := mock.NewMockCookLogRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCookLogUsecase(cookLogRepo, recipeRepo, mediaStorage, 1<<20, []string{"Sari"})
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(3)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 3, Servings: 4, Status: entity.RecipeStatusInReview, CreatedBy: "Budi"},
+	}, nil)
+
+	_, err := uc.CreateCookLog(context.Background(), usecase.CookLogParams{RecipeID: 3, Actor: "Andi"})
+
+	assert.ErrorIs(t, err, entity.ErrRecipeNotFound)
+}
+
+func TestCookLogUsecase_DeleteCookLog_DeletesPhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	cookLogRepo := mock.NewMockCookLogRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+	uc := usecase.NewCookLogUsecase(cookLogRepo, recipeRepo, mediaStorage, 1<<20, nil)
+
+	cookLogRepo.EXPECT().Get(gomock.Any(), uint64(1)).Return(&entity.CookLog{
+		ID:                1,
+		PhotoFileKey:      null.StringFrom("cook-logs/1/a.jpg"),
+		PhotoThumbnailKey: null.StringFrom("cook-logs/1/a_thumb.jpg"),
+	}, nil)
+	cookLogRepo.EXPECT().Delete(gomock.Any(), uint64(1)).Return(nil)
+	mediaStorage.EXPECT().Delete(gomock.Any(), "cook-logs/1/a.jpg").Return(nil)
+	mediaStorage.EXPECT().Delete(gomock.Any(), "cook-logs/1/a_thumb.jpg").Return(entity.ErrMediaNotFound)
+
+	err := uc.DeleteCookLog(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,357 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/ingredient_price_repo.go -source=cost_usecase.go -package=mock IngredientPriceRepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type IngredientPriceParams struct {
+	IngredientID     uint64
+	IngredientUnitID uint64
+	Quantity         float64
+	Price            float64
+	EffectiveAt      time.Time
+	Actor            string
+}
+
+type RecipeCostIncreaseFilter struct {
+	From               time.Time
+	To                 time.Time
+	MinIncreasePercent float64
+	Viewer             string
+}
+
+// IngredientPriceRepository defines contract for ingredient price repository dependency
+type IngredientPriceRepository interface {
+	Create(ctx context.Context, params IngredientPriceParams) (*entity.IngredientPrice, error)
+	Delete(ctx context.Context, id uint64) error
+	List(ctx context.Context, ingredientID uint64, limit, offset int) (entity.IngredientPrices, error)
+	ListEffective(ctx context.Context, ingredientIDs []uint64, at time.Time) (entity.IngredientPrices, error)
+}
+
+// CostUsecase is our cost usecase object
+type CostUsecase struct {
+	recipeRepo          RecipeRepository
+	ingredientRepo      IngredientRepository
+	ingredientUnitRepo  IngredientUnitRepository
+	ingredientPriceRepo IngredientPriceRepository
+	reviewers           reviewerSet
+}
+
+// NewCostUsecase instantiates CostUsecase, reviewers can see the recipes that are not published yet
+func NewCostUsecase(recipeRepo RecipeRepository, ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository, ingredientPriceRepo IngredientPriceRepository, reviewers []string) *CostUsecase {
+	return &CostUsecase{
+		recipeRepo:          recipeRepo,
+		ingredientRepo:      ingredientRepo,
+		ingredientUnitRepo:  ingredientUnitRepo,
+		ingredientPriceRepo: ingredientPriceRepo,
+		reviewers:           newReviewerSet(reviewers),
+	}
+}
+
+// CreateIngredientPrice records a new price of an ingredient
+func (u *CostUsecase) CreateIngredientPrice(ctx context.Context, params IngredientPriceParams) (*entity.IngredientPrice, error) {
+	if params.Quantity <= 0 {
+		params.Quantity = 1
+	}
+
+	if params.EffectiveAt.IsZero() {
+		params.EffectiveAt = time.Now()
+	}
+
+	return u.ingredientPriceRepo.Create(ctx, params)
+}
+
+// DeleteIngredientPrice deletes an ingredient price
+func (u *CostUsecase) DeleteIngredientPrice(ctx context.Context, id uint64) error {
+	return u.ingredientPriceRepo.Delete(ctx, id)
+}
+
+// ListIngredientPrices retrieves the price history of an ingredient, latest first
+func (u *CostUsecase) ListIngredientPrices(ctx context.Context, ingredientID uint64, limit, offset int) (entity.IngredientPrices, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.ingredientPriceRepo.List(ctx, ingredientID, lim, ofs)
+}
+
+// costedRecipe holds a recipe with its ingredients flattened, merged and scaled to the servings it is costed for
+type costedRecipe struct {
+	summary     entity.RecipeSummary
+	servings    int
+	ingredients entity.RecipeIngredients
+}
+
+// GetRecipeCost computes the total and per serving cost of a recipe the viewer can see with the current prices.
+// A zero servings uses the servings of the recipe.
+func (u *CostUsecase) GetRecipeCost(ctx context.Context, recipeID uint64, servings int, viewer string) (entity.RecipeCost, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, recipeID)
+	if err != nil {
+		return entity.RecipeCost{}, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, viewer) {
+		return entity.RecipeCost{}, entity.ErrRecipeNotFound
+	}
+
+	recipe, err := u.costedRecipe(ctx, summary, servings)
+	if err != nil {
+		return entity.RecipeCost{}, err
+	}
+
+	costs, err := u.costRecipes(ctx, []costedRecipe{recipe}, time.Now())
+	if err != nil {
+		return entity.RecipeCost{}, err
+	}
+
+	return costs[0][0], nil
+}
+
+// ListRecipeCostIncreases lists recipes the viewer can see whose cost rose more than the minimum percentage over a period.
+// A recipe that cannot be costed is skipped and reported instead of failing the report, and the prices are read once
+// per page of recipes
+func (u *CostUsecase) ListRecipeCostIncreases(ctx context.Context, filter RecipeCostIncreaseFilter) (entity.RecipeCostIncreaseReport, error) {
+	var res entity.RecipeCostIncreaseReport
+
+	for offset := 0; ; offset += listAllPageSize {
+		recipes, err := u.recipeRepo.List(ctx, ListRecipesFiter{}, listAllPageSize, offset)
+		if err != nil {
+			return entity.RecipeCostIncreaseReport{}, err
+		}
+
+		var costed []costedRecipe
+
+		for _, recipe := range recipes {
+			if !u.reviewers.canViewRecipe(*recipe, filter.Viewer) {
+				continue
+			}
+
+			c, err := u.readCostedRecipe(ctx, recipe.ID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return entity.RecipeCostIncreaseReport{}, ctx.Err()
+				}
+
+				res.Skipped = append(res.Skipped, entity.RecipeCostSkip{RecipeID: recipe.ID, RecipeName: recipe.Name, Reason: err.Error()})
+				continue
+			}
+
+			// the recipe was deleted since the page was listed
+			if c.summary.ID == 0 {
+				continue
+			}
+
+			costed = append(costed, c)
+		}
+
+		increases, err := u.recipeCostIncreases(ctx, costed, filter)
+		if err != nil {
+			return entity.RecipeCostIncreaseReport{}, err
+		}
+
+		res.Increases = append(res.Increases, increases...)
+
+		if len(recipes) < listAllPageSize {
+			return res, nil
+		}
+	}
+}
+
+// readCostedRecipe reads a recipe and flattens it for its own servings, the summary ID of a deleted recipe is 0
+func (u *CostUsecase) readCostedRecipe(ctx context.Context, recipeID uint64) (costedRecipe, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, recipeID)
+	if err != nil {
+		return costedRecipe{}, err
+	}
+
+	if summary.ID == 0 {
+		return costedRecipe{}, nil
+	}
+
+	return u.costedRecipe(ctx, summary, 0)
+}
+
+// recipeCostIncreases compares the costs of recipes at the start and the end of a period and keeps the ones that rose
+// more than the minimum percentage, a recipe that cost nothing at the start is left out
+func (u *CostUsecase) recipeCostIncreases(ctx context.Context, recipes []costedRecipe, filter RecipeCostIncreaseFilter) (entity.RecipeCostIncreases, error) {
+	if len(recipes) == 0 {
+		return nil, nil
+	}
+
+	costs, err := u.costRecipes(ctx, recipes, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var res entity.RecipeCostIncreases
+
+	for i, recipe := range recipes {
+		from, to := costs[0][i], costs[1][i]
+		if from.Total <= 0 {
+			continue
+		}
+
+		increase := &entity.RecipeCostIncrease{
+			RecipeID:        recipe.summary.ID,
+			RecipeName:      recipe.summary.Name,
+			FromCost:        from.Total,
+			ToCost:          to.Total,
+			IncreasePercent: (to.Total - from.Total) / from.Total * 100,
+			IsComplete:      from.IsComplete && to.IsComplete,
+		}
+
+		if increase.IncreasePercent > filter.MinIncreasePercent {
+			res = append(res, increase)
+		}
+	}
+
+	return res, nil
+}
+
+// costedRecipe flattens the ingredients of a recipe for a number of servings, zero servings uses the servings of the recipe
+func (u *CostUsecase) costedRecipe(ctx context.Context, summary entity.RecipeSummary, servings int) (costedRecipe, error) {
+	recipeServings := summary.Servings
+	if recipeServings <= 0 {
+		recipeServings = defaultServings
+	}
+
+	if servings <= 0 {
+		servings = recipeServings
+	}
+
+	flattened, err := flattenRecipeIngredients(ctx, u.recipeRepo, summary, float64(servings)/float64(recipeServings), map[uint64]bool{summary.ID: true})
+	if err != nil {
+		return costedRecipe{}, err
+	}
+
+	return costedRecipe{summary: summary, servings: servings, ingredients: mergeRecipeIngredients(flattened)}, nil
+}
+
+// costRecipes computes the costs of recipes with the prices effective at each of the times, costs[i][j] is the cost
+// of recipes[j] at times[i]. The prices, the ingredients and their conversions are read once for all the recipes
+func (u *CostUsecase) costRecipes(ctx context.Context, recipes []costedRecipe, times ...time.Time) ([][]entity.RecipeCost, error) {
+	var ingredientIDs []uint64
+	var recipeIngredients entity.RecipeIngredients
+
+	for _, recipe := range recipes {
+		for _, ri := range recipe.ingredients {
+			if ri.IngredientID != 0 {
+				ingredientIDs = appendUniqueID(ingredientIDs, ri.IngredientID)
+			}
+		}
+
+		recipeIngredients = append(recipeIngredients, recipe.ingredients...)
+	}
+
+	priceByIngredientID := make([]map[uint64]*entity.IngredientPrice, len(times))
+	var priceUnitNames []string
+
+	for i, at := range times {
+		priceByIngredientID[i] = map[uint64]*entity.IngredientPrice{}
+
+		if len(ingredientIDs) == 0 {
+			continue
+		}
+
+		prices, err := u.ingredientPriceRepo.ListEffective(ctx, ingredientIDs, at)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, price := range prices {
+			priceByIngredientID[i][price.IngredientID] = price
+			priceUnitNames = append(priceUnitNames, price.IngredientUnitName)
+		}
+	}
+
+	ingredientByID, converter, err := loadIngredientsWithConverter(ctx, u.ingredientRepo, u.ingredientUnitRepo, recipeIngredients, priceUnitNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]entity.RecipeCost, len(times))
+	for i := range times {
+		for _, recipe := range recipes {
+			res[i] = append(res[i], recipeCost(recipe, priceByIngredientID[i], ingredientByID, converter))
+		}
+	}
+
+	return res, nil
+}
+
+// recipeCost computes the cost of a recipe with the prices of its ingredients
+func recipeCost(recipe costedRecipe, priceByIngredientID map[uint64]*entity.IngredientPrice, ingredientByID map[uint64]*entity.Ingredient, converter *unitConverter) entity.RecipeCost {
+	res := entity.RecipeCost{
+		RecipeID:   recipe.summary.ID,
+		RecipeName: recipe.summary.Name,
+		Servings:   recipe.servings,
+		IsComplete: true,
+	}
+
+	for _, ri := range recipe.ingredients {
+		cost := ingredientCost(ri, ingredientByID[ri.IngredientID], priceByIngredientID[ri.IngredientID], converter)
+		if cost.Status != entity.CostStatusOK && cost.Status != entity.CostStatusToTaste {
+			res.IsComplete = false
+		}
+
+		res.Total += cost.Cost.Float64
+		res.Ingredients = append(res.Ingredients, cost)
+	}
+
+	res.PerServing = res.Total / float64(recipe.servings)
+
+	return res
+}
+
+// ingredientCost computes the cost of a recipe ingredient and flags a missing or unusable price
+func ingredientCost(ri *entity.RecipeIngredient, ingredient *entity.Ingredient, price *entity.IngredientPrice, converter *unitConverter) *entity.RecipeIngredientCost {
+	res := &entity.RecipeIngredientCost{
+		IngredientID:       ri.IngredientID,
+		IngredientName:     ri.IngredientName,
+		IngredientUnitName: ri.IngredientUnitName,
+		Amount:             ri.Amount,
+	}
+
+	if ri.IsToTaste() {
+		res.Status = entity.CostStatusToTaste
+		return res
+	}
+
+	if price == nil || price.Quantity <= 0 {
+		res.Status = entity.CostStatusMissingPrice
+		return res
+	}
+
+	// a price in the same unit as the recipe does not need any conversion
+	if normalizeUnitName(price.IngredientUnitName) == normalizeUnitName(ri.IngredientUnitName) {
+		res.Cost = null.FloatFrom(ri.Amount.Float64 / price.Quantity * price.Price)
+		res.Status = entity.CostStatusOK
+		return res
+	}
+
+	amount, ok := converter.toBaseAmount(ingredient, ri.IngredientUnitName, ri.Amount.Float64)
+	priceAmount, priceOk := converter.toBaseAmount(ingredient, price.IngredientUnitName, price.Quantity)
+	if !ok || !priceOk || priceAmount <= 0 {
+		res.Status = entity.CostStatusUnconvertibleUnit
+		return res
+	}
+
+	res.Cost = null.FloatFrom(amount / priceAmount * price.Price)
+	res.Status = entity.CostStatusOK
+
+	return res
+}
@@ -0,0 +1,221 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewCostUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientPriceRepo := mock.NewMockIngredientPriceRepository(ctrl)
+
+	uc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, nil)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestCostUsecase_GetRecipeCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientPriceRepo := mock.NewMockIngredientPriceRepository(ctrl)
+
+	uc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Goreng", Servings: 2},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 1, IngredientName: "Nasi", IngredientUnitName: "gram", Amount: null.FloatFrom(500)},
+			{ID: 11, IngredientID: 2, IngredientName: "Telur", IngredientUnitName: "butir", Amount: null.FloatFrom(2)},
+			{ID: 12, IngredientID: 8, IngredientName: "Kecap", IngredientUnitName: "sdm", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+
+	ingredientPriceRepo.EXPECT().ListEffective(gomock.Any(), []uint64{1, 2, 8}, gomock.Any()).Return(entity.IngredientPrices{
+		{IngredientID: 1, IngredientUnitName: "kg", Quantity: 1, Price: 12000},
+		{IngredientID: 2, IngredientUnitName: "butir", Quantity: 10, Price: 20000},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{1, 2, 8}).Return(entity.Ingredients{
+		{ID: 1, Name: "Nasi", BaseUnitName: null.StringFrom("gram")},
+		{ID: 2, Name: "Telur"},
+		{ID: 8, Name: "Kecap"},
+	}, nil)
+
+	ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), gomock.Any()).Return(entity.IngredientUnits{
+		{ID: 1, Name: "gram", BaseUnitName: null.StringFrom("gram"), BaseFactor: null.FloatFrom(1)},
+		{ID: 6, Name: "kg", BaseUnitName: null.StringFrom("gram"), BaseFactor: null.FloatFrom(1000)},
+	}, nil)
+
+	ingredientUnitRepo.EXPECT().ListConversions(gomock.Any(), []uint64{1, 2, 8}).Return(entity.IngredientUnitConversions{}, nil)
+
+	cost, err := uc.GetRecipeCost(context.Background(), 1, 4, "")
+
+	assert.NoError(t, err)
+	assert.False(t, cost.IsComplete)
+	assert.Equal(t, entity.CostStatusOK, cost.Ingredients[0].Status)
+	assert.Equal(t, entity.CostStatusOK, cost.Ingredients[1].Status)
+	assert.Equal(t, entity.CostStatusMissingPrice, cost.Ingredients[2].Status)
+	assert.InDelta(t, 20000, cost.Total, 0.001)
+	assert.InDelta(t, 5000, cost.PerServing, 0.001)
+}
+
+func TestCostUsecase_GetRecipeCost_DeletedIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientPriceRepo := mock.NewMockIngredientPriceRepository(ctrl)
+
+	uc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Goreng", Servings: 2},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 1, IngredientName: "Nasi", IngredientUnitName: "gram", Amount: null.FloatFrom(500)},
+		},
+	}, nil)
+
+	ingredientPriceRepo.EXPECT().ListEffective(gomock.Any(), []uint64{1}, gomock.Any()).Return(entity.IngredientPrices{
+		{IngredientID: 1, IngredientUnitName: "kg", Quantity: 1, Price: 12000},
+	}, nil)
+
+	// the ingredient is deleted but still in the recipe, its base unit is needed to convert the price in kg
+	ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{1}).Return(entity.Ingredients{
+		{ID: 1, Name: "Nasi", BaseUnitName: null.StringFrom("gram"), IsDeleted: true},
+	}, nil)
+
+	ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), gomock.Any()).Return(entity.IngredientUnits{
+		{ID: 1, Name: "gram", BaseUnitName: null.StringFrom("gram"), BaseFactor: null.FloatFrom(1)},
+		{ID: 6, Name: "kg", BaseUnitName: null.StringFrom("gram"), BaseFactor: null.FloatFrom(1000)},
+	}, nil)
+
+	ingredientUnitRepo.EXPECT().ListConversions(gomock.Any(), []uint64{1}).Return(entity.IngredientUnitConversions{}, nil)
+
+	cost, err := uc.GetRecipeCost(context.Background(), 1, 2, "")
+
+	assert.NoError(t, err)
+	assert.True(t, cost.IsComplete)
+	assert.Equal(t, entity.CostStatusOK, cost.Ingredients[0].Status)
+	assert.InDelta(t, 6000, cost.Total, 0.001)
+}
+
+func TestCostUsecase_GetRecipeCost_HidesDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientPriceRepo := mock.NewMockIngredientPriceRepository(ctrl)
+
+	uc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, []string{"Sari"})
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Rendang", Status: entity.RecipeStatusInReview, CreatedBy: "Budi"},
+	}, nil)
+
+	_, err := uc.GetRecipeCost(context.Background(), 1, 0, "")
+
+	assert.ErrorIs(t, err, entity.ErrRecipeNotFound)
+}
+
+func TestCostUsecase_ListRecipeCostIncreases_SkipsDrafts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientPriceRepo := mock.NewMockIngredientPriceRepository(ctrl)
+
+	uc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, []string{"Sari"})
+
+	// the draft of another author is left out without being costed
+	recipeRepo.EXPECT().List(gomock.Any(), usecase.ListRecipesFiter{}, gomock.Any(), 0).Return(entity.Recipes{
+		{ID: 1, Name: "Rendang", Status: entity.RecipeStatusDraft, CreatedBy: "Budi"},
+	}, nil)
+
+	report, err := uc.ListRecipeCostIncreases(context.Background(), usecase.RecipeCostIncreaseFilter{Viewer: "Andi"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Increases)
+	assert.Empty(t, report.Skipped)
+}
+
+func TestCostUsecase_ListRecipeCostIncreases_SkipsFailingRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientPriceRepo := mock.NewMockIngredientPriceRepository(ctrl)
+
+	uc := usecase.NewCostUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, ingredientPriceRepo, nil)
+
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	recipeRepo.EXPECT().List(gomock.Any(), usecase.ListRecipesFiter{}, gomock.Any(), 0).Return(entity.Recipes{
+		{ID: 1, Name: "Nasi Putih"},
+		{ID: 2, Name: "Nasi Campur"},
+		{ID: 3, Name: "Telur Rebus"},
+	}, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe:      entity.Recipe{ID: 1, Name: "Nasi Putih", Servings: 1},
+		Ingredients: entity.RecipeIngredients{{IngredientID: 1, IngredientName: "Nasi", IngredientUnitName: "gram", Amount: null.FloatFrom(1000)}},
+	}, nil)
+
+	// the sub-recipe is used in a unit it does not yield, the recipe is skipped and the others are still reported
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(2)).Return(entity.RecipeSummary{
+		Recipe:      entity.Recipe{ID: 2, Name: "Nasi Campur", Servings: 1},
+		Ingredients: entity.RecipeIngredients{{SubRecipeID: 1, IngredientName: "Nasi Putih", IngredientUnitName: "gram", Amount: null.FloatFrom(200)}},
+	}, nil)
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Putih", Servings: 1},
+	}, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(3)).Return(entity.RecipeSummary{
+		Recipe:      entity.Recipe{ID: 3, Name: "Telur Rebus", Servings: 1},
+		Ingredients: entity.RecipeIngredients{{IngredientID: 4, IngredientName: "Telur", IngredientUnitName: "butir", Amount: null.FloatFrom(10)}},
+	}, nil)
+
+	// the prices are read once per date for every recipe of the page
+	ingredientPriceRepo.EXPECT().ListEffective(gomock.Any(), []uint64{1, 4}, from).Return(entity.IngredientPrices{
+		{IngredientID: 1, IngredientUnitName: "gram", Quantity: 1000, Price: 10000},
+		{IngredientID: 4, IngredientUnitName: "butir", Quantity: 1, Price: 2000},
+	}, nil)
+	ingredientPriceRepo.EXPECT().ListEffective(gomock.Any(), []uint64{1, 4}, to).Return(entity.IngredientPrices{
+		{IngredientID: 1, IngredientUnitName: "gram", Quantity: 1000, Price: 12000},
+		{IngredientID: 4, IngredientUnitName: "butir", Quantity: 1, Price: 2000},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{1, 4}).Return(entity.Ingredients{{ID: 1, Name: "Nasi"}, {ID: 4, Name: "Telur"}}, nil)
+	ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), gomock.Any()).Return(entity.IngredientUnits{}, nil)
+	ingredientUnitRepo.EXPECT().ListConversions(gomock.Any(), []uint64{1, 4}).Return(entity.IngredientUnitConversions{}, nil)
+
+	report, err := uc.ListRecipeCostIncreases(context.Background(), usecase.RecipeCostIncreaseFilter{From: from, To: to, MinIncreasePercent: 5})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Increases, 1)
+	assert.Equal(t, uint64(1), report.Increases[0].RecipeID)
+	assert.InDelta(t, 20, report.Increases[0].IncreasePercent, 0.001)
+	assert.Len(t, report.Skipped, 1)
+	assert.Equal(t, uint64(2), report.Skipped[0].RecipeID)
+	assert.Equal(t, entity.ErrSubRecipeUnitMismatch.Error(), report.Skipped[0].Reason)
+}
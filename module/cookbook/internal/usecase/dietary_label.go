@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// normalizeAllergens lowercases and deduplicates allergens and rejects unsupported ones.
+// A nil input stays nil so an update without allergens keeps the current ones
+func normalizeAllergens(allergens []string) ([]string, error) {
+	return normalizeLabels(allergens, entity.Allergens, entity.ErrInvalidAllergen)
+}
+
+// normalizeDiets lowercases and deduplicates diets and rejects unsupported ones.
+// A vegan ingredient is vegetarian as well
+func normalizeDiets(diets []string) ([]string, error) {
+	res, err := normalizeLabels(diets, entity.Diets, entity.ErrInvalidDiet)
+	if err != nil {
+		return nil, err
+	}
+
+	if containsString(res, entity.DietVegan) && !containsString(res, entity.DietVegetarian) {
+		res = append(res, entity.DietVegetarian)
+	}
+
+	return res, nil
+}
+
+func normalizeLabels(labels []string, supported []string, errUnsupported error) ([]string, error) {
+	if labels == nil {
+		return nil, nil
+	}
+
+	res := []string{}
+
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || containsString(res, label) {
+			continue
+		}
+
+		if !containsString(supported, label) {
+			return nil, errUnsupported
+		}
+
+		res = append(res, label)
+	}
+
+	return res, nil
+}
+
+// recipeDietaryLabels computes dietary labels of a recipe from the ingredients of the recipe and its sub-recipes
+// after the substitutions are applied
+func recipeDietaryLabels(ctx context.Context, recipeRepo RecipeRepository, ingredientRepo IngredientRepository, summary entity.RecipeSummary, substitutions entity.IngredientSubstitutions) (entity.DietaryLabels, error) {
+	collectedIDs, err := collectIngredientIDs(ctx, recipeRepo, summary, map[uint64]bool{summary.ID: true})
+	if err != nil {
+		return entity.DietaryLabels{}, err
+	}
+
+	var ingredientIDs []uint64
+	for _, id := range collectedIDs {
+		for _, s := range substitutions {
+			if s.IngredientID == id {
+				id = s.SubstituteIngredientID
+				break
+			}
+		}
+
+		ingredientIDs = appendUniqueID(ingredientIDs, id)
+	}
+
+	if len(ingredientIDs) == 0 {
+		return entity.DietaryLabels{}, nil
+	}
+
+	// deleted ingredients stay in the recipes using them, so their labels count as in the recipe filters
+	ingredients, err := ingredientRepo.ListAllByIDs(ctx, ingredientIDs)
+	if err != nil {
+		return entity.DietaryLabels{}, err
+	}
+
+	res := entity.DietaryLabels{}
+
+	for _, allergen := range entity.Allergens {
+		for _, ingredient := range ingredients {
+			if containsString(ingredient.Allergens, allergen) {
+				res.Allergens = append(res.Allergens, allergen)
+				break
+			}
+		}
+	}
+
+	// a diet is only guaranteed when every ingredient is known and tagged with it
+	if len(ingredients) < len(ingredientIDs) {
+		return res, nil
+	}
+
+	for _, diet := range entity.Diets {
+		isSuitable := true
+		for _, ingredient := range ingredients {
+			if !containsString(ingredient.Diets, diet) {
+				isSuitable = false
+				break
+			}
+		}
+
+		if isSuitable {
+			res.Diets = append(res.Diets, diet)
+		}
+	}
+
+	return res, nil
+}
+
+// collectIngredientIDs returns the distinct ingredient IDs of a recipe, including the ones of its sub-recipes
+func collectIngredientIDs(ctx context.Context, recipeRepo RecipeRepository, summary entity.RecipeSummary, visited map[uint64]bool) ([]uint64, error) {
+	var res []uint64
+
+	for _, ingredient := range summary.Ingredients {
+		if ingredient.SubRecipeID == 0 {
+			res = appendUniqueID(res, ingredient.IngredientID)
+			continue
+		}
+
+		if visited[ingredient.SubRecipeID] {
+			return nil, entity.ErrRecipeCycle
+		}
+
+		subSummary, err := recipeRepo.GetSummary(ctx, ingredient.SubRecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if subSummary.ID == 0 {
+			return nil, entity.ErrRecipeNotFound
+		}
+
+		visited[ingredient.SubRecipeID] = true
+		subIngredientIDs, err := collectIngredientIDs(ctx, recipeRepo, subSummary, visited)
+		delete(visited, ingredient.SubRecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range subIngredientIDs {
+			res = appendUniqueID(res, id)
+		}
+	}
+
+	return res, nil
+}
+
+func appendUniqueID(ids []uint64, id uint64) []uint64 {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+
+	return append(ids, id)
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/equipment_repo.go -source=equipment_usecase.go -package=mock EquipmentRepository
+
+import (
+	"context"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type EquipmentParams struct {
+	Name  string
+	Actor string
+}
+
+// EquipmentRepository defines contract for equipment repository dependency
+type EquipmentRepository interface {
+	Create(ctx context.Context, params EquipmentParams) (*entity.Equipment, error)
+	Update(ctx context.Context, id uint64, params EquipmentParams) (*entity.Equipment, error)
+	Delete(ctx context.Context, id uint64) error
+	List(ctx context.Context, limit, offset int) (entity.Equipments, error)
+	ListByIDs(ctx context.Context, ids []uint64) (entity.Equipments, error)
+}
+
+// EquipmentUsecase is our equipment usecase object
+type EquipmentUsecase struct {
+	equipmentRepo EquipmentRepository
+}
+
+// NewEquipmentUsecase instantiates EquipmentUsecase
+func NewEquipmentUsecase(equipmentRepo EquipmentRepository) *EquipmentUsecase {
+	return &EquipmentUsecase{
+		equipmentRepo: equipmentRepo,
+	}
+}
+
+// CreateEquipment creates a new equipment
+func (u *EquipmentUsecase) CreateEquipment(ctx context.Context, params EquipmentParams) (*entity.Equipment, error) {
+	return u.equipmentRepo.Create(ctx, params)
+}
+
+// UpdateEquipment updates an equipment
+func (u *EquipmentUsecase) UpdateEquipment(ctx context.Context, id uint64, params EquipmentParams) (*entity.Equipment, error) {
+	return u.equipmentRepo.Update(ctx, id, params)
+}
+
+// DeleteEquipment deletes an equipment and removes it from its recipes
+func (u *EquipmentUsecase) DeleteEquipment(ctx context.Context, id uint64) error {
+	return u.equipmentRepo.Delete(ctx, id)
+}
+
+// ListEquipment retrieves a list of equipment
+func (u *EquipmentUsecase) ListEquipment(ctx context.Context, limit, offset int) (entity.Equipments, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.equipmentRepo.List(ctx, lim, ofs)
+}
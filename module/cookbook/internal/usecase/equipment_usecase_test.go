@@ -0,0 +1,36 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewEquipmentUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	equipmentRepo := mock.NewMockEquipmentRepository(ctrl)
+	uc := usecase.NewEquipmentUsecase(equipmentRepo)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestEquipmentUsecase_ListEquipment_DefaultPaging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	equipmentRepo := mock.NewMockEquipmentRepository(ctrl)
+	uc := usecase.NewEquipmentUsecase(equipmentRepo)
+
+	equipmentRepo.EXPECT().List(gomock.Any(), 20, 0).Return(entity.Equipments{{ID: 1, Name: "wajan"}}, nil)
+
+	equipment, err := uc.ListEquipment(context.Background(), 0, -1)
+
+	assert.NoError(t, err)
+	assert.Len(t, equipment, 1)
+}
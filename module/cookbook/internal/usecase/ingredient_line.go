@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libingredient"
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libtext"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+const (
+	// ingredientLineReviewThreshold is the minimum confidence for an ingredient to be proposed for a line
+	ingredientLineReviewThreshold = 0.7
+	// ingredientLineMatchThreshold is the minimum confidence to use the best candidate of a line without review
+	ingredientLineMatchThreshold = 0.9
+	// ingredientLineMatchMargin is the minimum confidence gap between the best and the second best candidate
+	ingredientLineMatchMargin   = 0.05
+	ingredientLineMaxCandidates = 3
+
+	// unknownUnitPenalty lowers the confidence of a reading whose unit is not one of the ingredient units
+	unknownUnitPenalty = 0.95
+)
+
+// ingredientLineReading is a way to read a parsed line, the name to match and the unit to use
+type ingredientLineReading struct {
+	name        string
+	unitName    string
+	unitMatched bool
+}
+
+// ParseIngredientLines reads free-text ingredient lines like "2 siung bawang putih, cincang halus" and matches them
+// against the ingredients, with their aliases, and the ingredient units. Every line gets its candidates, best first
+func (u *RecipeUsecase) ParseIngredientLines(ctx context.Context, lines []string) (entity.ParsedIngredientLines, error) {
+	res := make(entity.ParsedIngredientLines, 0, len(lines))
+	if len(lines) == 0 {
+		return res, nil
+	}
+
+	parsed := make([]libingredient.Line, 0, len(lines))
+	var unitNames []string
+
+	for _, line := range lines {
+		p := libingredient.Parse(line)
+		parsed = append(parsed, p)
+
+		word, _ := unlistedUnitWord(p)
+
+		for _, name := range []string{p.Unit, strings.ToLower(p.UnitText), word} {
+			if name != "" && !containsString(unitNames, name) {
+				unitNames = append(unitNames, name)
+			}
+		}
+	}
+
+	unitByName := map[string]string{}
+
+	if len(unitNames) > 0 {
+		units, err := u.ingredientUnitRepo.ListByNames(ctx, unitNames)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, unit := range units {
+			unitByName[strings.ToLower(unit.Name)] = unit.Name
+		}
+	}
+
+	ingredients, err := listAllIngredients(ctx, u.ingredientRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, p := range parsed {
+		res = append(res, &entity.ParsedIngredientLine{
+			Line:       lines[i],
+			Candidates: matchIngredientLine(p, unitByName, ingredients),
+		})
+	}
+
+	return res, nil
+}
+
+// resolveIngredientLines fills the recipe ingredients given as a free-text line from the best candidate of the line,
+// fields that are set explicitly are kept. A line without a clear candidate is rejected
+func (u *RecipeUsecase) resolveIngredientLines(ctx context.Context, params BulkRecipeIngredientParams) (BulkRecipeIngredientParams, error) {
+	var lines []string
+	for _, p := range params {
+		if p.Line != "" && p.IngredientID == 0 && p.SubRecipeID == 0 {
+			lines = append(lines, p.Line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return params, nil
+	}
+
+	parsed, err := u.ParseIngredientLines(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(BulkRecipeIngredientParams, 0, len(params))
+
+	for _, p := range params {
+		if p.Line == "" || p.IngredientID != 0 || p.SubRecipeID != 0 {
+			res = append(res, p)
+			continue
+		}
+
+		best, ok := acceptedIngredientCandidate(parsed[0].Candidates)
+		parsed = parsed[1:]
+
+		if !ok {
+			return nil, entity.ErrIngredientLineNotMatched
+		}
+
+		p.IngredientID = best.IngredientID
+
+		if p.Amount == 0 && p.AmountText == "" && !p.ToTaste {
+			p.Amount = best.Amount
+			p.AmountMax = best.AmountMax
+			p.AmountDenominator = best.AmountDenominator
+			p.ToTaste = best.ToTaste
+		}
+
+		if p.Optional == nil && best.Optional {
+			p.Optional = &best.Optional
+		}
+
+		if p.IngredientUnitName == "" {
+			p.IngredientUnitName = best.IngredientUnitName
+		}
+
+		if p.Notes == "" {
+			p.Notes = best.Notes
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+// matchIngredientLine scores the ingredients against the readings of a parsed line, an ingredient keeps the
+// confidence of its best reading
+func matchIngredientLine(p libingredient.Line, unitByName map[string]string, ingredients entity.Ingredients) entity.ParsedIngredientCandidates {
+	reading := ingredientLineReading{name: p.Name, unitMatched: true}
+
+	if p.Unit != "" {
+		reading.unitName = p.Unit
+		reading.unitMatched = false
+
+		for _, name := range []string{p.Unit, strings.ToLower(p.UnitText)} {
+			if unit, ok := unitByName[name]; ok {
+				reading.unitName = unit
+				reading.unitMatched = true
+				break
+			}
+		}
+	}
+
+	readings := []ingredientLineReading{reading}
+
+	// units the parser does not know, e.g. "2 bonggol pakcoy", may still be ingredient units
+	if word, rest := unlistedUnitWord(p); word != "" {
+		if unit, ok := unitByName[word]; ok {
+			readings = append(readings, ingredientLineReading{
+				name:        rest,
+				unitName:    unit,
+				unitMatched: true,
+			})
+		}
+	}
+
+	res := entity.ParsedIngredientCandidates{}
+	indexByID := map[uint64]int{}
+
+	for _, r := range readings {
+		for _, ingredient := range ingredients {
+			confidence := ingredientSimilarity(r.name, ingredient)
+			if !r.unitMatched {
+				confidence *= unknownUnitPenalty
+			}
+
+			if confidence < ingredientLineReviewThreshold {
+				continue
+			}
+
+			candidate := &entity.ParsedIngredientCandidate{
+				Amount:             p.Amount,
+				AmountMax:          p.AmountMax,
+				AmountDenominator:  p.Denominator,
+				ToTaste:            p.ToTaste,
+				Optional:           p.Optional,
+				IngredientID:       ingredient.ID,
+				IngredientName:     ingredient.Name,
+				IngredientUnitName: r.unitName,
+				UnitMatched:        r.unitMatched,
+				Notes:              p.Notes,
+				Confidence:         confidence,
+			}
+
+			i, ok := indexByID[ingredient.ID]
+			if !ok {
+				indexByID[ingredient.ID] = len(res)
+				res = append(res, candidate)
+				continue
+			}
+
+			if confidence > res[i].Confidence {
+				res[i] = candidate
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Confidence > res[j].Confidence
+	})
+
+	if len(res) > ingredientLineMaxCandidates {
+		res = res[:ingredientLineMaxCandidates]
+	}
+
+	return res
+}
+
+// acceptedIngredientCandidate returns the best candidate of a line when it is clear enough to be used without review
+func acceptedIngredientCandidate(candidates entity.ParsedIngredientCandidates) (*entity.ParsedIngredientCandidate, bool) {
+	if len(candidates) == 0 {
+		return nil, false
+	}
+
+	best := candidates[0]
+	if best.Confidence < ingredientLineMatchThreshold {
+		return nil, false
+	}
+
+	if len(candidates) > 1 && best.Confidence-candidates[1].Confidence < ingredientLineMatchMargin {
+		return nil, false
+	}
+
+	return best, true
+}
+
+// unlistedUnitWord splits the lowercased first word, which may be a unit of the ingredient units table,
+// from the rest of the name of a line with an amount but without a known unit
+func unlistedUnitWord(p libingredient.Line) (string, string) {
+	if !p.HasAmount() || p.Unit != "" {
+		return "", ""
+	}
+
+	word, rest, ok := strings.Cut(p.Name, " ")
+	if !ok {
+		return "", ""
+	}
+
+	return strings.ToLower(word), strings.TrimSpace(rest)
+}
+
+// ingredientSimilarity scores a name against an ingredient and its aliases
+func ingredientSimilarity(name string, ingredient *entity.Ingredient) float64 {
+	score := libtext.Similarity(name, ingredient.Name)
+	for _, alias := range ingredient.Aliases {
+		if aliasScore := libtext.Similarity(name, alias); aliasScore > score {
+			score = aliasScore
+		}
+	}
+
+	return score
+}
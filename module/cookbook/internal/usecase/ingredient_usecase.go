@@ -2,70 +2,219 @@ package usecase
 
 //go:generate mockgen -destination=../repository/mock/ingredient_repo.go -source=ingredient_usecase.go -package=mock IngredientRepository
 //go:generate mockgen -destination=../repository/mock/ingredient_unit_repo.go -source=ingredient_usecase.go -package=mock IngredientUnitRepository
+//go:generate mockgen -destination=../repository/mock/ingredient_kind_repo.go -source=ingredient_usecase.go -package=mock IngredientKindRepository
 
 import (
 	"context"
 
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libtext"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 )
 
+// IngredientParams holds the ingredient fields, KindID and ParentID are left unchanged when nil and point to 0 to be cleared
 type IngredientParams struct {
-	Name  string
-	Actor string
+	Name           string
+	NormalizedName string
+	KindID         *uint64
+	ParentID       *uint64
+	BaseUnitName   string
+	Nutrition      entity.Nutrition
+	Allergens      []string
+	Diets          []string
+	PropagateName  bool
+	Actor          string
+}
+
+// ListIngredientsFilter holds the ingredient filters, KindID matches the ingredients of the kind and of its sub-kinds
+type ListIngredientsFilter struct {
+	KindID uint64
+}
+
+// IngredientKindParams holds the ingredient kind fields, ParentID is left unchanged when nil and points to 0 for a root kind
+type IngredientKindParams struct {
+	Name     string
+	ParentID *uint64
+	Aisle    string
+	Actor    string
 }
 
 type IngredientUnitParams struct {
-	Name  string
-	Actor string
+	Name         string
+	BaseUnitName string
+	BaseFactor   null.Float
+	Actor        string
+}
+
+type IngredientNutritionParams struct {
+	IngredientID uint64
+	BaseUnitName string
+	Nutrition    entity.Nutrition
+	Actor        string
+}
+
+type IngredientAliasParams struct {
+	IngredientID   uint64
+	Name           string
+	NormalizedName string
+	Actor          string
+}
+
+type IngredientMergeParams struct {
+	IngredientID uint64
+	DuplicateIDs []uint64
+	Actor        string
+}
+
+type IngredientUnitConversionParams struct {
+	IngredientID     uint64
+	IngredientUnitID uint64
+	BaseAmount       float64
+	Actor            string
 }
 
 // IngredientRepository defines contract for ingredient repository dependency
 type IngredientRepository interface {
 	Create(ctx context.Context, params IngredientParams) (*entity.Ingredient, error)
 	Update(ctx context.Context, id uint64, params IngredientParams) (*entity.Ingredient, error)
-	Delete(ctx context.Context, id uint64) error
-	List(ctx context.Context, limit, offset int) (entity.Ingredients, error)
+	Delete(ctx context.Context, id uint64, params DeleteParams) error
+	List(ctx context.Context, filter ListIngredientsFilter, limit, offset int) (entity.Ingredients, error)
+	ListByIDs(ctx context.Context, ids []uint64) (entity.Ingredients, error)
+	ListAllByIDs(ctx context.Context, ids []uint64) (entity.Ingredients, error)
+	ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error)
+	ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error)
+	ListByNormalizedNames(ctx context.Context, normalizedNames []string) (entity.Ingredients, error)
+	BulkUpdateNutrition(ctx context.Context, params []IngredientNutritionParams) (int64, error)
+	Merge(ctx context.Context, params IngredientMergeParams) error
+	ListAliases(ctx context.Context, ingredientID uint64) (entity.IngredientAliases, error)
+	CreateAlias(ctx context.Context, params IngredientAliasParams) (*entity.IngredientAlias, error)
+	DeleteAlias(ctx context.Context, id uint64) error
 }
 
 // IngredientUnitRepository defines contract for ingredient unit repository dependency
 type IngredientUnitRepository interface {
 	Create(ctx context.Context, params IngredientUnitParams) (*entity.IngredientUnit, error)
 	Update(ctx context.Context, id uint64, params IngredientUnitParams) (*entity.IngredientUnit, error)
-	Delete(ctx context.Context, id uint64) error
+	Delete(ctx context.Context, id uint64, params DeleteParams) error
+	Get(ctx context.Context, id uint64) (*entity.IngredientUnit, error)
 	List(ctx context.Context, limit, offset int) (entity.IngredientUnits, error)
+	ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error)
+	ListByNames(ctx context.Context, names []string) (entity.IngredientUnits, error)
+	ListConversions(ctx context.Context, ingredientIDs []uint64) (entity.IngredientUnitConversions, error)
+	UpsertConversion(ctx context.Context, params IngredientUnitConversionParams) (*entity.IngredientUnitConversion, error)
+	DeleteConversion(ctx context.Context, id uint64) error
+}
+
+// IngredientKindRepository defines contract for ingredient kind repository dependency
+type IngredientKindRepository interface {
+	Create(ctx context.Context, params IngredientKindParams) (*entity.IngredientKind, error)
+	Update(ctx context.Context, id uint64, params IngredientKindParams) (*entity.IngredientKind, error)
+	Delete(ctx context.Context, id uint64, actor string) error
+	Get(ctx context.Context, id uint64) (*entity.IngredientKind, error)
+	List(ctx context.Context, limit, offset int) (entity.IngredientKinds, error)
+	ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error)
+	CountReferences(ctx context.Context, id uint64) (int, error)
 }
 
 // IngredientUsecase is our ingredient usecase object
 type IngredientUsecase struct {
 	ingredientRepo     IngredientRepository
 	ingredientUnitRepo IngredientUnitRepository
+	ingredientKindRepo IngredientKindRepository
+	renamePolicy       RenamePolicy
 }
 
 // NewIngredientUsecase instantiates IngredientUsecase
-func NewIngredientUsecase(ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository) *IngredientUsecase {
+func NewIngredientUsecase(ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository, ingredientKindRepo IngredientKindRepository, renamePolicy RenamePolicy) *IngredientUsecase {
 	return &IngredientUsecase{
 		ingredientRepo:     ingredientRepo,
 		ingredientUnitRepo: ingredientUnitRepo,
+		ingredientKindRepo: ingredientKindRepo,
+		renamePolicy:       renamePolicy,
 	}
 }
 
 // CreateIngredient creates a new Ingredient
 func (u *IngredientUsecase) CreateIngredient(ctx context.Context, params IngredientParams) (*entity.Ingredient, error) {
+	params, err := normalizeIngredientLabels(params)
+	if err != nil {
+		return nil, err
+	}
+
+	params.NormalizedName = libtext.Normalize(params.Name)
+
+	err = u.checkNameAvailable(ctx, 0, params.NormalizedName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.validateClassification(ctx, 0, params)
+	if err != nil {
+		return nil, err
+	}
+
 	return u.ingredientRepo.Create(ctx, params)
 }
 
-// UpdateIngredient updates a Ingredient
+// UpdateIngredient updates a Ingredient, a new name is written to the recipes using it unless the rename policy is snapshot
 func (u *IngredientUsecase) UpdateIngredient(ctx context.Context, id uint64, params IngredientParams) (*entity.Ingredient, error) {
+	params, err := normalizeIngredientLabels(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Name != "" {
+		params.NormalizedName = libtext.Normalize(params.Name)
+		params.PropagateName = u.renamePolicy != RenamePolicySnapshot
+
+		err = u.checkNameAvailable(ctx, id, params.NormalizedName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.validateClassification(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
 	return u.ingredientRepo.Update(ctx, id, params)
 }
 
-// DeleteIngredient deletes a Ingredient
-func (u *IngredientUsecase) DeleteIngredient(ctx context.Context, id uint64) error {
-	return u.ingredientRepo.Delete(ctx, id)
+// DeleteIngredient deletes a Ingredient. An ingredient used by recipes is only deleted when its recipe ingredients are reassigned to another ingredient
+func (u *IngredientUsecase) DeleteIngredient(ctx context.Context, id uint64, params DeleteParams) error {
+	if params.ReassignTo == 0 {
+		recipes, err := u.ingredientRepo.ListReferencingRecipes(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(recipes) > 0 {
+			return entity.NewReferenceConflictError(entity.ErrIngredientInUse, recipes)
+		}
+
+		return u.ingredientRepo.Delete(ctx, id, params)
+	}
+
+	if params.ReassignTo == id {
+		return entity.ErrInvalidReassign
+	}
+
+	targets, err := u.ingredientRepo.ListByIDs(ctx, []uint64{params.ReassignTo})
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return entity.ErrInvalidReassign
+	}
+
+	return u.ingredientRepo.Delete(ctx, id, params)
 }
 
 // ListIngredients retrieves a list of Ingredients
-func (u *IngredientUsecase) ListIngredients(ctx context.Context, limit, offset int) (entity.Ingredients, error) {
+func (u *IngredientUsecase) ListIngredients(ctx context.Context, filter ListIngredientsFilter, limit, offset int) (entity.Ingredients, error) {
 	lim := defaultLimit
 	ofs := defaultOffset
 
@@ -77,7 +226,167 @@ func (u *IngredientUsecase) ListIngredients(ctx context.Context, limit, offset i
 		ofs = offset
 	}
 
-	return u.ingredientRepo.List(ctx, lim, ofs)
+	return u.ingredientRepo.List(ctx, filter, lim, ofs)
+}
+
+// ListIngredientAliases retrieves the aliases of an ingredient
+func (u *IngredientUsecase) ListIngredientAliases(ctx context.Context, ingredientID uint64) (entity.IngredientAliases, error) {
+	return u.ingredientRepo.ListAliases(ctx, ingredientID)
+}
+
+// CreateIngredientAlias adds an alias to a live ingredient, it must not be used by another ingredient name or alias
+func (u *IngredientUsecase) CreateIngredientAlias(ctx context.Context, params IngredientAliasParams) (*entity.IngredientAlias, error) {
+	ingredients, err := u.ingredientRepo.ListByIDs(ctx, []uint64{params.IngredientID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ingredients) == 0 {
+		return nil, entity.ErrIngredientNotFound
+	}
+
+	params.NormalizedName = libtext.Normalize(params.Name)
+
+	err = u.checkNameAvailable(ctx, 0, params.NormalizedName)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.ingredientRepo.CreateAlias(ctx, params)
+}
+
+// DeleteIngredientAlias deletes an ingredient alias
+func (u *IngredientUsecase) DeleteIngredientAlias(ctx context.Context, id uint64) error {
+	return u.ingredientRepo.DeleteAlias(ctx, id)
+}
+
+// MergeIngredients merges duplicates into an ingredient. Recipe ingredients, prices, substitutions and unit conversions
+// of the duplicates, as well as their pantry stock, are moved to the ingredient, their names become aliases and the
+// duplicates are deleted. Duplicates in another base unit are rejected, and so are the grandparents and further
+// ancestors of the ingredient
+func (u *IngredientUsecase) MergeIngredients(ctx context.Context, params IngredientMergeParams) (*entity.Ingredient, error) {
+	var duplicateIDs []uint64
+	for _, id := range params.DuplicateIDs {
+		if id == params.IngredientID {
+			return nil, entity.ErrInvalidIngredientMerge
+		}
+
+		duplicateIDs = appendUniqueID(duplicateIDs, id)
+	}
+
+	if len(duplicateIDs) == 0 {
+		return nil, entity.ErrInvalidIngredientMerge
+	}
+
+	params.DuplicateIDs = duplicateIDs
+
+	ingredients, err := u.ingredientRepo.ListByIDs(ctx, append([]uint64{params.IngredientID}, duplicateIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ingredients) != len(duplicateIDs)+1 {
+		return nil, entity.ErrIngredientNotFound
+	}
+
+	// conversions, nutrition and pantry stock are kept in the base unit, they cannot be moved to another one. The
+	// ingredients without a base unit take the one of the others, which must all be the same
+	var baseUnitName null.String
+	var parentID uint64
+	for _, ingredient := range ingredients {
+		if ingredient.ID == params.IngredientID {
+			parentID = ingredient.ParentID
+		}
+
+		if !ingredient.BaseUnitName.Valid {
+			continue
+		}
+
+		if baseUnitName.Valid && ingredient.BaseUnitName.String != baseUnitName.String {
+			return nil, entity.ErrIngredientMergeUnitMismatch
+		}
+
+		baseUnitName = ingredient.BaseUnitName
+	}
+
+	// the ingredient moves up from under a duplicate parent, but the children of a duplicate further up, which
+	// include one of its ancestors, would move under the ingredient and close a cycle
+	ancestorIDs, err := u.ingredientRepo.ListAncestorIDs(ctx, params.IngredientID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range duplicateIDs {
+		if id != parentID && containsID(ancestorIDs, id) {
+			return nil, entity.ErrIngredientCycle
+		}
+	}
+
+	err = u.ingredientRepo.Merge(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := u.ingredientRepo.ListByIDs(ctx, []uint64{params.IngredientID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(merged) == 0 {
+		return nil, entity.ErrIngredientNotFound
+	}
+
+	return merged[0], nil
+}
+
+// CreateIngredientKind creates a new ingredient kind
+func (u *IngredientUsecase) CreateIngredientKind(ctx context.Context, params IngredientKindParams) (*entity.IngredientKind, error) {
+	err := u.validateKindParent(ctx, 0, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.ingredientKindRepo.Create(ctx, params)
+}
+
+// UpdateIngredientKind updates an ingredient kind, a kind cannot be moved under itself or one of its sub-kinds
+func (u *IngredientUsecase) UpdateIngredientKind(ctx context.Context, id uint64, params IngredientKindParams) (*entity.IngredientKind, error) {
+	err := u.validateKindParent(ctx, id, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.ingredientKindRepo.Update(ctx, id, params)
+}
+
+// DeleteIngredientKind deletes an ingredient kind that has no ingredients and no sub-kinds
+func (u *IngredientUsecase) DeleteIngredientKind(ctx context.Context, id uint64, actor string) error {
+	count, err := u.ingredientKindRepo.CountReferences(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return entity.ErrIngredientKindInUse
+	}
+
+	return u.ingredientKindRepo.Delete(ctx, id, actor)
+}
+
+// ListIngredientKinds retrieves a list of ingredient kinds
+func (u *IngredientUsecase) ListIngredientKinds(ctx context.Context, limit, offset int) (entity.IngredientKinds, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.ingredientKindRepo.List(ctx, lim, ofs)
 }
 
 // CreateIngredientUnit creates a new Ingredient
@@ -90,9 +399,35 @@ func (u *IngredientUsecase) UpdateIngredientUnit(ctx context.Context, id uint64,
 	return u.ingredientUnitRepo.Update(ctx, id, params)
 }
 
-// DeleteIngredientUnit deletes a Ingredient
-func (u *IngredientUsecase) DeleteIngredientUnit(ctx context.Context, id uint64) error {
-	return u.ingredientUnitRepo.Delete(ctx, id)
+// DeleteIngredientUnit deletes a Ingredient. A unit used by recipes is only deleted when its recipe ingredients and yields are reassigned to another unit
+func (u *IngredientUsecase) DeleteIngredientUnit(ctx context.Context, id uint64, params DeleteParams) error {
+	if params.ReassignTo == 0 {
+		recipes, err := u.ingredientUnitRepo.ListReferencingRecipes(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(recipes) > 0 {
+			return entity.NewReferenceConflictError(entity.ErrIngredientUnitInUse, recipes)
+		}
+
+		return u.ingredientUnitRepo.Delete(ctx, id, params)
+	}
+
+	if params.ReassignTo == id {
+		return entity.ErrInvalidReassign
+	}
+
+	_, err := u.ingredientUnitRepo.Get(ctx, params.ReassignTo)
+	if err == entity.ErrIngredientUnitNotFound {
+		return entity.ErrInvalidReassign
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return u.ingredientUnitRepo.Delete(ctx, id, params)
 }
 
 // ListIngredientUnits retrieves a list of Ingredients
@@ -110,3 +445,129 @@ func (u *IngredientUsecase) ListIngredientUnits(ctx context.Context, limit, offs
 
 	return u.ingredientUnitRepo.List(ctx, lim, ofs)
 }
+
+// ListIngredientUnitConversions retrieves unit conversions of an ingredient
+func (u *IngredientUsecase) ListIngredientUnitConversions(ctx context.Context, ingredientID uint64) (entity.IngredientUnitConversions, error) {
+	return u.ingredientUnitRepo.ListConversions(ctx, []uint64{ingredientID})
+}
+
+// UpsertIngredientUnitConversion creates or replaces the conversion of an ingredient unit
+func (u *IngredientUsecase) UpsertIngredientUnitConversion(ctx context.Context, params IngredientUnitConversionParams) (*entity.IngredientUnitConversion, error) {
+	return u.ingredientUnitRepo.UpsertConversion(ctx, params)
+}
+
+// DeleteIngredientUnitConversion deletes an ingredient unit conversion
+func (u *IngredientUsecase) DeleteIngredientUnitConversion(ctx context.Context, id uint64) error {
+	return u.ingredientUnitRepo.DeleteConversion(ctx, id)
+}
+
+// checkNameAvailable makes sure a normalized name is not used by the name or an alias of another ingredient
+func (u *IngredientUsecase) checkNameAvailable(ctx context.Context, id uint64, normalizedName string) error {
+	if normalizedName == "" {
+		return nil
+	}
+
+	ingredients, err := u.ingredientRepo.ListByNormalizedNames(ctx, []string{normalizedName})
+	if err != nil {
+		return err
+	}
+
+	for _, ingredient := range ingredients {
+		if ingredient.ID != id {
+			return entity.ErrIngredientNameConflict
+		}
+	}
+
+	return nil
+}
+
+// normalizeIngredientLabels validates and normalizes allergens and diets of ingredient params
+func normalizeIngredientLabels(params IngredientParams) (IngredientParams, error) {
+	var err error
+
+	params.Allergens, err = normalizeAllergens(params.Allergens)
+	if err != nil {
+		return params, err
+	}
+
+	params.Diets, err = normalizeDiets(params.Diets)
+	if err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// validateClassification checks that the kind of an ingredient exists and that its parent exists
+// and is neither the ingredient nor one of its descendants
+func (u *IngredientUsecase) validateClassification(ctx context.Context, id uint64, params IngredientParams) error {
+	if params.KindID != nil && *params.KindID != 0 {
+		_, err := u.ingredientKindRepo.Get(ctx, *params.KindID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if params.ParentID == nil || *params.ParentID == 0 {
+		return nil
+	}
+
+	if *params.ParentID == id {
+		return entity.ErrIngredientCycle
+	}
+
+	parents, err := u.ingredientRepo.ListByIDs(ctx, []uint64{*params.ParentID})
+	if err != nil {
+		return err
+	}
+
+	if len(parents) == 0 {
+		return entity.ErrIngredientNotFound
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	ancestorIDs, err := u.ingredientRepo.ListAncestorIDs(ctx, *params.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if containsID(ancestorIDs, id) {
+		return entity.ErrIngredientCycle
+	}
+
+	return nil
+}
+
+// validateKindParent checks that the parent of a kind exists and is not the kind or one of its sub-kinds
+func (u *IngredientUsecase) validateKindParent(ctx context.Context, id uint64, parentID *uint64) error {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+
+	if *parentID == id {
+		return entity.ErrIngredientKindCycle
+	}
+
+	_, err := u.ingredientKindRepo.Get(ctx, *parentID)
+	if err != nil {
+		return err
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	ancestorIDs, err := u.ingredientKindRepo.ListAncestorIDs(ctx, *parentID)
+	if err != nil {
+		return err
+	}
+
+	if containsID(ancestorIDs, id) {
+		return entity.ErrIngredientKindCycle
+	}
+
+	return nil
+}
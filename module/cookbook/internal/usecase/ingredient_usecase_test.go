@@ -1,11 +1,14 @@
 package usecase_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 )
@@ -15,8 +18,177 @@ func TestNewIngredientUsecase(t *testing.T) {
 
 	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
 	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
 
-	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo)
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
 
 	assert.NotEmpty(t, uc)
 }
+
+func TestIngredientUsecase_CreateIngredient_NameConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	ingredientRepo.EXPECT().ListByNormalizedNames(gomock.Any(), []string{"bawang merah"}).Return(entity.Ingredients{
+		{ID: 4, Name: "Bawang merah"},
+	}, nil)
+
+	_, err := uc.CreateIngredient(context.Background(), usecase.IngredientParams{Name: " BAWANG  Merah "})
+
+	assert.Equal(t, entity.ErrIngredientNameConflict, err)
+}
+
+func TestIngredientUsecase_CreateIngredientAlias_IngredientNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	// a deleted ingredient is not listed, so it cannot get aliases either
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{6}).Return(entity.Ingredients{}, nil)
+
+	_, err := uc.CreateIngredientAlias(context.Background(), usecase.IngredientAliasParams{IngredientID: 6, Name: "Telor"})
+
+	assert.Equal(t, entity.ErrIngredientNotFound, err)
+}
+
+func TestIngredientUsecase_MergeIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	_, err := uc.MergeIngredients(context.Background(), usecase.IngredientMergeParams{IngredientID: 2, DuplicateIDs: []uint64{2}})
+	assert.Equal(t, entity.ErrInvalidIngredientMerge, err)
+
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{2, 6, 9}).Return(entity.Ingredients{
+		{ID: 2, Name: "Telur"},
+		{ID: 6, Name: "Telor"},
+		{ID: 9, Name: "telur"},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAncestorIDs(gomock.Any(), uint64(2)).Return([]uint64{}, nil)
+
+	ingredientRepo.EXPECT().Merge(gomock.Any(), usecase.IngredientMergeParams{IngredientID: 2, DuplicateIDs: []uint64{6, 9}, Actor: "Naufal"}).Return(nil)
+
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{2}).Return(entity.Ingredients{
+		{ID: 2, Name: "Telur", Aliases: []string{"Telor"}},
+	}, nil)
+
+	merged, err := uc.MergeIngredients(context.Background(), usecase.IngredientMergeParams{IngredientID: 2, DuplicateIDs: []uint64{6, 9, 6}, Actor: "Naufal"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Telor"}, merged.Aliases)
+}
+
+func TestIngredientUsecase_MergeIngredients_BaseUnitMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	// the stock of a duplicate kept in ml cannot be added to grams
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{1, 3}).Return(entity.Ingredients{
+		{ID: 1, Name: "Santan", BaseUnitName: null.StringFrom("g")},
+		{ID: 3, Name: "Santan kental", BaseUnitName: null.StringFrom("ml")},
+	}, nil)
+
+	_, err := uc.MergeIngredients(context.Background(), usecase.IngredientMergeParams{IngredientID: 1, DuplicateIDs: []uint64{3}})
+
+	assert.Equal(t, entity.ErrIngredientMergeUnitMismatch, err)
+}
+
+func TestIngredientUsecase_MergeIngredients_BaseUnitMismatchWithoutSurvivorUnit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	// the surviving ingredient has no base unit, the stock of the duplicates in g and ml still cannot be added up
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{1, 3, 4}).Return(entity.Ingredients{
+		{ID: 1, Name: "Santan"},
+		{ID: 3, Name: "Santan kental", BaseUnitName: null.StringFrom("g")},
+		{ID: 4, Name: "Santan encer", BaseUnitName: null.StringFrom("ml")},
+	}, nil)
+
+	_, err := uc.MergeIngredients(context.Background(), usecase.IngredientMergeParams{IngredientID: 1, DuplicateIDs: []uint64{3, 4}})
+
+	assert.Equal(t, entity.ErrIngredientMergeUnitMismatch, err)
+}
+
+func TestIngredientUsecase_MergeIngredients_GrandparentDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	// cabai rawit is under cabai, itself under rempah: merging rempah would move cabai under cabai rawit
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{5, 1}).Return(entity.Ingredients{
+		{ID: 5, Name: "Cabai rawit", ParentID: 3},
+		{ID: 1, Name: "Rempah"},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAncestorIDs(gomock.Any(), uint64(5)).Return([]uint64{3, 1}, nil)
+
+	_, err := uc.MergeIngredients(context.Background(), usecase.IngredientMergeParams{IngredientID: 5, DuplicateIDs: []uint64{1}})
+
+	assert.Equal(t, entity.ErrIngredientCycle, err)
+}
+
+func TestIngredientUsecase_UpdateIngredient_ParentCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	parentID := uint64(7)
+
+	ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{7}).Return(entity.Ingredients{
+		{ID: 7, Name: "Cabai rawit"},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAncestorIDs(gomock.Any(), uint64(7)).Return([]uint64{7, 3}, nil)
+
+	_, err := uc.UpdateIngredient(context.Background(), 3, usecase.IngredientParams{ParentID: &parentID})
+
+	assert.Equal(t, entity.ErrIngredientCycle, err)
+}
+
+func TestIngredientUsecase_DeleteIngredientKind_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+	ingredientKindRepo := mock.NewMockIngredientKindRepository(ctrl)
+
+	uc := usecase.NewIngredientUsecase(ingredientRepo, ingredientUnitRepo, ingredientKindRepo, usecase.RenamePolicyPropagate)
+
+	ingredientKindRepo.EXPECT().CountReferences(gomock.Any(), uint64(2)).Return(3, nil)
+
+	err := uc.DeleteIngredientKind(context.Background(), 2, "Naufal")
+
+	assert.Equal(t, entity.ErrIngredientKindInUse, err)
+}
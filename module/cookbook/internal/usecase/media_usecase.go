@@ -0,0 +1,227 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/recipe_image_repo.go -source=media_usecase.go -package=mock RecipeImageRepository
+//go:generate mockgen -destination=../repository/mock/media_storage.go -source=media_usecase.go -package=mock MediaStorage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libimage"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+const thumbnailMaxSide = 320
+
+// UploadRecipeImageParams holds an uploaded image, Step is null for a photo of the whole recipe
+type UploadRecipeImageParams struct {
+	RecipeID uint64
+	Step     null.Int
+	Caption  string
+	Actor    string
+	Content  io.Reader
+}
+
+// RecipeImageParams holds a stored recipe image to be recorded
+type RecipeImageParams struct {
+	RecipeID     uint64
+	Step         null.Int
+	Caption      null.String
+	FileKey      string
+	ThumbnailKey string
+	ContentType  string
+	SizeBytes    int64
+	Width        int
+	Height       int
+	Actor        string
+}
+
+// RecipeImageRepository defines contract for recipe image repository dependency
+type RecipeImageRepository interface {
+	Create(ctx context.Context, params RecipeImageParams) (*entity.RecipeImage, error)
+	Get(ctx context.Context, id uint64) (*entity.RecipeImage, error)
+	ListByRecipeID(ctx context.Context, recipeID uint64) (entity.RecipeImages, error)
+	Delete(ctx context.Context, id uint64) error
+}
+
+// MediaStorage defines contract for the storage of media files, keys are slash separated relative paths
+type MediaStorage interface {
+	Save(ctx context.Context, key string, content []byte) error
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, entity.MediaFile, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// MediaUsecase is our media usecase object
+type MediaUsecase struct {
+	recipeImageRepo RecipeImageRepository
+	mediaStorage    MediaStorage
+	maxBytes        int64
+}
+
+// NewMediaUsecase instantiates MediaUsecase, uploads larger than maxBytes are rejected
+func NewMediaUsecase(recipeImageRepo RecipeImageRepository, mediaStorage MediaStorage, maxBytes int64) *MediaUsecase {
+	return &MediaUsecase{
+		recipeImageRepo: recipeImageRepo,
+		mediaStorage:    mediaStorage,
+		maxBytes:        maxBytes,
+	}
+}
+
+// UploadRecipeImage stores an image of a recipe or of one of its steps along with a thumbnail
+func (u *MediaUsecase) UploadRecipeImage(ctx context.Context, params UploadRecipeImageParams) (*entity.RecipeImage, error) {
+	if params.Step.Valid && params.Step.Int64 <= 0 {
+		return nil, entity.ErrInvalidRecipeStep
+	}
+
+	stored, err := storeImage(ctx, u.mediaStorage, u.maxBytes, fmt.Sprintf("recipes/%d", params.RecipeID), params.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	imageParams := RecipeImageParams{
+		RecipeID:     params.RecipeID,
+		Step:         params.Step,
+		Caption:      null.NewString(params.Caption, params.Caption != ""),
+		FileKey:      stored.FileKey,
+		ThumbnailKey: stored.ThumbnailKey,
+		ContentType:  stored.ContentType,
+		SizeBytes:    stored.SizeBytes,
+		Width:        stored.Width,
+		Height:       stored.Height,
+		Actor:        params.Actor,
+	}
+
+	image, err := u.recipeImageRepo.Create(ctx, imageParams)
+	if err != nil {
+		_ = u.mediaStorage.Delete(ctx, imageParams.FileKey)
+		_ = u.mediaStorage.Delete(ctx, imageParams.ThumbnailKey)
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// ListRecipeImages retrieves the images of a recipe, the recipe photos first and then the step photos by step
+func (u *MediaUsecase) ListRecipeImages(ctx context.Context, recipeID uint64) (entity.RecipeImages, error) {
+	return u.recipeImageRepo.ListByRecipeID(ctx, recipeID)
+}
+
+// DeleteRecipeImage deletes a recipe image and its files
+func (u *MediaUsecase) DeleteRecipeImage(ctx context.Context, id uint64) error {
+	image, err := u.recipeImageRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = u.recipeImageRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return deleteMediaFiles(ctx, u.mediaStorage, []string{image.FileKey, image.ThumbnailKey})
+}
+
+// MaxUploadBytes returns the upload size limit
+func (u *MediaUsecase) MaxUploadBytes() int64 {
+	return u.maxBytes
+}
+
+// OpenMedia opens a stored media file, the caller closes it
+func (u *MediaUsecase) OpenMedia(ctx context.Context, key string) (io.ReadSeekCloser, entity.MediaFile, error) {
+	return u.mediaStorage.Open(ctx, key)
+}
+
+// storedImage is an uploaded image saved in the media storage along with its thumbnail
+type storedImage struct {
+	FileKey      string
+	ThumbnailKey string
+	ContentType  string
+	SizeBytes    int64
+	Width        int
+	Height       int
+}
+
+// storeImage validates an uploaded image and saves it with a thumbnail under dir, a slash separated path.
+// The content type is sniffed from the content itself, whatever the client claims
+func storeImage(ctx context.Context, mediaStorage MediaStorage, maxBytes int64, dir string, r io.Reader) (storedImage, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return storedImage{}, err
+	}
+
+	if int64(len(content)) > maxBytes {
+		return storedImage{}, entity.ErrMediaTooLarge
+	}
+
+	contentType, ok := libimage.Sniff(content)
+	if !ok {
+		return storedImage{}, entity.ErrUnsupportedMediaType
+	}
+
+	img, err := libimage.Decode(content)
+	if err != nil {
+		return storedImage{}, entity.ErrUnsupportedMediaType
+	}
+
+	thumbnail, thumbnailType, err := libimage.Encode(libimage.Thumbnail(img, thumbnailMaxSide), contentType)
+	if err != nil {
+		return storedImage{}, err
+	}
+
+	name, err := newMediaName()
+	if err != nil {
+		return storedImage{}, err
+	}
+
+	base := dir + "/" + name
+	res := storedImage{
+		FileKey:      base + libimage.Extension(contentType),
+		ThumbnailKey: base + "_thumb" + libimage.Extension(thumbnailType),
+		ContentType:  contentType,
+		SizeBytes:    int64(len(content)),
+		Width:        img.Bounds().Dx(),
+		Height:       img.Bounds().Dy(),
+	}
+
+	err = mediaStorage.Save(ctx, res.FileKey, content)
+	if err != nil {
+		return storedImage{}, err
+	}
+
+	err = mediaStorage.Save(ctx, res.ThumbnailKey, thumbnail)
+	if err != nil {
+		_ = mediaStorage.Delete(ctx, res.FileKey)
+		return storedImage{}, err
+	}
+
+	return res, nil
+}
+
+// deleteMediaFiles deletes stored files, files already gone are skipped
+func deleteMediaFiles(ctx context.Context, mediaStorage MediaStorage, keys []string) error {
+	for _, key := range keys {
+		err := mediaStorage.Delete(ctx, key)
+		if err != nil && err != entity.ErrMediaNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newMediaName returns a random file name, so that a stored file never changes and can be cached forever
+func newMediaName() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
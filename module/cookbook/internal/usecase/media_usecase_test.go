@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewMediaUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeImageRepo := mock.NewMockRecipeImageRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+
+	uc := usecase.NewMediaUsecase(recipeImageRepo, mediaStorage, 1<<20)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestMediaUsecase_UploadRecipeImage_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeImageRepo := mock.NewMockRecipeImageRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+
+	uc := usecase.NewMediaUsecase(recipeImageRepo, mediaStorage, 64)
+
+	_, err := uc.UploadRecipeImage(context.Background(), usecase.UploadRecipeImageParams{
+		RecipeID: 7, Step: null.IntFrom(0), Content: bytes.NewReader(nil),
+	})
+	assert.Equal(t, entity.ErrInvalidRecipeStep, err)
+
+	_, err = uc.UploadRecipeImage(context.Background(), usecase.UploadRecipeImageParams{
+		RecipeID: 7, Content: bytes.NewReader(bytes.Repeat([]byte{0}, 65)),
+	})
+	assert.Equal(t, entity.ErrMediaTooLarge, err)
+
+	_, err = uc.UploadRecipeImage(context.Background(), usecase.UploadRecipeImageParams{
+		RecipeID: 7, Content: bytes.NewReader([]byte("<html><body>bukan gambar</body></html>")),
+	})
+	assert.Equal(t, entity.ErrUnsupportedMediaType, err)
+}
+
+func TestMediaUsecase_UploadRecipeImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeImageRepo := mock.NewMockRecipeImageRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+
+	uc := usecase.NewMediaUsecase(recipeImageRepo, mediaStorage, 1<<20)
+
+	var content bytes.Buffer
+	assert.NoError(t, png.Encode(&content, image.NewNRGBA(image.Rect(0, 0, 640, 480))))
+
+	mediaStorage.EXPECT().Save(gomock.Any(), gomock.Any(), content.Bytes()).Return(nil)
+	mediaStorage.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	var recorded usecase.RecipeImageParams
+	recipeImageRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, params usecase.RecipeImageParams) (*entity.RecipeImage, error) {
+		recorded = params
+		return &entity.RecipeImage{ID: 1, RecipeID: params.RecipeID, Step: params.Step}, nil
+	})
+
+	img, err := uc.UploadRecipeImage(context.Background(), usecase.UploadRecipeImageParams{
+		RecipeID: 7, Step: null.IntFrom(2), Caption: "Tumis bumbu", Actor: "Naufal", Content: &content,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), img.ID)
+	assert.Equal(t, "image/png", recorded.ContentType)
+	assert.Equal(t, 640, recorded.Width)
+	assert.Regexp(t, `^recipes/7/[0-9a-f]{32}\.png$`, recorded.FileKey)
+	assert.Regexp(t, `^recipes/7/[0-9a-f]{32}_thumb\.png$`, recorded.ThumbnailKey)
+	assert.Equal(t, null.StringFrom("Tumis bumbu"), recorded.Caption)
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+const (
+	// nutritionBasis is the amount of base units the ingredient nutrition values refer to
+	nutritionBasis = 100
+
+	// nutritionMatchThreshold is the minimum similarity to accept a food composition entry automatically
+	nutritionMatchThreshold = 0.9
+	// nutritionReviewThreshold is the minimum similarity for an ingredient to be proposed for review
+	nutritionReviewThreshold = 0.7
+	// nutritionMatchMargin is the minimum similarity gap between the best and the second best candidate
+	nutritionMatchMargin   = 0.05
+	nutritionMaxCandidates = 3
+)
+
+// FoodCompositionEntry is an entry of a food composition dataset with nutrition values per 100 base units
+type FoodCompositionEntry struct {
+	Name         string
+	BaseUnitName string
+	Nutrition    entity.Nutrition
+}
+
+// NutritionUsecase is our nutrition usecase object
+type NutritionUsecase struct {
+	recipeRepo         RecipeRepository
+	ingredientRepo     IngredientRepository
+	ingredientUnitRepo IngredientUnitRepository
+	reviewers          reviewerSet
+}
+
+// NewNutritionUsecase instantiates NutritionUsecase, reviewers can see the recipes that are not published yet
+func NewNutritionUsecase(recipeRepo RecipeRepository, ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository, reviewers []string) *NutritionUsecase {
+	return &NutritionUsecase{
+		recipeRepo:         recipeRepo,
+		ingredientRepo:     ingredientRepo,
+		ingredientUnitRepo: ingredientUnitRepo,
+		reviewers:          newReviewerSet(reviewers),
+	}
+}
+
+// GetRecipeNutrition computes nutrition totals and per serving values of a recipe the viewer can see
+func (u *NutritionUsecase) GetRecipeNutrition(ctx context.Context, recipeID uint64, viewer string) (entity.RecipeNutrition, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, recipeID)
+	if err != nil {
+		return entity.RecipeNutrition{}, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, viewer) {
+		return entity.RecipeNutrition{}, entity.ErrRecipeNotFound
+	}
+
+	flattened, err := flattenRecipeIngredients(ctx, u.recipeRepo, summary, 1, map[uint64]bool{summary.ID: true})
+	if err != nil {
+		return entity.RecipeNutrition{}, err
+	}
+
+	recipeIngredients := mergeRecipeIngredients(flattened)
+
+	ingredientByID, converter, err := loadIngredientsWithConverter(ctx, u.ingredientRepo, u.ingredientUnitRepo, recipeIngredients)
+	if err != nil {
+		return entity.RecipeNutrition{}, err
+	}
+
+	res := entity.RecipeNutrition{
+		RecipeID:   summary.ID,
+		Servings:   summary.Servings,
+		IsComplete: true,
+	}
+
+	for _, ri := range recipeIngredients {
+		nutrition := ingredientNutrition(ri, ingredientByID[ri.IngredientID], converter)
+		if nutrition.Status != entity.NutritionStatusOK && nutrition.Status != entity.NutritionStatusToTaste {
+			res.IsComplete = false
+		}
+
+		res.Total = res.Total.Add(nutrition.Facts)
+		res.Ingredients = append(res.Ingredients, nutrition)
+	}
+
+	servings := summary.Servings
+	if servings <= 0 {
+		servings = defaultServings
+	}
+
+	res.PerServing = res.Total.Scale(1 / float64(servings))
+
+	return res, nil
+}
+
+// ingredientNutrition computes the nutrition contribution of a recipe ingredient and flags untrusted values
+func ingredientNutrition(ri *entity.RecipeIngredient, ingredient *entity.Ingredient, converter *unitConverter) *entity.RecipeIngredientNutrition {
+	res := &entity.RecipeIngredientNutrition{
+		IngredientID:       ri.IngredientID,
+		IngredientName:     ri.IngredientName,
+		IngredientUnitName: ri.IngredientUnitName,
+		Amount:             ri.Amount,
+	}
+
+	if ri.IsToTaste() {
+		res.Status = entity.NutritionStatusToTaste
+		return res
+	}
+
+	if ingredient == nil || ingredient.Nutrition.IsEmpty() {
+		res.Status = entity.NutritionStatusMissingNutrition
+		return res
+	}
+
+	baseAmount, ok := converter.toBaseAmount(ingredient, ri.IngredientUnitName, ri.Amount.Float64)
+	if !ok {
+		res.Status = entity.NutritionStatusUnconvertibleUnit
+		return res
+	}
+
+	res.BaseAmount = null.FloatFrom(baseAmount)
+	res.BaseUnitName = ingredient.BaseUnitName
+
+	n := ingredient.Nutrition
+	res.Facts = entity.NutritionFacts{
+		Kcal:    n.Kcal.Float64,
+		Protein: n.Protein.Float64,
+		Fat:     n.Fat.Float64,
+		Carbs:   n.Carbs.Float64,
+		Fiber:   n.Fiber.Float64,
+		Sodium:  n.Sodium.Float64,
+	}.Scale(baseAmount / nutritionBasis)
+
+	res.Status = entity.NutritionStatusOK
+	if !n.IsComplete() {
+		res.Status = entity.NutritionStatusPartialNutrition
+	}
+
+	return res
+}
+
+// MatchNutritionEntries fuzzy matches food composition entries to existing ingredients
+func (u *NutritionUsecase) MatchNutritionEntries(ctx context.Context, entries []FoodCompositionEntry) (entity.NutritionImportMatches, error) {
+	ingredients, err := listAllIngredients(ctx, u.ingredientRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	var res entity.NutritionImportMatches
+	matchedBy := map[uint64]*entity.NutritionImportMatch{}
+
+	for _, entry := range entries {
+		match := &entity.NutritionImportMatch{
+			EntryName:    entry.Name,
+			BaseUnitName: entry.BaseUnitName,
+			Nutrition:    entry.Nutrition,
+			Status:       entity.NutritionImportStatusUnmatched,
+		}
+
+		for _, ingredient := range ingredients {
+			score := ingredientSimilarity(entry.Name, ingredient)
+
+			if score >= nutritionReviewThreshold {
+				match.Candidates = append(match.Candidates, entity.NutritionImportCandidate{
+					IngredientID:   ingredient.ID,
+					IngredientName: ingredient.Name,
+					Score:          score,
+				})
+			}
+		}
+
+		sort.SliceStable(match.Candidates, func(i, j int) bool {
+			return match.Candidates[i].Score > match.Candidates[j].Score
+		})
+
+		if len(match.Candidates) > nutritionMaxCandidates {
+			match.Candidates = match.Candidates[:nutritionMaxCandidates]
+		}
+
+		if len(match.Candidates) > 0 {
+			match.Status = nutritionMatchStatus(match.Candidates)
+		}
+
+		// two entries cannot both be applied to the same ingredient without a review
+		if match.Status == entity.NutritionImportStatusMatched {
+			best := match.Candidates[0]
+			if other, ok := matchedBy[best.IngredientID]; ok {
+				other.Status = entity.NutritionImportStatusAmbiguous
+				match.Status = entity.NutritionImportStatusAmbiguous
+			} else {
+				matchedBy[best.IngredientID] = match
+			}
+		}
+
+		res = append(res, match)
+	}
+
+	return res, nil
+}
+
+// ApplyNutritionEntries stores the accepted nutrition mappings in a single transaction and returns how many
+// ingredients were updated
+func (u *NutritionUsecase) ApplyNutritionEntries(ctx context.Context, params []IngredientNutritionParams) (int64, error) {
+	if len(params) == 0 {
+		return 0, nil
+	}
+
+	return u.ingredientRepo.BulkUpdateNutrition(ctx, params)
+}
+
+// listAllIngredients pages through every ingredient
+func listAllIngredients(ctx context.Context, ingredientRepo IngredientRepository) (entity.Ingredients, error) {
+	var res entity.Ingredients
+
+	for offset := 0; ; offset += listAllPageSize {
+		ingredients, err := ingredientRepo.List(ctx, ListIngredientsFilter{}, listAllPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, ingredients...)
+
+		if len(ingredients) < listAllPageSize {
+			return res, nil
+		}
+	}
+}
+
+// nutritionMatchStatus decides whether the best candidate is clear enough to be accepted without review
+func nutritionMatchStatus(candidates []entity.NutritionImportCandidate) string {
+	best := candidates[0]
+	if best.Score < nutritionMatchThreshold {
+		return entity.NutritionImportStatusAmbiguous
+	}
+
+	if len(candidates) > 1 && best.Score-candidates[1].Score < nutritionMatchMargin {
+		return entity.NutritionImportStatusAmbiguous
+	}
+
+	return entity.NutritionImportStatusMatched
+}
@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewNutritionUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewNutritionUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestNutritionUsecase_GetRecipeNutrition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewNutritionUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Goreng", Servings: 2},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 1, IngredientName: "Nasi", IngredientUnitName: "kg", Amount: null.FloatFrom(0.5)},
+			{ID: 11, IngredientID: 5, IngredientName: "Bawang putih", IngredientUnitName: "siung", Amount: null.FloatFrom(2)},
+			{ID: 12, IngredientID: 8, IngredientName: "Kecap", IngredientUnitName: "sdm", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{1, 5, 8}).Return(entity.Ingredients{
+		{ID: 1, Name: "Nasi", BaseUnitName: null.StringFrom("g"), Nutrition: entity.Nutrition{
+			Kcal: null.FloatFrom(130), Protein: null.FloatFrom(2.7), Fat: null.FloatFrom(0.3),
+			Carbs: null.FloatFrom(28), Fiber: null.FloatFrom(0.4), Sodium: null.FloatFrom(1),
+		}},
+		{ID: 5, Name: "Bawang putih", BaseUnitName: null.StringFrom("g"), Nutrition: entity.Nutrition{
+			Kcal: null.FloatFrom(149), Protein: null.FloatFrom(6.4), Fat: null.FloatFrom(0.5),
+			Carbs: null.FloatFrom(33), Fiber: null.FloatFrom(2.1), Sodium: null.FloatFrom(17),
+		}},
+		{ID: 8, Name: "Kecap"},
+	}, nil)
+
+	ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), gomock.Any()).Return(entity.IngredientUnits{
+		{ID: 6, Name: "kg", BaseUnitName: null.StringFrom("g"), BaseFactor: null.FloatFrom(1000)},
+		{ID: 3, Name: "siung"},
+	}, nil)
+
+	ingredientUnitRepo.EXPECT().ListConversions(gomock.Any(), []uint64{1, 5, 8}).Return(entity.IngredientUnitConversions{
+		{IngredientID: 5, IngredientUnitID: 3, IngredientUnitName: "siung", BaseAmount: 5},
+	}, nil)
+
+	nutrition, err := uc.GetRecipeNutrition(context.Background(), 1, "")
+
+	assert.NoError(t, err)
+	assert.False(t, nutrition.IsComplete)
+	assert.Equal(t, entity.NutritionStatusOK, nutrition.Ingredients[0].Status)
+	assert.Equal(t, entity.NutritionStatusOK, nutrition.Ingredients[1].Status)
+	assert.Equal(t, entity.NutritionStatusMissingNutrition, nutrition.Ingredients[2].Status)
+	assert.InDelta(t, 664.9, nutrition.Total.Kcal, 0.001)
+	assert.InDelta(t, 332.45, nutrition.PerServing.Kcal, 0.001)
+}
+
+func TestNutritionUsecase_GetRecipeNutrition_HidesDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewNutritionUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, []string{"Sari"})
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Goreng", Status: entity.RecipeStatusDraft, CreatedBy: "Budi"},
+	}, nil)
+
+	_, err := uc.GetRecipeNutrition(context.Background(), 1, "Andi")
+
+	assert.ErrorIs(t, err, entity.ErrRecipeNotFound)
+}
+
+func TestNutritionUsecase_MatchNutritionEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewNutritionUsecase(recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	ingredientRepo.EXPECT().List(gomock.Any(), usecase.ListIngredientsFilter{}, gomock.Any(), 0).Return(entity.Ingredients{
+		{ID: 2, Name: "Telor"},
+		{ID: 4, Name: "Bawang merah"},
+		{ID: 5, Name: "Bawang putih"},
+	}, nil)
+
+	matches, err := uc.MatchNutritionEntries(context.Background(), []usecase.FoodCompositionEntry{
+		{Name: "Bawang putih, segar"},
+		{Name: "Telur ayam"},
+		{Name: "Durian"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NutritionImportStatusMatched, matches[0].Status)
+	assert.Equal(t, uint64(5), matches[0].Candidates[0].IngredientID)
+	assert.Equal(t, entity.NutritionImportStatusAmbiguous, matches[1].Status)
+	assert.Equal(t, entity.NutritionImportStatusUnmatched, matches[2].Status)
+}
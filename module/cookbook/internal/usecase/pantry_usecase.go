@@ -0,0 +1,337 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/pantry_repo.go -source=pantry_usecase.go -package=mock PantryRepository
+
+import (
+	"context"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type ListPantryItemsFilter struct {
+	LowStockOnly bool
+}
+
+// PantryMovementParams holds a stock movement of an ingredient. Amount is written in UnitName
+// and BaseAmount is the signed change of the quantity in the base unit of the ingredient
+type PantryMovementParams struct {
+	IngredientID uint64
+	Kind         string
+	Amount       float64
+	UnitName     string
+	BaseAmount   float64
+	RecipeID     uint64
+	Notes        string
+	Actor        string
+}
+
+// PantryThresholdParams holds the low-stock threshold of an ingredient in its base unit, a null threshold turns the alert off
+type PantryThresholdParams struct {
+	IngredientID uint64
+	Threshold    null.Float
+	Actor        string
+}
+
+// CookRecipeParams holds a recipe cooked from the pantry, Multiplier scales the whole recipe
+type CookRecipeParams struct {
+	RecipeID   uint64
+	Multiplier float64
+	Actor      string
+}
+
+// PantryRepository defines contract for pantry repository dependency
+type PantryRepository interface {
+	ListItems(ctx context.Context, filter ListPantryItemsFilter, limit, offset int) (entity.PantryItems, error)
+	GetItems(ctx context.Context, ingredientIDs []uint64) (entity.PantryItems, error)
+	SetThreshold(ctx context.Context, params PantryThresholdParams) error
+	RecordMovements(ctx context.Context, params []PantryMovementParams) (entity.PantryMovements, error)
+	ListMovements(ctx context.Context, ingredientID uint64, limit, offset int) (entity.PantryMovements, error)
+}
+
+// PantryUsecase is our pantry usecase object
+type PantryUsecase struct {
+	pantryRepo         PantryRepository
+	recipeRepo         RecipeRepository
+	ingredientRepo     IngredientRepository
+	ingredientUnitRepo IngredientUnitRepository
+	reviewers          reviewerSet
+}
+
+// NewPantryUsecase instantiates PantryUsecase, reviewers can cook the recipes that are not published yet
+func NewPantryUsecase(pantryRepo PantryRepository, recipeRepo RecipeRepository, ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository, reviewers []string) *PantryUsecase {
+	return &PantryUsecase{
+		pantryRepo:         pantryRepo,
+		recipeRepo:         recipeRepo,
+		ingredientRepo:     ingredientRepo,
+		ingredientUnitRepo: ingredientUnitRepo,
+		reviewers:          newReviewerSet(reviewers),
+	}
+}
+
+// ListPantryItems retrieves the ingredients on hand, filter.LowStockOnly lists only those at or below their threshold
+func (u *PantryUsecase) ListPantryItems(ctx context.Context, filter ListPantryItemsFilter, limit, offset int) (entity.PantryItems, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.pantryRepo.ListItems(ctx, filter, lim, ofs)
+}
+
+// SetLowStockThreshold sets the quantity at or below which an ingredient is low on stock
+func (u *PantryUsecase) SetLowStockThreshold(ctx context.Context, params PantryThresholdParams) (*entity.PantryItem, error) {
+	if params.Threshold.Valid && params.Threshold.Float64 < 0 {
+		return nil, entity.ErrInvalidLowStockThreshold
+	}
+
+	ingredients, err := u.ingredientRepo.ListByIDs(ctx, []uint64{params.IngredientID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ingredients) == 0 {
+		return nil, entity.ErrIngredientNotFound
+	}
+
+	err = u.pantryRepo.SetThreshold(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.getItem(ctx, params.IngredientID)
+}
+
+// RecordPantryMovement records a purchase, a waste or an adjustment of an ingredient. The amount is converted
+// to the base unit of the ingredient, an adjustment is signed and cooking is recorded through CookRecipe
+func (u *PantryUsecase) RecordPantryMovement(ctx context.Context, params PantryMovementParams) (*entity.PantryMovement, error) {
+	sign := 1.0
+
+	switch params.Kind {
+	case entity.PantryMovementPurchase:
+		if params.Amount <= 0 {
+			return nil, entity.ErrInvalidPantryMovement
+		}
+	case entity.PantryMovementWaste:
+		if params.Amount <= 0 {
+			return nil, entity.ErrInvalidPantryMovement
+		}
+		sign = -1
+	case entity.PantryMovementAdjustment:
+		if params.Amount == 0 {
+			return nil, entity.ErrInvalidPantryMovement
+		}
+	default:
+		return nil, entity.ErrInvalidPantryMovement
+	}
+
+	ingredientByID, converter, err := loadIngredientsWithConverter(ctx, u.ingredientRepo, u.ingredientUnitRepo,
+		entity.RecipeIngredients{{IngredientID: params.IngredientID, IngredientUnitName: params.UnitName}})
+	if err != nil {
+		return nil, err
+	}
+
+	ingredient, ok := ingredientByID[params.IngredientID]
+	if !ok || ingredient.IsDeleted {
+		return nil, entity.ErrIngredientNotFound
+	}
+
+	if params.UnitName == "" {
+		params.UnitName = ingredient.BaseUnitName.String
+	}
+
+	baseAmount, ok := converter.toBaseAmount(ingredient, params.UnitName, params.Amount)
+	if !ok {
+		return nil, entity.ErrPantryUnitNotConvertible
+	}
+
+	params.BaseAmount = sign * baseAmount
+
+	movements, err := u.pantryRepo.RecordMovements(ctx, []PantryMovementParams{params})
+	if err != nil {
+		return nil, err
+	}
+
+	movement := movements[0]
+	movement.IngredientName = ingredient.Name
+
+	return movement, nil
+}
+
+// ListPantryMovements retrieves the ledger of an ingredient, latest first
+func (u *PantryUsecase) ListPantryMovements(ctx context.Context, ingredientID uint64, limit, offset int) (entity.PantryMovements, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.pantryRepo.ListMovements(ctx, ingredientID, lim, ofs)
+}
+
+// CookRecipe takes the ingredients of a recipe, sub-recipes included, out of the pantry. An ingredient short on stock
+// is used up and reported as a shortage, and ingredients to taste, optional, not stocked or in a unit that cannot
+// be converted are skipped. The ingredients left at or below their threshold are returned as low-stock alerts
+func (u *PantryUsecase) CookRecipe(ctx context.Context, params CookRecipeParams) (entity.PantryCook, error) {
+	if params.Multiplier == 0 {
+		params.Multiplier = 1
+	}
+
+	if params.Multiplier < 0 {
+		return entity.PantryCook{}, entity.ErrInvalidCookMultiplier
+	}
+
+	summary, err := u.recipeRepo.GetSummary(ctx, params.RecipeID)
+	if err != nil {
+		return entity.PantryCook{}, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, params.Actor) {
+		return entity.PantryCook{}, entity.ErrRecipeNotFound
+	}
+
+	flattened, err := flattenRecipeIngredients(ctx, u.recipeRepo, summary, params.Multiplier, map[uint64]bool{summary.ID: true})
+	if err != nil {
+		return entity.PantryCook{}, err
+	}
+
+	recipeIngredients := mergeRecipeIngredients(flattened)
+
+	ingredientByID, converter, err := loadIngredientsWithConverter(ctx, u.ingredientRepo, u.ingredientUnitRepo, recipeIngredients)
+	if err != nil {
+		return entity.PantryCook{}, err
+	}
+
+	res := entity.PantryCook{RecipeID: summary.ID, Multiplier: params.Multiplier}
+
+	// the amounts needed are summed by ingredient first, a recipe may list an ingredient in several units
+	var ingredientIDs []uint64
+	needByID := map[uint64]float64{}
+
+	for _, ri := range recipeIngredients {
+		skip := entity.PantrySkip{IngredientID: ri.IngredientID, IngredientName: ri.IngredientName}
+
+		switch {
+		case ri.IsToTaste():
+			skip.Reason = entity.PantrySkipToTaste
+		case ri.Optional:
+			skip.Reason = entity.PantrySkipOptional
+		}
+
+		if skip.Reason != "" {
+			res.Skipped = append(res.Skipped, skip)
+			continue
+		}
+
+		baseAmount, ok := converter.toBaseAmount(ingredientByID[ri.IngredientID], ri.IngredientUnitName, ri.Amount.Float64)
+		if !ok {
+			skip.Reason = entity.PantrySkipUnitNotConvertible
+			res.Skipped = append(res.Skipped, skip)
+			continue
+		}
+
+		if _, ok := needByID[ri.IngredientID]; !ok {
+			ingredientIDs = append(ingredientIDs, ri.IngredientID)
+		}
+		needByID[ri.IngredientID] += baseAmount
+	}
+
+	if len(ingredientIDs) == 0 {
+		return res, nil
+	}
+
+	items, err := u.pantryRepo.GetItems(ctx, ingredientIDs)
+	if err != nil {
+		return entity.PantryCook{}, err
+	}
+
+	itemByID := map[uint64]*entity.PantryItem{}
+	for _, item := range items {
+		itemByID[item.IngredientID] = item
+	}
+
+	var movements []PantryMovementParams
+
+	for _, id := range ingredientIDs {
+		ingredient := ingredientByID[id]
+		item, ok := itemByID[id]
+		if !ok || item.Quantity <= 0 {
+			res.Skipped = append(res.Skipped, entity.PantrySkip{IngredientID: id, IngredientName: ingredient.Name, Reason: entity.PantrySkipNotStocked})
+			continue
+		}
+
+		used := needByID[id]
+		if used > item.Quantity {
+			res.Shortages = append(res.Shortages, entity.PantryShortage{
+				IngredientID:   id,
+				IngredientName: ingredient.Name,
+				BaseUnitName:   ingredient.BaseUnitName.String,
+				Needed:         used,
+				OnHand:         item.Quantity,
+			})
+			used = item.Quantity
+		}
+
+		movements = append(movements, PantryMovementParams{
+			IngredientID: id,
+			Kind:         entity.PantryMovementCook,
+			Amount:       used,
+			UnitName:     ingredient.BaseUnitName.String,
+			BaseAmount:   -used,
+			RecipeID:     summary.ID,
+			Actor:        params.Actor,
+		})
+	}
+
+	if len(movements) == 0 {
+		return res, nil
+	}
+
+	res.Movements, err = u.pantryRepo.RecordMovements(ctx, movements)
+	if err != nil {
+		return entity.PantryCook{}, err
+	}
+
+	items, err = u.pantryRepo.GetItems(ctx, ingredientIDs)
+	if err != nil {
+		return entity.PantryCook{}, err
+	}
+
+	for _, movement := range res.Movements {
+		movement.IngredientName = ingredientByID[movement.IngredientID].Name
+	}
+
+	for _, item := range items {
+		if item.IsLowStock() {
+			res.LowStock = append(res.LowStock, item)
+		}
+	}
+
+	return res, nil
+}
+
+// getItem retrieves the pantry item of an ingredient
+func (u *PantryUsecase) getItem(ctx context.Context, ingredientID uint64) (*entity.PantryItem, error) {
+	items, err := u.pantryRepo.GetItems(ctx, []uint64{ingredientID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, entity.ErrIngredientNotFound
+	}
+
+	return items[0], nil
+}
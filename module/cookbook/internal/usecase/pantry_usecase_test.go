@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewPantryUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pantryRepo := mock.NewMockPantryRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewPantryUsecase(pantryRepo, recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestPantryUsecase_RecordPantryMovement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pantryRepo := mock.NewMockPantryRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewPantryUsecase(pantryRepo, recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{1}).Return(entity.Ingredients{
+		{ID: 1, Name: "Beras", BaseUnitName: null.StringFrom("gram")},
+	}, nil)
+	ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), []string{"kg"}).Return(entity.IngredientUnits{
+		{ID: 6, Name: "kg", BaseUnitName: null.StringFrom("gram"), BaseFactor: null.FloatFrom(1000)},
+	}, nil)
+	ingredientUnitRepo.EXPECT().ListConversions(gomock.Any(), []uint64{1}).Return(entity.IngredientUnitConversions{}, nil)
+
+	// waste is taken out of the stock in the base unit
+	pantryRepo.EXPECT().RecordMovements(gomock.Any(), []usecase.PantryMovementParams{{
+		IngredientID: 1,
+		Kind:         entity.PantryMovementWaste,
+		Amount:       0.5,
+		UnitName:     "kg",
+		BaseAmount:   -500,
+		Actor:        "Naufal",
+	}}).Return(entity.PantryMovements{{ID: 3, IngredientID: 1, Kind: entity.PantryMovementWaste, BaseAmount: -500, BalanceAfter: 1500}}, nil)
+
+	movement, err := uc.RecordPantryMovement(context.Background(), usecase.PantryMovementParams{
+		IngredientID: 1,
+		Kind:         entity.PantryMovementWaste,
+		Amount:       0.5,
+		UnitName:     "kg",
+		Actor:        "Naufal",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Beras", movement.IngredientName)
+	assert.Equal(t, float64(1500), movement.BalanceAfter)
+}
+
+func TestPantryUsecase_RecordPantryMovement_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pantryRepo := mock.NewMockPantryRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewPantryUsecase(pantryRepo, recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	for _, params := range []usecase.PantryMovementParams{
+		{IngredientID: 1, Kind: entity.PantryMovementPurchase, Amount: -1},
+		{IngredientID: 1, Kind: entity.PantryMovementAdjustment},
+		{IngredientID: 1, Kind: entity.PantryMovementCook, Amount: 1},
+	} {
+		_, err := uc.RecordPantryMovement(context.Background(), params)
+
+		assert.Equal(t, entity.ErrInvalidPantryMovement, err)
+	}
+}
+
+func TestPantryUsecase_CookRecipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pantryRepo := mock.NewMockPantryRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewPantryUsecase(pantryRepo, recipeRepo, ingredientRepo, ingredientUnitRepo, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng", Servings: 2},
+		Ingredients: entity.RecipeIngredients{
+			{IngredientID: 1, IngredientName: "Beras", IngredientUnitName: "gram", Amount: null.FloatFrom(200)},
+			{IngredientID: 2, IngredientName: "Telur", IngredientUnitName: "butir", Amount: null.FloatFrom(2)},
+			{IngredientID: 3, IngredientName: "Garam", IngredientUnitName: "sdt"},
+			{IngredientID: 4, IngredientName: "Kerupuk", IngredientUnitName: "buah", Amount: null.FloatFrom(2), Optional: true},
+			{IngredientID: 8, IngredientName: "Kecap", IngredientUnitName: "sdm", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+
+	ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{1, 2, 3, 4, 8}).Return(entity.Ingredients{
+		{ID: 1, Name: "Beras", BaseUnitName: null.StringFrom("gram")},
+		{ID: 2, Name: "Telur", BaseUnitName: null.StringFrom("butir")},
+		{ID: 3, Name: "Garam", BaseUnitName: null.StringFrom("gram")},
+		{ID: 4, Name: "Kerupuk", BaseUnitName: null.StringFrom("buah")},
+		{ID: 8, Name: "Kecap", BaseUnitName: null.StringFrom("ml")},
+	}, nil)
+	ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), gomock.Any()).Return(entity.IngredientUnits{}, nil)
+	ingredientUnitRepo.EXPECT().ListConversions(gomock.Any(), []uint64{1, 2, 3, 4, 8}).Return(entity.IngredientUnitConversions{}, nil)
+
+	pantryRepo.EXPECT().GetItems(gomock.Any(), []uint64{1, 2}).Return(entity.PantryItems{
+		{IngredientID: 1, Quantity: 1000},
+		{IngredientID: 2, Quantity: 3, LowStockThreshold: null.FloatFrom(2)},
+	}, nil)
+
+	// cooked twice, the eggs run out
+	pantryRepo.EXPECT().RecordMovements(gomock.Any(), []usecase.PantryMovementParams{
+		{IngredientID: 1, Kind: entity.PantryMovementCook, Amount: 400, UnitName: "gram", BaseAmount: -400, RecipeID: 5, Actor: "Naufal"},
+		{IngredientID: 2, Kind: entity.PantryMovementCook, Amount: 3, UnitName: "butir", BaseAmount: -3, RecipeID: 5, Actor: "Naufal"},
+	}).Return(entity.PantryMovements{
+		{ID: 1, IngredientID: 1, Kind: entity.PantryMovementCook, BaseAmount: -400, BalanceAfter: 600},
+		{ID: 2, IngredientID: 2, Kind: entity.PantryMovementCook, BaseAmount: -3, BalanceAfter: 0},
+	}, nil)
+
+	pantryRepo.EXPECT().GetItems(gomock.Any(), []uint64{1, 2}).Return(entity.PantryItems{
+		{IngredientID: 1, Quantity: 600},
+		{IngredientID: 2, Quantity: 0, LowStockThreshold: null.FloatFrom(2)},
+	}, nil)
+
+	cook, err := uc.CookRecipe(context.Background(), usecase.CookRecipeParams{RecipeID: 5, Multiplier: 2, Actor: "Naufal"})
+
+	assert.NoError(t, err)
+	assert.Len(t, cook.Movements, 2)
+	assert.Equal(t, "Telur", cook.Movements[1].IngredientName)
+	assert.Equal(t, []entity.PantryShortage{{IngredientID: 2, IngredientName: "Telur", BaseUnitName: "butir", Needed: 4, OnHand: 3}}, cook.Shortages)
+	assert.Equal(t, []entity.PantrySkip{
+		{IngredientID: 3, IngredientName: "Garam", Reason: entity.PantrySkipToTaste},
+		{IngredientID: 4, IngredientName: "Kerupuk", Reason: entity.PantrySkipOptional},
+		{IngredientID: 8, IngredientName: "Kecap", Reason: entity.PantrySkipUnitNotConvertible},
+	}, cook.Skipped)
+	assert.Len(t, cook.LowStock, 1)
+	assert.Equal(t, uint64(2), cook.LowStock[0].IngredientID)
+}
+
+func TestPantryUsecase_CookRecipe_HidesDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pantryRepo := mock.NewMockPantryRepository(ctrl)
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	ingredientUnitRepo := mock.NewMockIngredientUnitRepository(ctrl)
+
+	uc := usecase.NewPantryUsecase(pantryRepo, recipeRepo, ingredientRepo, ingredientUnitRepo, []string{"Sari"})
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Uduk", Status: entity.RecipeStatusDraft, CreatedBy: "Budi"},
+	}, nil)
+
+	_, err := uc.CookRecipe(context.Background(), usecase.CookRecipeParams{RecipeID: 1, Actor: "Andi"})
+
+	assert.ErrorIs(t, err, entity.ErrRecipeNotFound)
+}
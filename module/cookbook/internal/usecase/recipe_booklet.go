@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// GetCategoryBooklet retrieves the summaries of every published recipe of a category, and of its descendant categories when
+// includeSubcategories is set, ordered by name so that they can be printed as a booklet
+func (u *RecipeUsecase) GetCategoryBooklet(ctx context.Context, categoryID uint64, includeSubcategories bool) (entity.RecipeBooklet, error) {
+	category, err := u.categoryRepo.Get(ctx, categoryID)
+	if err != nil {
+		return entity.RecipeBooklet{}, err
+	}
+
+	filter := ListRecipesFiter{
+		CategoryID:           categoryID,
+		IncludeSubcategories: includeSubcategories,
+		Statuses:             []string{entity.RecipeStatusPublished},
+	}
+
+	var recipes entity.Recipes
+
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := u.recipeRepo.List(ctx, filter, listAllPageSize, offset)
+		if err != nil {
+			return entity.RecipeBooklet{}, err
+		}
+
+		recipes = append(recipes, page...)
+
+		if len(page) < listAllPageSize {
+			break
+		}
+	}
+
+	sort.SliceStable(recipes, func(i, j int) bool {
+		return strings.ToLower(recipes[i].Name) < strings.ToLower(recipes[j].Name)
+	})
+
+	res := entity.RecipeBooklet{Category: category}
+
+	for _, recipe := range recipes {
+		summary, err := u.recipeRepo.GetSummary(ctx, recipe.ID)
+		if err != nil {
+			return entity.RecipeBooklet{}, err
+		}
+
+		// a recipe deleted while the booklet is built is left out
+		if summary.ID == 0 {
+			continue
+		}
+
+		res.Recipes = append(res.Recipes, summary)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libingredient"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+var (
+	// jsonLDScriptPattern finds the JSON-LD blocks of an HTML page
+	jsonLDScriptPattern = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+	// isoDurationPattern matches the ISO 8601 durations used by schema.org, e.g. PT1H30M
+	isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// ImportRecipeParams holds a schema.org Recipe document to import, either JSON-LD or an HTML page containing it
+type ImportRecipeParams struct {
+	Document   []byte
+	CategoryID uint64
+	Actor      string
+}
+
+// schemaRecipe holds the parts of a schema.org Recipe we import, the fields that may be a text or a list are kept raw
+type schemaRecipe struct {
+	Name               string          `json:"name"`
+	Description        string          `json:"description"`
+	RecipeIngredient   json.RawMessage `json:"recipeIngredient"`
+	Ingredients        json.RawMessage `json:"ingredients"`
+	RecipeInstructions json.RawMessage `json:"recipeInstructions"`
+	RecipeYield        json.RawMessage `json:"recipeYield"`
+	RecipeCuisine      json.RawMessage `json:"recipeCuisine"`
+	PrepTime           string          `json:"prepTime"`
+	CookTime           string          `json:"cookTime"`
+}
+
+// ImportRecipe creates a recipe from a schema.org Recipe document. Ingredient lines are matched against the
+// ingredients with their aliases and the ingredient units, and the lines that could not be matched are reported.
+// Recipes have no separate steps, so the instructions become the lines of the description
+func (u *RecipeUsecase) ImportRecipe(ctx context.Context, params ImportRecipeParams) (entity.RecipeImport, error) {
+	doc, err := decodeSchemaRecipe(params.Document)
+	if err != nil {
+		return entity.RecipeImport{}, err
+	}
+
+	createParams := CreateRecipeParams{
+		RecipeParams: RecipeParams{
+			Name:        strings.TrimSpace(doc.Name),
+			Description: strings.TrimSpace(doc.Description),
+			CategoryID:  params.CategoryID,
+			Actor:       params.Actor,
+		},
+	}
+
+	if steps := schemaInstructions(doc.RecipeInstructions); len(steps) > 0 {
+		createParams.Description = strings.Join(steps, "\n")
+	}
+
+	if cuisines := schemaTexts(doc.RecipeCuisine); len(cuisines) > 0 {
+		createParams.Cuisine = cuisines[0]
+	}
+
+	createParams.PrepMinutes, _ = parseISODuration(doc.PrepTime)
+	createParams.CookMinutes, _ = parseISODuration(doc.CookTime)
+
+	for _, y := range schemaTexts(doc.RecipeYield) {
+		p := libingredient.Parse(y)
+		if !p.HasAmount() {
+			continue
+		}
+
+		unit := strings.TrimSpace(p.UnitText + " " + p.Name)
+
+		if servingUnitNames[strings.ToLower(unit)] {
+			if createParams.Servings == 0 {
+				createParams.Servings = int(p.Amount)
+			}
+			continue
+		}
+
+		if createParams.YieldAmount == 0 {
+			createParams.YieldAmount = p.Amount
+			createParams.YieldUnitName = unit
+		}
+	}
+
+	lines := schemaTexts(doc.RecipeIngredient)
+	if len(lines) == 0 {
+		lines = schemaTexts(doc.Ingredients)
+	}
+
+	var unmatched entity.RecipeImportIssues
+
+	createParams.Ingredients, unmatched, err = u.matchIngredientLines(ctx, lines, params.Actor)
+	if err != nil {
+		return entity.RecipeImport{}, err
+	}
+
+	recipe, err := u.createRecipe(ctx, createParams)
+	if err != nil {
+		return entity.RecipeImport{}, err
+	}
+
+	return entity.RecipeImport{
+		Recipe:      recipe,
+		Ingredients: len(createParams.Ingredients),
+		Unmatched:   unmatched,
+	}, nil
+}
+
+// matchIngredientLines turns ingredient lines into recipe ingredients. A line is kept when it clearly matches
+// an ingredient, with the unit as written when the unit is not found
+func (u *RecipeUsecase) matchIngredientLines(ctx context.Context, lines []string, actor string) (BulkRecipeIngredientParams, entity.RecipeImportIssues, error) {
+	parsed, err := u.ParseIngredientLines(ctx, lines)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res BulkRecipeIngredientParams
+	var unmatched entity.RecipeImportIssues
+
+	for _, line := range parsed {
+		best, ok := acceptedIngredientCandidate(line.Candidates)
+
+		switch {
+		case len(line.Candidates) == 0:
+			unmatched = append(unmatched, &entity.RecipeImportIssue{Line: line.Line, Reason: entity.ImportIssueIngredientNotFound})
+			continue
+		case !ok:
+			unmatched = append(unmatched, &entity.RecipeImportIssue{Line: line.Line, Reason: entity.ImportIssueAmbiguous})
+			continue
+		case !best.UnitMatched:
+			unmatched = append(unmatched, &entity.RecipeImportIssue{Line: line.Line, Reason: entity.ImportIssueUnitNotFound})
+		}
+
+		p := RecipeIngredientParams{
+			Amount:             best.Amount,
+			AmountMax:          best.AmountMax,
+			AmountDenominator:  best.AmountDenominator,
+			ToTaste:            best.ToTaste,
+			IngredientID:       best.IngredientID,
+			IngredientName:     best.IngredientName,
+			IngredientUnitName: best.IngredientUnitName,
+			OrderingIndex:      len(res) + 1,
+			Notes:              best.Notes,
+			Actor:              actor,
+		}
+
+		if best.Optional {
+			p.Optional = &best.Optional
+		}
+
+		res = append(res, p)
+	}
+
+	return res, unmatched, nil
+}
+
+// decodeSchemaRecipe finds the Recipe of a JSON-LD document, which may be an HTML page with JSON-LD blocks.
+// The Recipe may be the document itself, an item of a list or of an @graph
+func decodeSchemaRecipe(document []byte) (schemaRecipe, error) {
+	var blocks [][]byte
+
+	trimmed := bytes.TrimSpace(document)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		for _, m := range jsonLDScriptPattern.FindAllSubmatch(trimmed, -1) {
+			blocks = append(blocks, m[1])
+		}
+	} else {
+		blocks = append(blocks, trimmed)
+	}
+
+	for _, block := range blocks {
+		raw, ok := findSchemaRecipe(block)
+		if !ok {
+			continue
+		}
+
+		var doc schemaRecipe
+		if err := json.Unmarshal(raw, &doc); err != nil || strings.TrimSpace(doc.Name) == "" {
+			continue
+		}
+
+		return doc, nil
+	}
+
+	return schemaRecipe{}, entity.ErrInvalidRecipeDocument
+}
+
+func findSchemaRecipe(raw json.RawMessage) (json.RawMessage, bool) {
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		for _, item := range list {
+			if found, ok := findSchemaRecipe(item); ok {
+				return found, true
+			}
+		}
+
+		return nil, false
+	}
+
+	var node map[string]json.RawMessage
+	if json.Unmarshal(raw, &node) != nil {
+		return nil, false
+	}
+
+	if containsString(schemaTexts(node["@type"]), "Recipe") {
+		return raw, true
+	}
+
+	for _, key := range []string{"@graph", "mainEntity"} {
+		if found, ok := findSchemaRecipe(node[key]); ok {
+			return found, true
+		}
+	}
+
+	return nil, false
+}
+
+// schemaTexts reads a schema.org value that may be a text, a number or a list of them
+func schemaTexts(raw json.RawMessage) []string {
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil {
+		list = []json.RawMessage{raw}
+	}
+
+	var res []string
+
+	for _, item := range list {
+		var text string
+		if json.Unmarshal(item, &text) != nil {
+			var number json.Number
+			if json.Unmarshal(item, &number) != nil {
+				continue
+			}
+
+			text = number.String()
+		}
+
+		if text = strings.TrimSpace(text); text != "" {
+			res = append(res, text)
+		}
+	}
+
+	return res
+}
+
+// schemaInstructions reads recipeInstructions, which may be a text, a list of texts, HowToStep
+// or HowToSection nodes, into one line per step
+func schemaInstructions(raw json.RawMessage) []string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		var res []string
+		for _, line := range strings.Split(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				res = append(res, line)
+			}
+		}
+
+		return res
+	}
+
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil {
+		list = []json.RawMessage{raw}
+	}
+
+	var res []string
+
+	for _, item := range list {
+		var node struct {
+			Text            string          `json:"text"`
+			Name            string          `json:"name"`
+			ItemListElement json.RawMessage `json:"itemListElement"`
+		}
+
+		if json.Unmarshal(item, &node) != nil {
+			res = append(res, schemaInstructions(item)...)
+			continue
+		}
+
+		switch {
+		case len(node.ItemListElement) > 0:
+			res = append(res, schemaInstructions(node.ItemListElement)...)
+		case strings.TrimSpace(node.Text) != "":
+			res = append(res, strings.TrimSpace(node.Text))
+		case strings.TrimSpace(node.Name) != "":
+			res = append(res, strings.TrimSpace(node.Name))
+		}
+	}
+
+	return res
+}
+
+// parseISODuration reads an ISO 8601 duration into minutes, seconds are dropped
+func parseISODuration(s string) (int, bool) {
+	m := isoDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil || s == "" {
+		return 0, false
+	}
+
+	days, _ := strconv.Atoi(m[1])
+	hours, _ := strconv.Atoi(m[2])
+	minutes, _ := strconv.Atoi(m[3])
+
+	return days*24*60 + hours*60 + minutes, true
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libingredient"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// normalizeRecipeIngredientAmounts reads the amounts written as text and validates the amounts of every
+// recipe ingredient. A range that starts and ends on the same amount is a single amount
+func normalizeRecipeIngredientAmounts(params BulkRecipeIngredientParams) (BulkRecipeIngredientParams, error) {
+	res := make(BulkRecipeIngredientParams, 0, len(params))
+
+	for _, p := range params {
+		p, err := normalizeRecipeIngredientAmount(p)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+func normalizeRecipeIngredientAmount(p RecipeIngredientParams) (RecipeIngredientParams, error) {
+	if p.AmountText != "" {
+		line := libingredient.Parse(p.AmountText)
+		if line.Name != "" || line.Unit != "" || (!line.HasAmount() && !line.ToTaste) {
+			return p, entity.ErrInvalidAmount
+		}
+
+		p.Amount = line.Amount
+		p.AmountMax = line.AmountMax
+		p.AmountDenominator = line.Denominator
+		p.ToTaste = p.ToTaste || line.ToTaste
+	}
+
+	if p.Amount < 0 || p.AmountMax < 0 || (p.AmountMax > 0 && p.AmountMax < p.Amount) {
+		return p, entity.ErrInvalidAmount
+	}
+
+	if p.ToTaste && (p.Amount > 0 || p.AmountMax > 0) {
+		return p, entity.ErrInvalidAmount
+	}
+
+	if p.SubRecipeID != 0 && (p.ToTaste || p.AmountMax > 0) {
+		return p, entity.ErrInvalidAmount
+	}
+
+	if p.AmountMax == p.Amount {
+		p.AmountMax = 0
+	}
+
+	if p.AmountDenominator < 2 || p.Amount == 0 {
+		p.AmountDenominator = 0
+	}
+
+	return p, nil
+}
@@ -5,31 +5,117 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guregu/null"
+
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 )
 
+// servingUnitNames are unit names that express a sub-recipe amount in servings
+var servingUnitNames = map[string]bool{
+	"":         true,
+	"porsi":    true,
+	"serving":  true,
+	"servings": true,
+}
+
+// ListRecipesFiter holds the recipe filters, a recipe must have all of TagIDs and all of EquipmentIDs.
+// IncludeSubcategories also matches the recipes in the descendant categories of CategoryID.
+// A recipe must have one of Statuses when given, and when DraftAuthor is valid the drafts and recipes in review
+// are limited to those created by it. Viewer is the one listing the recipes, only reviewers can filter by status
 type ListRecipesFiter struct {
-	CategoryID   uint64
-	IngredientID uint64
+	CategoryID           uint64
+	IncludeSubcategories bool
+	IngredientID         uint64
+	ExcludeAllergens     []string
+	Diets                []string
+	TagIDs               []uint64
+	Cuisine              string
+	MaxTotalMinutes      int
+	EquipmentIDs         []uint64
+	MinRating            float64
+	Sort                 string
+	Statuses             []string
+	DraftAuthor          null.String
+	Viewer               string
 }
 
+// Sorts of the recipe list, the recipes are sorted by ID by default
+const (
+	RecipeSortID            = "id"
+	RecipeSortIDDesc        = "-id"
+	RecipeSortRating        = "rating"
+	RecipeSortRatingDesc    = "-rating"
+	RecipeSortCookCount     = "cook_count"
+	RecipeSortCookCountDesc = "-cook_count"
+)
+
+// RecipeSorts lists the sorts of the recipe list, a leading "-" sorts descending
+var RecipeSorts = []string{RecipeSortID, RecipeSortIDDesc, RecipeSortRating, RecipeSortRatingDesc, RecipeSortCookCount, RecipeSortCookCountDesc}
+
 type BulkRecipeIngredientParams []RecipeIngredientParams
 
+// RecipeSummaryOptions holds options of a recipe summary.
+// Substitutes maps an ingredient ID to the ID of its substitute, and a draft is only found when Viewer is its author or a reviewer
+type RecipeSummaryOptions struct {
+	ExpandSubRecipes bool
+	Substitutes      map[uint64]uint64
+	Viewer           string
+}
+
 type CreateRecipeParams struct {
 	RecipeParams
 	Ingredients BulkRecipeIngredientParams
 }
 
 type RecipeParams struct {
-	Name        string
-	Description string
-	CategoryID  uint64
-	Actor       string
+	Name          string
+	Description   string
+	CategoryID    uint64
+	Servings      int
+	YieldAmount   float64
+	YieldUnitName string
+	Cuisine       string
+	PrepMinutes   int
+	CookMinutes   int
+	Difficulty    string
+	TagIDs        []uint64
+	EquipmentIDs  []uint64
+	PropagateName bool
+	Actor         string
 }
 
+// RecipeTransitionParams holds an action of the recipe workflow, Comment is required to reject a recipe
+type RecipeTransitionParams struct {
+	Action  string
+	Comment string
+	Actor   string
+}
+
+// RecipeStatusParams holds a status change to be recorded, it applies only while the recipe is still in From
+type RecipeStatusParams struct {
+	Action  string
+	From    string
+	To      string
+	Comment string
+	Actor   string
+}
+
+// RecipeIngredientParams holds a recipe ingredient, Line is a free-text line like "2 siung bawang putih"
+// that is read when neither an ingredient nor a sub-recipe is given. AmountText is an amount written like "1/3"
+// or "2-3" that is read into the amounts, ToTaste leaves the amount empty and a nil Optional is left unchanged
 type RecipeIngredientParams struct {
+	Line               string
 	Amount             float64
+	AmountMax          float64
+	AmountDenominator  int
+	AmountText         string
+	ToTaste            bool
+	Optional           *bool
 	IngredientID       uint64
+	SubRecipeID        uint64
 	IngredientName     string
 	IngredientUnitName string
 	OrderingIndex      int
@@ -43,56 +129,189 @@ type RecipeRepository interface {
 	Update(ctx context.Context, id uint64, params RecipeParams) (*entity.Recipe, error)
 	Delete(ctx context.Context, id uint64) error
 	List(ctx context.Context, filter ListRecipesFiter, limit, offset int) (entity.Recipes, error)
+	ListByIDs(ctx context.Context, ids []uint64) (entity.Recipes, error)
+	ListReferencingRecipes(ctx context.Context, id uint64) (entity.RecipeReferences, error)
 	GetSummary(ctx context.Context, id uint64) (entity.RecipeSummary, error)
+	ListSubRecipeIDs(ctx context.Context, id uint64) ([]uint64, error)
+	ListFacets(ctx context.Context, filter ListRecipesFiter) (entity.RecipeFacets, error)
+	UpdateStatus(ctx context.Context, id uint64, params RecipeStatusParams) error
+	ListStatusChanges(ctx context.Context, id uint64) (entity.RecipeStatusChanges, error)
+	Clone(ctx context.Context, id uint64, params CloneRecipeParams) (*entity.Recipe, error)
+	ListFamily(ctx context.Context, id uint64) (entity.Recipes, error)
+	GetForkSnapshot(ctx context.Context, id uint64) (entity.RecipeSummary, error)
 }
 
 // RecipeIngredientRepository defines contract for recipe ingredient repository dependency
 type RecipeIngredientRepository interface {
 	BulkCreate(ctx context.Context, recipeID uint64, params BulkRecipeIngredientParams) error
+	Get(ctx context.Context, id uint64) (*entity.RecipeIngredient, error)
 	Update(ctx context.Context, id uint64, params RecipeIngredientParams) (*entity.RecipeIngredient, error)
 	Delete(ctx context.Context, id uint64) error
+	ListNameDrifts(ctx context.Context) (entity.RecipeIngredientNameDrifts, error)
+	RepairNameDrifts(ctx context.Context, actor string) (int64, error)
 }
 
 // RecipeUsecase is our recipe usecase object
 type RecipeUsecase struct {
-	recipeRepo           RecipeRepository
-	recipeIngredientRepo RecipeIngredientRepository
+	recipeRepo                 RecipeRepository
+	recipeIngredientRepo       RecipeIngredientRepository
+	ingredientRepo             IngredientRepository
+	ingredientUnitRepo         IngredientUnitRepository
+	ingredientSubstitutionRepo IngredientSubstitutionRepository
+	categoryRepo               CategoryRepository
+	tagRepo                    TagRepository
+	equipmentRepo              EquipmentRepository
+	renamePolicy               RenamePolicy
+	reviewers                  reviewerSet
 }
 
-// NewRecipeUsecase instantiates RecipeUsecase
-func NewRecipeUsecase(recipeRepo RecipeRepository, recipeIngredientRepo RecipeIngredientRepository) *RecipeUsecase {
+// NewRecipeUsecase instantiates RecipeUsecase, reviewers are the names allowed to approve, reject and publish recipes
+func NewRecipeUsecase(recipeRepo RecipeRepository, recipeIngredientRepo RecipeIngredientRepository, ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository, ingredientSubstitutionRepo IngredientSubstitutionRepository, categoryRepo CategoryRepository, tagRepo TagRepository, equipmentRepo EquipmentRepository, renamePolicy RenamePolicy, reviewers []string) *RecipeUsecase {
 	return &RecipeUsecase{
-		recipeRepo:           recipeRepo,
-		recipeIngredientRepo: recipeIngredientRepo,
+		recipeRepo:                 recipeRepo,
+		recipeIngredientRepo:       recipeIngredientRepo,
+		ingredientRepo:             ingredientRepo,
+		ingredientUnitRepo:         ingredientUnitRepo,
+		ingredientSubstitutionRepo: ingredientSubstitutionRepo,
+		categoryRepo:               categoryRepo,
+		tagRepo:                    tagRepo,
+		equipmentRepo:              equipmentRepo,
+		renamePolicy:               renamePolicy,
+		reviewers:                  newReviewerSet(reviewers),
 	}
 }
 
 // CreateRecipe creates a new recipe
 func (u *RecipeUsecase) CreateRecipe(ctx context.Context, params CreateRecipeParams) error {
+	_, err := u.createRecipe(ctx, params)
+	return err
+}
+
+// createRecipe creates a new recipe with its ingredients and returns the recipe
+func (u *RecipeUsecase) createRecipe(ctx context.Context, params CreateRecipeParams) (*entity.Recipe, error) {
+	if params.Servings <= 0 {
+		params.Servings = defaultServings
+	}
+
+	err := u.validateCategory(ctx, params.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	params.RecipeParams, err = u.validateDetails(ctx, params.RecipeParams)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err := u.resolveIngredientLines(ctx, params.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err = normalizeRecipeIngredientAmounts(ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients, err = u.resolveRecipeIngredientNames(ctx, ingredients)
+	if err != nil {
+		return nil, err
+	}
+
 	recipe, err := u.recipeRepo.Create(ctx, params)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return u.recipeIngredientRepo.BulkCreate(ctx, recipe.ID, params.Ingredients)
+	err = u.recipeIngredientRepo.BulkCreate(ctx, recipe.ID, ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipe, nil
 }
 
+// BulkCreateRecipeIngredients creates ingredients of an existing recipe
 func (u *RecipeUsecase) BulkCreateRecipeIngredients(ctx context.Context, recipeID uint64, params BulkRecipeIngredientParams) error {
+	for _, p := range params {
+		if err := u.validateSubRecipe(ctx, recipeID, p.SubRecipeID); err != nil {
+			return err
+		}
+	}
+
+	params, err := u.resolveIngredientLines(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	params, err = normalizeRecipeIngredientAmounts(params)
+	if err != nil {
+		return err
+	}
+
+	params, err = u.resolveRecipeIngredientNames(ctx, params)
+	if err != nil {
+		return err
+	}
+
 	return u.recipeIngredientRepo.BulkCreate(ctx, recipeID, params)
 }
 
 // UpdateRecipe updates a voyage
 func (u *RecipeUsecase) UpdateRecipe(ctx context.Context, id uint64, params RecipeParams) (*entity.Recipe, error) {
+	params.PropagateName = params.Name != "" && u.renamePolicy != RenamePolicySnapshot
+
+	err := u.validateCategory(ctx, params.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err = u.validateDetails(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
 	return u.recipeRepo.Update(ctx, id, params)
 }
 
 // UpdateRecipeIngredient updates a voyage
 func (u *RecipeUsecase) UpdateRecipeIngredient(ctx context.Context, id uint64, params RecipeIngredientParams) (*entity.RecipeIngredient, error) {
-	return u.recipeIngredientRepo.Update(ctx, id, params)
+	params, err := normalizeRecipeIngredientAmount(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.SubRecipeID != 0 {
+		current, err := u.recipeIngredientRepo.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := u.validateSubRecipe(ctx, current.RecipeID, params.SubRecipeID); err != nil {
+			return nil, err
+		}
+	}
+
+	resolved, err := u.resolveRecipeIngredientNames(ctx, BulkRecipeIngredientParams{params})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.recipeIngredientRepo.Update(ctx, id, resolved[0])
 }
 
-// DeleteRecipe updates a voyage
+// DeleteRecipe deletes a recipe, it is refused while live recipes use it as a sub-recipe
 func (u *RecipeUsecase) DeleteRecipe(ctx context.Context, id uint64) error {
+	// the summaries, costs and labels of the recipes using it as a sub-recipe would no longer find it
+	recipes, err := u.recipeRepo.ListReferencingRecipes(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(recipes) > 0 {
+		return entity.NewReferenceConflictError(entity.ErrRecipeInUse, recipes)
+	}
+
 	return u.recipeRepo.Delete(ctx, id)
 }
 
@@ -101,7 +320,7 @@ func (u *RecipeUsecase) DeleteRecipeIngredient(ctx context.Context, id uint64) e
 	return u.recipeIngredientRepo.Delete(ctx, id)
 }
 
-// ListRecipes retrieves a list of tracking
+// ListRecipes retrieves a list of tracking, only the published recipes are listed unless statuses are given
 func (u *RecipeUsecase) ListRecipes(ctx context.Context, filter ListRecipesFiter, limit, offset int) (entity.Recipes, error) {
 	lim := defaultLimit
 	ofs := defaultOffset
@@ -114,9 +333,492 @@ func (u *RecipeUsecase) ListRecipes(ctx context.Context, filter ListRecipesFiter
 		ofs = offset
 	}
 
+	filter, err := u.visibleRecipesFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	return u.recipeRepo.List(ctx, filter, lim, ofs)
 }
 
-func (u *RecipeUsecase) GetRecipeSummary(ctx context.Context, id uint64) (entity.RecipeSummary, error) {
-	return u.recipeRepo.GetSummary(ctx, id)
+// ListRecipeNames retrieves the names of recipes by their IDs, deleted recipes and drafts the viewer cannot see are left out
+func (u *RecipeUsecase) ListRecipeNames(ctx context.Context, ids []uint64, viewer string) (map[uint64]string, error) {
+	res := map[uint64]string{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	recipes, err := u.recipeRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recipe := range recipes {
+		if u.reviewers.canViewRecipe(*recipe, viewer) {
+			res[recipe.ID] = recipe.Name
+		}
+	}
+
+	return res, nil
+}
+
+// ListRecipeFacets counts the recipes matching a filter per tag and per category
+func (u *RecipeUsecase) ListRecipeFacets(ctx context.Context, filter ListRecipesFiter) (entity.RecipeFacets, error) {
+	filter, err := u.visibleRecipesFilter(filter)
+	if err != nil {
+		return entity.RecipeFacets{}, err
+	}
+
+	return u.recipeRepo.ListFacets(ctx, filter)
+}
+
+// GetRecipeSummary retrieves a recipe with its ingredients and dietary labels, with the requested substitutes applied
+func (u *RecipeUsecase) GetRecipeSummary(ctx context.Context, id uint64, opts RecipeSummaryOptions) (entity.RecipeSummary, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, id)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, opts.Viewer) {
+		return entity.RecipeSummary{}, nil
+	}
+
+	summary.Substitutions, err = findSubstitutions(ctx, u.ingredientSubstitutionRepo, opts.Substitutes)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	summary.DietaryLabels, err = recipeDietaryLabels(ctx, u.recipeRepo, u.ingredientRepo, summary, summary.Substitutions)
+	if err != nil {
+		return entity.RecipeSummary{}, err
+	}
+
+	if opts.ExpandSubRecipes {
+		flattened, err := flattenRecipeIngredients(ctx, u.recipeRepo, summary, 1, map[uint64]bool{summary.ID: true})
+		if err != nil {
+			return entity.RecipeSummary{}, err
+		}
+
+		summary.FlattenedIngredients = mergeRecipeIngredients(applySubstitutions(flattened, summary.Substitutions))
+	}
+
+	summary.Ingredients = applySubstitutions(summary.Ingredients, summary.Substitutions)
+
+	return summary, nil
+}
+
+// TransitionRecipe applies an action of the recipe workflow, see entity.RecipeTransitions for the allowed ones
+func (u *RecipeUsecase) TransitionRecipe(ctx context.Context, id uint64, params RecipeTransitionParams) (*entity.Recipe, error) {
+	var transition *entity.RecipeTransition
+	for i := range entity.RecipeTransitions {
+		if entity.RecipeTransitions[i].Action == params.Action {
+			transition = &entity.RecipeTransitions[i]
+		}
+	}
+
+	if transition == nil {
+		return nil, entity.ErrInvalidRecipeAction
+	}
+
+	summary, err := u.recipeRepo.GetSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, params.Actor) {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	if !containsString(transition.From, summary.Status) {
+		return nil, entity.ErrInvalidRecipeTransition
+	}
+
+	if !u.reviewers.contains(params.Actor) && (transition.ReviewerOnly || !isRecipeAuthor(summary.Recipe, params.Actor)) {
+		return nil, entity.ErrRecipeTransitionForbidden
+	}
+
+	params.Comment = strings.TrimSpace(params.Comment)
+	if transition.CommentRequired && params.Comment == "" {
+		return nil, entity.ErrReviewCommentRequired
+	}
+
+	err = u.recipeRepo.UpdateStatus(ctx, id, RecipeStatusParams{
+		Action:  transition.Action,
+		From:    summary.Status,
+		To:      transition.To,
+		Comment: params.Comment,
+		Actor:   params.Actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recipe := summary.Recipe
+	recipe.Status = transition.To
+
+	return &recipe, nil
+}
+
+// ListRecipeStatusChanges retrieves the workflow history of a recipe, oldest first
+func (u *RecipeUsecase) ListRecipeStatusChanges(ctx context.Context, id uint64, viewer string) (entity.RecipeStatusChanges, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, viewer) {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	return u.recipeRepo.ListStatusChanges(ctx, id)
+}
+
+// CheckRecipeIngredientNames lists recipe ingredients whose name differs from their ingredient or sub-recipe
+func (u *RecipeUsecase) CheckRecipeIngredientNames(ctx context.Context) (entity.RecipeIngredientNameDrifts, error) {
+	return u.recipeIngredientRepo.ListNameDrifts(ctx)
+}
+
+// RepairRecipeIngredientNames rewrites the names of drifted recipe ingredients and returns how many were repaired.
+// Under the snapshot policy the drifted names are the intended ones, so they are never repaired.
+func (u *RecipeUsecase) RepairRecipeIngredientNames(ctx context.Context, actor string) (int64, error) {
+	if u.renamePolicy == RenamePolicySnapshot {
+		return 0, entity.ErrRecipeIngredientNameSnapshot
+	}
+
+	return u.recipeIngredientRepo.RepairNameDrifts(ctx, actor)
+}
+
+// resolveRecipeIngredientNames sets the names of recipe ingredients from their ingredient or sub-recipe,
+// a name sent without an ingredient or a sub-recipe is ignored
+func (u *RecipeUsecase) resolveRecipeIngredientNames(ctx context.Context, params BulkRecipeIngredientParams) (BulkRecipeIngredientParams, error) {
+	var ingredientIDs []uint64
+	for _, p := range params {
+		if p.IngredientID != 0 {
+			ingredientIDs = appendUniqueID(ingredientIDs, p.IngredientID)
+		}
+	}
+
+	ingredientNames := map[uint64]string{}
+
+	if len(ingredientIDs) > 0 {
+		ingredients, err := u.ingredientRepo.ListByIDs(ctx, ingredientIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ingredient := range ingredients {
+			ingredientNames[ingredient.ID] = ingredient.Name
+		}
+	}
+
+	subRecipeNames := map[uint64]string{}
+
+	res := make(BulkRecipeIngredientParams, 0, len(params))
+
+	for _, p := range params {
+		p.IngredientName = ""
+
+		if p.IngredientID != 0 {
+			name, ok := ingredientNames[p.IngredientID]
+			if !ok {
+				return nil, entity.ErrIngredientNotFound
+			}
+
+			p.IngredientName = name
+		}
+
+		if p.SubRecipeID != 0 {
+			name, ok := subRecipeNames[p.SubRecipeID]
+			if !ok {
+				subSummary, err := u.recipeRepo.GetSummary(ctx, p.SubRecipeID)
+				if err != nil {
+					return nil, err
+				}
+
+				if subSummary.ID == 0 {
+					return nil, entity.ErrRecipeNotFound
+				}
+
+				name = subSummary.Name
+				subRecipeNames[p.SubRecipeID] = name
+			}
+
+			p.IngredientName = name
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+// validateCategory rejects a category that does not exist or is deleted
+func (u *RecipeUsecase) validateCategory(ctx context.Context, categoryID uint64) error {
+	if categoryID == 0 {
+		return nil
+	}
+
+	_, err := u.categoryRepo.Get(ctx, categoryID)
+	return err
+}
+
+// validateTags rejects tags that do not exist or are deleted and returns the tag IDs without duplicates.
+// nil is kept as is, it leaves the tags of a recipe unchanged
+func (u *RecipeUsecase) validateTags(ctx context.Context, tagIDs []uint64) ([]uint64, error) {
+	if len(tagIDs) == 0 {
+		return tagIDs, nil
+	}
+
+	var uniqueIDs []uint64
+	for _, id := range tagIDs {
+		uniqueIDs = appendUniqueID(uniqueIDs, id)
+	}
+
+	tags, err := u.tagRepo.ListByIDs(ctx, uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) != len(uniqueIDs) {
+		return nil, entity.ErrTagNotFound
+	}
+
+	return uniqueIDs, nil
+}
+
+// validateDetails validates the tags, timing, difficulty and equipment of a recipe, duplicated IDs are removed
+func (u *RecipeUsecase) validateDetails(ctx context.Context, params RecipeParams) (RecipeParams, error) {
+	var err error
+
+	if params.PrepMinutes < 0 || params.CookMinutes < 0 {
+		return params, entity.ErrInvalidDuration
+	}
+
+	if params.Difficulty != "" {
+		params.Difficulty = strings.ToLower(strings.TrimSpace(params.Difficulty))
+		if !containsString(entity.Difficulties, params.Difficulty) {
+			return params, entity.ErrInvalidDifficulty
+		}
+	}
+
+	params.TagIDs, err = u.validateTags(ctx, params.TagIDs)
+	if err != nil {
+		return params, err
+	}
+
+	params.EquipmentIDs, err = u.validateEquipment(ctx, params.EquipmentIDs)
+	if err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// validateEquipment rejects unknown equipment, an empty non-nil list is kept so that it removes all equipment
+func (u *RecipeUsecase) validateEquipment(ctx context.Context, equipmentIDs []uint64) ([]uint64, error) {
+	if len(equipmentIDs) == 0 {
+		return equipmentIDs, nil
+	}
+
+	var uniqueIDs []uint64
+	for _, id := range equipmentIDs {
+		uniqueIDs = appendUniqueID(uniqueIDs, id)
+	}
+
+	equipment, err := u.equipmentRepo.ListByIDs(ctx, uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(equipment) != len(uniqueIDs) {
+		return nil, entity.ErrEquipmentNotFound
+	}
+
+	return uniqueIDs, nil
+}
+
+// normalizeRecipesFilter validates the dietary labels of a filter and removes duplicated tags and equipment
+func normalizeRecipesFilter(filter ListRecipesFiter) (ListRecipesFiter, error) {
+	var err error
+
+	filter.ExcludeAllergens, err = normalizeLabels(filter.ExcludeAllergens, entity.Allergens, entity.ErrInvalidAllergen)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.Diets, err = normalizeLabels(filter.Diets, entity.Diets, entity.ErrInvalidDiet)
+	if err != nil {
+		return filter, err
+	}
+
+	var tagIDs []uint64
+	for _, id := range filter.TagIDs {
+		tagIDs = appendUniqueID(tagIDs, id)
+	}
+
+	var equipmentIDs []uint64
+	for _, id := range filter.EquipmentIDs {
+		equipmentIDs = appendUniqueID(equipmentIDs, id)
+	}
+
+	if filter.MinRating < 0 || filter.MinRating > entity.MaxRating {
+		return filter, entity.ErrInvalidRating
+	}
+
+	var statuses []string
+	for _, status := range filter.Statuses {
+		status = strings.TrimSpace(status)
+		if !containsString(entity.RecipeStatuses, status) {
+			return filter, entity.ErrInvalidRecipeStatus
+		}
+
+		if !containsString(statuses, status) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	filter.Statuses = statuses
+	filter.Viewer = strings.TrimSpace(filter.Viewer)
+
+	filter.Sort = strings.TrimSpace(filter.Sort)
+	if filter.Sort != "" && !containsString(RecipeSorts, filter.Sort) {
+		return filter, entity.ErrInvalidRecipeSort
+	}
+
+	filter.TagIDs = tagIDs
+	filter.EquipmentIDs = equipmentIDs
+	filter.Cuisine = strings.TrimSpace(filter.Cuisine)
+
+	return filter, nil
+}
+
+// visibleRecipesFilter normalizes a filter and limits it to the recipes the viewer may see, published ones by default.
+// Reviewers may list any status while the others only see their own drafts and recipes in review, so they must name themselves to list them
+func (u *RecipeUsecase) visibleRecipesFilter(filter ListRecipesFiter) (ListRecipesFiter, error) {
+	filter, err := normalizeRecipesFilter(filter)
+	if err != nil {
+		return filter, err
+	}
+
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []string{entity.RecipeStatusPublished}
+		return filter, nil
+	}
+
+	if u.reviewers.contains(filter.Viewer) {
+		return filter, nil
+	}
+
+	for _, status := range filter.Statuses {
+		if entity.IsUnpublishedDraft(status) && filter.Viewer == "" {
+			return filter, entity.ErrRecipeStatusFilterForbidden
+		}
+	}
+
+	filter.DraftAuthor = null.StringFrom(filter.Viewer)
+
+	return filter, nil
+}
+
+// validateSubRecipe rejects a sub-recipe that is the recipe itself or transitively includes it
+func (u *RecipeUsecase) validateSubRecipe(ctx context.Context, recipeID, subRecipeID uint64) error {
+	if subRecipeID == 0 {
+		return nil
+	}
+
+	if subRecipeID == recipeID {
+		return entity.ErrRecipeCycle
+	}
+
+	descendantIDs, err := u.recipeRepo.ListSubRecipeIDs(ctx, subRecipeID)
+	if err != nil {
+		return err
+	}
+
+	for _, descendantID := range descendantIDs {
+		if descendantID == recipeID {
+			return entity.ErrRecipeCycle
+		}
+	}
+
+	return nil
+}
+
+// flattenRecipeIngredients expands sub-recipes recursively and scales their amounts by factor
+func flattenRecipeIngredients(ctx context.Context, recipeRepo RecipeRepository, summary entity.RecipeSummary, factor float64, visited map[uint64]bool) (entity.RecipeIngredients, error) {
+	var res entity.RecipeIngredients
+
+	for _, ingredient := range summary.Ingredients {
+		if ingredient.SubRecipeID == 0 {
+			res = append(res, ingredient.Scale(factor))
+			continue
+		}
+
+		if visited[ingredient.SubRecipeID] {
+			return nil, entity.ErrRecipeCycle
+		}
+
+		subSummary, err := recipeRepo.GetSummary(ctx, ingredient.SubRecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if subSummary.ID == 0 {
+			return nil, entity.ErrRecipeNotFound
+		}
+
+		scale, err := subRecipeScale(ingredient, subSummary.Recipe)
+		if err != nil {
+			return nil, err
+		}
+
+		visited[ingredient.SubRecipeID] = true
+		subIngredients, err := flattenRecipeIngredients(ctx, recipeRepo, subSummary, factor*scale, visited)
+		delete(visited, ingredient.SubRecipeID)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, subIngredients...)
+	}
+
+	return res, nil
+}
+
+// subRecipeScale returns the fraction of the sub-recipe used by a recipe ingredient row
+func subRecipeScale(ingredient *entity.RecipeIngredient, subRecipe entity.Recipe) (float64, error) {
+	unitName := strings.ToLower(strings.TrimSpace(ingredient.IngredientUnitName))
+
+	if subRecipe.YieldAmount.Valid && subRecipe.YieldAmount.Float64 > 0 && strings.EqualFold(unitName, subRecipe.YieldUnitName.String) {
+		return ingredient.Amount.Float64 / subRecipe.YieldAmount.Float64, nil
+	}
+
+	if servingUnitNames[unitName] && subRecipe.Servings > 0 {
+		return ingredient.Amount.Float64 / float64(subRecipe.Servings), nil
+	}
+
+	return 0, entity.ErrSubRecipeUnitMismatch
+}
+
+// mergeRecipeIngredients sums amounts of the same ingredient and unit while keeping the first seen order.
+// Rows to taste and optional rows are only merged with their own kind, and the ends of ranges are summed separately
+func mergeRecipeIngredients(ingredients entity.RecipeIngredients) entity.RecipeIngredients {
+	var res entity.RecipeIngredients
+	indexes := map[string]int{}
+
+	for _, ingredient := range ingredients {
+		key := fmt.Sprintf("%d|%s|%t|%t", ingredient.IngredientID, strings.ToLower(ingredient.IngredientUnitName), ingredient.IsToTaste(), ingredient.Optional)
+
+		if i, ok := indexes[key]; ok {
+			res[i] = res[i].AddAmount(*ingredient)
+			continue
+		}
+
+		indexes[key] = len(res)
+		merged := *ingredient
+		merged.OrderingIndex = len(res) + 1
+		res = append(res, &merged)
+	}
+
+	return res
 }
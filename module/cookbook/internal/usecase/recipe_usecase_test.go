@@ -1,21 +1,695 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
 	"github.com/stretchr/testify/assert"
 	"testing"
 
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 )
 
+// recipeUsecaseFixture holds a RecipeUsecase with its mocked repositories
+type recipeUsecaseFixture struct {
+	recipeRepo                 *mock.MockRecipeRepository
+	recipeIngredientRepo       *mock.MockRecipeIngredientRepository
+	ingredientRepo             *mock.MockIngredientRepository
+	ingredientUnitRepo         *mock.MockIngredientUnitRepository
+	ingredientSubstitutionRepo *mock.MockIngredientSubstitutionRepository
+	categoryRepo               *mock.MockCategoryRepository
+	tagRepo                    *mock.MockTagRepository
+	equipmentRepo              *mock.MockEquipmentRepository
+	uc                         *usecase.RecipeUsecase
+}
+
+// newRecipeUsecaseFixture instantiates RecipeUsecase with mocked repositories and the propagate rename policy
+func newRecipeUsecaseFixture(t *testing.T, reviewers ...string) recipeUsecaseFixture {
+	ctrl := gomock.NewController(t)
+
+	f := recipeUsecaseFixture{
+		recipeRepo:                 mock.NewMockRecipeRepository(ctrl),
+		recipeIngredientRepo:       mock.NewMockRecipeIngredientRepository(ctrl),
+		ingredientRepo:             mock.NewMockIngredientRepository(ctrl),
+		ingredientUnitRepo:         mock.NewMockIngredientUnitRepository(ctrl),
+		ingredientSubstitutionRepo: mock.NewMockIngredientSubstitutionRepository(ctrl),
+		categoryRepo:               mock.NewMockCategoryRepository(ctrl),
+		tagRepo:                    mock.NewMockTagRepository(ctrl),
+		equipmentRepo:              mock.NewMockEquipmentRepository(ctrl),
+	}
+
+	f.uc = usecase.NewRecipeUsecase(f.recipeRepo, f.recipeIngredientRepo, f.ingredientRepo, f.ingredientUnitRepo, f.ingredientSubstitutionRepo,
+		f.categoryRepo, f.tagRepo, f.equipmentRepo, usecase.RenamePolicyPropagate, reviewers)
+
+	return f
+}
+
 func TestNewRecipeUsecase(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	assert.NotEmpty(t, f.uc)
+}
+
+func TestRecipeUsecase_BulkCreateRecipeIngredients_RejectsCycle(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().ListSubRecipeIDs(gomock.Any(), uint64(2)).Return([]uint64{3, 1}, nil)
+
+	err := f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{
+		{SubRecipeID: 2, Amount: 1},
+	})
+
+	assert.Equal(t, entity.ErrRecipeCycle, err)
+}
+
+func TestRecipeUsecase_BulkCreateRecipeIngredients_ResolvesNames(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().ListSubRecipeIDs(gomock.Any(), uint64(2)).Return(nil, nil)
+
+	f.ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{4}).Return(entity.Ingredients{
+		{ID: 4, Name: "Bawang merah"},
+	}, nil)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(2)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 2, Name: "Bumbu Dasar Merah", Servings: 4},
+	}, nil)
+
+	f.recipeIngredientRepo.EXPECT().BulkCreate(gomock.Any(), uint64(1), usecase.BulkRecipeIngredientParams{
+		{IngredientID: 4, IngredientName: "Bawang merah", IngredientUnitName: "siung", Amount: 2},
+		{SubRecipeID: 2, IngredientName: "Bumbu Dasar Merah", IngredientUnitName: "porsi", Amount: 1},
+	}).Return(nil)
+
+	err := f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{
+		{IngredientID: 4, IngredientName: "bawang mrh", IngredientUnitName: "siung", Amount: 2},
+		{SubRecipeID: 2, IngredientName: "Bumbu", IngredientUnitName: "porsi", Amount: 1},
+	})
+
+	assert.NoError(t, err)
+
+	f.ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{99}).Return(nil, nil)
+
+	err = f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{
+		{IngredientID: 99, IngredientName: "Bawang merah", Amount: 2},
+	})
+
+	assert.Equal(t, entity.ErrIngredientNotFound, err)
+}
+
+func TestRecipeUsecase_CreateRecipe_RejectsDeletedCategory(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.categoryRepo.EXPECT().Get(gomock.Any(), uint64(3)).Return(nil, entity.ErrCategoryNotFound)
+
+	err := f.uc.CreateRecipe(context.Background(), usecase.CreateRecipeParams{
+		RecipeParams: usecase.RecipeParams{Name: "Nasi Goreng", CategoryID: 3},
+	})
+
+	assert.Equal(t, entity.ErrCategoryNotFound, err)
+}
+
+func TestRecipeUsecase_GetRecipeSummary_ExpandSubRecipes(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Nasi Goreng", Servings: 1},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 4, IngredientName: "Bawang merah", IngredientUnitName: "siung", Amount: null.FloatFrom(2)},
+			{ID: 11, SubRecipeID: 2, IngredientName: "Bumbu Dasar Merah", IngredientUnitName: "gram", Amount: null.FloatFrom(50)},
+		},
+	}, nil)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(2)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 2, Name: "Bumbu Dasar Merah", Servings: 4, YieldAmount: null.FloatFrom(200), YieldUnitName: null.StringFrom("gram")},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 20, IngredientID: 4, IngredientName: "Bawang merah", IngredientUnitName: "siung", Amount: null.FloatFrom(8)},
+			{ID: 21, IngredientID: 9, IngredientName: "Garam", IngredientUnitName: "sdt", Amount: null.FloatFrom(2)},
+		},
+	}, nil).Times(2)
+
+	f.ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{4, 9}).Return(entity.Ingredients{
+		{ID: 4, Name: "Bawang merah", Diets: []string{entity.DietVegetarian, entity.DietVegan, entity.DietHalal}},
+		{ID: 9, Name: "Garam", Diets: []string{entity.DietVegetarian, entity.DietVegan, entity.DietHalal}},
+	}, nil)
+
+	summary, err := f.uc.GetRecipeSummary(context.Background(), 1, usecase.RecipeSummaryOptions{ExpandSubRecipes: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, summary.FlattenedIngredients, 2)
+	assert.Equal(t, null.FloatFrom(4), summary.FlattenedIngredients[0].Amount)
+	assert.Equal(t, null.FloatFrom(0.5), summary.FlattenedIngredients[1].Amount)
+}
+
+func TestRecipeUsecase_GetRecipeSummary_DietaryLabels(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Gado-gado", Servings: 1},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 3, IngredientName: "Tahu", IngredientUnitName: "potong", Amount: null.FloatFrom(2)},
+			{ID: 11, SubRecipeID: 2, IngredientName: "Bumbu Kacang", IngredientUnitName: "porsi", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(2)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 2, Name: "Bumbu Kacang", Servings: 4},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 20, IngredientID: 7, IngredientName: "Kacang tanah", IngredientUnitName: "gram", Amount: null.FloatFrom(200)},
+			{ID: 21, IngredientID: 3, IngredientName: "Tahu", IngredientUnitName: "potong", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+
+	f.ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{3, 7}).Return(entity.Ingredients{
+		{ID: 3, Name: "Tahu", Allergens: []string{entity.AllergenSoy}, Diets: []string{entity.DietVegetarian, entity.DietVegan, entity.DietHalal}},
+		{ID: 7, Name: "Kacang tanah", Allergens: []string{entity.AllergenPeanut}, Diets: []string{entity.DietVegetarian, entity.DietHalal}},
+	}, nil)
+
+	summary, err := f.uc.GetRecipeSummary(context.Background(), 1, usecase.RecipeSummaryOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{entity.AllergenPeanut, entity.AllergenSoy}, summary.DietaryLabels.Allergens)
+	assert.Equal(t, []string{entity.DietVegetarian, entity.DietHalal}, summary.DietaryLabels.Diets)
+}
+
+func TestRecipeUsecase_GetRecipeSummary_DietaryLabelsOfDeletedIngredient(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Tahu Goreng", Servings: 1},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 3, IngredientName: "Tahu", IngredientUnitName: "potong", Amount: null.FloatFrom(2)},
+		},
+	}, nil)
+
+	// the ingredient is deleted but still in the recipe, its labels count as in the exclude_allergen and diet filters
+	f.ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{3}).Return(entity.Ingredients{
+		{ID: 3, Name: "Tahu", Allergens: []string{entity.AllergenSoy}, Diets: []string{entity.DietVegetarian}, IsDeleted: true},
+	}, nil)
+
+	summary, err := f.uc.GetRecipeSummary(context.Background(), 1, usecase.RecipeSummaryOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{entity.AllergenSoy}, summary.DietaryLabels.Allergens)
+	assert.Equal(t, []string{entity.DietVegetarian}, summary.DietaryLabels.Diets)
+}
+
+func TestRecipeUsecase_GetRecipeSummary_Substitute(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Tumis Cesim", Servings: 1},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 12, IngredientName: "Sayur cesim", IngredientUnitName: "ikat", Amount: null.FloatFrom(2)},
+			{ID: 11, IngredientID: 5, IngredientName: "Bawang putih", IngredientUnitName: "siung", Amount: null.FloatFrom(3)},
+		},
+	}, nil)
+
+	f.ingredientSubstitutionRepo.EXPECT().ListByIngredientIDs(gomock.Any(), []uint64{12}).Return(entity.IngredientSubstitutions{
+		{ID: 1, IngredientID: 12, SubstituteIngredientID: 30, SubstituteIngredientName: "Sawi hijau", Ratio: 1},
+		{ID: 2, IngredientID: 12, SubstituteIngredientID: 34, SubstituteIngredientName: "Pakcoy", Ratio: 1.5, IngredientUnitName: null.StringFrom("bonggol")},
+	}, nil)
+
+	f.ingredientRepo.EXPECT().ListAllByIDs(gomock.Any(), []uint64{34, 5}).Return(entity.Ingredients{
+		{ID: 34, Name: "Pakcoy"},
+		{ID: 5, Name: "Bawang putih"},
+	}, nil)
+
+	summary, err := f.uc.GetRecipeSummary(context.Background(), 1, usecase.RecipeSummaryOptions{Substitutes: map[uint64]uint64{12: 34}})
+
+	assert.NoError(t, err)
+	assert.Len(t, summary.Substitutions, 1)
+	assert.Equal(t, uint64(34), summary.Ingredients[0].IngredientID)
+	assert.Equal(t, "Pakcoy", summary.Ingredients[0].IngredientName)
+	assert.Equal(t, "bonggol", summary.Ingredients[0].IngredientUnitName)
+	assert.Equal(t, null.FloatFrom(3), summary.Ingredients[0].Amount)
+	assert.Equal(t, uint64(5), summary.Ingredients[1].IngredientID)
+}
+
+func TestRecipeUsecase_UpdateRecipe_UnknownTag(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.tagRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{1, 2}).Return(entity.Tags{{ID: 1, Name: "Pedas"}}, nil)
+
+	_, err := f.uc.UpdateRecipe(context.Background(), 7, usecase.RecipeParams{TagIDs: []uint64{1, 2, 1}})
+	assert.Equal(t, entity.ErrTagNotFound, err)
+}
+
+func TestRecipeUsecase_UpdateRecipe_InvalidDetails(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	_, err := f.uc.UpdateRecipe(context.Background(), 7, usecase.RecipeParams{PrepMinutes: -5})
+	assert.Equal(t, entity.ErrInvalidDuration, err)
+
+	_, err = f.uc.UpdateRecipe(context.Background(), 7, usecase.RecipeParams{Difficulty: "expert"})
+	assert.Equal(t, entity.ErrInvalidDifficulty, err)
+
+	f.equipmentRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{1, 4}).Return(entity.Equipments{{ID: 1, Name: "wajan"}}, nil)
+
+	_, err = f.uc.UpdateRecipe(context.Background(), 7, usecase.RecipeParams{EquipmentIDs: []uint64{1, 4, 1}})
+	assert.Equal(t, entity.ErrEquipmentNotFound, err)
+
+	f.equipmentRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{1}).Return(entity.Equipments{{ID: 1, Name: "wajan"}}, nil)
+	f.recipeRepo.EXPECT().Update(gomock.Any(), uint64(7), usecase.RecipeParams{
+		PrepMinutes: 10, CookMinutes: 15, Difficulty: entity.DifficultyEasy, EquipmentIDs: []uint64{1},
+	}).Return(&entity.Recipe{ID: 7}, nil)
+
+	_, err = f.uc.UpdateRecipe(context.Background(), 7, usecase.RecipeParams{
+		PrepMinutes: 10, CookMinutes: 15, Difficulty: " Easy ", EquipmentIDs: []uint64{1, 1},
+	})
+	assert.NoError(t, err)
+}
+
+func TestRecipeUsecase_ImportRecipe(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	document := `<html><head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@graph": [
+  {"@type": "WebPage", "name": "Resep"},
+  {"@type": ["Recipe"], "name": "Telur Puyuh Balado", "recipeCuisine": "Indonesian",
+   "prepTime": "PT15M", "cookTime": "PT1H", "recipeYield": ["4", "20 butir"],
+   "recipeIngredient": ["2 siung bawang putih, cincang halus", "1½ sdt garam", "3 butir telur puyuh", "100 g keju parmesan"],
+   "recipeInstructions": [{"@type": "HowToStep", "text": "Rebus telur."}, {"@type": "HowToStep", "text": "Tumis bumbu."}]}
+]}</script>
+</head></html>`
+
+	f.ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), []string{"siung", "sdt", "butir", "gram", "g"}).Return(entity.IngredientUnits{
+		{ID: 1, Name: "siung"}, {ID: 2, Name: "sdt"}, {ID: 3, Name: "gram"},
+	}, nil)
+
+	f.ingredientRepo.EXPECT().List(gomock.Any(), usecase.ListIngredientsFilter{}, 100, 0).Return(entity.Ingredients{
+		{ID: 5, Name: "Bawang putih"},
+		{ID: 6, Name: "Bawang merah"},
+		{ID: 9, Name: "Garam"},
+		{ID: 10, Name: "Telur ayam"},
+		{ID: 11, Name: "Telur puyuh"},
+	}, nil)
+
+	f.categoryRepo.EXPECT().Get(gomock.Any(), uint64(2)).Return(&entity.Category{ID: 2}, nil)
+	f.ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{5, 9, 11}).Return(entity.Ingredients{
+		{ID: 5, Name: "Bawang putih"}, {ID: 9, Name: "Garam"}, {ID: 11, Name: "Telur puyuh"},
+	}, nil)
+
+	var created usecase.CreateRecipeParams
+	f.recipeRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, params usecase.CreateRecipeParams) (*entity.Recipe, error) {
+		created = params
+		return &entity.Recipe{ID: 30, Name: params.Name}, nil
+	})
+
+	var ingredients usecase.BulkRecipeIngredientParams
+	f.recipeIngredientRepo.EXPECT().BulkCreate(gomock.Any(), uint64(30), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint64, params usecase.BulkRecipeIngredientParams) error {
+		ingredients = params
+		return nil
+	})
+
+	res, err := f.uc.ImportRecipe(context.Background(), usecase.ImportRecipeParams{Document: []byte(document), CategoryID: 2, Actor: "naufal"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(30), res.Recipe.ID)
+	assert.Equal(t, 3, res.Ingredients)
+	assert.Equal(t, entity.RecipeImportIssues{
+		{Line: "3 butir telur puyuh", Reason: entity.ImportIssueUnitNotFound},
+		{Line: "100 g keju parmesan", Reason: entity.ImportIssueIngredientNotFound},
+	}, res.Unmatched)
+
+	assert.Equal(t, "Telur Puyuh Balado", created.Name)
+	assert.Equal(t, "Rebus telur.\nTumis bumbu.", created.Description)
+	assert.Equal(t, "Indonesian", created.Cuisine)
+	assert.Equal(t, 15, created.PrepMinutes)
+	assert.Equal(t, 60, created.CookMinutes)
+	assert.Equal(t, 4, created.Servings)
+	assert.Equal(t, float64(20), created.YieldAmount)
+	assert.Equal(t, "butir", created.YieldUnitName)
+
+	assert.Len(t, ingredients, 3)
+	assert.Equal(t, usecase.RecipeIngredientParams{
+		Amount: 2, IngredientID: 5, IngredientName: "Bawang putih", IngredientUnitName: "siung", OrderingIndex: 1, Notes: "cincang halus", Actor: "naufal",
+	}, ingredients[0])
+	assert.Equal(t, 1.5, ingredients[1].Amount)
+	assert.Equal(t, "sdt", ingredients[1].IngredientUnitName)
+	assert.Equal(t, "butir", ingredients[2].IngredientUnitName)
+}
+
+func TestRecipeUsecase_ImportRecipe_InvalidDocument(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	_, err := f.uc.ImportRecipe(context.Background(), usecase.ImportRecipeParams{Document: []byte(`{"@type": "Article", "name": "Bukan resep"}`)})
+	assert.Equal(t, entity.ErrInvalidRecipeDocument, err)
+
+	_, err = f.uc.ImportRecipe(context.Background(), usecase.ImportRecipeParams{Document: []byte(`<html><body>no recipe</body></html>`)})
+	assert.Equal(t, entity.ErrInvalidRecipeDocument, err)
+}
+
+func TestRecipeUsecase_ParseIngredientLines(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), []string{"sdt", "tsp", "bonggol"}).Return(entity.IngredientUnits{
+		{ID: 2, Name: "sdt"}, {ID: 7, Name: "bonggol"},
+	}, nil)
+
+	f.ingredientRepo.EXPECT().List(gomock.Any(), usecase.ListIngredientsFilter{}, 100, 0).Return(entity.Ingredients{
+		{ID: 9, Name: "Garam", Aliases: []string{"salt"}},
+		{ID: 34, Name: "Pakcoy"},
+	}, nil)
+
+	lines, err := f.uc.ParseIngredientLines(context.Background(), []string{"1/2 tsp salt", "2-3 bonggol pakcoy, potong dua", "sejumput gula"})
+
+	assert.NoError(t, err)
+	assert.Len(t, lines, 3)
+
+	assert.Equal(t, entity.ParsedIngredientCandidates{
+		{Amount: 0.5, AmountDenominator: 2, IngredientID: 9, IngredientName: "Garam", IngredientUnitName: "sdt", UnitMatched: true, Confidence: 1},
+	}, lines[0].Candidates)
+
+	assert.Equal(t, entity.ParsedIngredientCandidates{
+		{Amount: 2, AmountMax: 3, IngredientID: 34, IngredientName: "Pakcoy", IngredientUnitName: "bonggol", UnitMatched: true, Notes: "potong dua", Confidence: 1},
+	}, lines[1].Candidates)
+
+	assert.Empty(t, lines[2].Candidates)
+}
+
+func TestRecipeUsecase_BulkCreateRecipeIngredients_Lines(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.ingredientUnitRepo.EXPECT().ListByNames(gomock.Any(), gomock.Any()).Return(entity.IngredientUnits{{ID: 1, Name: "siung"}}, nil).Times(2)
+	f.ingredientRepo.EXPECT().List(gomock.Any(), usecase.ListIngredientsFilter{}, 100, 0).Return(entity.Ingredients{
+		{ID: 5, Name: "Bawang putih"},
+		{ID: 6, Name: "Bawang merah"},
+	}, nil).Times(2)
+
+	f.ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{5}).Return(entity.Ingredients{{ID: 5, Name: "Bawang putih"}}, nil)
+	f.recipeIngredientRepo.EXPECT().BulkCreate(gomock.Any(), uint64(1), usecase.BulkRecipeIngredientParams{
+		{Line: "2 siung bawang putih, cincang halus", Amount: 2, IngredientID: 5, IngredientName: "Bawang putih", IngredientUnitName: "siung", Notes: "cincang halus", OrderingIndex: 1},
+	}).Return(nil)
+
+	err := f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{
+		{Line: "2 siung bawang putih, cincang halus", OrderingIndex: 1},
+	})
+	assert.NoError(t, err)
+
+	err = f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{
+		{Line: "2 siung bawang"},
+	})
+	assert.Equal(t, entity.ErrIngredientLineNotMatched, err)
+}
+
+func TestRecipeUsecase_BulkCreateRecipeIngredients_Amounts(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	optional := true
+
+	f.ingredientRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{11, 12, 9}).Return(entity.Ingredients{
+		{ID: 9, Name: "Garam"},
+		{ID: 11, Name: "Santan"},
+		{ID: 12, Name: "Cabai rawit"},
+	}, nil)
+	f.recipeIngredientRepo.EXPECT().BulkCreate(gomock.Any(), uint64(1), usecase.BulkRecipeIngredientParams{
+		{AmountText: "1/3", Amount: 1.0 / 3, AmountDenominator: 3, IngredientID: 11, IngredientName: "Santan", IngredientUnitName: "cangkir"},
+		{Amount: 2, AmountMax: 3, IngredientID: 12, IngredientName: "Cabai rawit", IngredientUnitName: "buah", Optional: &optional},
+		{ToTaste: true, IngredientID: 9, IngredientName: "Garam"},
+	}).Return(nil)
+
+	err := f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{
+		{AmountText: "1/3", IngredientID: 11, IngredientUnitName: "cangkir"},
+		{Amount: 2, AmountMax: 3, IngredientID: 12, IngredientUnitName: "buah", Optional: &optional},
+		{ToTaste: true, IngredientID: 9},
+	})
+	assert.NoError(t, err)
+
+	f.recipeRepo.EXPECT().ListSubRecipeIDs(gomock.Any(), uint64(2)).Return(nil, nil)
+
+	for _, params := range []usecase.RecipeIngredientParams{
+		{Amount: 3, AmountMax: 2, IngredientID: 12},
+		{Amount: 1, ToTaste: true, IngredientID: 9},
+		{AmountText: "dua sendok", IngredientID: 9},
+		{AmountMax: 2, Amount: 1, SubRecipeID: 2},
+	} {
+		err = f.uc.BulkCreateRecipeIngredients(context.Background(), 1, usecase.BulkRecipeIngredientParams{params})
+		assert.Equal(t, entity.ErrInvalidAmount, err)
+	}
+}
+
+func TestRecipeUsecase_GetCategoryBooklet(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.categoryRepo.EXPECT().Get(gomock.Any(), uint64(3)).Return(&entity.Category{ID: 3, Name: "Nasi"}, nil)
+	f.recipeRepo.EXPECT().List(gomock.Any(), usecase.ListRecipesFiter{CategoryID: 3, IncludeSubcategories: true, Statuses: []string{entity.RecipeStatusPublished}}, 100, 0).Return(entity.Recipes{
+		{ID: 7, Name: "Nasi Uduk"},
+		{ID: 5, Name: "nasi goreng"},
+		{ID: 6, Name: "Nasi Kuning"},
+	}, nil)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 5, Name: "nasi goreng"}}, nil)
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(6)).Return(entity.RecipeSummary{}, nil)
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(7)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 7, Name: "Nasi Uduk"}}, nil)
+
+	booklet, err := f.uc.GetCategoryBooklet(context.Background(), 3, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Nasi", booklet.Category.Name)
+	assert.Len(t, booklet.Recipes, 2)
+	assert.Equal(t, uint64(5), booklet.Recipes[0].ID)
+	assert.Equal(t, uint64(7), booklet.Recipes[1].ID)
+
+	f.categoryRepo.EXPECT().Get(gomock.Any(), uint64(4)).Return(nil, entity.ErrCategoryNotFound)
+
+	_, err = f.uc.GetCategoryBooklet(context.Background(), 4, false)
+	assert.Equal(t, entity.ErrCategoryNotFound, err)
+}
+
+func TestRecipeUsecase_ListRecipes_SortAndMinRating(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	_, err := f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{Sort: "-name"}, 0, 0)
+	assert.Equal(t, entity.ErrInvalidRecipeSort, err)
+
+	_, err = f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{MinRating: 6}, 0, 0)
+	assert.Equal(t, entity.ErrInvalidRating, err)
+
+	f.recipeRepo.EXPECT().List(gomock.Any(), usecase.ListRecipesFiter{Sort: usecase.RecipeSortRatingDesc, MinRating: 4, Statuses: []string{entity.RecipeStatusPublished}}, 20, 0).
+		Return(entity.Recipes{{ID: 3, AverageRating: null.FloatFrom(4.5), RatingCount: 2, CookCount: 3}}, nil)
+
+	recipes, err := f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{Sort: " -rating ", MinRating: 4}, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, recipes, 1)
+}
+
+func TestRecipeUsecase_TransitionRecipe(t *testing.T) {
+	f := newRecipeUsecaseFixture(t, " Sari ")
+
+	_, err := f.uc.TransitionRecipe(context.Background(), 5, usecase.RecipeTransitionParams{Action: "delete", Actor: "Naufal"})
+	assert.Equal(t, entity.ErrInvalidRecipeAction, err)
+
+	inReview := entity.RecipeSummary{Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng", Status: entity.RecipeStatusInReview, CreatedBy: "Naufal"}}
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(inReview, nil).Times(4)
+
+	// only reviewers approve, and the recipe is not visible to others while in review
+	_, err = f.uc.TransitionRecipe(context.Background(), 5, usecase.RecipeTransitionParams{Action: entity.RecipeActionApprove, Actor: "Naufal"})
+	assert.Equal(t, entity.ErrRecipeTransitionForbidden, err)
+
+	_, err = f.uc.TransitionRecipe(context.Background(), 5, usecase.RecipeTransitionParams{Action: entity.RecipeActionSubmit, Actor: "Budi"})
+	assert.Equal(t, entity.ErrRecipeNotFound, err)
+
+	_, err = f.uc.TransitionRecipe(context.Background(), 5, usecase.RecipeTransitionParams{Action: entity.RecipeActionReject, Actor: "sari"})
+	assert.Equal(t, entity.ErrReviewCommentRequired, err)
+
+	f.recipeRepo.EXPECT().UpdateStatus(gomock.Any(), uint64(5), usecase.RecipeStatusParams{
+		Action:  entity.RecipeActionReject,
+		From:    entity.RecipeStatusInReview,
+		To:      entity.RecipeStatusDraft,
+		Comment: "Kurang garam",
+		Actor:   "sari",
+	}).Return(nil)
+
+	recipe, err := f.uc.TransitionRecipe(context.Background(), 5, usecase.RecipeTransitionParams{Action: entity.RecipeActionReject, Comment: " Kurang garam ", Actor: "sari"})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RecipeStatusDraft, recipe.Status)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(6)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 6, Status: entity.RecipeStatusPublished, CreatedBy: "Naufal"}}, nil)
+
+	_, err = f.uc.TransitionRecipe(context.Background(), 6, usecase.RecipeTransitionParams{Action: entity.RecipeActionSubmit, Actor: "Naufal"})
+	assert.Equal(t, entity.ErrInvalidRecipeTransition, err)
+}
+
+func TestRecipeUsecase_RecipeVisibility(t *testing.T) {
+	f := newRecipeUsecaseFixture(t, "Sari")
+
+	// a draft is not found by anyone but its author and the reviewers
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 5, Status: entity.RecipeStatusDraft, CreatedBy: "Naufal"}}, nil)
+
+	summary, err := f.uc.GetRecipeSummary(context.Background(), 5, usecase.RecipeSummaryOptions{Viewer: "Budi"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), summary.ID)
+
+	// non-reviewers only list their own drafts, and must name themselves to do so
+	_, err = f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{Statuses: []string{entity.RecipeStatusDraft}}, 0, 0)
+	assert.Equal(t, entity.ErrRecipeStatusFilterForbidden, err)
+
+	_, err = f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{Statuses: []string{"deleted"}}, 0, 0)
+	assert.Equal(t, entity.ErrInvalidRecipeStatus, err)
+
+	f.recipeRepo.EXPECT().List(gomock.Any(), usecase.ListRecipesFiter{
+		Statuses:    []string{entity.RecipeStatusDraft, entity.RecipeStatusInReview},
+		DraftAuthor: null.StringFrom("Naufal"),
+		Viewer:      "Naufal",
+	}, 20, 0).Return(entity.Recipes{{ID: 5}}, nil)
+
+	_, err = f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{Statuses: []string{"draft", "in_review", "draft"}, Viewer: "Naufal"}, 0, 0)
+	assert.NoError(t, err)
+
+	f.recipeRepo.EXPECT().List(gomock.Any(), usecase.ListRecipesFiter{Statuses: []string{entity.RecipeStatusInReview}, Viewer: "Sari"}, 20, 0).Return(entity.Recipes{{ID: 5}}, nil)
+
+	_, err = f.uc.ListRecipes(context.Background(), usecase.ListRecipesFiter{Statuses: []string{"in_review"}, Viewer: "Sari"}, 0, 0)
+	assert.NoError(t, err)
+}
+
+func TestRecipeUsecase_CloneRecipe(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng", Status: entity.RecipeStatusPublished}}, nil).Times(2)
+	f.recipeRepo.EXPECT().Clone(gomock.Any(), uint64(5), usecase.CloneRecipeParams{Name: "Nasi Goreng (copy)", Actor: "Naufal"}).
+		Return(&entity.Recipe{ID: 9, Name: "Nasi Goreng (copy)", Status: entity.RecipeStatusDraft}, nil)
+	f.recipeRepo.EXPECT().Clone(gomock.Any(), uint64(5), usecase.CloneRecipeParams{Name: "Nasi Goreng Seafood", Variant: true, Actor: "Naufal"}).
+		Return(&entity.Recipe{ID: 10, Name: "Nasi Goreng Seafood", Status: entity.RecipeStatusDraft, VariantOfID: null.IntFrom(5)}, nil)
+
+	recipe, err := f.uc.CloneRecipe(context.Background(), 5, usecase.CloneRecipeParams{Actor: "Naufal"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), recipe.ID)
+
+	recipe, err = f.uc.CloneRecipe(context.Background(), 5, usecase.CloneRecipeParams{Name: " Nasi Goreng Seafood ", Variant: true, Actor: "Naufal"})
+	assert.NoError(t, err)
+	assert.Equal(t, null.IntFrom(5), recipe.VariantOfID)
+
+	// the draft of someone else cannot be cloned
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(6)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 6, Status: entity.RecipeStatusDraft, CreatedBy: "Sari"}}, nil)
+
+	_, err = f.uc.CloneRecipe(context.Background(), 6, usecase.CloneRecipeParams{Actor: "Naufal"})
+	assert.Equal(t, entity.ErrRecipeNotFound, err)
+}
+
+func TestRecipeUsecase_GetRecipeFamily(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	// the deleted variant 6 is left out and its variant 8 hangs from the root instead
+	f.recipeRepo.EXPECT().ListFamily(gomock.Any(), uint64(8)).Return(entity.Recipes{
+		{ID: 5, Name: "Nasi Goreng", Status: entity.RecipeStatusPublished},
+		{ID: 6, Name: "Nasi Goreng Kampung", Status: entity.RecipeStatusPublished, VariantOfID: null.IntFrom(5), IsDeleted: true},
+		{ID: 7, Name: "Nasi Goreng Pedas", Status: entity.RecipeStatusDraft, VariantOfID: null.IntFrom(5), CreatedBy: "Sari"},
+		{ID: 8, Name: "Nasi Goreng Teri", Status: entity.RecipeStatusPublished, VariantOfID: null.IntFrom(6)},
+	}, nil)
+
+	family, err := f.uc.GetRecipeFamily(context.Background(), 8, "Naufal")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), family.Recipe.ID)
+	assert.Len(t, family.Variants, 1)
+	assert.Equal(t, uint64(8), family.Variants[0].Recipe.ID)
+}
+
+func TestRecipeUsecase_GetRecipeParentChanges(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(10)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 10, Name: "Nasi Goreng Seafood", Status: entity.RecipeStatusPublished, VariantOfID: null.IntFrom(5)},
+	}, nil)
+	f.recipeRepo.EXPECT().GetForkSnapshot(gomock.Any(), uint64(10)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng", Servings: 2, Description: "Tumis bumbu\nMasukkan nasi"},
+		Ingredients: entity.RecipeIngredients{
+			{IngredientID: 1, IngredientName: "Beras", IngredientUnitName: "gram", Amount: null.FloatFrom(200)},
+			{IngredientID: 2, IngredientName: "Telur", IngredientUnitName: "butir", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng", Servings: 4, Status: entity.RecipeStatusPublished, Description: "Tumis bumbu\nMasukkan nasi\nSajikan"},
+		Ingredients: entity.RecipeIngredients{
+			{IngredientID: 1, IngredientName: "Beras", IngredientUnitName: "gram", Amount: null.FloatFrom(400)},
+			{IngredientID: 3, IngredientName: "Kecap", IngredientUnitName: "sdm", Amount: null.FloatFrom(1)},
+		},
+	}, nil)
+
+	changes, err := f.uc.GetRecipeParentChanges(context.Background(), 10, "")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), changes.ParentID)
+	assert.Equal(t, []entity.RecipeFieldChange{{Field: "servings", Before: null.StringFrom("2"), After: null.StringFrom("4")}}, changes.Fields)
+	assert.Equal(t, []entity.RecipeStepChange{{Step: 3, After: null.StringFrom("Sajikan")}}, changes.Steps)
+	assert.Len(t, changes.Ingredients, 3)
+	assert.Equal(t, entity.RecipeIngredientChanged, changes.Ingredients[0].Change)
+	assert.Equal(t, entity.RecipeIngredientAdded, changes.Ingredients[1].Change)
+	assert.Equal(t, "Telur", changes.Ingredients[2].Before.IngredientName)
+
+	// a recipe cloned without the variant link has nothing to compare with
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(9)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 9, Status: entity.RecipeStatusPublished}}, nil)
+	f.recipeRepo.EXPECT().GetForkSnapshot(gomock.Any(), uint64(9)).Return(entity.RecipeSummary{}, nil)
+
+	_, err = f.uc.GetRecipeParentChanges(context.Background(), 9, "")
+	assert.Equal(t, entity.ErrRecipeNotVariant, err)
+}
+
+func TestRecipeUsecase_GetRecipeParentChanges_HiddenParent(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	// the variant is the viewer's own, but its parent went back to being somebody else's draft
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(10)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 10, Name: "Nasi Goreng Seafood", Status: entity.RecipeStatusDraft, CreatedBy: "Andi", VariantOfID: null.IntFrom(5)},
+	}, nil)
+	f.recipeRepo.EXPECT().GetForkSnapshot(gomock.Any(), uint64(10)).Return(entity.RecipeSummary{Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng"}}, nil)
+	f.recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(5)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 5, Name: "Nasi Goreng", Status: entity.RecipeStatusDraft, CreatedBy: "Budi"},
+	}, nil)
+
+	_, err := f.uc.GetRecipeParentChanges(context.Background(), 10, "Andi")
+	assert.Equal(t, entity.ErrRecipeNotFound, err)
+}
+
+func TestRecipeUsecase_RepairRecipeIngredientNames_RefusedUnderSnapshot(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	recipeRepo := mock.NewMockRecipeRepository(ctrl)
-	recipeIngredientRepo := mock.NewMockRecipeIngredientRepository(ctrl)
+	// the recipe ingredient repository expects no call, the drifted names are kept
+	uc := usecase.NewRecipeUsecase(mock.NewMockRecipeRepository(ctrl), mock.NewMockRecipeIngredientRepository(ctrl), mock.NewMockIngredientRepository(ctrl),
+		mock.NewMockIngredientUnitRepository(ctrl), mock.NewMockIngredientSubstitutionRepository(ctrl), mock.NewMockCategoryRepository(ctrl),
+		mock.NewMockTagRepository(ctrl), mock.NewMockEquipmentRepository(ctrl), usecase.RenamePolicySnapshot, nil)
+
+	repaired, err := uc.RepairRecipeIngredientNames(context.Background(), "check-names")
+
+	assert.ErrorIs(t, err, entity.ErrRecipeIngredientNameSnapshot)
+	assert.Zero(t, repaired)
+}
+
+func TestRecipeUsecase_ListRecipeNames_HidesDrafts(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	f.recipeRepo.EXPECT().ListByIDs(gomock.Any(), []uint64{1, 2, 3}).Return(entity.Recipes{
+		{ID: 1, Name: "Nasi Goreng", Status: entity.RecipeStatusPublished, CreatedBy: "Naufal"},
+		{ID: 2, Name: "Soto Betawi", Status: entity.RecipeStatusDraft, CreatedBy: "Naufal"},
+		{ID: 3, Name: "Rendang", Status: entity.RecipeStatusDraft, CreatedBy: "Sari"},
+	}, nil)
+
+	names, err := f.uc.ListRecipeNames(context.Background(), []uint64{1, 2, 3}, "Naufal")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint64]string{1: "Nasi Goreng", 2: "Soto Betawi"}, names)
+}
+
+func TestRecipeUsecase_DeleteRecipe_UsedAsSubRecipe(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
+
+	// the summaries of the recipes using it would no longer find the sub-recipe, so it is not deleted
+	f.recipeRepo.EXPECT().ListReferencingRecipes(gomock.Any(), uint64(2)).Return(entity.RecipeReferences{
+		{RecipeID: 1, RecipeName: "Gado-gado"},
+	}, nil)
+
+	err := f.uc.DeleteRecipe(context.Background(), 2)
+
+	var conflict *entity.ReferenceConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Len(t, conflict.Recipes, 1)
+}
+
+func TestRecipeUsecase_DeleteRecipe(t *testing.T) {
+	f := newRecipeUsecaseFixture(t)
 
-	uc := usecase.NewRecipeUsecase(recipeRepo, recipeIngredientRepo)
+	f.recipeRepo.EXPECT().ListReferencingRecipes(gomock.Any(), uint64(2)).Return(entity.RecipeReferences{}, nil)
+	f.recipeRepo.EXPECT().Delete(gomock.Any(), uint64(2)).Return(nil)
 
-	assert.NotEmpty(t, uc)
+	assert.NoError(t, f.uc.DeleteRecipe(context.Background(), 2))
 }
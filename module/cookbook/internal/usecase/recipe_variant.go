@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// CloneRecipeParams holds a clone of a recipe, Name defaults to the name of the recipe followed by "(copy)".
+// A variant keeps a link to the recipe it was cloned from along with a snapshot of it, to see what changed since
+type CloneRecipeParams struct {
+	Name    string
+	Variant bool
+	Actor   string
+}
+
+// CloneRecipe deep-copies a recipe visible to the actor with its ingredients, steps, tags and equipment into a new draft.
+// Images, the recipe photos as well as the photos of its steps, and cook logs belong to the original recipe and are
+// not copied: an image shares its stored files with no other recipe, so that deleting it never removes a file still in use
+func (u *RecipeUsecase) CloneRecipe(ctx context.Context, id uint64, params CloneRecipeParams) (*entity.Recipe, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, params.Actor) {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" {
+		params.Name = fmt.Sprintf("%s (copy)", summary.Name)
+	}
+
+	return u.recipeRepo.Clone(ctx, id, params)
+}
+
+// GetRecipeFamily retrieves the family tree of a recipe from the recipe its variants were first cloned from.
+// Deleted recipes and drafts the viewer cannot see are left out, their variants hang from the nearest shown ancestor
+func (u *RecipeUsecase) GetRecipeFamily(ctx context.Context, id uint64, viewer string) (*entity.RecipeFamily, error) {
+	recipes, err := u.recipeRepo.ListFamily(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *entity.Recipe
+	variants := map[uint64]entity.Recipes{}
+
+	for _, recipe := range recipes {
+		if recipe.ID == id && (recipe.IsDeleted || !u.reviewers.canViewRecipe(*recipe, viewer)) {
+			return nil, entity.ErrRecipeNotFound
+		}
+
+		if !recipe.VariantOfID.Valid {
+			root = recipe
+			continue
+		}
+
+		parentID := uint64(recipe.VariantOfID.Int64)
+		variants[parentID] = append(variants[parentID], recipe)
+	}
+
+	if root == nil {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	// a hidden root leaves several families, the requested recipe is in one of them
+	for _, family := range u.recipeFamilies(root, variants, viewer) {
+		if family.Recipe.ID == id || familyContains(family, id) {
+			return family, nil
+		}
+	}
+
+	return nil, entity.ErrRecipeNotFound
+}
+
+// recipeFamilies builds the shown families of a recipe, a hidden recipe is replaced by the families of its variants
+func (u *RecipeUsecase) recipeFamilies(recipe *entity.Recipe, variants map[uint64]entity.Recipes, viewer string) []*entity.RecipeFamily {
+	var children []*entity.RecipeFamily
+	for _, variant := range variants[recipe.ID] {
+		children = append(children, u.recipeFamilies(variant, variants, viewer)...)
+	}
+
+	if recipe.IsDeleted || !u.reviewers.canViewRecipe(*recipe, viewer) {
+		return children
+	}
+
+	return []*entity.RecipeFamily{{Recipe: recipe, Variants: children}}
+}
+
+// familyContains returns true when a recipe is somewhere in a family
+func familyContains(family *entity.RecipeFamily, id uint64) bool {
+	for _, variant := range family.Variants {
+		if variant.Recipe.ID == id || familyContains(variant, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRecipeParentChanges compares the parent of a variant as it was when the variant was forked with the parent
+// as it is now, the fields, the steps and the ingredients that differ are listed
+func (u *RecipeUsecase) GetRecipeParentChanges(ctx context.Context, id uint64, viewer string) (entity.RecipeParentChanges, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, id)
+	if err != nil {
+		return entity.RecipeParentChanges{}, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, viewer) {
+		return entity.RecipeParentChanges{}, entity.ErrRecipeNotFound
+	}
+
+	snapshot, err := u.recipeRepo.GetForkSnapshot(ctx, id)
+	if err != nil {
+		return entity.RecipeParentChanges{}, err
+	}
+
+	if !summary.VariantOfID.Valid || snapshot.ID == 0 {
+		return entity.RecipeParentChanges{}, entity.ErrRecipeNotVariant
+	}
+
+	parent, err := u.recipeRepo.GetSummary(ctx, uint64(summary.VariantOfID.Int64))
+	if err != nil {
+		return entity.RecipeParentChanges{}, err
+	}
+
+	// a parent the viewer cannot see is not found, so that its drafts are not disclosed through the variant
+	if parent.ID == 0 || !u.reviewers.canViewRecipe(parent.Recipe, viewer) {
+		return entity.RecipeParentChanges{}, entity.ErrRecipeNotFound
+	}
+
+	return entity.RecipeParentChanges{
+		RecipeID:    id,
+		ParentID:    parent.ID,
+		ForkedAt:    summary.ForkedAt.Time,
+		Fields:      recipeFieldChanges(snapshot.Recipe, parent.Recipe),
+		Steps:       recipeStepChanges(snapshot.Steps(), parent.Steps()),
+		Ingredients: recipeIngredientChanges(snapshot.Ingredients, parent.Ingredients),
+	}, nil
+}
+
+// recipeFieldChanges lists the recipe fields that differ, the description is compared step by step instead
+func recipeFieldChanges(before, after entity.Recipe) []entity.RecipeFieldChange {
+	fields := []struct {
+		name          string
+		before, after null.String
+	}{
+		{"name", null.StringFrom(before.Name), null.StringFrom(after.Name)},
+		{"category_id", null.StringFrom(strconv.FormatUint(before.CategoryID, 10)), null.StringFrom(strconv.FormatUint(after.CategoryID, 10))},
+		{"servings", null.StringFrom(strconv.Itoa(before.Servings)), null.StringFrom(strconv.Itoa(after.Servings))},
+		{"yield_amount", formatNullFloat(before.YieldAmount), formatNullFloat(after.YieldAmount)},
+		{"yield_unit_name", before.YieldUnitName, after.YieldUnitName},
+		{"cuisine", before.Cuisine, after.Cuisine},
+		{"prep_minutes", formatNullInt(before.PrepMinutes), formatNullInt(after.PrepMinutes)},
+		{"cook_minutes", formatNullInt(before.CookMinutes), formatNullInt(after.CookMinutes)},
+		{"difficulty", before.Difficulty, after.Difficulty},
+	}
+
+	var res []entity.RecipeFieldChange
+	for _, field := range fields {
+		if field.before != field.after {
+			res = append(res, entity.RecipeFieldChange{Field: field.name, Before: field.before, After: field.after})
+		}
+	}
+
+	return res
+}
+
+// recipeStepChanges compares the steps position by position
+func recipeStepChanges(before, after []string) []entity.RecipeStepChange {
+	var res []entity.RecipeStepChange
+
+	for i := 0; i < len(before) || i < len(after); i++ {
+		var change entity.RecipeStepChange
+		if i < len(before) {
+			change.Before = null.StringFrom(before[i])
+		}
+
+		if i < len(after) {
+			change.After = null.StringFrom(after[i])
+		}
+
+		if change.Before != change.After {
+			change.Step = i + 1
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
+
+// recipeIngredientChanges matches the ingredients by sub-recipe, ingredient or name and lists the added, removed and
+// changed ones in the order of the parent, the removed ones last
+func recipeIngredientChanges(before, after entity.RecipeIngredients) []entity.RecipeIngredientChange {
+	previous := map[string]*entity.RecipeIngredient{}
+	for _, ingredient := range before {
+		previous[recipeIngredientKey(ingredient)] = ingredient
+	}
+
+	var res []entity.RecipeIngredientChange
+	seen := map[string]bool{}
+
+	for _, ingredient := range after {
+		key := recipeIngredientKey(ingredient)
+		seen[key] = true
+
+		old, ok := previous[key]
+		if !ok {
+			res = append(res, entity.RecipeIngredientChange{Change: entity.RecipeIngredientAdded, After: ingredient})
+			continue
+		}
+
+		if !sameRecipeIngredient(old, ingredient) {
+			res = append(res, entity.RecipeIngredientChange{Change: entity.RecipeIngredientChanged, Before: old, After: ingredient})
+		}
+	}
+
+	for _, ingredient := range before {
+		if !seen[recipeIngredientKey(ingredient)] {
+			res = append(res, entity.RecipeIngredientChange{Change: entity.RecipeIngredientRemoved, Before: ingredient})
+		}
+	}
+
+	return res
+}
+
+func recipeIngredientKey(ingredient *entity.RecipeIngredient) string {
+	if ingredient.SubRecipeID > 0 {
+		return fmt.Sprintf("recipe:%d", ingredient.SubRecipeID)
+	}
+
+	if ingredient.IngredientID > 0 {
+		return fmt.Sprintf("ingredient:%d", ingredient.IngredientID)
+	}
+
+	return "name:" + strings.ToLower(ingredient.IngredientName)
+}
+
+// sameRecipeIngredient returns true when the amounts, unit, notes and name of two ingredients are the same
+func sameRecipeIngredient(a, b *entity.RecipeIngredient) bool {
+	return a.IngredientName == b.IngredientName && a.IngredientUnitName == b.IngredientUnitName &&
+		a.Amount == b.Amount && a.AmountMax == b.AmountMax && a.AmountDenominator == b.AmountDenominator &&
+		a.Optional == b.Optional && a.Notes == b.Notes
+}
+
+func formatNullFloat(f null.Float) null.String {
+	return null.NewString(strconv.FormatFloat(f.Float64, 'f', -1, 64), f.Valid)
+}
+
+func formatNullInt(i null.Int) null.String {
+	return null.NewString(strconv.FormatInt(i.Int64, 10), i.Valid)
+}
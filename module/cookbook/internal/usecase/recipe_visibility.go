@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// reviewerSet holds the names allowed to see and review every recipe, compared case-insensitively
+type reviewerSet map[string]bool
+
+// newReviewerSet instantiates reviewerSet, blank names are ignored
+func newReviewerSet(names []string) reviewerSet {
+	set := reviewerSet{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			set[name] = true
+		}
+	}
+
+	return set
+}
+
+// contains returns true when name is one of the reviewers
+func (s reviewerSet) contains(name string) bool {
+	return s[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// canViewRecipe returns true when a recipe is live, or when the viewer is its author or a reviewer
+func (s reviewerSet) canViewRecipe(recipe entity.Recipe, viewer string) bool {
+	return !entity.IsUnpublishedDraft(recipe.Status) || isRecipeAuthor(recipe, viewer) || s.contains(viewer)
+}
+
+// isRecipeAuthor returns true when name created the recipe
+func isRecipeAuthor(recipe entity.Recipe, name string) bool {
+	name = strings.TrimSpace(name)
+	return name != "" && strings.EqualFold(recipe.CreatedBy, name)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/ingredient_substitution_repo.go -source=substitution_usecase.go -package=mock IngredientSubstitutionRepository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type IngredientSubstitutionParams struct {
+	IngredientID           uint64
+	SubstituteIngredientID uint64
+	Ratio                  float64
+	IngredientUnitName     string
+	Notes                  string
+	Actor                  string
+}
+
+// IngredientSubstitutionRepository defines contract for ingredient substitution repository dependency
+type IngredientSubstitutionRepository interface {
+	Create(ctx context.Context, params IngredientSubstitutionParams) (*entity.IngredientSubstitution, error)
+	Update(ctx context.Context, id uint64, params IngredientSubstitutionParams) (*entity.IngredientSubstitution, error)
+	Delete(ctx context.Context, id uint64) error
+	ListByIngredientIDs(ctx context.Context, ingredientIDs []uint64) (entity.IngredientSubstitutions, error)
+}
+
+// SubstitutionUsecase is our substitution usecase object
+type SubstitutionUsecase struct {
+	recipeRepo                 RecipeRepository
+	ingredientSubstitutionRepo IngredientSubstitutionRepository
+	reviewers                  reviewerSet
+}
+
+// NewSubstitutionUsecase instantiates SubstitutionUsecase, reviewers can see the recipes that are not published yet
+func NewSubstitutionUsecase(recipeRepo RecipeRepository, ingredientSubstitutionRepo IngredientSubstitutionRepository, reviewers []string) *SubstitutionUsecase {
+	return &SubstitutionUsecase{
+		recipeRepo:                 recipeRepo,
+		ingredientSubstitutionRepo: ingredientSubstitutionRepo,
+		reviewers:                  newReviewerSet(reviewers),
+	}
+}
+
+// CreateIngredientSubstitution creates a new substitute of an ingredient
+func (u *SubstitutionUsecase) CreateIngredientSubstitution(ctx context.Context, params IngredientSubstitutionParams) (*entity.IngredientSubstitution, error) {
+	if params.Ratio <= 0 {
+		params.Ratio = 1
+	}
+
+	return u.ingredientSubstitutionRepo.Create(ctx, params)
+}
+
+// UpdateIngredientSubstitution updates an ingredient substitution
+func (u *SubstitutionUsecase) UpdateIngredientSubstitution(ctx context.Context, id uint64, params IngredientSubstitutionParams) (*entity.IngredientSubstitution, error) {
+	return u.ingredientSubstitutionRepo.Update(ctx, id, params)
+}
+
+// DeleteIngredientSubstitution deletes an ingredient substitution
+func (u *SubstitutionUsecase) DeleteIngredientSubstitution(ctx context.Context, id uint64) error {
+	return u.ingredientSubstitutionRepo.Delete(ctx, id)
+}
+
+// ListIngredientSubstitutions retrieves the substitutes of an ingredient
+func (u *SubstitutionUsecase) ListIngredientSubstitutions(ctx context.Context, ingredientID uint64) (entity.IngredientSubstitutions, error) {
+	return u.ingredientSubstitutionRepo.ListByIngredientIDs(ctx, []uint64{ingredientID})
+}
+
+// SuggestSubstitutions suggests substitutes for the ingredients of a recipe the viewer can see, including its sub-recipes,
+// that are missing from the pantry. Substitutes available in the pantry come first
+func (u *SubstitutionUsecase) SuggestSubstitutions(ctx context.Context, recipeID uint64, pantryIngredientIDs []uint64, viewer string) (entity.SubstitutionSuggestions, error) {
+	summary, err := u.recipeRepo.GetSummary(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.ID == 0 || !u.reviewers.canViewRecipe(summary.Recipe, viewer) {
+		return nil, entity.ErrRecipeNotFound
+	}
+
+	flattened, err := flattenRecipeIngredients(ctx, u.recipeRepo, summary, 1, map[uint64]bool{summary.ID: true})
+	if err != nil {
+		return nil, err
+	}
+
+	pantry := map[uint64]bool{}
+	for _, id := range pantryIngredientIDs {
+		pantry[id] = true
+	}
+
+	var missing entity.RecipeIngredients
+	var missingIDs []uint64
+
+	for _, ri := range mergeRecipeIngredients(flattened) {
+		if ri.IngredientID == 0 || pantry[ri.IngredientID] {
+			continue
+		}
+
+		missing = append(missing, ri)
+		missingIDs = appendUniqueID(missingIDs, ri.IngredientID)
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	substitutions, err := u.ingredientSubstitutionRepo.ListByIngredientIDs(ctx, missingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	substitutionsByIngredientID := map[uint64]entity.IngredientSubstitutions{}
+	for _, s := range substitutions {
+		substitutionsByIngredientID[s.IngredientID] = append(substitutionsByIngredientID[s.IngredientID], s)
+	}
+
+	var res entity.SubstitutionSuggestions
+
+	for _, ri := range missing {
+		suggestion := &entity.SubstitutionSuggestion{
+			IngredientID:       ri.IngredientID,
+			IngredientName:     ri.IngredientName,
+			IngredientUnitName: ri.IngredientUnitName,
+			Amount:             ri.Amount,
+		}
+
+		for _, s := range substitutionsByIngredientID[ri.IngredientID] {
+			substituted := substituteRecipeIngredient(ri, s)
+
+			suggestion.Substitutes = append(suggestion.Substitutes, &entity.SubstituteOption{
+				Substitution:       s,
+				IngredientUnitName: substituted.IngredientUnitName,
+				Amount:             substituted.Amount,
+				IsAvailable:        pantry[s.SubstituteIngredientID],
+			})
+		}
+
+		sort.SliceStable(suggestion.Substitutes, func(i, j int) bool {
+			return suggestion.Substitutes[i].IsAvailable && !suggestion.Substitutes[j].IsAvailable
+		})
+
+		res = append(res, suggestion)
+	}
+
+	return res, nil
+}
+
+// findSubstitutions looks up the substitutions of the requested ingredient to substitute pairs
+func findSubstitutions(ctx context.Context, ingredientSubstitutionRepo IngredientSubstitutionRepository, substitutes map[uint64]uint64) (entity.IngredientSubstitutions, error) {
+	if len(substitutes) == 0 {
+		return nil, nil
+	}
+
+	var ingredientIDs []uint64
+	for id := range substitutes {
+		ingredientIDs = append(ingredientIDs, id)
+	}
+
+	sort.Slice(ingredientIDs, func(i, j int) bool {
+		return ingredientIDs[i] < ingredientIDs[j]
+	})
+
+	substitutions, err := ingredientSubstitutionRepo.ListByIngredientIDs(ctx, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var res entity.IngredientSubstitutions
+
+	for _, id := range ingredientIDs {
+		var found *entity.IngredientSubstitution
+
+		for _, s := range substitutions {
+			if s.IngredientID == id && s.SubstituteIngredientID == substitutes[id] {
+				found = s
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, entity.ErrIngredientSubstitutionNotFound
+		}
+
+		res = append(res, found)
+	}
+
+	return res, nil
+}
+
+// applySubstitutions returns a copy of the recipe ingredients with the substitutions applied
+func applySubstitutions(ingredients entity.RecipeIngredients, substitutions entity.IngredientSubstitutions) entity.RecipeIngredients {
+	if len(substitutions) == 0 {
+		return ingredients
+	}
+
+	var res entity.RecipeIngredients
+
+	for _, ingredient := range ingredients {
+		substituted := ingredient
+
+		for _, s := range substitutions {
+			if ingredient.SubRecipeID == 0 && ingredient.IngredientID == s.IngredientID {
+				substituted = substituteRecipeIngredient(ingredient, s)
+				break
+			}
+		}
+
+		res = append(res, substituted)
+	}
+
+	return res
+}
+
+// substituteRecipeIngredient returns a copy of the recipe ingredient that uses the substitute instead
+func substituteRecipeIngredient(ingredient *entity.RecipeIngredient, substitution *entity.IngredientSubstitution) *entity.RecipeIngredient {
+	res := ingredient.Scale(substitution.Ratio)
+	res.IngredientID = substitution.SubstituteIngredientID
+	res.IngredientName = substitution.SubstituteIngredientName
+
+	if substitution.IngredientUnitName.Valid && substitution.IngredientUnitName.String != "" {
+		res.IngredientUnitName = substitution.IngredientUnitName.String
+	}
+
+	return res
+}
@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewSubstitutionUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientSubstitutionRepo := mock.NewMockIngredientSubstitutionRepository(ctrl)
+
+	uc := usecase.NewSubstitutionUsecase(recipeRepo, ingredientSubstitutionRepo, nil)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestSubstitutionUsecase_SuggestSubstitutions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientSubstitutionRepo := mock.NewMockIngredientSubstitutionRepository(ctrl)
+
+	uc := usecase.NewSubstitutionUsecase(recipeRepo, ingredientSubstitutionRepo, nil)
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Tumis Cesim", Servings: 1},
+		Ingredients: entity.RecipeIngredients{
+			{ID: 10, IngredientID: 12, IngredientName: "Sayur cesim", IngredientUnitName: "ikat", Amount: null.FloatFrom(2)},
+			{ID: 11, IngredientID: 5, IngredientName: "Bawang putih", IngredientUnitName: "siung", Amount: null.FloatFrom(3)},
+		},
+	}, nil)
+
+	ingredientSubstitutionRepo.EXPECT().ListByIngredientIDs(gomock.Any(), []uint64{12}).Return(entity.IngredientSubstitutions{
+		{ID: 1, IngredientID: 12, SubstituteIngredientID: 30, SubstituteIngredientName: "Sawi hijau", Ratio: 1},
+		{ID: 2, IngredientID: 12, SubstituteIngredientID: 34, SubstituteIngredientName: "Pakcoy", Ratio: 1.5},
+	}, nil)
+
+	suggestions, err := uc.SuggestSubstitutions(context.Background(), 1, []uint64{5, 34}, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, uint64(12), suggestions[0].IngredientID)
+	assert.Len(t, suggestions[0].Substitutes, 2)
+	assert.True(t, suggestions[0].Substitutes[0].IsAvailable)
+	assert.Equal(t, uint64(34), suggestions[0].Substitutes[0].Substitution.SubstituteIngredientID)
+	assert.Equal(t, null.FloatFrom(3), suggestions[0].Substitutes[0].Amount)
+	assert.False(t, suggestions[0].Substitutes[1].IsAvailable)
+}
+
+func TestSubstitutionUsecase_SuggestSubstitutions_HidesDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	recipeRepo := mock.NewMockRecipeRepository(ctrl)
+	ingredientSubstitutionRepo := mock.NewMockIngredientSubstitutionRepository(ctrl)
+
+	uc := usecase.NewSubstitutionUsecase(recipeRepo, ingredientSubstitutionRepo, []string{"Sari"})
+
+	recipeRepo.EXPECT().GetSummary(gomock.Any(), uint64(1)).Return(entity.RecipeSummary{
+		Recipe: entity.Recipe{ID: 1, Name: "Opor Ayam", Status: entity.RecipeStatusDraft, CreatedBy: "Budi"},
+	}, nil)
+
+	_, err := uc.SuggestSubstitutions(context.Background(), 1, nil, "Andi")
+
+	assert.ErrorIs(t, err, entity.ErrRecipeNotFound)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/tag_repo.go -source=tag_usecase.go -package=mock TagRepository
+
+import (
+	"context"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libtext"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type TagParams struct {
+	Name           string
+	NormalizedName string
+	Actor          string
+}
+
+// TagRepository defines contract for tag repository dependency
+type TagRepository interface {
+	Create(ctx context.Context, params TagParams) (*entity.Tag, error)
+	Update(ctx context.Context, id uint64, params TagParams) (*entity.Tag, error)
+	Delete(ctx context.Context, id uint64) error
+	List(ctx context.Context, limit, offset int) (entity.Tags, error)
+	ListByIDs(ctx context.Context, ids []uint64) (entity.Tags, error)
+	ListByNormalizedNames(ctx context.Context, normalizedNames []string) (entity.Tags, error)
+}
+
+// TagUsecase is our tag usecase object
+type TagUsecase struct {
+	tagRepo TagRepository
+}
+
+// NewTagUsecase instantiates TagUsecase
+func NewTagUsecase(tagRepo TagRepository) *TagUsecase {
+	return &TagUsecase{
+		tagRepo: tagRepo,
+	}
+}
+
+// CreateTag creates a new tag, tag names are unique regardless of case, diacritics and punctuation
+func (u *TagUsecase) CreateTag(ctx context.Context, params TagParams) (*entity.Tag, error) {
+	params.NormalizedName = libtext.Normalize(params.Name)
+
+	err := u.checkNameAvailable(ctx, 0, params.NormalizedName)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.tagRepo.Create(ctx, params)
+}
+
+// UpdateTag updates a tag
+func (u *TagUsecase) UpdateTag(ctx context.Context, id uint64, params TagParams) (*entity.Tag, error) {
+	if params.Name != "" {
+		params.NormalizedName = libtext.Normalize(params.Name)
+
+		err := u.checkNameAvailable(ctx, id, params.NormalizedName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return u.tagRepo.Update(ctx, id, params)
+}
+
+// DeleteTag deletes a tag and removes it from its recipes
+func (u *TagUsecase) DeleteTag(ctx context.Context, id uint64) error {
+	return u.tagRepo.Delete(ctx, id)
+}
+
+// ListTags retrieves a list of tags
+func (u *TagUsecase) ListTags(ctx context.Context, limit, offset int) (entity.Tags, error) {
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.tagRepo.List(ctx, lim, ofs)
+}
+
+// checkNameAvailable rejects a normalized name used by another tag
+func (u *TagUsecase) checkNameAvailable(ctx context.Context, id uint64, normalizedName string) error {
+	if normalizedName == "" {
+		return nil
+	}
+
+	tags, err := u.tagRepo.ListByNormalizedNames(ctx, []string{normalizedName})
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if tag.ID != id {
+			return entity.ErrTagNameConflict
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+	"testing"
+)
+
+func TestNewTagUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tagRepo := mock.NewMockTagRepository(ctrl)
+	uc := usecase.NewTagUsecase(tagRepo)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestTagUsecase_CreateTag_NameConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tagRepo := mock.NewMockTagRepository(ctrl)
+	uc := usecase.NewTagUsecase(tagRepo)
+
+	tagRepo.EXPECT().ListByNormalizedNames(gomock.Any(), []string{"pedas"}).Return(entity.Tags{
+		{ID: 3, Name: "Pedas", NormalizedName: "pedas"},
+	}, nil)
+
+	_, err := uc.CreateTag(context.Background(), usecase.TagParams{Name: " PEDAS "})
+	assert.Equal(t, entity.ErrTagNameConflict, err)
+}
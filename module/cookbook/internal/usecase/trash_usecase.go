@@ -0,0 +1,117 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/trash_repo.go -source=trash_usecase.go -package=mock TrashRepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libtext"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// trashParentTypes tells which record must be live before a record of a type can be restored
+var trashParentTypes = map[string]string{
+	entity.TrashTypeRecipeIngredient: entity.TrashTypeRecipe,
+	entity.TrashTypeRecipe:           entity.TrashTypeCategory,
+	entity.TrashTypeCategory:         entity.TrashTypeCategory,
+	entity.TrashTypeIngredient:       entity.TrashTypeIngredient,
+}
+
+// TrashRepository defines contract for trash repository dependency
+type TrashRepository interface {
+	List(ctx context.Context, itemType string, limit, offset int) (entity.TrashItems, error)
+	Get(ctx context.Context, itemType string, id uint64) (*entity.TrashItem, error)
+	Restore(ctx context.Context, itemType string, id uint64, actor string) error
+	Purge(ctx context.Context, before time.Time) (entity.TrashPurgeResult, error)
+}
+
+// TrashUsecase is our trash usecase object
+type TrashUsecase struct {
+	trashRepo      TrashRepository
+	ingredientRepo IngredientRepository
+	mediaStorage   MediaStorage
+}
+
+// NewTrashUsecase instantiates TrashUsecase
+func NewTrashUsecase(trashRepo TrashRepository, ingredientRepo IngredientRepository, mediaStorage MediaStorage) *TrashUsecase {
+	return &TrashUsecase{
+		trashRepo:      trashRepo,
+		ingredientRepo: ingredientRepo,
+		mediaStorage:   mediaStorage,
+	}
+}
+
+// ListTrash retrieves the soft-deleted records of a type, the latest deleted first
+func (u *TrashUsecase) ListTrash(ctx context.Context, itemType string, limit, offset int) (entity.TrashItems, error) {
+	if !containsString(entity.TrashTypes, itemType) {
+		return nil, entity.ErrInvalidTrashType
+	}
+
+	lim := defaultLimit
+	ofs := defaultOffset
+
+	if limit > 0 {
+		lim = limit
+	}
+
+	if offset > 0 {
+		ofs = offset
+	}
+
+	return u.trashRepo.List(ctx, itemType, lim, ofs)
+}
+
+// RestoreTrashItem restores a soft-deleted record. A recipe is restored with the ingredient rows deleted along with it,
+// while a record whose recipe, category or parent ingredient is still deleted, or that uses a deleted sub-recipe, is rejected
+func (u *TrashUsecase) RestoreTrashItem(ctx context.Context, itemType string, id uint64, actor string) error {
+	if !containsString(entity.TrashTypes, itemType) {
+		return entity.ErrInvalidTrashType
+	}
+
+	item, err := u.trashRepo.Get(ctx, itemType, id)
+	if err != nil {
+		return err
+	}
+
+	parentType, ok := trashParentTypes[itemType]
+	if ok && item.ParentID != 0 {
+		_, err = u.trashRepo.Get(ctx, parentType, item.ParentID)
+		if err == nil {
+			return entity.ErrTrashParentDeleted
+		}
+
+		if err != entity.ErrTrashItemNotFound {
+			return err
+		}
+	}
+
+	if itemType == entity.TrashTypeIngredient {
+		conflicts, err := u.ingredientRepo.ListByNormalizedNames(ctx, []string{libtext.Normalize(item.Name)})
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			return entity.ErrIngredientNameConflict
+		}
+	}
+
+	return u.trashRepo.Restore(ctx, itemType, id, actor)
+}
+
+// PurgeTrash permanently removes the records deleted more than retentionDays ago, along with the image files of the purged recipes
+func (u *TrashUsecase) PurgeTrash(ctx context.Context, retentionDays int) (entity.TrashPurgeResult, error) {
+	if retentionDays < 0 {
+		retentionDays = 0
+	}
+
+	before := time.Now().AddDate(0, 0, -retentionDays)
+
+	res, err := u.trashRepo.Purge(ctx, before)
+	if err != nil {
+		return res, err
+	}
+
+	return res, deleteMediaFiles(ctx, u.mediaStorage, res.MediaKeys)
+}
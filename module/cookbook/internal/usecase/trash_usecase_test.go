@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+func TestNewTrashUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	trashRepo := mock.NewMockTrashRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+
+	uc := usecase.NewTrashUsecase(trashRepo, ingredientRepo, mediaStorage)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestTrashUsecase_RestoreTrashItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	trashRepo := mock.NewMockTrashRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+
+	uc := usecase.NewTrashUsecase(trashRepo, ingredientRepo, mediaStorage)
+
+	err := uc.RestoreTrashItem(context.Background(), "pantry", 1, "Naufal")
+	assert.Equal(t, entity.ErrInvalidTrashType, err)
+
+	trashRepo.EXPECT().Get(gomock.Any(), entity.TrashTypeRecipeIngredient, uint64(30)).Return(&entity.TrashItem{
+		Type: entity.TrashTypeRecipeIngredient, ID: 30, Name: "Bawang merah", ParentID: 7,
+	}, nil)
+	trashRepo.EXPECT().Get(gomock.Any(), entity.TrashTypeRecipe, uint64(7)).Return(&entity.TrashItem{
+		Type: entity.TrashTypeRecipe, ID: 7, Name: "Nasi Goreng",
+	}, nil)
+
+	err = uc.RestoreTrashItem(context.Background(), entity.TrashTypeRecipeIngredient, 30, "Naufal")
+	assert.Equal(t, entity.ErrTrashParentDeleted, err)
+
+	trashRepo.EXPECT().Get(gomock.Any(), entity.TrashTypeIngredient, uint64(4)).Return(&entity.TrashItem{
+		Type: entity.TrashTypeIngredient, ID: 4, Name: "Bawang Merah",
+	}, nil)
+	ingredientRepo.EXPECT().ListByNormalizedNames(gomock.Any(), []string{"bawang merah"}).Return(entity.Ingredients{
+		{ID: 9, Name: "Bawang merah"},
+	}, nil)
+
+	err = uc.RestoreTrashItem(context.Background(), entity.TrashTypeIngredient, 4, "Naufal")
+	assert.Equal(t, entity.ErrIngredientNameConflict, err)
+
+	trashRepo.EXPECT().Get(gomock.Any(), entity.TrashTypeRecipe, uint64(7)).Return(&entity.TrashItem{
+		Type: entity.TrashTypeRecipe, ID: 7, Name: "Nasi Goreng", ParentID: 2,
+	}, nil)
+	trashRepo.EXPECT().Get(gomock.Any(), entity.TrashTypeCategory, uint64(2)).Return(nil, entity.ErrTrashItemNotFound)
+	trashRepo.EXPECT().Restore(gomock.Any(), entity.TrashTypeRecipe, uint64(7), "Naufal").Return(nil)
+
+	err = uc.RestoreTrashItem(context.Background(), entity.TrashTypeRecipe, 7, "Naufal")
+	assert.NoError(t, err)
+}
+
+func TestTrashUsecase_PurgeTrash_DeletesImageFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	trashRepo := mock.NewMockTrashRepository(ctrl)
+	ingredientRepo := mock.NewMockIngredientRepository(ctrl)
+	mediaStorage := mock.NewMockMediaStorage(ctrl)
+
+	uc := usecase.NewTrashUsecase(trashRepo, ingredientRepo, mediaStorage)
+
+	trashRepo.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(entity.TrashPurgeResult{
+		Recipes:      1,
+		RecipeImages: 1,
+		MediaKeys:    []string{"recipes/7/a1.jpg", "recipes/7/a1_thumb.jpg"},
+	}, nil)
+
+	mediaStorage.EXPECT().Delete(gomock.Any(), "recipes/7/a1.jpg").Return(nil)
+	mediaStorage.EXPECT().Delete(gomock.Any(), "recipes/7/a1_thumb.jpg").Return(entity.ErrMediaNotFound)
+
+	res, err := uc.PurgeTrash(context.Background(), 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.RecipeImages)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// unitConverter converts recipe ingredient amounts into the base unit of their ingredient
+type unitConverter struct {
+	units       map[string]*entity.IngredientUnit
+	conversions map[uint64]map[string]float64
+}
+
+func newUnitConverter(units entity.IngredientUnits, conversions entity.IngredientUnitConversions) *unitConverter {
+	c := &unitConverter{
+		units:       map[string]*entity.IngredientUnit{},
+		conversions: map[uint64]map[string]float64{},
+	}
+
+	for _, unit := range units {
+		c.units[normalizeUnitName(unit.Name)] = unit
+	}
+
+	for _, conversion := range conversions {
+		if c.conversions[conversion.IngredientID] == nil {
+			c.conversions[conversion.IngredientID] = map[string]float64{}
+		}
+		c.conversions[conversion.IngredientID][normalizeUnitName(conversion.IngredientUnitName)] = conversion.BaseAmount
+	}
+
+	return c
+}
+
+// toBaseAmount converts an amount into the ingredient base unit, it returns false when there is no known conversion
+func (c *unitConverter) toBaseAmount(ingredient *entity.Ingredient, unitName string, amount float64) (float64, bool) {
+	if ingredient == nil || !ingredient.BaseUnitName.Valid {
+		return 0, false
+	}
+
+	name := normalizeUnitName(unitName)
+	baseUnitName := normalizeUnitName(ingredient.BaseUnitName.String)
+
+	if name == baseUnitName {
+		return amount, true
+	}
+
+	if baseAmount, ok := c.conversions[ingredient.ID][name]; ok {
+		return amount * baseAmount, true
+	}
+
+	unit, ok := c.units[name]
+	if ok && unit.BaseFactor.Valid && normalizeUnitName(unit.BaseUnitName.String) == baseUnitName {
+		return amount * unit.BaseFactor.Float64, true
+	}
+
+	return 0, false
+}
+
+// loadIngredientsWithConverter retrieves master data of recipe ingredients and a converter for their units and the extra units.
+// A deleted ingredient still in a recipe is loaded too, so that it is converted like the others
+func loadIngredientsWithConverter(ctx context.Context, ingredientRepo IngredientRepository, ingredientUnitRepo IngredientUnitRepository, recipeIngredients entity.RecipeIngredients, extraUnitNames ...string) (map[uint64]*entity.Ingredient, *unitConverter, error) {
+	var ingredientIDs []uint64
+	var unitNames []string
+
+	for _, name := range extraUnitNames {
+		unitNames = append(unitNames, normalizeUnitName(name))
+	}
+
+	for _, ri := range recipeIngredients {
+		if ri.IngredientID != 0 {
+			ingredientIDs = appendUniqueID(ingredientIDs, ri.IngredientID)
+		}
+		unitNames = append(unitNames, normalizeUnitName(ri.IngredientUnitName))
+	}
+
+	ingredientByID := map[uint64]*entity.Ingredient{}
+	if len(ingredientIDs) == 0 {
+		return ingredientByID, newUnitConverter(nil, nil), nil
+	}
+
+	ingredients, err := ingredientRepo.ListAllByIDs(ctx, ingredientIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ingredient := range ingredients {
+		ingredientByID[ingredient.ID] = ingredient
+	}
+
+	units, err := ingredientUnitRepo.ListByNames(ctx, unitNames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conversions, err := ingredientUnitRepo.ListConversions(ctx, ingredientIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ingredientByID, newUnitConverter(units, conversions), nil
+}
+
+func normalizeUnitName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
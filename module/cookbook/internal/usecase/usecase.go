@@ -3,4 +3,25 @@ package usecase
 const (
 	defaultLimit  = 20
 	defaultOffset = 0
+
+	defaultServings = 1
+
+	listAllPageSize = 100
 )
+
+// RenamePolicy tells what happens to the denormalized names in recipe ingredients when an ingredient or a recipe is renamed
+type RenamePolicy string
+
+const (
+	// RenamePolicyPropagate rewrites the names in all recipes
+	RenamePolicyPropagate RenamePolicy = "propagate"
+	// RenamePolicySnapshot keeps the names recipes were written with
+	RenamePolicySnapshot RenamePolicy = "snapshot"
+)
+
+// DeleteParams holds the options of deleting master data still referenced by recipes.
+// When ReassignTo is set the references are moved to that row before deleting
+type DeleteParams struct {
+	ReassignTo uint64
+	Actor      string
+}
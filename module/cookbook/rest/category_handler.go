@@ -15,14 +15,17 @@ import (
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 )
 
+// CategoryRequest holds a category payload, parent_id 0 moves a category to the root
 type CategoryRequest struct {
-	Name  string `json:"name"`
-	Actor string `json:"actor"`
+	Name     string  `json:"name"`
+	ParentID *uint64 `json:"parent_id"`
+	Actor    string  `json:"actor"`
 }
 
 type CategoryResponse struct {
 	ID        uint64      `json:"id"`
 	Name      string      `json:"name"`
+	ParentID  uint64      `json:"parent_id,omitempty"`
 	CreatedAt time.Time   `json:"created_at"`
 	CreatedBy string      `json:"created_by"`
 	UpdatedAt null.Time   `json:"updated_at"`
@@ -34,6 +37,15 @@ type CategoriesResponse struct {
 	CategoryResponse []CategoryResponse `json:"categories"`
 }
 
+type CategoryNodeResponse struct {
+	CategoryResponse
+	Children []CategoryNodeResponse `json:"children"`
+}
+
+type CategoryTreeResponse struct {
+	Categories []CategoryNodeResponse `json:"categories"`
+}
+
 // CreateCategory is a create category handler
 func (h *CookbookHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 	var req CategoryRequest
@@ -45,13 +57,14 @@ func (h *CookbookHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 	}
 
 	params := usecase.CategoryParams{
-		Name:  req.Name,
-		Actor: req.Actor,
+		Name:     req.Name,
+		ParentID: req.ParentID,
+		Actor:    req.Actor,
 	}
 
 	category, err := h.categoryUsecase.CreateCategory(r.Context(), params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, categoryErrorStatus(err), err)
 		return
 	}
 
@@ -79,7 +92,7 @@ func (h *CookbookHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 
 	category, err := h.categoryUsecase.UpdateCategory(r.Context(), id, params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, categoryErrorStatus(err), err)
 		return
 	}
 
@@ -97,9 +110,15 @@ func (h *CookbookHandler) DeleteCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.categoryUsecase.DeleteCategory(r.Context(), id)
+	params, err := parseDeleteParams(r)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.categoryUsecase.DeleteCategory(r.Context(), id, params)
+	if err != nil {
+		withReferenceError(w, err)
 		return
 	}
 
@@ -132,10 +151,50 @@ func (h *CookbookHandler) ListCategories(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+// GetCategoryTree is a handler returning the category tree, or the subtree of ?root_id=
+func (h *CookbookHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	var rootID uint64
+
+	rawRootID := r.URL.Query().Get("root_id")
+	if rawRootID != "" {
+		var err error
+
+		rootID, err = strconv.ParseUint(rawRootID, 10, 64)
+		if err != nil {
+			libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid root_id"))
+			return
+		}
+	}
+
+	nodes, err := h.categoryUsecase.GetCategoryTree(r.Context(), rootID)
+	if err == entity.ErrCategoryNotFound {
+		libhttp.WithError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, CategoryTreeResponse{Categories: categoryNodeResponsesFromEntity(nodes)})
+}
+
+// categoryErrorStatus maps errors of category writes to a response status
+func categoryErrorStatus(err error) int {
+	switch err {
+	case entity.ErrCategoryCycle, entity.ErrCategoryNotFound:
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
 // normalizeUpdateCategoryRequest converts input to usecase params
 func normalizeUpdateCategoryRequest(input CategoryRequest) usecase.CategoryParams {
 	params := usecase.CategoryParams{
-		Actor: input.Actor,
+		ParentID: input.ParentID,
+		Actor:    input.Actor,
 	}
 
 	if input.Name != "" {
@@ -150,6 +209,7 @@ func categoryResponseFromEntity(ent *entity.Category) CategoryResponse {
 	return CategoryResponse{
 		ID:        ent.ID,
 		Name:      ent.Name,
+		ParentID:  ent.ParentID,
 		CreatedAt: ent.CreatedAt,
 		CreatedBy: ent.CreatedBy,
 		UpdatedAt: ent.UpdatedAt,
@@ -157,3 +217,17 @@ func categoryResponseFromEntity(ent *entity.Category) CategoryResponse {
 		IsDeleted: ent.IsDeleted,
 	}
 }
+
+// categoryNodeResponsesFromEntity converts category nodes to responses, children are always arrays
+func categoryNodeResponsesFromEntity(nodes entity.CategoryNodes) []CategoryNodeResponse {
+	res := []CategoryNodeResponse{}
+
+	for _, node := range nodes {
+		res = append(res, CategoryNodeResponse{
+			CategoryResponse: categoryResponseFromEntity(&node.Category),
+			Children:         categoryNodeResponsesFromEntity(node.Children),
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,450 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type CollectionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Actor       string `json:"actor"`
+}
+
+type CollectionRecipeRequest struct {
+	RecipeID uint64 `json:"recipe_id"`
+	Actor    string `json:"actor"`
+}
+
+type CollectionOrderRequest struct {
+	RecipeIDs []uint64 `json:"recipe_ids"`
+	Actor     string   `json:"actor"`
+}
+
+type CollectionResponse struct {
+	ID                uint64      `json:"id"`
+	Name              string      `json:"name"`
+	Description       null.String `json:"description"`
+	CoverURL          null.String `json:"cover_url"`
+	CoverThumbnailURL null.String `json:"cover_thumbnail_url"`
+	RecipeCount       int         `json:"recipe_count"`
+	CreatedAt         time.Time   `json:"created_at"`
+	CreatedBy         string      `json:"created_by"`
+	UpdatedAt         null.Time   `json:"updated_at"`
+	UpdatedBy         null.String `json:"updated_by"`
+	IsDeleted         bool        `json:"is_deleted"`
+}
+
+type CollectionResponses struct {
+	Data []CollectionResponse `json:"collections"`
+}
+
+type CollectionRecipeResponse struct {
+	RecipeID  uint64    `json:"recipe_id"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	GapBefore bool      `json:"gap_before"`
+	AddedAt   time.Time `json:"added_at"`
+	AddedBy   string    `json:"added_by"`
+}
+
+type CollectionDetailResponse struct {
+	CollectionResponse
+	Recipes       []CollectionRecipeResponse `json:"recipes"`
+	HiddenRecipes int                        `json:"hidden_recipes"`
+	TrailingGap   bool                       `json:"trailing_gap"`
+}
+
+type CollectionExportRecipeResponse struct {
+	GapBefore bool               `json:"gap_before"`
+	Recipe    GetSummaryResponse `json:"recipe"`
+}
+
+type CollectionExportResponse struct {
+	Collection    CollectionResponse               `json:"collection"`
+	Recipes       []CollectionExportRecipeResponse `json:"recipes"`
+	HiddenRecipes int                              `json:"hidden_recipes"`
+	TrailingGap   bool                             `json:"trailing_gap"`
+}
+
+type collectionView struct {
+	Title         string
+	Description   string
+	CoverURL      string
+	Recipes       []collectionRecipeView
+	HiddenRecipes int
+	TrailingGap   bool
+}
+
+type collectionRecipeView struct {
+	Card      recipeCardView
+	GapBefore bool
+}
+
+// CreateCollection is a create collection handler
+func (h *CookbookHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	var req CollectionRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.Name == "" {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("name cannot be empty"))
+		return
+	}
+
+	params := usecase.CollectionParams{
+		Name:        req.Name,
+		Description: req.Description,
+		Actor:       req.Actor,
+	}
+
+	collection, err := h.collectionUsecase.CreateCollection(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionResponseFromEntity(collection))
+}
+
+// UpdateCollection is a update collection handler
+func (h *CookbookHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	var req CollectionRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.CollectionParams{
+		Name:        req.Name,
+		Description: req.Description,
+		Actor:       req.Actor,
+	}
+
+	collection, err := h.collectionUsecase.UpdateCollection(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionResponseFromEntity(collection))
+}
+
+// DeleteCollection is a delete collection handler, the recipes of the collection are left untouched
+func (h *CookbookHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.collectionUsecase.DeleteCollection(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted collection")
+}
+
+// ListCollections is a list collections handler
+func (h *CookbookHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	collections, err := h.collectionUsecase.ListCollections(r.Context(), lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp CollectionResponses
+	for _, c := range collections {
+		resp.Data = append(resp.Data, collectionResponseFromEntity(c))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// GetCollection is a get collection handler, deleted recipes and the drafts of others are hidden and flagged by
+// gap_before and trailing_gap
+func (h *CookbookHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	detail, err := h.collectionUsecase.GetCollection(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionDetailResponseFromEntity(detail))
+}
+
+// AddCollectionRecipe is an add recipe to collection handler, the recipe is appended to the end
+func (h *CookbookHandler) AddCollectionRecipe(w http.ResponseWriter, r *http.Request) {
+	var req CollectionRecipeRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.RecipeID == 0 {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("recipe_id cannot be empty"))
+		return
+	}
+
+	detail, err := h.collectionUsecase.AddCollectionRecipe(r.Context(), id, req.RecipeID, req.Actor)
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionDetailResponseFromEntity(detail))
+}
+
+// RemoveCollectionRecipe is a remove recipe from collection handler
+func (h *CookbookHandler) RemoveCollectionRecipe(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	recipeID, err := strconv.ParseUint(chi.URLParam(r, "recipe_id"), 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("recipe_id cannot be empty"))
+		return
+	}
+
+	detail, err := h.collectionUsecase.RemoveCollectionRecipe(r.Context(), id, recipeID, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionDetailResponseFromEntity(detail))
+}
+
+// ReorderCollectionRecipes is a reorder collection handler, recipe_ids lists every visible recipe in the new order
+func (h *CookbookHandler) ReorderCollectionRecipes(w http.ResponseWriter, r *http.Request) {
+	var req CollectionOrderRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	detail, err := h.collectionUsecase.ReorderCollectionRecipes(r.Context(), id, req.RecipeIDs, req.Actor)
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionDetailResponseFromEntity(detail))
+}
+
+// UploadCollectionCover is an upload collection cover handler, the image is sent as the "file" field of a multipart form
+// with an optional "actor" field
+func (h *CookbookHandler) UploadCollectionCover(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	file, done, ok := openUploadedImage(w, r, h.mediaUsecase.MaxUploadBytes())
+	if !ok {
+		return
+	}
+
+	defer done()
+
+	params := usecase.UploadCollectionCoverParams{
+		CollectionID: id,
+		Actor:        r.FormValue("actor"),
+		Content:      file,
+	}
+
+	collection, err := h.collectionUsecase.UploadCollectionCover(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, collectionResponseFromEntity(collection))
+}
+
+// ExportCollection is an export collection handler, it sends the whole collection with the summaries of its recipes
+// as a single JSON document, or as a Markdown document when the client accepts text/markdown
+func (h *CookbookHandler) ExportCollection(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	export, err := h.collectionUsecase.ExportCollection(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, collectionErrorStatus(err), err)
+		return
+	}
+
+	if acceptedCardFormat(r) == cardFormatMarkdown {
+		h.withRecipeCardPage(w, "collection", cardFormatMarkdown, collectionViewFromEntity(export))
+		return
+	}
+
+	resp := CollectionExportResponse{
+		Collection:    collectionResponseFromEntity(export.Collection),
+		Recipes:       []CollectionExportRecipeResponse{},
+		HiddenRecipes: export.HiddenRecipes,
+		TrailingGap:   export.TrailingGap,
+	}
+
+	for _, recipe := range export.Recipes {
+		resp.Recipes = append(resp.Recipes, CollectionExportRecipeResponse{
+			GapBefore: recipe.GapBefore,
+			Recipe:    getSummaryResponseFromEntity(recipe.Summary),
+		})
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// collectionErrorStatus maps collection errors to a response status
+func collectionErrorStatus(err error) int {
+	switch err {
+	case entity.ErrRecipeNotFound, entity.ErrInvalidCollectionOrder:
+		return http.StatusBadRequest
+	case entity.ErrCollectionNotFound, entity.ErrCollectionRecipeNotFound:
+		return http.StatusNotFound
+	case entity.ErrCollectionRecipeExists:
+		return http.StatusConflict
+	case entity.ErrMediaTooLarge, entity.ErrUnsupportedMediaType:
+		return mediaErrorStatus(err)
+	}
+
+	return http.StatusInternalServerError
+}
+
+// collectionResponseFromEntity converts collection entity to response
+func collectionResponseFromEntity(ent *entity.Collection) CollectionResponse {
+	resp := CollectionResponse{
+		ID:          ent.ID,
+		Name:        ent.Name,
+		Description: ent.Description,
+		RecipeCount: ent.RecipeCount,
+		CreatedAt:   ent.CreatedAt,
+		CreatedBy:   ent.CreatedBy,
+		UpdatedAt:   ent.UpdatedAt,
+		UpdatedBy:   ent.UpdatedBy,
+		IsDeleted:   ent.IsDeleted,
+	}
+
+	if ent.CoverFileKey.Valid {
+		resp.CoverURL = null.StringFrom(mediaURLPrefix + ent.CoverFileKey.String)
+		resp.CoverThumbnailURL = null.StringFrom(mediaURLPrefix + ent.CoverThumbnailKey.String)
+	}
+
+	return resp
+}
+
+func collectionDetailResponseFromEntity(ent entity.CollectionDetail) CollectionDetailResponse {
+	resp := CollectionDetailResponse{
+		CollectionResponse: collectionResponseFromEntity(ent.Collection),
+		Recipes:            []CollectionRecipeResponse{},
+		HiddenRecipes:      ent.HiddenRecipes,
+		TrailingGap:        ent.TrailingGap,
+	}
+
+	for _, recipe := range ent.Recipes {
+		resp.Recipes = append(resp.Recipes, CollectionRecipeResponse{
+			RecipeID:  recipe.RecipeID,
+			Name:      recipe.RecipeName,
+			Position:  recipe.Position,
+			GapBefore: recipe.GapBefore,
+			AddedAt:   recipe.CreatedAt,
+			AddedBy:   recipe.CreatedBy,
+		})
+	}
+
+	return resp
+}
+
+// collectionViewFromEntity converts an exported collection to the data of its Markdown document
+func collectionViewFromEntity(ent entity.CollectionExport) collectionView {
+	view := collectionView{
+		Title:         ent.Collection.Name,
+		Description:   ent.Collection.Description.String,
+		Recipes:       []collectionRecipeView{},
+		HiddenRecipes: ent.HiddenRecipes,
+		TrailingGap:   ent.TrailingGap,
+	}
+
+	if ent.Collection.CoverFileKey.Valid {
+		view.CoverURL = mediaURLPrefix + ent.Collection.CoverFileKey.String
+	}
+
+	for _, recipe := range ent.Recipes {
+		view.Recipes = append(view.Recipes, collectionRecipeView{
+			Card:      recipeCardViewFromEntity(recipe.Summary),
+			GapBefore: recipe.GapBefore,
+		})
+	}
+
+	return view
+}
@@ -0,0 +1,201 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type CookLogRequest struct {
+	CookedOn string   `json:"cooked_on"`
+	Servings int      `json:"servings"`
+	Rating   null.Int `json:"rating"`
+	Notes    string   `json:"notes"`
+	Actor    string   `json:"actor"`
+}
+
+type CookLogResponse struct {
+	ID                uint64      `json:"id"`
+	RecipeID          uint64      `json:"recipe_id"`
+	CookedOn          string      `json:"cooked_on"`
+	Servings          int         `json:"servings"`
+	Rating            null.Int    `json:"rating"`
+	Notes             null.String `json:"notes"`
+	PhotoURL          null.String `json:"photo_url"`
+	PhotoThumbnailURL null.String `json:"photo_thumbnail_url"`
+	CreatedAt         time.Time   `json:"created_at"`
+	CreatedBy         string      `json:"created_by"`
+	UpdatedAt         null.Time   `json:"updated_at"`
+	UpdatedBy         null.String `json:"updated_by"`
+}
+
+type CookLogResponses struct {
+	Data []CookLogResponse `json:"cook_logs"`
+}
+
+// CreateCookLog is a create cook log handler, it records that a recipe was cooked
+func (h *CookbookHandler) CreateCookLog(w http.ResponseWriter, r *http.Request) {
+	var req CookLogRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.CookLogParams{
+		RecipeID: id,
+		Servings: req.Servings,
+		Rating:   req.Rating,
+		Notes:    req.Notes,
+		Actor:    req.Actor,
+	}
+
+	if req.CookedOn != "" {
+		params.CookedOn, err = time.Parse(dateLayout, req.CookedOn)
+		if err != nil {
+			libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("cooked_on must be formatted as %s", dateLayout))
+			return
+		}
+	}
+
+	cookLog, err := h.cookLogUsecase.CreateCookLog(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, cookLogErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, cookLogResponseFromEntity(cookLog))
+}
+
+// ListCookLogs is a list cook logs handler, it returns the cook logs of a recipe latest first
+func (h *CookbookHandler) ListCookLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	cookLogs, err := h.cookLogUsecase.ListCookLogs(r.Context(), id, lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := CookLogResponses{Data: []CookLogResponse{}}
+	for _, cookLog := range cookLogs {
+		resp.Data = append(resp.Data, cookLogResponseFromEntity(cookLog))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// DeleteCookLog is a delete cook log handler
+func (h *CookbookHandler) DeleteCookLog(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.cookLogUsecase.DeleteCookLog(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, cookLogErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted cook log")
+}
+
+// UploadCookLogPhoto is an upload cook log photo handler, the multipart form carries the image in the "file" field
+func (h *CookbookHandler) UploadCookLogPhoto(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	file, done, ok := openUploadedImage(w, r, h.mediaUsecase.MaxUploadBytes())
+	if !ok {
+		return
+	}
+
+	defer done()
+
+	params := usecase.UploadCookLogPhotoParams{
+		CookLogID: id,
+		Actor:     r.FormValue("actor"),
+		Content:   file,
+	}
+
+	cookLog, err := h.cookLogUsecase.UploadCookLogPhoto(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, cookLogErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, cookLogResponseFromEntity(cookLog))
+}
+
+// cookLogErrorStatus maps errors of cook log writes to a response status
+func cookLogErrorStatus(err error) int {
+	switch err {
+	case entity.ErrInvalidRating, entity.ErrInvalidServings, entity.ErrInvalidCookedOn:
+		return http.StatusBadRequest
+	case entity.ErrRecipeNotFound, entity.ErrCookLogNotFound:
+		return http.StatusNotFound
+	case entity.ErrMediaTooLarge, entity.ErrUnsupportedMediaType:
+		return mediaErrorStatus(err)
+	}
+
+	return http.StatusInternalServerError
+}
+
+// cookLogResponseFromEntity converts cook log entity to response
+func cookLogResponseFromEntity(ent *entity.CookLog) CookLogResponse {
+	resp := CookLogResponse{
+		ID:        ent.ID,
+		RecipeID:  ent.RecipeID,
+		CookedOn:  ent.CookedOn.Format(dateLayout),
+		Servings:  ent.Servings,
+		Rating:    ent.Rating,
+		Notes:     ent.Notes,
+		CreatedAt: ent.CreatedAt,
+		CreatedBy: ent.CreatedBy,
+		UpdatedAt: ent.UpdatedAt,
+		UpdatedBy: ent.UpdatedBy,
+	}
+
+	if ent.PhotoFileKey.Valid {
+		resp.PhotoURL = null.StringFrom(mediaURLPrefix + ent.PhotoFileKey.String)
+		resp.PhotoThumbnailURL = null.StringFrom(mediaURLPrefix + ent.PhotoThumbnailKey.String)
+	}
+
+	return resp
+}
@@ -0,0 +1,318 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	defaultCostIncreasePeriod = 30 * 24 * time.Hour
+)
+
+type IngredientPriceRequest struct {
+	IngredientUnitID uint64  `json:"ingredient_unit_id"`
+	Quantity         float64 `json:"quantity"`
+	Price            float64 `json:"price"`
+	EffectiveAt      string  `json:"effective_at"`
+	Actor            string  `json:"actor"`
+}
+
+type IngredientPriceResponse struct {
+	ID                 uint64      `json:"id"`
+	IngredientID       uint64      `json:"ingredient_id"`
+	IngredientUnitID   uint64      `json:"ingredient_unit_id"`
+	IngredientUnitName string      `json:"ingredient_unit_name"`
+	Quantity           float64     `json:"quantity"`
+	Price              float64     `json:"price"`
+	EffectiveAt        string      `json:"effective_at"`
+	CreatedAt          time.Time   `json:"created_at"`
+	CreatedBy          string      `json:"created_by"`
+	UpdatedAt          null.Time   `json:"updated_at"`
+	UpdatedBy          null.String `json:"updated_by"`
+	IsDeleted          bool        `json:"is_deleted"`
+}
+
+type IngredientPriceResponses struct {
+	Data []IngredientPriceResponse `json:"ingredient_prices"`
+}
+
+type RecipeIngredientCostResponse struct {
+	IngredientID       uint64     `json:"ingredient_id"`
+	IngredientName     string     `json:"ingredient_name"`
+	IngredientUnitName string     `json:"ingredient_unit_name"`
+	Amount             null.Float `json:"amount"`
+	Cost               null.Float `json:"cost"`
+	Status             string     `json:"status"`
+}
+
+type RecipeCostResponse struct {
+	RecipeID    uint64                         `json:"recipe_id"`
+	RecipeName  string                         `json:"recipe_name"`
+	Servings    int                            `json:"servings"`
+	Total       float64                        `json:"total"`
+	PerServing  float64                        `json:"per_serving"`
+	IsComplete  bool                           `json:"is_complete"`
+	Ingredients []RecipeIngredientCostResponse `json:"ingredients"`
+}
+
+type RecipeCostIncreaseResponse struct {
+	RecipeID        uint64  `json:"recipe_id"`
+	RecipeName      string  `json:"recipe_name"`
+	FromCost        float64 `json:"from_cost"`
+	ToCost          float64 `json:"to_cost"`
+	IncreasePercent float64 `json:"increase_percent"`
+	IsComplete      bool    `json:"is_complete"`
+}
+
+type RecipeCostSkipResponse struct {
+	RecipeID   uint64 `json:"recipe_id"`
+	RecipeName string `json:"recipe_name"`
+	Reason     string `json:"reason"`
+}
+
+type RecipeCostIncreaseResponses struct {
+	From    string                       `json:"from"`
+	To      string                       `json:"to"`
+	Data    []RecipeCostIncreaseResponse `json:"recipes"`
+	Skipped []RecipeCostSkipResponse     `json:"skipped"`
+}
+
+// GetRecipeCost is a get recipe cost handler
+func (h *CookbookHandler) GetRecipeCost(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	servings, _ := strconv.Atoi(r.URL.Query().Get("servings"))
+
+	cost, err := h.costUsecase.GetRecipeCost(r.Context(), id, servings, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeCostResponseFromEntity(cost))
+}
+
+// ListRecipeCostIncreases is a list recipe cost increases report handler, the recipes that could not be costed are
+// listed under skipped
+func (h *CookbookHandler) ListRecipeCostIncreases(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	to := time.Now()
+	if rawTo := query.Get("to"); rawTo != "" {
+		parsed, err := time.Parse(dateLayout, rawTo)
+		if err != nil {
+			libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("to must be formatted as %s", dateLayout))
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultCostIncreasePeriod)
+	if rawFrom := query.Get("from"); rawFrom != "" {
+		parsed, err := time.Parse(dateLayout, rawFrom)
+		if err != nil {
+			libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("from must be formatted as %s", dateLayout))
+			return
+		}
+		from = parsed
+	}
+
+	minIncrease, _ := strconv.ParseFloat(query.Get("min_increase_percent"), 64)
+
+	filter := usecase.RecipeCostIncreaseFilter{
+		From:               from,
+		To:                 to,
+		MinIncreasePercent: minIncrease,
+		Viewer:             query.Get("actor"),
+	}
+
+	report, err := h.costUsecase.ListRecipeCostIncreases(r.Context(), filter)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := RecipeCostIncreaseResponses{
+		From:    from.Format(dateLayout),
+		To:      to.Format(dateLayout),
+		Skipped: []RecipeCostSkipResponse{},
+	}
+
+	for _, i := range report.Increases {
+		resp.Data = append(resp.Data, RecipeCostIncreaseResponse{
+			RecipeID:        i.RecipeID,
+			RecipeName:      i.RecipeName,
+			FromCost:        i.FromCost,
+			ToCost:          i.ToCost,
+			IncreasePercent: i.IncreasePercent,
+			IsComplete:      i.IsComplete,
+		})
+	}
+
+	for _, skip := range report.Skipped {
+		resp.Skipped = append(resp.Skipped, RecipeCostSkipResponse{
+			RecipeID:   skip.RecipeID,
+			RecipeName: skip.RecipeName,
+			Reason:     skip.Reason,
+		})
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// CreateIngredientPrice is a create ingredient price handler
+func (h *CookbookHandler) CreateIngredientPrice(w http.ResponseWriter, r *http.Request) {
+	var req IngredientPriceRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.IngredientUnitID == 0 || req.Price < 0 {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("ingredient_unit_id and a non-negative price are required"))
+		return
+	}
+
+	params := usecase.IngredientPriceParams{
+		IngredientID:     id,
+		IngredientUnitID: req.IngredientUnitID,
+		Quantity:         req.Quantity,
+		Price:            req.Price,
+		Actor:            req.Actor,
+	}
+
+	if req.EffectiveAt != "" {
+		params.EffectiveAt, err = time.Parse(dateLayout, req.EffectiveAt)
+		if err != nil {
+			libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("effective_at must be formatted as %s", dateLayout))
+			return
+		}
+	}
+
+	price, err := h.costUsecase.CreateIngredientPrice(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientPriceResponseFromEntity(price))
+}
+
+// ListIngredientPrices is a list ingredient price history handler
+func (h *CookbookHandler) ListIngredientPrices(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	prices, err := h.costUsecase.ListIngredientPrices(r.Context(), id, lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp IngredientPriceResponses
+	for _, p := range prices {
+		resp.Data = append(resp.Data, ingredientPriceResponseFromEntity(p))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// DeleteIngredientPrice is a delete ingredient price handler
+func (h *CookbookHandler) DeleteIngredientPrice(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.costUsecase.DeleteIngredientPrice(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted ingredient price")
+}
+
+// ingredientPriceResponseFromEntity converts ingredient price entity to response
+func ingredientPriceResponseFromEntity(ent *entity.IngredientPrice) IngredientPriceResponse {
+	return IngredientPriceResponse{
+		ID:                 ent.ID,
+		IngredientID:       ent.IngredientID,
+		IngredientUnitID:   ent.IngredientUnitID,
+		IngredientUnitName: ent.IngredientUnitName,
+		Quantity:           ent.Quantity,
+		Price:              ent.Price,
+		EffectiveAt:        ent.EffectiveAt.Format(dateLayout),
+		CreatedAt:          ent.CreatedAt,
+		CreatedBy:          ent.CreatedBy,
+		UpdatedAt:          ent.UpdatedAt,
+		UpdatedBy:          ent.UpdatedBy,
+		IsDeleted:          ent.IsDeleted,
+	}
+}
+
+// recipeCostResponseFromEntity converts recipe cost entity to response
+func recipeCostResponseFromEntity(ent entity.RecipeCost) RecipeCostResponse {
+	resp := RecipeCostResponse{
+		RecipeID:   ent.RecipeID,
+		RecipeName: ent.RecipeName,
+		Servings:   ent.Servings,
+		Total:      ent.Total,
+		PerServing: ent.PerServing,
+		IsComplete: ent.IsComplete,
+	}
+
+	for _, i := range ent.Ingredients {
+		resp.Ingredients = append(resp.Ingredients, RecipeIngredientCostResponse{
+			IngredientID:       i.IngredientID,
+			IngredientName:     i.IngredientName,
+			IngredientUnitName: i.IngredientUnitName,
+			Amount:             i.Amount,
+			Cost:               i.Cost,
+			Status:             i.Status,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type EquipmentRequest struct {
+	Name  string `json:"name"`
+	Actor string `json:"actor"`
+}
+
+type EquipmentResponse struct {
+	ID        uint64      `json:"id"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+	CreatedBy string      `json:"created_by"`
+	UpdatedAt null.Time   `json:"updated_at"`
+	UpdatedBy null.String `json:"updated_by"`
+	IsDeleted bool        `json:"is_deleted"`
+}
+
+type EquipmentResponses struct {
+	Data []EquipmentResponse `json:"equipment"`
+}
+
+// CreateEquipment is a create equipment handler
+func (h *CookbookHandler) CreateEquipment(w http.ResponseWriter, r *http.Request) {
+	var req EquipmentRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.Name == "" {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("name cannot be empty"))
+		return
+	}
+
+	params := usecase.EquipmentParams{
+		Name:  req.Name,
+		Actor: req.Actor,
+	}
+
+	equipment, err := h.equipmentUsecase.CreateEquipment(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, equipmentResponseFromEntity(equipment))
+}
+
+// UpdateEquipment is a update equipment handler
+func (h *CookbookHandler) UpdateEquipment(w http.ResponseWriter, r *http.Request) {
+	var req EquipmentRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.EquipmentParams{
+		Name:  req.Name,
+		Actor: req.Actor,
+	}
+
+	equipment, err := h.equipmentUsecase.UpdateEquipment(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, equipmentErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, equipmentResponseFromEntity(equipment))
+}
+
+// DeleteEquipment is a delete equipment handler, the equipment is removed from every recipe
+func (h *CookbookHandler) DeleteEquipment(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.equipmentUsecase.DeleteEquipment(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, equipmentErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted equipment")
+}
+
+// ListEquipment is a list equipment handler
+func (h *CookbookHandler) ListEquipment(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	equipment, err := h.equipmentUsecase.ListEquipment(r.Context(), lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp EquipmentResponses
+	for _, e := range equipment {
+		resp.Data = append(resp.Data, equipmentResponseFromEntity(e))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// equipmentErrorStatus maps equipment errors to a response status
+func equipmentErrorStatus(err error) int {
+	if err == entity.ErrEquipmentNotFound {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
+
+// equipmentResponseFromEntity converts equipment entity to response
+func equipmentResponseFromEntity(ent *entity.Equipment) EquipmentResponse {
+	return EquipmentResponse{
+		ID:        ent.ID,
+		Name:      ent.Name,
+		CreatedAt: ent.CreatedAt,
+		CreatedBy: ent.CreatedBy,
+		UpdatedAt: ent.UpdatedAt,
+		UpdatedBy: ent.UpdatedBy,
+		IsDeleted: ent.IsDeleted,
+	}
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"io"
 
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
@@ -11,20 +12,32 @@ import (
 type CategoryUsecase interface {
 	CreateCategory(ctx context.Context, params usecase.CategoryParams) (*entity.Category, error)
 	UpdateCategory(ctx context.Context, id uint64, params usecase.CategoryParams) (*entity.Category, error)
-	DeleteCategory(ctx context.Context, id uint64) error
+	DeleteCategory(ctx context.Context, id uint64, params usecase.DeleteParams) error
 	ListCategories(ctx context.Context, limit, offset int) (entity.Categories, error)
+	GetCategoryTree(ctx context.Context, rootID uint64) (entity.CategoryNodes, error)
 }
 
 // IngredientUsecase defines the contract for voyage usecase dependency
 type IngredientUsecase interface {
 	CreateIngredient(ctx context.Context, params usecase.IngredientParams) (*entity.Ingredient, error)
 	UpdateIngredient(ctx context.Context, id uint64, params usecase.IngredientParams) (*entity.Ingredient, error)
-	DeleteIngredient(ctx context.Context, id uint64) error
-	ListIngredients(ctx context.Context, limit, offset int) (entity.Ingredients, error)
+	DeleteIngredient(ctx context.Context, id uint64, params usecase.DeleteParams) error
+	ListIngredients(ctx context.Context, filter usecase.ListIngredientsFilter, limit, offset int) (entity.Ingredients, error)
 	CreateIngredientUnit(ctx context.Context, params usecase.IngredientUnitParams) (*entity.IngredientUnit, error)
 	UpdateIngredientUnit(ctx context.Context, id uint64, params usecase.IngredientUnitParams) (*entity.IngredientUnit, error)
-	DeleteIngredientUnit(ctx context.Context, id uint64) error
+	DeleteIngredientUnit(ctx context.Context, id uint64, params usecase.DeleteParams) error
 	ListIngredientUnits(ctx context.Context, limit, offset int) (entity.IngredientUnits, error)
+	ListIngredientUnitConversions(ctx context.Context, ingredientID uint64) (entity.IngredientUnitConversions, error)
+	UpsertIngredientUnitConversion(ctx context.Context, params usecase.IngredientUnitConversionParams) (*entity.IngredientUnitConversion, error)
+	DeleteIngredientUnitConversion(ctx context.Context, id uint64) error
+	ListIngredientAliases(ctx context.Context, ingredientID uint64) (entity.IngredientAliases, error)
+	CreateIngredientAlias(ctx context.Context, params usecase.IngredientAliasParams) (*entity.IngredientAlias, error)
+	DeleteIngredientAlias(ctx context.Context, id uint64) error
+	MergeIngredients(ctx context.Context, params usecase.IngredientMergeParams) (*entity.Ingredient, error)
+	CreateIngredientKind(ctx context.Context, params usecase.IngredientKindParams) (*entity.IngredientKind, error)
+	UpdateIngredientKind(ctx context.Context, id uint64, params usecase.IngredientKindParams) (*entity.IngredientKind, error)
+	DeleteIngredientKind(ctx context.Context, id uint64, actor string) error
+	ListIngredientKinds(ctx context.Context, limit, offset int) (entity.IngredientKinds, error)
 }
 
 type RecipeUsecase interface {
@@ -35,21 +48,137 @@ type RecipeUsecase interface {
 	UpdateRecipeIngredient(ctx context.Context, id uint64, params usecase.RecipeIngredientParams) (*entity.RecipeIngredient, error)
 	DeleteRecipeIngredient(ctx context.Context, id uint64) error
 	ListRecipes(ctx context.Context, filter usecase.ListRecipesFiter, limit, offset int) (entity.Recipes, error)
-	GetRecipeSummary(ctx context.Context, id uint64) (entity.RecipeSummary, error)
+	GetRecipeSummary(ctx context.Context, id uint64, opts usecase.RecipeSummaryOptions) (entity.RecipeSummary, error)
+	ListRecipeFacets(ctx context.Context, filter usecase.ListRecipesFiter) (entity.RecipeFacets, error)
+	ImportRecipe(ctx context.Context, params usecase.ImportRecipeParams) (entity.RecipeImport, error)
+	ParseIngredientLines(ctx context.Context, lines []string) (entity.ParsedIngredientLines, error)
+	GetCategoryBooklet(ctx context.Context, categoryID uint64, includeSubcategories bool) (entity.RecipeBooklet, error)
+	TransitionRecipe(ctx context.Context, id uint64, params usecase.RecipeTransitionParams) (*entity.Recipe, error)
+	ListRecipeStatusChanges(ctx context.Context, id uint64, viewer string) (entity.RecipeStatusChanges, error)
+	CloneRecipe(ctx context.Context, id uint64, params usecase.CloneRecipeParams) (*entity.Recipe, error)
+	GetRecipeFamily(ctx context.Context, id uint64, viewer string) (*entity.RecipeFamily, error)
+	GetRecipeParentChanges(ctx context.Context, id uint64, viewer string) (entity.RecipeParentChanges, error)
+}
+
+// NutritionUsecase defines the contract for nutrition usecase dependency
+type NutritionUsecase interface {
+	GetRecipeNutrition(ctx context.Context, recipeID uint64, viewer string) (entity.RecipeNutrition, error)
+}
+
+// CostUsecase defines the contract for cost usecase dependency
+type CostUsecase interface {
+	CreateIngredientPrice(ctx context.Context, params usecase.IngredientPriceParams) (*entity.IngredientPrice, error)
+	DeleteIngredientPrice(ctx context.Context, id uint64) error
+	ListIngredientPrices(ctx context.Context, ingredientID uint64, limit, offset int) (entity.IngredientPrices, error)
+	GetRecipeCost(ctx context.Context, recipeID uint64, servings int, viewer string) (entity.RecipeCost, error)
+	ListRecipeCostIncreases(ctx context.Context, filter usecase.RecipeCostIncreaseFilter) (entity.RecipeCostIncreaseReport, error)
+}
+
+// SubstitutionUsecase defines the contract for substitution usecase dependency
+type SubstitutionUsecase interface {
+	CreateIngredientSubstitution(ctx context.Context, params usecase.IngredientSubstitutionParams) (*entity.IngredientSubstitution, error)
+	UpdateIngredientSubstitution(ctx context.Context, id uint64, params usecase.IngredientSubstitutionParams) (*entity.IngredientSubstitution, error)
+	DeleteIngredientSubstitution(ctx context.Context, id uint64) error
+	ListIngredientSubstitutions(ctx context.Context, ingredientID uint64) (entity.IngredientSubstitutions, error)
+	SuggestSubstitutions(ctx context.Context, recipeID uint64, pantryIngredientIDs []uint64, viewer string) (entity.SubstitutionSuggestions, error)
+}
+
+// TrashUsecase defines the contract for trash usecase dependency
+type TrashUsecase interface {
+	ListTrash(ctx context.Context, itemType string, limit, offset int) (entity.TrashItems, error)
+	RestoreTrashItem(ctx context.Context, itemType string, id uint64, actor string) error
+}
+
+// TagUsecase defines the contract for tag usecase dependency
+type TagUsecase interface {
+	CreateTag(ctx context.Context, params usecase.TagParams) (*entity.Tag, error)
+	UpdateTag(ctx context.Context, id uint64, params usecase.TagParams) (*entity.Tag, error)
+	DeleteTag(ctx context.Context, id uint64) error
+	ListTags(ctx context.Context, limit, offset int) (entity.Tags, error)
+}
+
+// MediaUsecase defines the contract for media usecase dependency
+type MediaUsecase interface {
+	UploadRecipeImage(ctx context.Context, params usecase.UploadRecipeImageParams) (*entity.RecipeImage, error)
+	ListRecipeImages(ctx context.Context, recipeID uint64) (entity.RecipeImages, error)
+	DeleteRecipeImage(ctx context.Context, id uint64) error
+	OpenMedia(ctx context.Context, key string) (io.ReadSeekCloser, entity.MediaFile, error)
+	MaxUploadBytes() int64
+}
+
+// EquipmentUsecase defines the contract for equipment usecase dependency
+type EquipmentUsecase interface {
+	CreateEquipment(ctx context.Context, params usecase.EquipmentParams) (*entity.Equipment, error)
+	UpdateEquipment(ctx context.Context, id uint64, params usecase.EquipmentParams) (*entity.Equipment, error)
+	DeleteEquipment(ctx context.Context, id uint64) error
+	ListEquipment(ctx context.Context, limit, offset int) (entity.Equipments, error)
+}
+
+// CollectionUsecase defines the contract for collection usecase dependency
+type CollectionUsecase interface {
+	CreateCollection(ctx context.Context, params usecase.CollectionParams) (*entity.Collection, error)
+	UpdateCollection(ctx context.Context, id uint64, params usecase.CollectionParams) (*entity.Collection, error)
+	DeleteCollection(ctx context.Context, id uint64, actor string) error
+	ListCollections(ctx context.Context, limit, offset int) (entity.Collections, error)
+	GetCollection(ctx context.Context, id uint64, viewer string) (entity.CollectionDetail, error)
+	AddCollectionRecipe(ctx context.Context, id, recipeID uint64, actor string) (entity.CollectionDetail, error)
+	RemoveCollectionRecipe(ctx context.Context, id, recipeID uint64, viewer string) (entity.CollectionDetail, error)
+	ReorderCollectionRecipes(ctx context.Context, id uint64, recipeIDs []uint64, viewer string) (entity.CollectionDetail, error)
+	UploadCollectionCover(ctx context.Context, params usecase.UploadCollectionCoverParams) (*entity.Collection, error)
+	ExportCollection(ctx context.Context, id uint64, viewer string) (entity.CollectionExport, error)
+}
+
+// PantryUsecase defines the contract for pantry usecase dependency
+type PantryUsecase interface {
+	ListPantryItems(ctx context.Context, filter usecase.ListPantryItemsFilter, limit, offset int) (entity.PantryItems, error)
+	SetLowStockThreshold(ctx context.Context, params usecase.PantryThresholdParams) (*entity.PantryItem, error)
+	RecordPantryMovement(ctx context.Context, params usecase.PantryMovementParams) (*entity.PantryMovement, error)
+	ListPantryMovements(ctx context.Context, ingredientID uint64, limit, offset int) (entity.PantryMovements, error)
+	CookRecipe(ctx context.Context, params usecase.CookRecipeParams) (entity.PantryCook, error)
+}
+
+// CookLogUsecase defines the contract for cook log usecase dependency
+type CookLogUsecase interface {
+	CreateCookLog(ctx context.Context, params usecase.CookLogParams) (*entity.CookLog, error)
+	ListCookLogs(ctx context.Context, recipeID uint64, limit, offset int) (entity.CookLogs, error)
+	DeleteCookLog(ctx context.Context, id uint64) error
+	UploadCookLogPhoto(ctx context.Context, params usecase.UploadCookLogPhotoParams) (*entity.CookLog, error)
 }
 
 // CookbookHandler is our GraphQL resolver object
 type CookbookHandler struct {
-	categoryUsecase   CategoryUsecase
-	ingredientUsecase IngredientUsecase
-	recipeUsecase     RecipeUsecase
+	categoryUsecase     CategoryUsecase
+	ingredientUsecase   IngredientUsecase
+	recipeUsecase       RecipeUsecase
+	nutritionUsecase    NutritionUsecase
+	costUsecase         CostUsecase
+	substitutionUsecase SubstitutionUsecase
+	trashUsecase        TrashUsecase
+	tagUsecase          TagUsecase
+	mediaUsecase        MediaUsecase
+	equipmentUsecase    EquipmentUsecase
+	collectionUsecase   CollectionUsecase
+	pantryUsecase       PantryUsecase
+	cookLogUsecase      CookLogUsecase
+	cardTemplates       *RecipeCardTemplates
 }
 
 // NewCookbookHandler instantiates cookbookHandler
-func NewCookbookHandler(categoryUsecase CategoryUsecase, ingredientUsecase IngredientUsecase, recipeUsecase RecipeUsecase) *CookbookHandler {
+func NewCookbookHandler(categoryUsecase CategoryUsecase, ingredientUsecase IngredientUsecase, recipeUsecase RecipeUsecase, nutritionUsecase NutritionUsecase, costUsecase CostUsecase, substitutionUsecase SubstitutionUsecase, trashUsecase TrashUsecase, tagUsecase TagUsecase, mediaUsecase MediaUsecase, equipmentUsecase EquipmentUsecase, collectionUsecase CollectionUsecase, pantryUsecase PantryUsecase, cookLogUsecase CookLogUsecase, cardTemplates *RecipeCardTemplates) *CookbookHandler {
 	return &CookbookHandler{
-		categoryUsecase:   categoryUsecase,
-		ingredientUsecase: ingredientUsecase,
-		recipeUsecase:     recipeUsecase,
+		categoryUsecase:     categoryUsecase,
+		ingredientUsecase:   ingredientUsecase,
+		recipeUsecase:       recipeUsecase,
+		nutritionUsecase:    nutritionUsecase,
+		costUsecase:         costUsecase,
+		substitutionUsecase: substitutionUsecase,
+		trashUsecase:        trashUsecase,
+		tagUsecase:          tagUsecase,
+		mediaUsecase:        mediaUsecase,
+		equipmentUsecase:    equipmentUsecase,
+		collectionUsecase:   collectionUsecase,
+		pantryUsecase:       pantryUsecase,
+		cookLogUsecase:      cookLogUsecase,
+		cardTemplates:       cardTemplates,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi"
@@ -15,44 +16,130 @@ import (
 	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
 )
 
+// IngredientRequest holds an ingredient payload, kind_id and parent_id 0 clear the kind and the parent ingredient
 type IngredientRequest struct {
-	Name  string `json:"name"`
-	Actor string `json:"actor"`
+	Name         string            `json:"name"`
+	KindID       *uint64           `json:"kind_id"`
+	ParentID     *uint64           `json:"parent_id"`
+	BaseUnitName string            `json:"base_unit_name"`
+	Nutrition    *NutritionRequest `json:"nutrition"`
+	Allergens    []string          `json:"allergens"`
+	Diets        []string          `json:"diets"`
+	Actor        string            `json:"actor"`
+}
+
+// NutritionRequest holds nutrition values per 100 of the ingredient base unit
+type NutritionRequest struct {
+	Kcal    null.Float `json:"kcal"`
+	Protein null.Float `json:"protein_g"`
+	Fat     null.Float `json:"fat_g"`
+	Carbs   null.Float `json:"carbs_g"`
+	Fiber   null.Float `json:"fiber_g"`
+	Sodium  null.Float `json:"sodium_mg"`
 }
 
 type IngredientResponse struct {
-	ID        uint64      `json:"id"`
-	Name      string      `json:"name"`
-	CreatedAt time.Time   `json:"created_at"`
-	CreatedBy string      `json:"created_by"`
-	UpdatedAt null.Time   `json:"updated_at"`
-	UpdatedBy null.String `json:"updated_by"`
-	IsDeleted bool        `json:"is_deleted"`
+	ID           uint64            `json:"id"`
+	Name         string            `json:"name"`
+	KindID       uint64            `json:"kind_id,omitempty"`
+	ParentID     uint64            `json:"parent_id,omitempty"`
+	Aisle        null.String       `json:"aisle"`
+	Aliases      []string          `json:"aliases"`
+	BaseUnitName null.String       `json:"base_unit_name"`
+	Nutrition    NutritionResponse `json:"nutrition"`
+	Allergens    []string          `json:"allergens"`
+	Diets        []string          `json:"diets"`
+	CreatedAt    time.Time         `json:"created_at"`
+	CreatedBy    string            `json:"created_by"`
+	UpdatedAt    null.Time         `json:"updated_at"`
+	UpdatedBy    null.String       `json:"updated_by"`
+	IsDeleted    bool              `json:"is_deleted"`
+}
+
+type NutritionResponse struct {
+	Kcal    null.Float `json:"kcal"`
+	Protein null.Float `json:"protein_g"`
+	Fat     null.Float `json:"fat_g"`
+	Carbs   null.Float `json:"carbs_g"`
+	Fiber   null.Float `json:"fiber_g"`
+	Sodium  null.Float `json:"sodium_mg"`
 }
 
 type IngredientsResponse struct {
 	Data []IngredientResponse `json:"ingredients"`
 }
 
-type IngredientUnitRequest struct {
+type IngredientAliasRequest struct {
 	Name  string `json:"name"`
 	Actor string `json:"actor"`
 }
 
+type IngredientAliasResponse struct {
+	ID           uint64      `json:"id"`
+	IngredientID uint64      `json:"ingredient_id"`
+	Name         string      `json:"name"`
+	CreatedAt    time.Time   `json:"created_at"`
+	CreatedBy    string      `json:"created_by"`
+	UpdatedAt    null.Time   `json:"updated_at"`
+	UpdatedBy    null.String `json:"updated_by"`
+	IsDeleted    bool        `json:"is_deleted"`
+}
+
+type IngredientAliasResponses struct {
+	Data []IngredientAliasResponse `json:"ingredient_aliases"`
+}
+
+type MergeIngredientsRequest struct {
+	DuplicateIDs []uint64 `json:"duplicate_ids"`
+	Actor        string   `json:"actor"`
+}
+
+type IngredientUnitRequest struct {
+	Name         string     `json:"name"`
+	BaseUnitName string     `json:"base_unit_name"`
+	BaseFactor   null.Float `json:"base_factor"`
+	Actor        string     `json:"actor"`
+}
+
 type IngredientUnitResponse struct {
-	ID        uint64      `json:"id"`
-	Name      string      `json:"name"`
-	CreatedAt time.Time   `json:"created_at"`
-	CreatedBy string      `json:"created_by"`
-	UpdatedAt null.Time   `json:"updated_at"`
-	UpdatedBy null.String `json:"updated_by"`
-	IsDeleted bool        `json:"is_deleted"`
+	ID           uint64      `json:"id"`
+	Name         string      `json:"name"`
+	BaseUnitName null.String `json:"base_unit_name"`
+	BaseFactor   null.Float  `json:"base_factor"`
+	CreatedAt    time.Time   `json:"created_at"`
+	CreatedBy    string      `json:"created_by"`
+	UpdatedAt    null.Time   `json:"updated_at"`
+	UpdatedBy    null.String `json:"updated_by"`
+	IsDeleted    bool        `json:"is_deleted"`
 }
 
 type IngredientUnitResponses struct {
 	Data []IngredientUnitResponse `json:"ingredient_units"`
 }
 
+type IngredientUnitConversionRequest struct {
+	IngredientUnitID uint64  `json:"ingredient_unit_id"`
+	BaseAmount       float64 `json:"base_amount"`
+	Actor            string  `json:"actor"`
+}
+
+type IngredientUnitConversionResponse struct {
+	ID                 uint64      `json:"id"`
+	IngredientID       uint64      `json:"ingredient_id"`
+	IngredientUnitID   uint64      `json:"ingredient_unit_id"`
+	IngredientUnitName string      `json:"ingredient_unit_name,omitempty"`
+	BaseAmount         float64     `json:"base_amount"`
+	CreatedAt          time.Time   `json:"created_at"`
+	CreatedBy          string      `json:"created_by"`
+	UpdatedAt          null.Time   `json:"updated_at"`
+	UpdatedBy          null.String `json:"updated_by"`
+	IsDeleted          bool        `json:"is_deleted"`
+}
+
+type IngredientUnitConversionResponses struct {
+	Data []IngredientUnitConversionResponse `json:"ingredient_unit_conversions"`
+}
+
 // CreateIngredient is a create ingredient handler
 func (h *CookbookHandler) CreateIngredient(w http.ResponseWriter, r *http.Request) {
 	var req IngredientRequest
@@ -64,13 +151,19 @@ func (h *CookbookHandler) CreateIngredient(w http.ResponseWriter, r *http.Reques
 	}
 
 	params := usecase.IngredientParams{
-		Name:  req.Name,
-		Actor: req.Actor,
+		Name:         req.Name,
+		KindID:       req.KindID,
+		ParentID:     req.ParentID,
+		BaseUnitName: req.BaseUnitName,
+		Nutrition:    nutritionFromRequest(req.Nutrition),
+		Allergens:    req.Allergens,
+		Diets:        req.Diets,
+		Actor:        req.Actor,
 	}
 
 	ingredient, err := h.ingredientUsecase.CreateIngredient(r.Context(), params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, ingredientErrorStatus(err), err)
 		return
 	}
 
@@ -99,7 +192,7 @@ func (h *CookbookHandler) UpdateIngredient(w http.ResponseWriter, r *http.Reques
 
 	ingredient, err := h.ingredientUsecase.UpdateIngredient(r.Context(), id, params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, ingredientErrorStatus(err), err)
 		return
 	}
 
@@ -117,9 +210,15 @@ func (h *CookbookHandler) DeleteIngredient(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = h.ingredientUsecase.DeleteIngredient(r.Context(), id)
+	params, err := parseDeleteParams(r)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ingredientUsecase.DeleteIngredient(r.Context(), id, params)
+	if err != nil {
+		withReferenceError(w, err)
 		return
 	}
 
@@ -137,7 +236,13 @@ func (h *CookbookHandler) ListIngredients(w http.ResponseWriter, r *http.Request
 	ofs, _ := strconv.Atoi(rawOfs)
 	lim, _ := strconv.Atoi(rawLim)
 
-	ingredients, err := h.ingredientUsecase.ListIngredients(r.Context(), lim, ofs)
+	kindID, _ := strconv.ParseUint(query.Get("kind_id"), 10, 64)
+
+	filter := usecase.ListIngredientsFilter{
+		KindID: kindID,
+	}
+
+	ingredients, err := h.ingredientUsecase.ListIngredients(r.Context(), filter, lim, ofs)
 	if err != nil {
 		libhttp.WithError(w, http.StatusInternalServerError, err)
 		return
@@ -152,6 +257,120 @@ func (h *CookbookHandler) ListIngredients(w http.ResponseWriter, r *http.Request
 	return
 }
 
+// ListIngredientAliases is a list ingredient alias handler
+func (h *CookbookHandler) ListIngredientAliases(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	aliases, err := h.ingredientUsecase.ListIngredientAliases(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp IngredientAliasResponses
+	for _, a := range aliases {
+		resp.Data = append(resp.Data, ingredientAliasResponseFromEntity(a))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// CreateIngredientAlias is a create ingredient alias handler
+func (h *CookbookHandler) CreateIngredientAlias(w http.ResponseWriter, r *http.Request) {
+	var req IngredientAliasRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("name cannot be empty"))
+		return
+	}
+
+	params := usecase.IngredientAliasParams{
+		IngredientID: id,
+		Name:         strings.TrimSpace(req.Name),
+		Actor:        req.Actor,
+	}
+
+	alias, err := h.ingredientUsecase.CreateIngredientAlias(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, ingredientErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientAliasResponseFromEntity(alias))
+}
+
+// DeleteIngredientAlias is a delete ingredient alias handler
+func (h *CookbookHandler) DeleteIngredientAlias(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.ingredientUsecase.DeleteIngredientAlias(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted ingredient alias")
+}
+
+// MergeIngredients is a merge duplicate ingredients handler
+func (h *CookbookHandler) MergeIngredients(w http.ResponseWriter, r *http.Request) {
+	var req MergeIngredientsRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.IngredientMergeParams{
+		IngredientID: id,
+		DuplicateIDs: req.DuplicateIDs,
+		Actor:        req.Actor,
+	}
+
+	ingredient, err := h.ingredientUsecase.MergeIngredients(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, ingredientErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientResponseFromEntity(ingredient))
+}
+
 // CreateIngredientUnit is a create ingredient handler
 func (h *CookbookHandler) CreateIngredientUnit(w http.ResponseWriter, r *http.Request) {
 	var req IngredientUnitRequest
@@ -163,8 +382,10 @@ func (h *CookbookHandler) CreateIngredientUnit(w http.ResponseWriter, r *http.Re
 	}
 
 	params := usecase.IngredientUnitParams{
-		Name:  req.Name,
-		Actor: req.Actor,
+		Name:         req.Name,
+		BaseUnitName: req.BaseUnitName,
+		BaseFactor:   req.BaseFactor,
+		Actor:        req.Actor,
 	}
 
 	ingredient, err := h.ingredientUsecase.CreateIngredientUnit(r.Context(), params)
@@ -216,9 +437,15 @@ func (h *CookbookHandler) DeleteIngredientUnit(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.ingredientUsecase.DeleteIngredientUnit(r.Context(), id)
+	params, err := parseDeleteParams(r)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.ingredientUsecase.DeleteIngredientUnit(r.Context(), id, params)
+	if err != nil {
+		withReferenceError(w, err)
 		return
 	}
 
@@ -251,10 +478,98 @@ func (h *CookbookHandler) ListIngredientUnits(w http.ResponseWriter, r *http.Req
 	return
 }
 
+// ListIngredientUnitConversions is a list ingredient unit conversion handler
+func (h *CookbookHandler) ListIngredientUnitConversions(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	conversions, err := h.ingredientUsecase.ListIngredientUnitConversions(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp IngredientUnitConversionResponses
+	for _, c := range conversions {
+		resp.Data = append(resp.Data, ingredientUnitConversionResponseFromEntity(c))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// UpsertIngredientUnitConversion is an upsert ingredient unit conversion handler
+func (h *CookbookHandler) UpsertIngredientUnitConversion(w http.ResponseWriter, r *http.Request) {
+	var req IngredientUnitConversionRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.IngredientUnitID == 0 || req.BaseAmount <= 0 {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("ingredient_unit_id and a positive base_amount are required"))
+		return
+	}
+
+	params := usecase.IngredientUnitConversionParams{
+		IngredientID:     id,
+		IngredientUnitID: req.IngredientUnitID,
+		BaseAmount:       req.BaseAmount,
+		Actor:            req.Actor,
+	}
+
+	conversion, err := h.ingredientUsecase.UpsertIngredientUnitConversion(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientUnitConversionResponseFromEntity(conversion))
+}
+
+// DeleteIngredientUnitConversion is a delete ingredient unit conversion handler
+func (h *CookbookHandler) DeleteIngredientUnitConversion(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.ingredientUsecase.DeleteIngredientUnitConversion(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted ingredient unit conversion")
+}
+
 // normalizeUpdateIngredientRequest converts input to usecase params
 func normalizeUpdateIngredientRequest(input IngredientRequest) usecase.IngredientParams {
 	params := usecase.IngredientParams{
-		Actor: input.Actor,
+		KindID:       input.KindID,
+		ParentID:     input.ParentID,
+		BaseUnitName: input.BaseUnitName,
+		Nutrition:    nutritionFromRequest(input.Nutrition),
+		Allergens:    input.Allergens,
+		Diets:        input.Diets,
+		Actor:        input.Actor,
 	}
 
 	if input.Name != "" {
@@ -264,10 +579,25 @@ func normalizeUpdateIngredientRequest(input IngredientRequest) usecase.Ingredien
 	return params
 }
 
+// ingredientErrorStatus maps errors of ingredient writes to a response status
+func ingredientErrorStatus(err error) int {
+	switch err {
+	case entity.ErrIngredientCycle, entity.ErrIngredientNotFound, entity.ErrIngredientKindNotFound, entity.ErrInvalidAllergen, entity.ErrInvalidDiet,
+		entity.ErrInvalidIngredientMerge, entity.ErrIngredientMergeUnitMismatch:
+		return http.StatusBadRequest
+	case entity.ErrIngredientNameConflict:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
 // normalizeUpdateIngredientUnitRequest converts input to usecase params
 func normalizeUpdateIngredientUnitRequest(input IngredientUnitRequest) usecase.IngredientUnitParams {
 	params := usecase.IngredientUnitParams{
-		Actor: input.Actor,
+		BaseUnitName: input.BaseUnitName,
+		BaseFactor:   input.BaseFactor,
+		Actor:        input.Actor,
 	}
 
 	if input.Name != "" {
@@ -280,8 +610,23 @@ func normalizeUpdateIngredientUnitRequest(input IngredientUnitRequest) usecase.I
 // ingredientResponseFromEntity converts ingredient entity to response
 func ingredientResponseFromEntity(ent *entity.Ingredient) IngredientResponse {
 	return IngredientResponse{
-		ID:        ent.ID,
-		Name:      ent.Name,
+		ID:           ent.ID,
+		Name:         ent.Name,
+		KindID:       ent.KindID,
+		ParentID:     ent.ParentID,
+		Aisle:        ent.Aisle,
+		Aliases:      ent.Aliases,
+		BaseUnitName: ent.BaseUnitName,
+		Nutrition: NutritionResponse{
+			Kcal:    ent.Nutrition.Kcal,
+			Protein: ent.Nutrition.Protein,
+			Fat:     ent.Nutrition.Fat,
+			Carbs:   ent.Nutrition.Carbs,
+			Fiber:   ent.Nutrition.Fiber,
+			Sodium:  ent.Nutrition.Sodium,
+		},
+		Allergens: ent.Allergens,
+		Diets:     ent.Diets,
 		CreatedAt: ent.CreatedAt,
 		CreatedBy: ent.CreatedBy,
 		UpdatedAt: ent.UpdatedAt,
@@ -290,15 +635,63 @@ func ingredientResponseFromEntity(ent *entity.Ingredient) IngredientResponse {
 	}
 }
 
+// ingredientAliasResponseFromEntity converts ingredient alias entity to response
+func ingredientAliasResponseFromEntity(ent *entity.IngredientAlias) IngredientAliasResponse {
+	return IngredientAliasResponse{
+		ID:           ent.ID,
+		IngredientID: ent.IngredientID,
+		Name:         ent.Name,
+		CreatedAt:    ent.CreatedAt,
+		CreatedBy:    ent.CreatedBy,
+		UpdatedAt:    ent.UpdatedAt,
+		UpdatedBy:    ent.UpdatedBy,
+		IsDeleted:    ent.IsDeleted,
+	}
+}
+
 // ingredientUnitResponseFromEntity converts ingredient unit entity to response
 func ingredientUnitResponseFromEntity(ent *entity.IngredientUnit) IngredientUnitResponse {
 	return IngredientUnitResponse{
-		ID:        ent.ID,
-		Name:      ent.Name,
-		CreatedAt: ent.CreatedAt,
-		CreatedBy: ent.CreatedBy,
-		UpdatedAt: ent.UpdatedAt,
-		UpdatedBy: ent.UpdatedBy,
-		IsDeleted: ent.IsDeleted,
+		ID:           ent.ID,
+		Name:         ent.Name,
+		BaseUnitName: ent.BaseUnitName,
+		BaseFactor:   ent.BaseFactor,
+		CreatedAt:    ent.CreatedAt,
+		CreatedBy:    ent.CreatedBy,
+		UpdatedAt:    ent.UpdatedAt,
+		UpdatedBy:    ent.UpdatedBy,
+		IsDeleted:    ent.IsDeleted,
+	}
+}
+
+// ingredientUnitConversionResponseFromEntity converts ingredient unit conversion entity to response
+func ingredientUnitConversionResponseFromEntity(ent *entity.IngredientUnitConversion) IngredientUnitConversionResponse {
+	return IngredientUnitConversionResponse{
+		ID:                 ent.ID,
+		IngredientID:       ent.IngredientID,
+		IngredientUnitID:   ent.IngredientUnitID,
+		IngredientUnitName: ent.IngredientUnitName,
+		BaseAmount:         ent.BaseAmount,
+		CreatedAt:          ent.CreatedAt,
+		CreatedBy:          ent.CreatedBy,
+		UpdatedAt:          ent.UpdatedAt,
+		UpdatedBy:          ent.UpdatedBy,
+		IsDeleted:          ent.IsDeleted,
+	}
+}
+
+// nutritionFromRequest converts an optional nutrition request to entity
+func nutritionFromRequest(req *NutritionRequest) entity.Nutrition {
+	if req == nil {
+		return entity.Nutrition{}
+	}
+
+	return entity.Nutrition{
+		Kcal:    req.Kcal,
+		Protein: req.Protein,
+		Fat:     req.Fat,
+		Carbs:   req.Carbs,
+		Fiber:   req.Fiber,
+		Sodium:  req.Sodium,
 	}
 }
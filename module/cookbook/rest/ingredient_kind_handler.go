@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+// IngredientKindRequest holds an ingredient kind payload, parent_id 0 moves a kind to the root
+type IngredientKindRequest struct {
+	Name     string  `json:"name"`
+	ParentID *uint64 `json:"parent_id"`
+	Aisle    string  `json:"aisle"`
+	Actor    string  `json:"actor"`
+}
+
+type IngredientKindResponse struct {
+	ID        uint64      `json:"id"`
+	Name      string      `json:"name"`
+	ParentID  uint64      `json:"parent_id,omitempty"`
+	Aisle     null.String `json:"aisle"`
+	CreatedAt time.Time   `json:"created_at"`
+	CreatedBy string      `json:"created_by"`
+	UpdatedAt null.Time   `json:"updated_at"`
+	UpdatedBy null.String `json:"updated_by"`
+	IsDeleted bool        `json:"is_deleted"`
+}
+
+type IngredientKindResponses struct {
+	Data []IngredientKindResponse `json:"ingredient_kinds"`
+}
+
+// CreateIngredientKind is a create ingredient kind handler
+func (h *CookbookHandler) CreateIngredientKind(w http.ResponseWriter, r *http.Request) {
+	var req IngredientKindRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.Name == "" {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("name cannot be empty"))
+		return
+	}
+
+	params := usecase.IngredientKindParams{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+		Aisle:    req.Aisle,
+		Actor:    req.Actor,
+	}
+
+	kind, err := h.ingredientUsecase.CreateIngredientKind(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, ingredientKindErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientKindResponseFromEntity(kind))
+}
+
+// UpdateIngredientKind is a update ingredient kind handler
+func (h *CookbookHandler) UpdateIngredientKind(w http.ResponseWriter, r *http.Request) {
+	var req IngredientKindRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.IngredientKindParams{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+		Aisle:    req.Aisle,
+		Actor:    req.Actor,
+	}
+
+	kind, err := h.ingredientUsecase.UpdateIngredientKind(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, ingredientKindErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientKindResponseFromEntity(kind))
+}
+
+// DeleteIngredientKind is a delete ingredient kind handler
+func (h *CookbookHandler) DeleteIngredientKind(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.ingredientUsecase.DeleteIngredientKind(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, ingredientKindErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted ingredient kind")
+}
+
+// ListIngredientKinds is a list ingredient kind handler
+func (h *CookbookHandler) ListIngredientKinds(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	kinds, err := h.ingredientUsecase.ListIngredientKinds(r.Context(), lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp IngredientKindResponses
+	for _, k := range kinds {
+		resp.Data = append(resp.Data, ingredientKindResponseFromEntity(k))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// ingredientKindErrorStatus maps ingredient kind errors to a response status
+func ingredientKindErrorStatus(err error) int {
+	switch err {
+	case entity.ErrIngredientKindCycle, entity.ErrIngredientKindNotFound:
+		return http.StatusBadRequest
+	case entity.ErrIngredientKindInUse:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ingredientKindResponseFromEntity converts ingredient kind entity to response
+func ingredientKindResponseFromEntity(ent *entity.IngredientKind) IngredientKindResponse {
+	return IngredientKindResponse{
+		ID:        ent.ID,
+		Name:      ent.Name,
+		ParentID:  ent.ParentID,
+		Aisle:     ent.Aisle,
+		CreatedAt: ent.CreatedAt,
+		CreatedBy: ent.CreatedBy,
+		UpdatedAt: ent.UpdatedAt,
+		UpdatedBy: ent.UpdatedBy,
+		IsDeleted: ent.IsDeleted,
+	}
+}
@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// maxParsedLines is the number of lines a parse request may contain
+const maxParsedLines = 200
+
+type ParseIngredientLinesRequest struct {
+	Lines []string `json:"lines"`
+}
+
+type ParsedIngredientLinesResponse struct {
+	Data []ParsedIngredientLineResponse `json:"lines"`
+}
+
+type ParsedIngredientLineResponse struct {
+	Line       string                              `json:"line"`
+	Candidates []ParsedIngredientCandidateResponse `json:"candidates"`
+}
+
+type ParsedIngredientCandidateResponse struct {
+	Amount             float64 `json:"amount"`
+	AmountMax          float64 `json:"amount_max,omitempty"`
+	IngredientID       uint64  `json:"ingredient_id"`
+	IngredientName     string  `json:"ingredient_name"`
+	IngredientUnitName string  `json:"ingredient_unit_name"`
+	UnitMatched        bool    `json:"unit_matched"`
+	Notes              string  `json:"notes"`
+	Confidence         float64 `json:"confidence"`
+}
+
+// ParseIngredientLines is a parse ingredient lines handler, it reads free-text lines like "1/2 sdt garam"
+// and returns the matching ingredients of every line, best candidate first
+func (h *CookbookHandler) ParseIngredientLines(w http.ResponseWriter, r *http.Request) {
+	var req ParseIngredientLinesRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if len(req.Lines) > maxParsedLines {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("lines cannot exceed %d", maxParsedLines))
+		return
+	}
+
+	lines, err := h.recipeUsecase.ParseIngredientLines(r.Context(), req.Lines)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := ParsedIngredientLinesResponse{
+		Data: []ParsedIngredientLineResponse{},
+	}
+
+	for _, line := range lines {
+		resp.Data = append(resp.Data, parsedIngredientLineResponseFromEntity(line))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+func parsedIngredientLineResponseFromEntity(ent *entity.ParsedIngredientLine) ParsedIngredientLineResponse {
+	resp := ParsedIngredientLineResponse{
+		Line:       ent.Line,
+		Candidates: []ParsedIngredientCandidateResponse{},
+	}
+
+	for _, c := range ent.Candidates {
+		resp.Candidates = append(resp.Candidates, ParsedIngredientCandidateResponse{
+			Amount:             c.Amount,
+			AmountMax:          c.AmountMax,
+			IngredientID:       c.IngredientID,
+			IngredientName:     c.IngredientName,
+			IngredientUnitName: c.IngredientUnitName,
+			UnitMatched:        c.UnitMatched,
+			Notes:              c.Notes,
+			Confidence:         c.Confidence,
+		})
+	}
+
+	return resp
+}
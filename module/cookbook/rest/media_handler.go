@@ -0,0 +1,231 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+const (
+	// mediaURLPrefix is where ServeMedia is routed
+	mediaURLPrefix = "/v1/media/"
+
+	// maxUploadMemory is the part of a multipart upload kept in memory, the rest goes to temporary files
+	maxUploadMemory = 8 << 20
+
+	// uploadFormOverhead leaves room for the multipart boundaries and the form fields next to the image
+	uploadFormOverhead = 64 << 10
+)
+
+type RecipeImageResponse struct {
+	ID           uint64      `json:"id"`
+	RecipeID     uint64      `json:"recipe_id"`
+	Step         null.Int    `json:"step"`
+	Caption      null.String `json:"caption"`
+	URL          string      `json:"url"`
+	ThumbnailURL string      `json:"thumbnail_url"`
+	ContentType  string      `json:"content_type"`
+	SizeBytes    int64       `json:"size_bytes"`
+	Width        int         `json:"width"`
+	Height       int         `json:"height"`
+	CreatedAt    time.Time   `json:"created_at"`
+	CreatedBy    string      `json:"created_by"`
+}
+
+type RecipeImagesResponse struct {
+	Data []RecipeImageResponse `json:"images"`
+}
+
+// UploadRecipeImage is an upload recipe photo handler, the image is sent as the "file" field of a multipart form
+// with optional "caption" and "actor" fields
+func (h *CookbookHandler) UploadRecipeImage(w http.ResponseWriter, r *http.Request) {
+	h.uploadRecipeImage(w, r, null.Int{})
+}
+
+// UploadRecipeStepImage is an upload recipe step photo handler, the step is the 1-based step number
+func (h *CookbookHandler) UploadRecipeStepImage(w http.ResponseWriter, r *http.Request) {
+	step, err := strconv.ParseInt(chi.URLParam(r, "step"), 10, 64)
+	if err != nil || step <= 0 {
+		libhttp.WithError(w, http.StatusBadRequest, entity.ErrInvalidRecipeStep)
+		return
+	}
+
+	h.uploadRecipeImage(w, r, null.IntFrom(step))
+}
+
+func (h *CookbookHandler) uploadRecipeImage(w http.ResponseWriter, r *http.Request, step null.Int) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	file, done, ok := openUploadedImage(w, r, h.mediaUsecase.MaxUploadBytes())
+	if !ok {
+		return
+	}
+
+	defer done()
+
+	params := usecase.UploadRecipeImageParams{
+		RecipeID: id,
+		Step:     step,
+		Caption:  r.FormValue("caption"),
+		Actor:    r.FormValue("actor"),
+		Content:  file,
+	}
+
+	image, err := h.mediaUsecase.UploadRecipeImage(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, mediaErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeImageResponseFromEntity(image))
+}
+
+// ListRecipeImages is a list recipe images handler
+func (h *CookbookHandler) ListRecipeImages(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	images, err := h.mediaUsecase.ListRecipeImages(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := RecipeImagesResponse{
+		Data: []RecipeImageResponse{},
+	}
+
+	for _, img := range images {
+		resp.Data = append(resp.Data, recipeImageResponseFromEntity(img))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// DeleteRecipeImage is a delete recipe image handler, the image files are deleted too
+func (h *CookbookHandler) DeleteRecipeImage(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.mediaUsecase.DeleteRecipeImage(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, mediaErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted recipe image")
+}
+
+// ServeMedia serves a stored media file. Stored files never change, so they are cached for a year
+// and conditional and range requests are answered by http.ServeContent
+func (h *CookbookHandler) ServeMedia(w http.ResponseWriter, r *http.Request) {
+	content, file, err := h.mediaUsecase.OpenMedia(r.Context(), chi.URLParam(r, "*"))
+	if err != nil {
+		libhttp.WithError(w, mediaErrorStatus(err), err)
+		return
+	}
+
+	defer content.Close()
+
+	if file.ContentType != "" {
+		w.Header().Set("Content-Type", file.ContentType)
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", strconv.Quote(path.Base(file.Key)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, path.Base(file.Key), file.ModifiedAt, content)
+}
+
+// openUploadedImage parses a multipart form and opens its "file" field, an error response is sent when it fails.
+// done closes the file and removes the temporary files of the form
+func openUploadedImage(w http.ResponseWriter, r *http.Request, maxBytes int64) (multipart.File, func(), bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+uploadFormOverhead)
+
+	err := r.ParseMultipartForm(maxUploadMemory)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			libhttp.WithError(w, http.StatusRequestEntityTooLarge, entity.ErrMediaTooLarge)
+			return nil, nil, false
+		}
+
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return nil, nil, false
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		_ = r.MultipartForm.RemoveAll()
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("file cannot be empty"))
+		return nil, nil, false
+	}
+
+	done := func() {
+		_ = file.Close()
+		_ = r.MultipartForm.RemoveAll()
+	}
+
+	return file, done, true
+}
+
+// mediaErrorStatus maps media errors to a response status
+func mediaErrorStatus(err error) int {
+	switch err {
+	case entity.ErrInvalidRecipeStep:
+		return http.StatusBadRequest
+	case entity.ErrRecipeNotFound, entity.ErrRecipeImageNotFound, entity.ErrMediaNotFound:
+		return http.StatusNotFound
+	case entity.ErrMediaTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case entity.ErrUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+	}
+
+	return http.StatusInternalServerError
+}
+
+func recipeImageResponseFromEntity(ent *entity.RecipeImage) RecipeImageResponse {
+	return RecipeImageResponse{
+		ID:           ent.ID,
+		RecipeID:     ent.RecipeID,
+		Step:         ent.Step,
+		Caption:      ent.Caption,
+		URL:          mediaURLPrefix + ent.FileKey,
+		ThumbnailURL: mediaURLPrefix + ent.ThumbnailKey,
+		ContentType:  ent.ContentType,
+		SizeBytes:    ent.SizeBytes,
+		Width:        ent.Width,
+		Height:       ent.Height,
+		CreatedAt:    ent.CreatedAt,
+		CreatedBy:    ent.CreatedBy,
+	}
+}
@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type NutritionFactsResponse struct {
+	Kcal    float64 `json:"kcal"`
+	Protein float64 `json:"protein_g"`
+	Fat     float64 `json:"fat_g"`
+	Carbs   float64 `json:"carbs_g"`
+	Fiber   float64 `json:"fiber_g"`
+	Sodium  float64 `json:"sodium_mg"`
+}
+
+type RecipeIngredientNutritionResponse struct {
+	IngredientID       uint64                 `json:"ingredient_id"`
+	IngredientName     string                 `json:"ingredient_name"`
+	IngredientUnitName string                 `json:"ingredient_unit_name"`
+	Amount             null.Float             `json:"amount"`
+	BaseAmount         null.Float             `json:"base_amount"`
+	BaseUnitName       null.String            `json:"base_unit_name"`
+	Nutrition          NutritionFactsResponse `json:"nutrition"`
+	Status             string                 `json:"status"`
+}
+
+type RecipeNutritionResponse struct {
+	RecipeID    uint64                              `json:"recipe_id"`
+	Servings    int                                 `json:"servings"`
+	Total       NutritionFactsResponse              `json:"total"`
+	PerServing  NutritionFactsResponse              `json:"per_serving"`
+	IsComplete  bool                                `json:"is_complete"`
+	Ingredients []RecipeIngredientNutritionResponse `json:"ingredients"`
+}
+
+// GetRecipeNutrition is a get recipe nutrition handler
+func (h *CookbookHandler) GetRecipeNutrition(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	nutrition, err := h.nutritionUsecase.GetRecipeNutrition(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeNutritionResponseFromEntity(nutrition))
+}
+
+// recipeNutritionResponseFromEntity converts recipe nutrition entity to response
+func recipeNutritionResponseFromEntity(ent entity.RecipeNutrition) RecipeNutritionResponse {
+	resp := RecipeNutritionResponse{
+		RecipeID:   ent.RecipeID,
+		Servings:   ent.Servings,
+		Total:      nutritionFactsResponseFromEntity(ent.Total),
+		PerServing: nutritionFactsResponseFromEntity(ent.PerServing),
+		IsComplete: ent.IsComplete,
+	}
+
+	for _, i := range ent.Ingredients {
+		resp.Ingredients = append(resp.Ingredients, RecipeIngredientNutritionResponse{
+			IngredientID:       i.IngredientID,
+			IngredientName:     i.IngredientName,
+			IngredientUnitName: i.IngredientUnitName,
+			Amount:             i.Amount,
+			BaseAmount:         i.BaseAmount,
+			BaseUnitName:       i.BaseUnitName,
+			Nutrition:          nutritionFactsResponseFromEntity(i.Facts),
+			Status:             i.Status,
+		})
+	}
+
+	return resp
+}
+
+// nutritionFactsResponseFromEntity converts nutrition facts entity to response
+func nutritionFactsResponseFromEntity(ent entity.NutritionFacts) NutritionFactsResponse {
+	return NutritionFactsResponse{
+		Kcal:    ent.Kcal,
+		Protein: ent.Protein,
+		Fat:     ent.Fat,
+		Carbs:   ent.Carbs,
+		Fiber:   ent.Fiber,
+		Sodium:  ent.Sodium,
+	}
+}
@@ -0,0 +1,326 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type PantryThresholdRequest struct {
+	LowStockThreshold null.Float `json:"low_stock_threshold"`
+	Actor             string     `json:"actor"`
+}
+
+type PantryMovementRequest struct {
+	Kind     string  `json:"kind"`
+	Amount   float64 `json:"amount"`
+	UnitName string  `json:"unit_name"`
+	Notes    string  `json:"notes"`
+	Actor    string  `json:"actor"`
+}
+
+type CookRecipeRequest struct {
+	Multiplier float64 `json:"multiplier"`
+	Actor      string  `json:"actor"`
+}
+
+type PantryItemResponse struct {
+	IngredientID      uint64     `json:"ingredient_id"`
+	IngredientName    string     `json:"ingredient_name"`
+	BaseUnitName      string     `json:"base_unit_name"`
+	Quantity          float64    `json:"quantity"`
+	LowStockThreshold null.Float `json:"low_stock_threshold"`
+	IsLowStock        bool       `json:"is_low_stock"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	UpdatedBy         string     `json:"updated_by"`
+}
+
+type PantryItemResponses struct {
+	Data []PantryItemResponse `json:"pantry_items"`
+}
+
+type PantryMovementResponse struct {
+	ID             uint64      `json:"id"`
+	IngredientID   uint64      `json:"ingredient_id"`
+	IngredientName string      `json:"ingredient_name"`
+	Kind           string      `json:"kind"`
+	Amount         float64     `json:"amount"`
+	UnitName       string      `json:"unit_name"`
+	BaseAmount     float64     `json:"base_amount"`
+	BalanceAfter   float64     `json:"balance_after"`
+	RecipeID       null.Int    `json:"recipe_id"`
+	Notes          null.String `json:"notes"`
+	CreatedAt      time.Time   `json:"created_at"`
+	CreatedBy      string      `json:"created_by"`
+}
+
+type PantryMovementResponses struct {
+	Data []PantryMovementResponse `json:"pantry_movements"`
+}
+
+type PantryShortageResponse struct {
+	IngredientID   uint64  `json:"ingredient_id"`
+	IngredientName string  `json:"ingredient_name"`
+	BaseUnitName   string  `json:"base_unit_name"`
+	Needed         float64 `json:"needed"`
+	OnHand         float64 `json:"on_hand"`
+}
+
+type PantrySkipResponse struct {
+	IngredientID   uint64 `json:"ingredient_id"`
+	IngredientName string `json:"ingredient_name"`
+	Reason         string `json:"reason"`
+}
+
+type CookRecipeResponse struct {
+	RecipeID   uint64                   `json:"recipe_id"`
+	Multiplier float64                  `json:"multiplier"`
+	Movements  []PantryMovementResponse `json:"movements"`
+	Shortages  []PantryShortageResponse `json:"shortages"`
+	Skipped    []PantrySkipResponse     `json:"skipped"`
+	LowStock   []PantryItemResponse     `json:"low_stock"`
+}
+
+// ListPantryItems is a list pantry items handler, ?low_stock=true lists only the low-stock alerts
+func (h *CookbookHandler) ListPantryItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+	lowStock, _ := strconv.ParseBool(query.Get("low_stock"))
+
+	items, err := h.pantryUsecase.ListPantryItems(r.Context(), usecase.ListPantryItemsFilter{LowStockOnly: lowStock}, lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := PantryItemResponses{Data: []PantryItemResponse{}}
+	for _, item := range items {
+		resp.Data = append(resp.Data, pantryItemResponseFromEntity(item))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// SetLowStockThreshold is a set low-stock threshold handler, a null threshold turns the alert off
+func (h *CookbookHandler) SetLowStockThreshold(w http.ResponseWriter, r *http.Request) {
+	var req PantryThresholdRequest
+	rawID := chi.URLParam(r, "ingredient_id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("ingredient_id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.PantryThresholdParams{
+		IngredientID: id,
+		Threshold:    req.LowStockThreshold,
+		Actor:        req.Actor,
+	}
+
+	item, err := h.pantryUsecase.SetLowStockThreshold(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, pantryErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, pantryItemResponseFromEntity(item))
+}
+
+// RecordPantryMovement is a record pantry movement handler for purchases, waste and adjustments
+func (h *CookbookHandler) RecordPantryMovement(w http.ResponseWriter, r *http.Request) {
+	var req PantryMovementRequest
+	rawID := chi.URLParam(r, "ingredient_id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("ingredient_id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.PantryMovementParams{
+		IngredientID: id,
+		Kind:         req.Kind,
+		Amount:       req.Amount,
+		UnitName:     req.UnitName,
+		Notes:        req.Notes,
+		Actor:        req.Actor,
+	}
+
+	movement, err := h.pantryUsecase.RecordPantryMovement(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, pantryErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, pantryMovementResponseFromEntity(movement))
+}
+
+// ListPantryMovements is a list pantry movements handler, it returns the ledger of an ingredient latest first
+func (h *CookbookHandler) ListPantryMovements(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	rawID := chi.URLParam(r, "ingredient_id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("ingredient_id cannot be empty"))
+		return
+	}
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	movements, err := h.pantryUsecase.ListPantryMovements(r.Context(), id, lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := PantryMovementResponses{Data: []PantryMovementResponse{}}
+	for _, movement := range movements {
+		resp.Data = append(resp.Data, pantryMovementResponseFromEntity(movement))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// CookRecipe is a cook recipe handler, it takes the ingredients of a recipe times the multiplier out of the pantry
+func (h *CookbookHandler) CookRecipe(w http.ResponseWriter, r *http.Request) {
+	var req CookRecipeRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.CookRecipeParams{
+		RecipeID:   id,
+		Multiplier: req.Multiplier,
+		Actor:      req.Actor,
+	}
+
+	cook, err := h.pantryUsecase.CookRecipe(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, pantryErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, cookRecipeResponseFromEntity(cook))
+}
+
+// pantryErrorStatus maps errors of pantry writes to a response status
+func pantryErrorStatus(err error) int {
+	switch err {
+	case entity.ErrInvalidPantryMovement, entity.ErrPantryUnitNotConvertible, entity.ErrInvalidLowStockThreshold,
+		entity.ErrInvalidCookMultiplier, entity.ErrSubRecipeUnitMismatch, entity.ErrRecipeCycle:
+		return http.StatusBadRequest
+	case entity.ErrIngredientNotFound, entity.ErrRecipeNotFound:
+		return http.StatusNotFound
+	case entity.ErrInsufficientStock:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// pantryItemResponseFromEntity converts pantry item entity to response
+func pantryItemResponseFromEntity(ent *entity.PantryItem) PantryItemResponse {
+	return PantryItemResponse{
+		IngredientID:      ent.IngredientID,
+		IngredientName:    ent.IngredientName,
+		BaseUnitName:      ent.BaseUnitName,
+		Quantity:          ent.Quantity,
+		LowStockThreshold: ent.LowStockThreshold,
+		IsLowStock:        ent.IsLowStock(),
+		UpdatedAt:         ent.UpdatedAt,
+		UpdatedBy:         ent.UpdatedBy,
+	}
+}
+
+// pantryMovementResponseFromEntity converts pantry movement entity to response
+func pantryMovementResponseFromEntity(ent *entity.PantryMovement) PantryMovementResponse {
+	return PantryMovementResponse{
+		ID:             ent.ID,
+		IngredientID:   ent.IngredientID,
+		IngredientName: ent.IngredientName,
+		Kind:           ent.Kind,
+		Amount:         ent.Amount,
+		UnitName:       ent.UnitName,
+		BaseAmount:     ent.BaseAmount,
+		BalanceAfter:   ent.BalanceAfter,
+		RecipeID:       ent.RecipeID,
+		Notes:          ent.Notes,
+		CreatedAt:      ent.CreatedAt,
+		CreatedBy:      ent.CreatedBy,
+	}
+}
+
+func cookRecipeResponseFromEntity(ent entity.PantryCook) CookRecipeResponse {
+	resp := CookRecipeResponse{
+		RecipeID:   ent.RecipeID,
+		Multiplier: ent.Multiplier,
+		Movements:  []PantryMovementResponse{},
+		Shortages:  []PantryShortageResponse{},
+		Skipped:    []PantrySkipResponse{},
+		LowStock:   []PantryItemResponse{},
+	}
+
+	for _, movement := range ent.Movements {
+		resp.Movements = append(resp.Movements, pantryMovementResponseFromEntity(movement))
+	}
+
+	for _, shortage := range ent.Shortages {
+		resp.Shortages = append(resp.Shortages, PantryShortageResponse{
+			IngredientID:   shortage.IngredientID,
+			IngredientName: shortage.IngredientName,
+			BaseUnitName:   shortage.BaseUnitName,
+			Needed:         shortage.Needed,
+			OnHand:         shortage.OnHand,
+		})
+	}
+
+	for _, skip := range ent.Skipped {
+		resp.Skipped = append(resp.Skipped, PantrySkipResponse{
+			IngredientID:   skip.IngredientID,
+			IngredientName: skip.IngredientName,
+			Reason:         skip.Reason,
+		})
+	}
+
+	for _, item := range ent.LowStock {
+		resp.LowStock = append(resp.LowStock, pantryItemResponseFromEntity(item))
+	}
+
+	return resp
+}
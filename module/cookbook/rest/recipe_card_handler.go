@@ -0,0 +1,267 @@
+package rest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+const (
+	contentTypeMarkdown = "text/markdown; charset=utf-8"
+	contentTypeHTML     = "text/html; charset=utf-8"
+
+	cardFormatMarkdown = "md"
+	cardFormatHTML     = "html"
+)
+
+//go:embed templates/*.tmpl
+var embeddedTemplates embed.FS
+
+// cardTemplateFuncs are the functions the recipe card templates may use
+var cardTemplateFuncs = map[string]interface{}{
+	"join": strings.Join,
+	"inc": func(i int) int {
+		return i + 1
+	},
+}
+
+// RecipeCardTemplates renders printable recipe cards and category booklets as Markdown or HTML.
+// The templates are embedded, and the template files of a deployment's template directory are parsed over them,
+// so that e.g. a card.html.tmpl defining only "style" restyles every card and booklet
+type RecipeCardTemplates struct {
+	markdown *texttemplate.Template
+	html     *htmltemplate.Template
+}
+
+// NewRecipeCardTemplates parses the embedded templates and the overrides found in dir, dir may be empty
+func NewRecipeCardTemplates(dir string) (*RecipeCardTemplates, error) {
+	markdown, err := texttemplate.New("").Funcs(cardTemplateFuncs).ParseFS(embeddedTemplates, "templates/*.md.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.New("").Funcs(cardTemplateFuncs).ParseFS(embeddedTemplates, "templates/*.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	if dir == "" {
+		return &RecipeCardTemplates{markdown: markdown, html: html}, nil
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.md.tmpl")); len(files) > 0 {
+		markdown, err = markdown.ParseFiles(files...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.html.tmpl")); len(files) > 0 {
+		html, err = html.ParseFiles(files...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &RecipeCardTemplates{markdown: markdown, html: html}, nil
+}
+
+// render executes the template of a page, e.g. "recipe_card", in a format and returns its content type and body
+func (t *RecipeCardTemplates) render(page, format string, data interface{}) (string, []byte, error) {
+	var buf bytes.Buffer
+
+	if format == cardFormatMarkdown {
+		err := t.markdown.ExecuteTemplate(&buf, page+".md.tmpl", data)
+		return contentTypeMarkdown, buf.Bytes(), err
+	}
+
+	err := t.html.ExecuteTemplate(&buf, page+".html.tmpl", data)
+	return contentTypeHTML, buf.Bytes(), err
+}
+
+type recipeCardView struct {
+	ID          uint64
+	Name        string
+	Servings    int
+	Yield       string
+	Cuisine     string
+	Difficulty  string
+	PrepTime    string
+	CookTime    string
+	TotalTime   string
+	Tags        []string
+	Equipment   []string
+	Ingredients []recipeCardIngredientView
+	Steps       []string
+}
+
+type recipeCardIngredientView struct {
+	Amount   string
+	Unit     string
+	Name     string
+	Notes    string
+	ToTaste  bool
+	Optional bool
+}
+
+type recipeBookletView struct {
+	Title   string
+	Recipes []recipeCardView
+}
+
+// GetCategoryBooklet is a category booklet handler, it renders every recipe of a category as a single printable
+// document, HTML unless the client accepts text/markdown. ?include_subcategories=true adds the descendant categories
+func (h *CookbookHandler) GetCategoryBooklet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	includeSubcategories, _ := strconv.ParseBool(r.URL.Query().Get("include_subcategories"))
+
+	booklet, err := h.recipeUsecase.GetCategoryBooklet(r.Context(), id, includeSubcategories)
+	if err != nil {
+		if err == entity.ErrCategoryNotFound {
+			libhttp.WithError(w, http.StatusNotFound, err)
+			return
+		}
+
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	view := recipeBookletView{
+		Title:   booklet.Category.Name,
+		Recipes: []recipeCardView{},
+	}
+
+	for _, summary := range booklet.Recipes {
+		view.Recipes = append(view.Recipes, recipeCardViewFromEntity(summary))
+	}
+
+	format := acceptedCardFormat(r)
+	if format == "" {
+		format = cardFormatHTML
+	}
+
+	h.withRecipeCardPage(w, "booklet", format, view)
+}
+
+// withRecipeCard sends a recipe summary as a printable recipe card
+func (h *CookbookHandler) withRecipeCard(w http.ResponseWriter, summary entity.RecipeSummary, format string) {
+	if summary.ID == 0 {
+		libhttp.WithError(w, http.StatusNotFound, entity.ErrRecipeNotFound)
+		return
+	}
+
+	h.withRecipeCardPage(w, "recipe_card", format, recipeCardViewFromEntity(summary))
+}
+
+func (h *CookbookHandler) withRecipeCardPage(w http.ResponseWriter, page, format string, data interface{}) {
+	contentType, body, err := h.cardTemplates.render(page, format, data)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithBody(w, http.StatusOK, contentType, body)
+}
+
+// acceptedCardFormat returns the recipe card format the client asks for, or an empty string for neither
+func acceptedCardFormat(r *http.Request) string {
+	accept := r.Header.Get("Accept")
+
+	switch {
+	case strings.Contains(accept, "text/markdown"):
+		return cardFormatMarkdown
+	case strings.Contains(accept, "text/html"):
+		return cardFormatHTML
+	}
+
+	return ""
+}
+
+// recipeCardViewFromEntity converts a recipe summary to the data of its card, with the ingredients
+// in ordering_index order and the lines of the description as the steps
+func recipeCardViewFromEntity(ent entity.RecipeSummary) recipeCardView {
+	view := recipeCardView{
+		ID:          ent.ID,
+		Name:        ent.Name,
+		Servings:    ent.Servings,
+		Cuisine:     ent.Cuisine.String,
+		Difficulty:  ent.Difficulty.String,
+		PrepTime:    formatMinutes(ent.PrepMinutes),
+		CookTime:    formatMinutes(ent.CookMinutes),
+		TotalTime:   formatMinutes(ent.TotalMinutes),
+		Ingredients: []recipeCardIngredientView{},
+		Steps:       ent.Steps(),
+	}
+
+	if ent.YieldAmount.Valid {
+		view.Yield = strings.TrimSpace(formatAmount(ent.YieldAmount.Float64) + " " + ent.YieldUnitName.String)
+	}
+
+	for _, t := range ent.Tags {
+		view.Tags = append(view.Tags, t.Name)
+	}
+
+	for _, e := range ent.Equipment {
+		view.Equipment = append(view.Equipment, e.Name)
+	}
+
+	ingredients := append(entity.RecipeIngredients{}, ent.Ingredients...)
+	sort.SliceStable(ingredients, func(i, j int) bool {
+		return ingredients[i].OrderingIndex < ingredients[j].OrderingIndex
+	})
+
+	for _, ingredient := range ingredients {
+		iv := recipeCardIngredientView{
+			Name:     ingredient.IngredientName,
+			Notes:    ingredient.Notes,
+			ToTaste:  ingredient.IsToTaste(),
+			Optional: ingredient.Optional,
+		}
+
+		// a unit without an amount, e.g. "sdt garam" to taste, reads like a part of the name
+		if ingredient.Amount.Float64 > 0 {
+			iv.Amount = ingredient.AmountText()
+			iv.Unit = ingredient.IngredientUnitName
+		}
+
+		view.Ingredients = append(view.Ingredients, iv)
+	}
+
+	return view
+}
+
+// formatMinutes writes minutes for a reader, e.g. "1 h 30 min"
+func formatMinutes(minutes null.Int) string {
+	if !minutes.Valid {
+		return ""
+	}
+
+	hours, mins := minutes.Int64/60, minutes.Int64%60
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%d min", mins)
+	case mins == 0:
+		return fmt.Sprintf("%d h", hours)
+	}
+
+	return fmt.Sprintf("%d h %d min", hours, mins)
+}
@@ -21,10 +21,19 @@ type CreateRecipeRequest struct {
 }
 
 type RecipeRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	CategoryID  uint64 `json:"category_id"`
-	Actor       string `json:"actor"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	CategoryID    uint64   `json:"category_id"`
+	Servings      int      `json:"servings"`
+	YieldAmount   float64  `json:"yield_amount"`
+	YieldUnitName string   `json:"yield_unit_name"`
+	Cuisine       string   `json:"cuisine"`
+	PrepMinutes   int      `json:"prep_minutes"`
+	CookMinutes   int      `json:"cook_minutes"`
+	Difficulty    string   `json:"difficulty"`
+	TagIDs        []uint64 `json:"tag_ids"`
+	EquipmentIDs  []uint64 `json:"equipment_ids"`
+	Actor         string   `json:"actor"`
 }
 
 type BulkCreateRecipeIngredientsRequest struct {
@@ -35,8 +44,14 @@ type BulkCreateRecipeIngredientsRequest struct {
 type RecipeIngredientRequests []RecipeIngredientRequest
 
 type RecipeIngredientRequest struct {
+	Line               string  `json:"line"`
 	Amount             float64 `json:"amount"`
+	AmountMax          float64 `json:"amount_max"`
+	AmountText         string  `json:"amount_text"`
+	ToTaste            bool    `json:"to_taste"`
+	Optional           *bool   `json:"optional"`
 	IngredientID       uint64  `json:"ingredient_id"`
+	SubRecipeID        uint64  `json:"sub_recipe_id"`
 	IngredientName     string  `json:"ingredient_name"`
 	IngredientUnitName string  `json:"ingredient_unit_name"`
 	OrderingIndex      int     `json:"ordering_index"`
@@ -45,15 +60,29 @@ type RecipeIngredientRequest struct {
 }
 
 type RecipeResponse struct {
-	ID          uint64      `json:"id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	CategoryID  uint64      `json:"category_id"`
-	CreatedAt   time.Time   `json:"created_at"`
-	CreatedBy   string      `json:"created_by"`
-	UpdatedAt   null.Time   `json:"updated_at"`
-	UpdatedBy   null.String `json:"updated_by"`
-	IsDeleted   bool        `json:"is_deleted"`
+	ID            uint64      `json:"id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	CategoryID    uint64      `json:"category_id"`
+	Servings      int         `json:"servings"`
+	YieldAmount   null.Float  `json:"yield_amount"`
+	YieldUnitName null.String `json:"yield_unit_name"`
+	Cuisine       null.String `json:"cuisine"`
+	PrepMinutes   null.Int    `json:"prep_minutes"`
+	CookMinutes   null.Int    `json:"cook_minutes"`
+	TotalMinutes  null.Int    `json:"total_minutes"`
+	Difficulty    null.String `json:"difficulty"`
+	Status        string      `json:"status"`
+	VariantOfID   null.Int    `json:"variant_of_id"`
+	ForkedAt      null.Time   `json:"forked_at"`
+	CookCount     int         `json:"cook_count"`
+	RatingCount   int         `json:"rating_count"`
+	AverageRating null.Float  `json:"average_rating"`
+	CreatedAt     time.Time   `json:"created_at"`
+	CreatedBy     string      `json:"created_by"`
+	UpdatedAt     null.Time   `json:"updated_at"`
+	UpdatedBy     null.String `json:"updated_by"`
+	IsDeleted     bool        `json:"is_deleted"`
 }
 
 type RecipeResponses struct {
@@ -64,9 +93,15 @@ type RecipeIngredientResponse struct {
 	ID                 uint64      `json:"id"`
 	RecipeID           uint64      `json:"recipe_id,omitempty"`
 	IngredientID       uint64      `json:"ingredient_id,omitempty"`
+	SubRecipeID        uint64      `json:"sub_recipe_id,omitempty"`
 	IngredientName     string      `json:"ingredient_name"`
 	IngredientUnitName string      `json:"ingredient_unit_name"`
-	Amount             float64     `json:"amount"`
+	Amount             null.Float  `json:"amount"`
+	AmountMax          null.Float  `json:"amount_max"`
+	AmountDenominator  null.Int    `json:"amount_denominator"`
+	AmountText         string      `json:"amount_text"`
+	ToTaste            bool        `json:"to_taste"`
+	Optional           bool        `json:"optional"`
 	OrderingIndex      int         `json:"ordering_index"`
 	Notes              string      `json:"notes"`
 	CreatedAt          time.Time   `json:"created_at"`
@@ -82,7 +117,14 @@ type RecipeIngredientResponses struct {
 
 type GetSummaryResponse struct {
 	RecipeResponse
-	Ingredients []RecipeIngredientResponse `json:"ingredients"`
+	Tags                 []TagResponse                    `json:"tags"`
+	Images               []RecipeImageResponse            `json:"images"`
+	Equipment            []EquipmentResponse              `json:"equipment"`
+	Ingredients          []RecipeIngredientResponse       `json:"ingredients"`
+	FlattenedIngredients []RecipeIngredientResponse       `json:"flattened_ingredients,omitempty"`
+	Allergens            []string                         `json:"allergens"`
+	Diets                []string                         `json:"diets"`
+	Substitutions        []IngredientSubstitutionResponse `json:"substitutions,omitempty"`
 }
 
 // CreateRecipe is a create recipe handler
@@ -97,17 +139,32 @@ func (h *CookbookHandler) CreateRecipe(w http.ResponseWriter, r *http.Request) {
 
 	params := usecase.CreateRecipeParams{
 		RecipeParams: usecase.RecipeParams{
-			Name:        req.Name,
-			Description: req.Description,
-			CategoryID:  req.CategoryID,
-			Actor:       req.Actor,
+			Name:          req.Name,
+			Description:   req.Description,
+			CategoryID:    req.CategoryID,
+			Servings:      req.Servings,
+			YieldAmount:   req.YieldAmount,
+			YieldUnitName: req.YieldUnitName,
+			Cuisine:       req.Cuisine,
+			PrepMinutes:   req.PrepMinutes,
+			CookMinutes:   req.CookMinutes,
+			Difficulty:    req.Difficulty,
+			TagIDs:        req.TagIDs,
+			EquipmentIDs:  req.EquipmentIDs,
+			Actor:         req.Actor,
 		},
 	}
 
 	for _, ingredient := range req.Ingredients {
 		params.Ingredients = append(params.Ingredients, usecase.RecipeIngredientParams{
+			Line:               ingredient.Line,
 			Amount:             ingredient.Amount,
+			AmountMax:          ingredient.AmountMax,
+			AmountText:         ingredient.AmountText,
+			ToTaste:            ingredient.ToTaste,
+			Optional:           ingredient.Optional,
 			IngredientID:       ingredient.IngredientID,
+			SubRecipeID:        ingredient.SubRecipeID,
 			IngredientName:     ingredient.IngredientName,
 			IngredientUnitName: ingredient.IngredientUnitName,
 			OrderingIndex:      ingredient.OrderingIndex,
@@ -118,7 +175,7 @@ func (h *CookbookHandler) CreateRecipe(w http.ResponseWriter, r *http.Request) {
 
 	err = h.recipeUsecase.CreateRecipe(r.Context(), params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		withReferenceError(w, err)
 		return
 	}
 
@@ -146,7 +203,7 @@ func (h *CookbookHandler) UpdateRecipe(w http.ResponseWriter, r *http.Request) {
 
 	recipe, err := h.recipeUsecase.UpdateRecipe(r.Context(), id, params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		withReferenceError(w, err)
 		return
 	}
 
@@ -166,7 +223,7 @@ func (h *CookbookHandler) DeleteRecipe(w http.ResponseWriter, r *http.Request) {
 
 	err = h.recipeUsecase.DeleteRecipe(r.Context(), id)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		withReferenceError(w, err)
 		return
 	}
 
@@ -174,7 +231,7 @@ func (h *CookbookHandler) DeleteRecipe(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-// BulkCreateRecipeIngredients is a create recipe recipe handler
+// BulkCreateRecipeIngredients is a create recipe recipe handler, an ingredient may be given as a free-text line instead
 func (h *CookbookHandler) BulkCreateRecipeIngredients(w http.ResponseWriter, r *http.Request) {
 	var req BulkCreateRecipeIngredientsRequest
 
@@ -187,8 +244,14 @@ func (h *CookbookHandler) BulkCreateRecipeIngredients(w http.ResponseWriter, r *
 	var params usecase.BulkRecipeIngredientParams
 	for _, i := range req.Ingredients {
 		params = append(params, usecase.RecipeIngredientParams{
+			Line:               i.Line,
 			Amount:             i.Amount,
+			AmountMax:          i.AmountMax,
+			AmountText:         i.AmountText,
+			ToTaste:            i.ToTaste,
+			Optional:           i.Optional,
 			IngredientID:       i.IngredientID,
+			SubRecipeID:        i.SubRecipeID,
 			IngredientName:     i.IngredientName,
 			IngredientUnitName: i.IngredientUnitName,
 			OrderingIndex:      i.OrderingIndex,
@@ -199,7 +262,7 @@ func (h *CookbookHandler) BulkCreateRecipeIngredients(w http.ResponseWriter, r *
 
 	err = h.recipeUsecase.BulkCreateRecipeIngredients(r.Context(), req.RecipeID, params)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		withReferenceError(w, err)
 		return
 	}
 
@@ -263,23 +326,18 @@ func (h *CookbookHandler) ListRecipes(w http.ResponseWriter, r *http.Request) {
 	rawOfs := query.Get("offset")
 	rawLim := query.Get("limit")
 
-	categoryID := query.Get("category_id")
-	ingredientID := query.Get("ingredient_id")
-
 	ofs, _ := strconv.Atoi(rawOfs)
 	lim, _ := strconv.Atoi(rawLim)
 
-	catID, _ := strconv.ParseUint(categoryID, 10, 64)
-	ingID, _ := strconv.ParseUint(ingredientID, 10, 64)
-
-	filter := usecase.ListRecipesFiter{
-		CategoryID:   catID,
-		IngredientID: ingID,
+	filter, err := parseListRecipesFilter(r)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
 	}
 
 	recipeUnits, err := h.recipeUsecase.ListRecipes(r.Context(), filter, lim, ofs)
 	if err != nil {
-		libhttp.WithError(w, http.StatusInternalServerError, err)
+		libhttp.WithError(w, recipeListErrorStatus(err), err)
 		return
 	}
 
@@ -292,7 +350,25 @@ func (h *CookbookHandler) ListRecipes(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-// GetRecipeSummary is a get summary handler
+// ListRecipeFacets is a handler counting the recipes of the ListRecipes filter per tag and per category
+func (h *CookbookHandler) ListRecipeFacets(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListRecipesFilter(r)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	facets, err := h.recipeUsecase.ListRecipeFacets(r.Context(), filter)
+	if err != nil {
+		libhttp.WithError(w, recipeListErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeFacetsResponseFromEntity(facets))
+}
+
+// GetRecipeSummary is a get summary handler, a schema.org Recipe is sent instead when the client accepts
+// application/ld+json and a printable recipe card when it accepts text/markdown or text/html
 func (h *CookbookHandler) GetRecipeSummary(w http.ResponseWriter, r *http.Request) {
 	rawID := chi.URLParam(r, "id")
 
@@ -302,16 +378,112 @@ func (h *CookbookHandler) GetRecipeSummary(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	summary, err := h.recipeUsecase.GetRecipeSummary(r.Context(), id)
+	substitutes, err := parseSubstitutes(r.URL.Query()["substitute"])
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	opts := usecase.RecipeSummaryOptions{
+		ExpandSubRecipes: r.URL.Query().Get("expand") == "sub_recipes",
+		Substitutes:      substitutes,
+		Viewer:           r.URL.Query().Get("actor"),
+	}
+
+	summary, err := h.recipeUsecase.GetRecipeSummary(r.Context(), id, opts)
 	if err != nil {
 		libhttp.WithError(w, http.StatusInternalServerError, err)
 		return
 	}
 
+	if acceptsJSONLD(r) {
+		h.withRecipeJSONLD(w, r, summary)
+		return
+	}
+
+	if format := acceptedCardFormat(r); format != "" {
+		h.withRecipeCard(w, summary, format)
+		return
+	}
+
 	libhttp.WithJSON(w, http.StatusOK, getSummaryResponseFromEntity(summary))
 	return
 }
 
+// parseListRecipesFilter reads the ListRecipes filter from the query, tags and equipment are given as repeated
+// ?tag=ID and ?equipment_id=ID and ?include_subcategories=true widens ?category_id= to its descendant categories.
+// Statuses are given as repeated ?status= and ?actor= names the viewer, who sees their own drafts
+func parseListRecipesFilter(r *http.Request) (usecase.ListRecipesFiter, error) {
+	query := r.URL.Query()
+
+	catID, _ := strconv.ParseUint(query.Get("category_id"), 10, 64)
+	ingID, _ := strconv.ParseUint(query.Get("ingredient_id"), 10, 64)
+
+	includeSubcategories, _ := strconv.ParseBool(query.Get("include_subcategories"))
+
+	filter := usecase.ListRecipesFiter{
+		CategoryID:           catID,
+		IncludeSubcategories: includeSubcategories,
+		IngredientID:         ingID,
+		ExcludeAllergens:     query["exclude_allergen"],
+		Diets:                query["diet"],
+		Cuisine:              query.Get("cuisine"),
+		Sort:                 query.Get("sort"),
+		Statuses:             query["status"],
+		Viewer:               query.Get("actor"),
+	}
+
+	for _, rawTagID := range query["tag"] {
+		tagID, err := strconv.ParseUint(rawTagID, 10, 64)
+		if err != nil || tagID == 0 {
+			return filter, fmt.Errorf("invalid tag %q", rawTagID)
+		}
+
+		filter.TagIDs = append(filter.TagIDs, tagID)
+	}
+
+	for _, rawEquipmentID := range query["equipment_id"] {
+		equipmentID, err := strconv.ParseUint(rawEquipmentID, 10, 64)
+		if err != nil || equipmentID == 0 {
+			return filter, fmt.Errorf("invalid equipment_id %q", rawEquipmentID)
+		}
+
+		filter.EquipmentIDs = append(filter.EquipmentIDs, equipmentID)
+	}
+
+	if rawMaxTotalMinutes := query.Get("max_total_minutes"); rawMaxTotalMinutes != "" {
+		maxTotalMinutes, err := strconv.Atoi(rawMaxTotalMinutes)
+		if err != nil || maxTotalMinutes <= 0 {
+			return filter, fmt.Errorf("invalid max_total_minutes %q", rawMaxTotalMinutes)
+		}
+
+		filter.MaxTotalMinutes = maxTotalMinutes
+	}
+
+	if rawMinRating := query.Get("min_rating"); rawMinRating != "" {
+		minRating, err := strconv.ParseFloat(rawMinRating, 64)
+		if err != nil || minRating < entity.MinRating || minRating > entity.MaxRating {
+			return filter, fmt.Errorf("invalid min_rating %q", rawMinRating)
+		}
+
+		filter.MinRating = minRating
+	}
+
+	return filter, nil
+}
+
+// recipeListErrorStatus maps errors of the recipe list filter to a response status
+func recipeListErrorStatus(err error) int {
+	switch err {
+	case entity.ErrInvalidAllergen, entity.ErrInvalidDiet, entity.ErrInvalidRating, entity.ErrInvalidRecipeSort, entity.ErrInvalidRecipeStatus:
+		return http.StatusBadRequest
+	case entity.ErrRecipeStatusFilterForbidden:
+		return http.StatusForbidden
+	}
+
+	return http.StatusInternalServerError
+}
+
 // normalizeUpdateRecipeRequest converts input to usecase params
 func normalizeUpdateRecipeRequest(input RecipeRequest) usecase.RecipeParams {
 	params := usecase.RecipeParams{
@@ -322,13 +494,54 @@ func normalizeUpdateRecipeRequest(input RecipeRequest) usecase.RecipeParams {
 		params.Name = input.Name
 	}
 
+	if input.Description != "" {
+		params.Description = input.Description
+	}
+
+	if input.CategoryID != 0 {
+		params.CategoryID = input.CategoryID
+	}
+
+	if input.Servings > 0 {
+		params.Servings = input.Servings
+	}
+
+	if input.YieldAmount > 0 {
+		params.YieldAmount = input.YieldAmount
+	}
+
+	if input.YieldUnitName != "" {
+		params.YieldUnitName = input.YieldUnitName
+	}
+
+	if input.Cuisine != "" {
+		params.Cuisine = input.Cuisine
+	}
+
+	params.PrepMinutes = input.PrepMinutes
+	params.CookMinutes = input.CookMinutes
+	params.Difficulty = input.Difficulty
+	params.TagIDs = input.TagIDs
+	params.EquipmentIDs = input.EquipmentIDs
+
 	return params
 }
 
 // normalizeUpdateRecipeIngredientRequest converts input to usecase params
 func normalizeUpdateRecipeIngredientRequest(input RecipeIngredientRequest) usecase.RecipeIngredientParams {
 	params := usecase.RecipeIngredientParams{
-		Actor: input.Actor,
+		Amount:             input.Amount,
+		AmountMax:          input.AmountMax,
+		AmountText:         input.AmountText,
+		ToTaste:            input.ToTaste,
+		Optional:           input.Optional,
+		IngredientID:       input.IngredientID,
+		SubRecipeID:        input.SubRecipeID,
+		IngredientName:     input.IngredientName,
+		IngredientUnitName: input.IngredientUnitName,
+		OrderingIndex:      input.OrderingIndex,
+		Notes:              input.Notes,
+		Actor:              input.Actor,
 	}
 
 	return params
@@ -337,15 +550,29 @@ func normalizeUpdateRecipeIngredientRequest(input RecipeIngredientRequest) useca
 // recipeResponseFromEntity converts recipe entity to response
 func recipeResponseFromEntity(ent *entity.Recipe) RecipeResponse {
 	return RecipeResponse{
-		ID:          ent.ID,
-		Name:        ent.Name,
-		CategoryID:  ent.CategoryID,
-		Description: ent.Description,
-		CreatedAt:   ent.CreatedAt,
-		CreatedBy:   ent.CreatedBy,
-		UpdatedAt:   ent.UpdatedAt,
-		UpdatedBy:   ent.UpdatedBy,
-		IsDeleted:   ent.IsDeleted,
+		ID:            ent.ID,
+		Name:          ent.Name,
+		CategoryID:    ent.CategoryID,
+		Servings:      ent.Servings,
+		YieldAmount:   ent.YieldAmount,
+		YieldUnitName: ent.YieldUnitName,
+		Cuisine:       ent.Cuisine,
+		PrepMinutes:   ent.PrepMinutes,
+		CookMinutes:   ent.CookMinutes,
+		TotalMinutes:  ent.TotalMinutes,
+		Difficulty:    ent.Difficulty,
+		Status:        ent.Status,
+		VariantOfID:   ent.VariantOfID,
+		ForkedAt:      ent.ForkedAt,
+		CookCount:     ent.CookCount,
+		RatingCount:   ent.RatingCount,
+		AverageRating: ent.AverageRating,
+		Description:   ent.Description,
+		CreatedAt:     ent.CreatedAt,
+		CreatedBy:     ent.CreatedBy,
+		UpdatedAt:     ent.UpdatedAt,
+		UpdatedBy:     ent.UpdatedBy,
+		IsDeleted:     ent.IsDeleted,
 	}
 }
 
@@ -355,9 +582,15 @@ func recipeIngredientResponseFromEntity(ent *entity.RecipeIngredient) RecipeIngr
 		ID:                 ent.ID,
 		RecipeID:           ent.RecipeID,
 		IngredientID:       ent.IngredientID,
+		SubRecipeID:        ent.SubRecipeID,
 		IngredientName:     ent.IngredientName,
 		IngredientUnitName: ent.IngredientUnitName,
 		Amount:             ent.Amount,
+		AmountMax:          ent.AmountMax,
+		AmountDenominator:  ent.AmountDenominator,
+		AmountText:         ent.AmountText(),
+		ToTaste:            ent.IsToTaste(),
+		Optional:           ent.Optional,
 		OrderingIndex:      ent.OrderingIndex,
 		Notes:              ent.Notes,
 		CreatedAt:          ent.CreatedAt,
@@ -371,37 +604,55 @@ func recipeIngredientResponseFromEntity(ent *entity.RecipeIngredient) RecipeIngr
 // getSummaryResponseFromEntity converts summary entity to response
 func getSummaryResponseFromEntity(ent entity.RecipeSummary) GetSummaryResponse {
 	var ingredientResponses []RecipeIngredientResponse
+	var flattenedResponses []RecipeIngredientResponse
 
 	for _, ingredient := range ent.Ingredients {
-		ingredientResponses = append(ingredientResponses, RecipeIngredientResponse{
-			ID:                 ingredient.ID,
-			RecipeID:           ingredient.RecipeID,
-			IngredientID:       ingredient.IngredientID,
-			IngredientName:     ingredient.IngredientName,
-			IngredientUnitName: ingredient.IngredientUnitName,
-			Amount:             ingredient.Amount,
-			OrderingIndex:      ingredient.OrderingIndex,
-			Notes:              ingredient.Notes,
-			CreatedAt:          ingredient.CreatedAt,
-			CreatedBy:          ingredient.CreatedBy,
-			UpdatedAt:          ingredient.UpdatedAt,
-			UpdatedBy:          ingredient.UpdatedBy,
-			IsDeleted:          ingredient.IsDeleted,
-		})
+		ingredientResponses = append(ingredientResponses, recipeIngredientResponseFromEntity(ingredient))
+	}
+
+	for _, ingredient := range ent.FlattenedIngredients {
+		flattenedResponses = append(flattenedResponses, recipeIngredientResponseFromEntity(ingredient))
+	}
+
+	// labels are always arrays so that an empty list is not mistaken for unknown
+	allergens := []string{}
+	allergens = append(allergens, ent.DietaryLabels.Allergens...)
+
+	diets := []string{}
+	diets = append(diets, ent.DietaryLabels.Diets...)
+
+	// tags are always an array, like the labels
+	tagResponses := []TagResponse{}
+	for _, t := range ent.Tags {
+		tagResponses = append(tagResponses, tagResponseFromEntity(t))
+	}
+
+	// images are always an array, like the tags
+	imageResponses := []RecipeImageResponse{}
+	for _, img := range ent.Images {
+		imageResponses = append(imageResponses, recipeImageResponseFromEntity(img))
+	}
+
+	// equipment is always an array, like the tags
+	equipmentResponses := []EquipmentResponse{}
+	for _, e := range ent.Equipment {
+		equipmentResponses = append(equipmentResponses, equipmentResponseFromEntity(e))
+	}
+
+	var substitutionResponses []IngredientSubstitutionResponse
+	for _, s := range ent.Substitutions {
+		substitutionResponses = append(substitutionResponses, ingredientSubstitutionResponseFromEntity(s))
 	}
 
 	return GetSummaryResponse{
-		RecipeResponse: RecipeResponse{
-			ID:          ent.ID,
-			Name:        ent.Name,
-			Description: ent.Description,
-			CategoryID:  ent.CategoryID,
-			CreatedAt:   ent.CreatedAt,
-			CreatedBy:   ent.CreatedBy,
-			UpdatedAt:   ent.UpdatedAt,
-			UpdatedBy:   ent.UpdatedBy,
-			IsDeleted:   ent.IsDeleted,
-		},
-		Ingredients: ingredientResponses,
+		RecipeResponse:       recipeResponseFromEntity(&ent.Recipe),
+		Tags:                 tagResponses,
+		Images:               imageResponses,
+		Equipment:            equipmentResponses,
+		Ingredients:          ingredientResponses,
+		FlattenedIngredients: flattenedResponses,
+		Allergens:            allergens,
+		Diets:                diets,
+		Substitutions:        substitutionResponses,
 	}
 }
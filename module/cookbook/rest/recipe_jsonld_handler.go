@@ -0,0 +1,334 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+const (
+	// contentTypeJSONLD is the content type of schema.org documents
+	contentTypeJSONLD = "application/ld+json"
+
+	// maxImportBytes is the size limit of an imported document
+	maxImportBytes = 2 << 20
+)
+
+type RecipeJSONLD struct {
+	Context            string            `json:"@context"`
+	Type               string            `json:"@type"`
+	Name               string            `json:"name"`
+	Description        string            `json:"description,omitempty"`
+	Image              []string          `json:"image,omitempty"`
+	DatePublished      string            `json:"datePublished"`
+	RecipeCuisine      string            `json:"recipeCuisine,omitempty"`
+	Keywords           string            `json:"keywords,omitempty"`
+	RecipeYield        []string          `json:"recipeYield"`
+	PrepTime           string            `json:"prepTime,omitempty"`
+	CookTime           string            `json:"cookTime,omitempty"`
+	TotalTime          string            `json:"totalTime,omitempty"`
+	Tool               []string          `json:"tool,omitempty"`
+	RecipeIngredient   []string          `json:"recipeIngredient"`
+	RecipeInstructions []HowToStepJSONLD `json:"recipeInstructions"`
+	Nutrition          *NutritionJSONLD  `json:"nutrition,omitempty"`
+}
+
+type HowToStepJSONLD struct {
+	Type     string   `json:"@type"`
+	Position int      `json:"position"`
+	Text     string   `json:"text"`
+	Image    []string `json:"image,omitempty"`
+}
+
+type NutritionJSONLD struct {
+	Type                string `json:"@type"`
+	ServingSize         string `json:"servingSize"`
+	Calories            string `json:"calories"`
+	ProteinContent      string `json:"proteinContent"`
+	FatContent          string `json:"fatContent"`
+	CarbohydrateContent string `json:"carbohydrateContent"`
+	FiberContent        string `json:"fiberContent"`
+	SodiumContent       string `json:"sodiumContent"`
+}
+
+type ImportRecipeResponse struct {
+	Recipe      RecipeResponse              `json:"recipe"`
+	Ingredients int                         `json:"ingredients"`
+	Unmatched   []RecipeImportIssueResponse `json:"unmatched"`
+}
+
+type RecipeImportIssueResponse struct {
+	Line   string `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// ImportRecipe is an import recipe handler. The body is a schema.org Recipe as JSON-LD or an HTML page containing it,
+// either sent as is or as the "file" field of a multipart form, and the category and the actor are given as
+// ?category_id= and ?actor=
+func (h *CookbookHandler) ImportRecipe(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	categoryID, err := strconv.ParseUint(query.Get("category_id"), 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("category_id cannot be empty"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	document, err := readImportDocument(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			libhttp.WithError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("document is too large"))
+			return
+		}
+
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.ImportRecipeParams{
+		Document:   document,
+		CategoryID: categoryID,
+		Actor:      query.Get("actor"),
+	}
+
+	res, err := h.recipeUsecase.ImportRecipe(r.Context(), params)
+	if err != nil {
+		if err == entity.ErrInvalidRecipeDocument {
+			libhttp.WithError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		withReferenceError(w, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, importRecipeResponseFromEntity(res))
+}
+
+// readImportDocument reads the imported document from the body or from the "file" field of a multipart form
+func readImportDocument(r *http.Request) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+
+	err := r.ParseMultipartForm(maxImportBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+// withRecipeJSONLD sends a recipe summary as a schema.org Recipe, along with its nutrition per serving
+// when the nutrition of every ingredient is known
+func (h *CookbookHandler) withRecipeJSONLD(w http.ResponseWriter, r *http.Request, summary entity.RecipeSummary) {
+	if summary.ID == 0 {
+		libhttp.WithError(w, http.StatusNotFound, entity.ErrRecipeNotFound)
+		return
+	}
+
+	nutrition, err := h.nutritionUsecase.GetRecipeNutrition(r.Context(), summary.ID, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	body, err := json.Marshal(recipeJSONLDFromEntity(summary, nutrition, requestBaseURL(r)))
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithBody(w, http.StatusOK, contentTypeJSONLD, body)
+}
+
+// acceptsJSONLD returns true when the client asks for a schema.org document
+func acceptsJSONLD(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), contentTypeJSONLD)
+}
+
+// requestBaseURL returns the scheme and host the client used, so that image URLs can be absolute
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + r.Host
+}
+
+// recipeJSONLDFromEntity converts a recipe summary to a schema.org Recipe. Recipes have no separate steps,
+// so every line of the description is a step, numbered like the step images
+func recipeJSONLDFromEntity(ent entity.RecipeSummary, nutrition entity.RecipeNutrition, baseURL string) RecipeJSONLD {
+	doc := RecipeJSONLD{
+		Context:            "https://schema.org",
+		Type:               "Recipe",
+		Name:               ent.Name,
+		Description:        ent.Description,
+		DatePublished:      ent.CreatedAt.Format("2006-01-02"),
+		RecipeCuisine:      ent.Cuisine.String,
+		RecipeYield:        []string{strconv.Itoa(ent.Servings)},
+		PrepTime:           isoDuration(ent.PrepMinutes.Int64, ent.PrepMinutes.Valid),
+		CookTime:           isoDuration(ent.CookMinutes.Int64, ent.CookMinutes.Valid),
+		TotalTime:          isoDuration(ent.TotalMinutes.Int64, ent.TotalMinutes.Valid),
+		RecipeIngredient:   []string{},
+		RecipeInstructions: []HowToStepJSONLD{},
+	}
+
+	if ent.YieldAmount.Valid {
+		doc.RecipeYield = append(doc.RecipeYield, strings.TrimSpace(formatAmount(ent.YieldAmount.Float64)+" "+ent.YieldUnitName.String))
+	}
+
+	var keywords []string
+	for _, t := range ent.Tags {
+		keywords = append(keywords, t.Name)
+	}
+	doc.Keywords = strings.Join(keywords, ", ")
+
+	for _, e := range ent.Equipment {
+		doc.Tool = append(doc.Tool, e.Name)
+	}
+
+	for _, ingredient := range ent.Ingredients {
+		doc.RecipeIngredient = append(doc.RecipeIngredient, recipeIngredientLine(ingredient))
+	}
+
+	stepImages := map[int64][]string{}
+	for _, img := range ent.Images {
+		url := baseURL + mediaURLPrefix + img.FileKey
+		if !img.Step.Valid {
+			doc.Image = append(doc.Image, url)
+			continue
+		}
+
+		stepImages[img.Step.Int64] = append(stepImages[img.Step.Int64], url)
+	}
+
+	for i, step := range ent.Steps() {
+		doc.RecipeInstructions = append(doc.RecipeInstructions, HowToStepJSONLD{
+			Type:     "HowToStep",
+			Position: i + 1,
+			Text:     step,
+			Image:    stepImages[int64(i+1)],
+		})
+	}
+
+	if nutrition.IsComplete && len(nutrition.Ingredients) > 0 {
+		perServing := nutrition.PerServing
+		doc.Nutrition = &NutritionJSONLD{
+			Type:                "NutritionInformation",
+			ServingSize:         "1/" + strconv.Itoa(nutrition.Servings) + " recipe",
+			Calories:            fmt.Sprintf("%.0f kcal", perServing.Kcal),
+			ProteinContent:      fmt.Sprintf("%.1f g", perServing.Protein),
+			FatContent:          fmt.Sprintf("%.1f g", perServing.Fat),
+			CarbohydrateContent: fmt.Sprintf("%.1f g", perServing.Carbs),
+			FiberContent:        fmt.Sprintf("%.1f g", perServing.Fiber),
+			SodiumContent:       fmt.Sprintf("%.0f mg", perServing.Sodium),
+		}
+	}
+
+	return doc
+}
+
+// recipeIngredientLine writes a recipe ingredient as a line like "2-3 siung Bawang putih, cincang halus"
+// or "Garam, secukupnya", which is also how imported lines are read
+func recipeIngredientLine(ent *entity.RecipeIngredient) string {
+	var buf bytes.Buffer
+
+	if ent.Amount.Float64 > 0 {
+		buf.WriteString(ent.AmountText())
+		buf.WriteString(" ")
+
+		if ent.IngredientUnitName != "" {
+			buf.WriteString(ent.IngredientUnitName)
+			buf.WriteString(" ")
+		}
+	}
+
+	buf.WriteString(ent.IngredientName)
+
+	if ent.IsToTaste() {
+		buf.WriteString(", secukupnya")
+	}
+
+	if ent.Notes != "" {
+		buf.WriteString(", ")
+		buf.WriteString(ent.Notes)
+	}
+
+	if ent.Optional {
+		buf.WriteString(" (opsional)")
+	}
+
+	return buf.String()
+}
+
+// formatAmount writes an amount with at most two decimals and without trailing zeros
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', -1, 64)
+}
+
+// isoDuration writes minutes as an ISO 8601 duration, e.g. PT1H30M
+func isoDuration(minutes int64, valid bool) string {
+	if !valid {
+		return ""
+	}
+
+	hours, minutes := minutes/60, minutes%60
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("PT%dM", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("PT%dH", hours)
+	}
+
+	return fmt.Sprintf("PT%dH%dM", hours, minutes)
+}
+
+func importRecipeResponseFromEntity(ent entity.RecipeImport) ImportRecipeResponse {
+	resp := ImportRecipeResponse{
+		Recipe:      recipeResponseFromEntity(ent.Recipe),
+		Ingredients: ent.Ingredients,
+		Unmatched:   []RecipeImportIssueResponse{},
+	}
+
+	for _, issue := range ent.Unmatched {
+		resp.Unmatched = append(resp.Unmatched, RecipeImportIssueResponse{
+			Line:   issue.Line,
+			Reason: issue.Reason,
+		})
+	}
+
+	return resp
+}
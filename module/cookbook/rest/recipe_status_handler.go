@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type RecipeTransitionRequest struct {
+	Action  string `json:"action"`
+	Comment string `json:"comment"`
+	Actor   string `json:"actor"`
+}
+
+type RecipeStatusChangeResponse struct {
+	ID         uint64      `json:"id"`
+	RecipeID   uint64      `json:"recipe_id"`
+	Action     string      `json:"action"`
+	FromStatus string      `json:"from_status"`
+	ToStatus   string      `json:"to_status"`
+	Comment    null.String `json:"comment"`
+	CreatedAt  time.Time   `json:"created_at"`
+	CreatedBy  string      `json:"created_by"`
+}
+
+type RecipeStatusChangeResponses struct {
+	Data []RecipeStatusChangeResponse `json:"status_changes"`
+}
+
+// TransitionRecipe is a recipe workflow handler, the action submits, approves, rejects, publishes or archives the recipe
+func (h *CookbookHandler) TransitionRecipe(w http.ResponseWriter, r *http.Request) {
+	var req RecipeTransitionRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.RecipeTransitionParams{
+		Action:  req.Action,
+		Comment: req.Comment,
+		Actor:   req.Actor,
+	}
+
+	recipe, err := h.recipeUsecase.TransitionRecipe(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, recipeTransitionErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeResponseFromEntity(recipe))
+}
+
+// ListRecipeStatusChanges is a recipe workflow history handler, ?actor= names the viewer of a draft
+func (h *CookbookHandler) ListRecipeStatusChanges(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	changes, err := h.recipeUsecase.ListRecipeStatusChanges(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, recipeTransitionErrorStatus(err), err)
+		return
+	}
+
+	resp := RecipeStatusChangeResponses{Data: []RecipeStatusChangeResponse{}}
+	for _, change := range changes {
+		resp.Data = append(resp.Data, RecipeStatusChangeResponse{
+			ID:         change.ID,
+			RecipeID:   change.RecipeID,
+			Action:     change.Action,
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Comment:    change.Comment,
+			CreatedAt:  change.CreatedAt,
+			CreatedBy:  change.CreatedBy,
+		})
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// recipeTransitionErrorStatus maps errors of the recipe workflow to a response status
+func recipeTransitionErrorStatus(err error) int {
+	switch err {
+	case entity.ErrInvalidRecipeAction, entity.ErrReviewCommentRequired:
+		return http.StatusBadRequest
+	case entity.ErrRecipeTransitionForbidden:
+		return http.StatusForbidden
+	case entity.ErrRecipeNotFound:
+		return http.StatusNotFound
+	case entity.ErrInvalidRecipeTransition:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
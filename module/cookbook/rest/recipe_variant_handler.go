@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type CloneRecipeRequest struct {
+	Name    string `json:"name"`
+	Variant bool   `json:"variant"`
+	Actor   string `json:"actor"`
+}
+
+type RecipeFamilyResponse struct {
+	RecipeResponse
+	Variants []RecipeFamilyResponse `json:"variants"`
+}
+
+type RecipeFieldChangeResponse struct {
+	Field  string      `json:"field"`
+	Before null.String `json:"before"`
+	After  null.String `json:"after"`
+}
+
+type RecipeStepChangeResponse struct {
+	Step   int         `json:"step"`
+	Before null.String `json:"before"`
+	After  null.String `json:"after"`
+}
+
+type RecipeIngredientChangeResponse struct {
+	Change string                    `json:"change"`
+	Before *RecipeIngredientResponse `json:"before"`
+	After  *RecipeIngredientResponse `json:"after"`
+}
+
+type RecipeParentChangesResponse struct {
+	RecipeID    uint64                           `json:"recipe_id"`
+	ParentID    uint64                           `json:"parent_id"`
+	ForkedAt    time.Time                        `json:"forked_at"`
+	HasChanges  bool                             `json:"has_changes"`
+	Fields      []RecipeFieldChangeResponse      `json:"fields"`
+	Steps       []RecipeStepChangeResponse       `json:"steps"`
+	Ingredients []RecipeIngredientChangeResponse `json:"ingredients"`
+}
+
+// CloneRecipe is a clone recipe handler, it deep-copies a recipe into a new draft, linked to it when variant is set.
+// The recipe and step images are not copied
+func (h *CookbookHandler) CloneRecipe(w http.ResponseWriter, r *http.Request) {
+	var req CloneRecipeRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.CloneRecipeParams{
+		Name:    req.Name,
+		Variant: req.Variant,
+		Actor:   req.Actor,
+	}
+
+	recipe, err := h.recipeUsecase.CloneRecipe(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, recipeVariantErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeResponseFromEntity(recipe))
+}
+
+// GetRecipeFamily is a recipe family tree handler, ?actor= names the viewer of the drafts
+func (h *CookbookHandler) GetRecipeFamily(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	family, err := h.recipeUsecase.GetRecipeFamily(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, recipeVariantErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeFamilyResponseFromEntity(family))
+}
+
+// GetRecipeParentChanges is a handler listing what changed in the parent of a variant since it was forked
+func (h *CookbookHandler) GetRecipeParentChanges(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	changes, err := h.recipeUsecase.GetRecipeParentChanges(r.Context(), id, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, recipeVariantErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, recipeParentChangesResponseFromEntity(changes))
+}
+
+// recipeVariantErrorStatus maps errors of recipe clones and variants to a response status
+func recipeVariantErrorStatus(err error) int {
+	switch err {
+	case entity.ErrRecipeNotFound:
+		return http.StatusNotFound
+	case entity.ErrRecipeNotVariant:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// recipeFamilyResponseFromEntity converts recipe family entity to response, variants are always an array
+func recipeFamilyResponseFromEntity(ent *entity.RecipeFamily) RecipeFamilyResponse {
+	resp := RecipeFamilyResponse{
+		RecipeResponse: recipeResponseFromEntity(ent.Recipe),
+		Variants:       []RecipeFamilyResponse{},
+	}
+
+	for _, variant := range ent.Variants {
+		resp.Variants = append(resp.Variants, recipeFamilyResponseFromEntity(variant))
+	}
+
+	return resp
+}
+
+// recipeParentChangesResponseFromEntity converts recipe parent changes entity to response
+func recipeParentChangesResponseFromEntity(ent entity.RecipeParentChanges) RecipeParentChangesResponse {
+	resp := RecipeParentChangesResponse{
+		RecipeID:    ent.RecipeID,
+		ParentID:    ent.ParentID,
+		ForkedAt:    ent.ForkedAt,
+		HasChanges:  ent.HasChanges(),
+		Fields:      []RecipeFieldChangeResponse{},
+		Steps:       []RecipeStepChangeResponse{},
+		Ingredients: []RecipeIngredientChangeResponse{},
+	}
+
+	for _, field := range ent.Fields {
+		resp.Fields = append(resp.Fields, RecipeFieldChangeResponse{Field: field.Field, Before: field.Before, After: field.After})
+	}
+
+	for _, step := range ent.Steps {
+		resp.Steps = append(resp.Steps, RecipeStepChangeResponse{Step: step.Step, Before: step.Before, After: step.After})
+	}
+
+	for _, ingredient := range ent.Ingredients {
+		change := RecipeIngredientChangeResponse{Change: ingredient.Change}
+
+		if ingredient.Before != nil {
+			before := recipeIngredientResponseFromEntity(ingredient.Before)
+			change.Before = &before
+		}
+
+		if ingredient.After != nil {
+			after := recipeIngredientResponseFromEntity(ingredient.After)
+			change.After = &after
+		}
+
+		resp.Ingredients = append(resp.Ingredients, change)
+	}
+
+	return resp
+}
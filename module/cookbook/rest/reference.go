@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type RecipeReferenceResponse struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+type ReferenceConflictResponse struct {
+	Recipes []RecipeReferenceResponse `json:"recipes"`
+}
+
+// parseDeleteParams reads the reassign_to and actor query params of master data delete handlers
+func parseDeleteParams(r *http.Request) (usecase.DeleteParams, error) {
+	query := r.URL.Query()
+
+	params := usecase.DeleteParams{
+		Actor: query.Get("actor"),
+	}
+
+	rawReassignTo := query.Get("reassign_to")
+	if rawReassignTo == "" {
+		return params, nil
+	}
+
+	reassignTo, err := strconv.ParseUint(rawReassignTo, 10, 64)
+	if err != nil || reassignTo == 0 {
+		return params, fmt.Errorf("invalid reassign_to")
+	}
+
+	params.ReassignTo = reassignTo
+
+	return params, nil
+}
+
+// referenceErrorStatus maps errors about references between recipes and master data to a response status
+func referenceErrorStatus(err error) int {
+	var conflict *entity.ReferenceConflictError
+	if errors.As(err, &conflict) {
+		return http.StatusConflict
+	}
+
+	switch err {
+	case entity.ErrCategoryHasChildren:
+		return http.StatusConflict
+	case entity.ErrInvalidReassign, entity.ErrCategoryNotFound, entity.ErrIngredientNotFound, entity.ErrRecipeNotFound, entity.ErrTagNotFound,
+		entity.ErrEquipmentNotFound, entity.ErrInvalidDifficulty, entity.ErrInvalidDuration, entity.ErrIngredientLineNotMatched, entity.ErrInvalidAmount:
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// withReferenceError sends a reference error, a conflict lists the referencing recipes
+func withReferenceError(w http.ResponseWriter, err error) {
+	var conflict *entity.ReferenceConflictError
+	if !errors.As(err, &conflict) {
+		libhttp.WithError(w, referenceErrorStatus(err), err)
+		return
+	}
+
+	resp := ReferenceConflictResponse{
+		Recipes: []RecipeReferenceResponse{},
+	}
+
+	for _, recipe := range conflict.Recipes {
+		resp.Recipes = append(resp.Recipes, RecipeReferenceResponse{
+			ID:   recipe.RecipeID,
+			Name: recipe.RecipeName,
+		})
+	}
+
+	libhttp.WithErrorData(w, http.StatusConflict, err, resp)
+}
@@ -0,0 +1,287 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type IngredientSubstitutionRequest struct {
+	SubstituteIngredientID uint64  `json:"substitute_ingredient_id"`
+	Ratio                  float64 `json:"ratio"`
+	IngredientUnitName     string  `json:"ingredient_unit_name"`
+	Notes                  string  `json:"notes"`
+	Actor                  string  `json:"actor"`
+}
+
+type IngredientSubstitutionResponse struct {
+	ID                       uint64      `json:"id"`
+	IngredientID             uint64      `json:"ingredient_id"`
+	IngredientName           string      `json:"ingredient_name,omitempty"`
+	SubstituteIngredientID   uint64      `json:"substitute_ingredient_id"`
+	SubstituteIngredientName string      `json:"substitute_ingredient_name,omitempty"`
+	Ratio                    float64     `json:"ratio"`
+	IngredientUnitName       null.String `json:"ingredient_unit_name"`
+	Notes                    null.String `json:"notes"`
+	CreatedAt                time.Time   `json:"created_at"`
+	CreatedBy                string      `json:"created_by"`
+	UpdatedAt                null.Time   `json:"updated_at"`
+	UpdatedBy                null.String `json:"updated_by"`
+	IsDeleted                bool        `json:"is_deleted"`
+}
+
+type IngredientSubstitutionResponses struct {
+	Data []IngredientSubstitutionResponse `json:"ingredient_substitutions"`
+}
+
+type SubstitutionSuggestionRequest struct {
+	PantryIngredientIDs []uint64 `json:"pantry_ingredient_ids"`
+	Actor               string   `json:"actor"`
+}
+
+type SubstituteOptionResponse struct {
+	IngredientSubstitutionResponse
+	Amount      null.Float `json:"amount"`
+	IsAvailable bool       `json:"is_available"`
+}
+
+type SubstitutionSuggestionResponse struct {
+	IngredientID       uint64                     `json:"ingredient_id"`
+	IngredientName     string                     `json:"ingredient_name"`
+	IngredientUnitName string                     `json:"ingredient_unit_name"`
+	Amount             null.Float                 `json:"amount"`
+	Substitutes        []SubstituteOptionResponse `json:"substitutes"`
+}
+
+type SubstitutionSuggestionResponses struct {
+	Data []SubstitutionSuggestionResponse `json:"missing_ingredients"`
+}
+
+// CreateIngredientSubstitution is a create ingredient substitution handler
+func (h *CookbookHandler) CreateIngredientSubstitution(w http.ResponseWriter, r *http.Request) {
+	var req IngredientSubstitutionRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.SubstituteIngredientID == 0 || req.SubstituteIngredientID == id || req.Ratio < 0 {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("a different substitute_ingredient_id and a non-negative ratio are required"))
+		return
+	}
+
+	params := usecase.IngredientSubstitutionParams{
+		IngredientID:           id,
+		SubstituteIngredientID: req.SubstituteIngredientID,
+		Ratio:                  req.Ratio,
+		IngredientUnitName:     req.IngredientUnitName,
+		Notes:                  req.Notes,
+		Actor:                  req.Actor,
+	}
+
+	substitution, err := h.substitutionUsecase.CreateIngredientSubstitution(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientSubstitutionResponseFromEntity(substitution))
+}
+
+// UpdateIngredientSubstitution is an update ingredient substitution handler
+func (h *CookbookHandler) UpdateIngredientSubstitution(w http.ResponseWriter, r *http.Request) {
+	var req IngredientSubstitutionRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.IngredientSubstitutionParams{
+		SubstituteIngredientID: req.SubstituteIngredientID,
+		Ratio:                  req.Ratio,
+		IngredientUnitName:     req.IngredientUnitName,
+		Notes:                  req.Notes,
+		Actor:                  req.Actor,
+	}
+
+	substitution, err := h.substitutionUsecase.UpdateIngredientSubstitution(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, ingredientSubstitutionResponseFromEntity(substitution))
+}
+
+// DeleteIngredientSubstitution is a delete ingredient substitution handler
+func (h *CookbookHandler) DeleteIngredientSubstitution(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.substitutionUsecase.DeleteIngredientSubstitution(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted ingredient substitution")
+}
+
+// ListIngredientSubstitutions is a list ingredient substitution handler
+func (h *CookbookHandler) ListIngredientSubstitutions(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	substitutions, err := h.substitutionUsecase.ListIngredientSubstitutions(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp IngredientSubstitutionResponses
+	for _, s := range substitutions {
+		resp.Data = append(resp.Data, ingredientSubstitutionResponseFromEntity(s))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// SuggestSubstitutions is a suggest substitutions for missing recipe ingredients handler
+func (h *CookbookHandler) SuggestSubstitutions(w http.ResponseWriter, r *http.Request) {
+	var req SubstitutionSuggestionRequest
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	suggestions, err := h.substitutionUsecase.SuggestSubstitutions(r.Context(), id, req.PantryIngredientIDs, req.Actor)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp SubstitutionSuggestionResponses
+	for _, s := range suggestions {
+		suggestion := SubstitutionSuggestionResponse{
+			IngredientID:       s.IngredientID,
+			IngredientName:     s.IngredientName,
+			IngredientUnitName: s.IngredientUnitName,
+			Amount:             s.Amount,
+			Substitutes:        []SubstituteOptionResponse{},
+		}
+
+		for _, o := range s.Substitutes {
+			option := SubstituteOptionResponse{
+				IngredientSubstitutionResponse: ingredientSubstitutionResponseFromEntity(o.Substitution),
+				Amount:                         o.Amount,
+				IsAvailable:                    o.IsAvailable,
+			}
+			option.IngredientUnitName = null.StringFrom(o.IngredientUnitName)
+
+			suggestion.Substitutes = append(suggestion.Substitutes, option)
+		}
+
+		resp.Data = append(resp.Data, suggestion)
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// parseSubstitutes parses substitute query values formatted as ingredientID:replacementID
+func parseSubstitutes(values []string) (map[uint64]uint64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	res := map[uint64]uint64{}
+
+	for _, value := range values {
+		rawIngredientID, rawSubstituteID, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("substitute must be formatted as ingredientID:replacementID")
+		}
+
+		ingredientID, err := strconv.ParseUint(rawIngredientID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("substitute must be formatted as ingredientID:replacementID")
+		}
+
+		substituteID, err := strconv.ParseUint(rawSubstituteID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("substitute must be formatted as ingredientID:replacementID")
+		}
+
+		res[ingredientID] = substituteID
+	}
+
+	return res, nil
+}
+
+// ingredientSubstitutionResponseFromEntity converts ingredient substitution entity to response
+func ingredientSubstitutionResponseFromEntity(ent *entity.IngredientSubstitution) IngredientSubstitutionResponse {
+	return IngredientSubstitutionResponse{
+		ID:                       ent.ID,
+		IngredientID:             ent.IngredientID,
+		IngredientName:           ent.IngredientName,
+		SubstituteIngredientID:   ent.SubstituteIngredientID,
+		SubstituteIngredientName: ent.SubstituteIngredientName,
+		Ratio:                    ent.Ratio,
+		IngredientUnitName:       ent.IngredientUnitName,
+		Notes:                    ent.Notes,
+		CreatedAt:                ent.CreatedAt,
+		CreatedBy:                ent.CreatedBy,
+		UpdatedAt:                ent.UpdatedAt,
+		UpdatedBy:                ent.UpdatedBy,
+		IsDeleted:                ent.IsDeleted,
+	}
+}
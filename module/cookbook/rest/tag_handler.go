@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/internal/usecase"
+)
+
+type TagRequest struct {
+	Name  string `json:"name"`
+	Actor string `json:"actor"`
+}
+
+type TagResponse struct {
+	ID        uint64      `json:"id"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+	CreatedBy string      `json:"created_by"`
+	UpdatedAt null.Time   `json:"updated_at"`
+	UpdatedBy null.String `json:"updated_by"`
+	IsDeleted bool        `json:"is_deleted"`
+}
+
+type TagsResponse struct {
+	TagResponse []TagResponse `json:"tags"`
+}
+
+type FacetCountResponse struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type RecipeFacetsResponse struct {
+	Tags       []FacetCountResponse `json:"tags"`
+	Categories []FacetCountResponse `json:"categories"`
+}
+
+// CreateTag is a create tag handler
+func (h *CookbookHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var req TagRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.Name == "" {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("name cannot be empty"))
+		return
+	}
+
+	params := usecase.TagParams{
+		Name:  req.Name,
+		Actor: req.Actor,
+	}
+
+	tag, err := h.tagUsecase.CreateTag(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, tagErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, tagResponseFromEntity(tag))
+}
+
+// UpdateTag is a update tag handler
+func (h *CookbookHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	var req TagRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.TagParams{
+		Name:  req.Name,
+		Actor: req.Actor,
+	}
+
+	tag, err := h.tagUsecase.UpdateTag(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, tagErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, tagResponseFromEntity(tag))
+}
+
+// DeleteTag is a delete tag handler, the tag is removed from every recipe
+func (h *CookbookHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.tagUsecase.DeleteTag(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, tagErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted tag")
+}
+
+// ListTags is a list tag handler
+func (h *CookbookHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	tags, err := h.tagUsecase.ListTags(r.Context(), lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var resp TagsResponse
+	for _, t := range tags {
+		resp.TagResponse = append(resp.TagResponse, tagResponseFromEntity(t))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// tagErrorStatus maps tag errors to a response status
+func tagErrorStatus(err error) int {
+	switch err {
+	case entity.ErrTagNotFound:
+		return http.StatusNotFound
+	case entity.ErrTagNameConflict:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// tagResponseFromEntity converts tag entity to response
+func tagResponseFromEntity(ent *entity.Tag) TagResponse {
+	return TagResponse{
+		ID:        ent.ID,
+		Name:      ent.Name,
+		CreatedAt: ent.CreatedAt,
+		CreatedBy: ent.CreatedBy,
+		UpdatedAt: ent.UpdatedAt,
+		UpdatedBy: ent.UpdatedBy,
+		IsDeleted: ent.IsDeleted,
+	}
+}
+
+// recipeFacetsResponseFromEntity converts recipe facets entity to response, facets are always arrays
+func recipeFacetsResponseFromEntity(ent entity.RecipeFacets) RecipeFacetsResponse {
+	resp := RecipeFacetsResponse{
+		Tags:       []FacetCountResponse{},
+		Categories: []FacetCountResponse{},
+	}
+
+	for _, f := range ent.Tags {
+		resp.Tags = append(resp.Tags, FacetCountResponse{ID: f.ID, Name: f.Name, Count: f.Count})
+	}
+
+	for _, f := range ent.Categories {
+		resp.Categories = append(resp.Categories, FacetCountResponse{ID: f.ID, Name: f.Name, Count: f.Count})
+	}
+
+	return resp
+}
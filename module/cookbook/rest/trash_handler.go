@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+type RestoreRequest struct {
+	Actor string `json:"actor"`
+}
+
+type TrashItemResponse struct {
+	Type      string      `json:"type"`
+	ID        uint64      `json:"id"`
+	Name      string      `json:"name"`
+	ParentID  uint64      `json:"parent_id,omitempty"`
+	DeletedAt null.Time   `json:"deleted_at"`
+	DeletedBy null.String `json:"deleted_by"`
+}
+
+type TrashItemsResponse struct {
+	Data []TrashItemResponse `json:"items"`
+}
+
+// ListTrash is a list soft-deleted records handler, the record type is given with ?type=
+func (h *CookbookHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	ofs, _ := strconv.Atoi(query.Get("offset"))
+	lim, _ := strconv.Atoi(query.Get("limit"))
+
+	items, err := h.trashUsecase.ListTrash(r.Context(), query.Get("type"), lim, ofs)
+	if err != nil {
+		libhttp.WithError(w, trashErrorStatus(err), err)
+		return
+	}
+
+	resp := TrashItemsResponse{
+		Data: []TrashItemResponse{},
+	}
+
+	for _, item := range items {
+		resp.Data = append(resp.Data, trashItemResponseFromEntity(item))
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, resp)
+}
+
+// RestoreRecipe is a restore deleted recipe handler
+func (h *CookbookHandler) RestoreRecipe(w http.ResponseWriter, r *http.Request) {
+	h.restoreTrashItem(w, r, entity.TrashTypeRecipe)
+}
+
+// RestoreRecipeIngredient is a restore deleted recipe ingredient handler
+func (h *CookbookHandler) RestoreRecipeIngredient(w http.ResponseWriter, r *http.Request) {
+	h.restoreTrashItem(w, r, entity.TrashTypeRecipeIngredient)
+}
+
+// RestoreCategory is a restore deleted category handler
+func (h *CookbookHandler) RestoreCategory(w http.ResponseWriter, r *http.Request) {
+	h.restoreTrashItem(w, r, entity.TrashTypeCategory)
+}
+
+// RestoreIngredient is a restore deleted ingredient handler
+func (h *CookbookHandler) RestoreIngredient(w http.ResponseWriter, r *http.Request) {
+	h.restoreTrashItem(w, r, entity.TrashTypeIngredient)
+}
+
+// RestoreIngredientUnit is a restore deleted ingredient unit handler
+func (h *CookbookHandler) RestoreIngredientUnit(w http.ResponseWriter, r *http.Request) {
+	h.restoreTrashItem(w, r, entity.TrashTypeIngredientUnit)
+}
+
+// restoreTrashItem restores a soft-deleted record of a type, the request body is optional
+func (h *CookbookHandler) restoreTrashItem(w http.ResponseWriter, r *http.Request, itemType string) {
+	var req RestoreRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && err != io.EOF {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	err = h.trashUsecase.RestoreTrashItem(r.Context(), itemType, id, req.Actor)
+	if err != nil {
+		libhttp.WithError(w, trashErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully restored "+itemType)
+}
+
+// trashErrorStatus maps trash errors to a response status
+func trashErrorStatus(err error) int {
+	switch err {
+	case entity.ErrInvalidTrashType:
+		return http.StatusBadRequest
+	case entity.ErrTrashItemNotFound:
+		return http.StatusNotFound
+	case entity.ErrTrashParentDeleted, entity.ErrTrashSubRecipeDeleted, entity.ErrIngredientNameConflict:
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+func trashItemResponseFromEntity(ent *entity.TrashItem) TrashItemResponse {
+	return TrashItemResponse{
+		Type:      ent.Type,
+		ID:        ent.ID,
+		Name:      ent.Name,
+		ParentID:  ent.ParentID,
+		DeletedAt: ent.DeletedAt,
+		DeletedBy: ent.DeletedBy,
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/jmoiron/sqlx"
+	mealPlanPostgresRepo "github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/repository/postgres"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/usecase"
+	mealPlanRest "github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/rest"
+)
+
+// RegisterMealPlanHandler wires the meal plan module, recipes are read from the cookbook module through recipeReader
+func RegisterMealPlanHandler(db *sqlx.DB, recipeReader usecase.RecipeReader) *mealPlanRest.MealPlanHandler {
+	mealPlanRepo := mealPlanPostgresRepo.NewMealPlanPostgresRepository(db)
+
+	mealPlanUc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	return mealPlanRest.NewMealPlanHandler(mealPlanUc)
+}
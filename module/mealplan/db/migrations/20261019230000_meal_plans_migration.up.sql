@@ -0,0 +1,21 @@
+BEGIN;
+
+-- recipe_id has no foreign key, recipes belong to the cookbook module and a purged recipe leaves its entries behind,
+-- they are flagged as missing
+CREATE TABLE IF NOT EXISTS meal_plan_entries (
+    id          serial          PRIMARY KEY,
+    plan_date   date            NOT NULL,
+    slot        varchar(16)     NOT NULL CHECK (slot IN ('breakfast', 'lunch', 'dinner')),
+    recipe_id   int             NOT NULL,
+    servings    int             NOT NULL CHECK (servings > 0),
+    notes       text            NULL,
+    created_at  timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by  varchar(64)     NOT NULL,
+    updated_at  timestamp       NULL,
+    updated_by  varchar(64)     NULL
+);
+
+CREATE INDEX idx_meal_plan_entries_plan_date ON meal_plan_entries(plan_date);
+CREATE INDEX idx_meal_plan_entries_recipe_id ON meal_plan_entries(recipe_id);
+
+COMMIT;
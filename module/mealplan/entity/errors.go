@@ -0,0 +1,11 @@
+package entity
+
+import "github.com/tlab-backend-test-naufal/cookbook-management/internal/liberr"
+
+var (
+	ErrMealPlanEntryNotFound = liberr.NewErrorDetails("MEALPLAN_COOKBOOK-MANAGEMENT_MEAL-PLAN-ENTRY-NOT-FOUND", "Meal plan entry is not found")
+	ErrRecipeNotFound        = liberr.NewErrorDetails("MEALPLAN_COOKBOOK-MANAGEMENT_RECIPE-NOT-FOUND", "Recipe is not found")
+	ErrInvalidMealSlot       = liberr.NewErrorDetails("MEALPLAN_COOKBOOK-MANAGEMENT_INVALID-MEAL-SLOT", "Meal slot must be breakfast, lunch or dinner")
+	ErrInvalidServings       = liberr.NewErrorDetails("MEALPLAN_COOKBOOK-MANAGEMENT_INVALID-SERVINGS", "Servings must be a positive number")
+	ErrInvalidDateRange      = liberr.NewErrorDetails("MEALPLAN_COOKBOOK-MANAGEMENT_INVALID-DATE-RANGE", "Date range must not end before it starts and must not be longer than 92 days")
+)
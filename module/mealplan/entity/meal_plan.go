@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+const (
+	MealSlotBreakfast = "breakfast"
+	MealSlotLunch     = "lunch"
+	MealSlotDinner    = "dinner"
+)
+
+// MealSlots are the meals of a day, in the order they are eaten
+var MealSlots = []string{
+	MealSlotBreakfast,
+	MealSlotLunch,
+	MealSlotDinner,
+}
+
+// MealPlanEntries is the plural form of MealPlanEntry
+type MealPlanEntries []*MealPlanEntry
+
+// MealPlanEntry assigns a recipe to a meal slot of a date, cooked for a number of servings.
+// RecipeName is read from the cookbook, and RecipeMissing flags a recipe deleted since it was planned or hidden from the viewer
+type MealPlanEntry struct {
+	ID            uint64
+	Date          time.Time
+	Slot          string
+	RecipeID      uint64
+	RecipeName    string
+	RecipeMissing bool
+	Servings      int
+	Notes         null.String
+	CreatedAt     time.Time
+	CreatedBy     string
+	UpdatedAt     null.Time
+	UpdatedBy     null.String
+}
+
+// MealPlanDay holds the entries of a date by slot, a day without entries is kept so that a calendar has every date
+type MealPlanDay struct {
+	Date    time.Time
+	Entries MealPlanEntries
+}
+
+// MealPlan holds the days of a date range, From and To included
+type MealPlan struct {
+	From time.Time
+	To   time.Time
+	Days []MealPlanDay
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
+
+// ShoppingList holds the ingredients needed to cook the meal plan of a date range.
+// MissingRecipeIDs lists the planned recipes deleted since or hidden from the viewer, their ingredients are left out
+type ShoppingList struct {
+	From             time.Time
+	To               time.Time
+	Items            []*ShoppingListItem
+	MissingRecipeIDs []uint64
+}
+
+// ShoppingListItem is an ingredient in a unit summed over every planned recipe using it, with the sub-recipes expanded.
+// An item without an amount is to taste, and an optional item is only used optionally by its recipes
+type ShoppingListItem struct {
+	IngredientID      uint64
+	IngredientName    string
+	UnitName          string
+	Amount            null.Float
+	AmountMax         null.Float
+	AmountDenominator null.Int
+	Optional          bool
+	RecipeNames       []string
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: meal_plan_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity0 "github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+	usecase "github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/usecase"
+)
+
+// MockMealPlanRepository is a mock of MealPlanRepository interface.
+type MockMealPlanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMealPlanRepositoryMockRecorder
+}
+
+// MockMealPlanRepositoryMockRecorder is the mock recorder for MockMealPlanRepository.
+type MockMealPlanRepositoryMockRecorder struct {
+	mock *MockMealPlanRepository
+}
+
+// NewMockMealPlanRepository creates a new mock instance.
+func NewMockMealPlanRepository(ctrl *gomock.Controller) *MockMealPlanRepository {
+	mock := &MockMealPlanRepository{ctrl: ctrl}
+	mock.recorder = &MockMealPlanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMealPlanRepository) EXPECT() *MockMealPlanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMealPlanRepository) Create(ctx context.Context, params usecase.MealPlanEntryParams) (*entity0.MealPlanEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*entity0.MealPlanEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMealPlanRepositoryMockRecorder) Create(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMealPlanRepository)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockMealPlanRepository) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMealPlanRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMealPlanRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockMealPlanRepository) Get(ctx context.Context, id uint64) (*entity0.MealPlanEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity0.MealPlanEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMealPlanRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMealPlanRepository)(nil).Get), ctx, id)
+}
+
+// ListByDateRange mocks base method.
+func (m *MockMealPlanRepository) ListByDateRange(ctx context.Context, from, to time.Time) (entity0.MealPlanEntries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDateRange", ctx, from, to)
+	ret0, _ := ret[0].(entity0.MealPlanEntries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDateRange indicates an expected call of ListByDateRange.
+func (mr *MockMealPlanRepositoryMockRecorder) ListByDateRange(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDateRange", reflect.TypeOf((*MockMealPlanRepository)(nil).ListByDateRange), ctx, from, to)
+}
+
+// Update mocks base method.
+func (m *MockMealPlanRepository) Update(ctx context.Context, id uint64, params usecase.MealPlanEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMealPlanRepositoryMockRecorder) Update(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMealPlanRepository)(nil).Update), ctx, id, params)
+}
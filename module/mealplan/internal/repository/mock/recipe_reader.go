@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: meal_plan_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+)
+
+// MockRecipeReader is a mock of RecipeReader interface.
+type MockRecipeReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeReaderMockRecorder
+}
+
+// MockRecipeReaderMockRecorder is the mock recorder for MockRecipeReader.
+type MockRecipeReaderMockRecorder struct {
+	mock *MockRecipeReader
+}
+
+// NewMockRecipeReader creates a new mock instance.
+func NewMockRecipeReader(ctrl *gomock.Controller) *MockRecipeReader {
+	mock := &MockRecipeReader{ctrl: ctrl}
+	mock.recorder = &MockRecipeReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeReader) EXPECT() *MockRecipeReaderMockRecorder {
+	return m.recorder
+}
+
+// GetRecipeSummary mocks base method.
+func (m *MockRecipeReader) GetRecipeSummary(ctx context.Context, id uint64, viewer string) (entity.RecipeSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeSummary", ctx, id, viewer)
+	ret0, _ := ret[0].(entity.RecipeSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeSummary indicates an expected call of GetRecipeSummary.
+func (mr *MockRecipeReaderMockRecorder) GetRecipeSummary(ctx, id, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeSummary", reflect.TypeOf((*MockRecipeReader)(nil).GetRecipeSummary), ctx, id, viewer)
+}
+
+// ListRecipeNames mocks base method.
+func (m *MockRecipeReader) ListRecipeNames(ctx context.Context, ids []uint64, viewer string) (map[uint64]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeNames", ctx, ids, viewer)
+	ret0, _ := ret[0].(map[uint64]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeNames indicates an expected call of ListRecipeNames.
+func (mr *MockRecipeReaderMockRecorder) ListRecipeNames(ctx, ids, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeNames", reflect.TypeOf((*MockRecipeReader)(nil).ListRecipeNames), ctx, ids, viewer)
+}
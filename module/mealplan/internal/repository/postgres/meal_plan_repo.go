@@ -0,0 +1,210 @@
+package postgres_repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/usecase"
+)
+
+// MealPlanPostgresRepository is the PostgreSQL implementation for MealPlanRepository interface
+type MealPlanPostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewMealPlanPostgresRepository instantiates MealPlanPostgresRepository
+func NewMealPlanPostgresRepository(db *sqlx.DB) *MealPlanPostgresRepository {
+	return &MealPlanPostgresRepository{db: db}
+}
+
+type mealPlanEntryDto struct {
+	ID        uint64      `db:"id"`
+	Date      time.Time   `db:"plan_date"`
+	Slot      string      `db:"slot"`
+	RecipeID  uint64      `db:"recipe_id"`
+	Servings  int         `db:"servings"`
+	Notes     null.String `db:"notes"`
+	CreatedAt time.Time   `db:"created_at"`
+	CreatedBy string      `db:"created_by"`
+	UpdatedAt null.Time   `db:"updated_at"`
+	UpdatedBy null.String `db:"updated_by"`
+}
+
+func (c mealPlanEntryDto) toEntity() *entity.MealPlanEntry {
+	return &entity.MealPlanEntry{
+		ID:        c.ID,
+		Date:      c.Date,
+		Slot:      c.Slot,
+		RecipeID:  c.RecipeID,
+		Servings:  c.Servings,
+		Notes:     c.Notes,
+		CreatedAt: c.CreatedAt,
+		CreatedBy: c.CreatedBy,
+		UpdatedAt: c.UpdatedAt,
+		UpdatedBy: c.UpdatedBy,
+	}
+}
+
+const selectMealPlanEntryColumns = `
+select id, plan_date, slot, recipe_id, servings, notes, created_at, created_by, updated_at, updated_by
+from meal_plan_entries`
+
+// selectMealPlanEntriesByDateRangeQuery orders the entries of a date by slot, in the order the meals are eaten
+const selectMealPlanEntriesByDateRangeQuery = selectMealPlanEntryColumns + `
+where plan_date between $1 and $2
+order by plan_date, case slot when 'breakfast' then 1 when 'lunch' then 2 else 3 end, id;
+`
+
+// ListByDateRange retrieves the entries planned from a date to another, both included
+func (r *MealPlanPostgresRepository) ListByDateRange(ctx context.Context, from, to time.Time) (res entity.MealPlanEntries, err error) {
+	var dtos []mealPlanEntryDto
+
+	err = r.db.SelectContext(ctx, &dtos, selectMealPlanEntriesByDateRangeQuery, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dto := range dtos {
+		res = append(res, dto.toEntity())
+	}
+
+	return res, nil
+}
+
+const selectMealPlanEntryByIDQuery = selectMealPlanEntryColumns + `
+where id = $1;
+`
+
+// Get retrieves a meal plan entry by its ID
+func (r *MealPlanPostgresRepository) Get(ctx context.Context, id uint64) (*entity.MealPlanEntry, error) {
+	var dto mealPlanEntryDto
+
+	err := r.db.GetContext(ctx, &dto, selectMealPlanEntryByIDQuery, id)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrMealPlanEntryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+const insertMealPlanEntryQuery = `
+INSERT INTO meal_plan_entries (plan_date, slot, recipe_id, servings, notes, created_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+// Create creates a new meal plan entry
+func (r *MealPlanPostgresRepository) Create(ctx context.Context, params usecase.MealPlanEntryParams) (*entity.MealPlanEntry, error) {
+	dto := mealPlanEntryDto{
+		Date:      params.Date,
+		Slot:      params.Slot,
+		RecipeID:  params.RecipeID,
+		Servings:  params.Servings,
+		Notes:     null.NewString(params.Notes, params.Notes != ""),
+		CreatedAt: time.Now(),
+		CreatedBy: params.Actor,
+	}
+
+	err := r.db.QueryRowxContext(ctx, insertMealPlanEntryQuery, dto.Date, dto.Slot, dto.RecipeID, dto.Servings, dto.Notes,
+		dto.CreatedAt, dto.CreatedBy).Scan(&dto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.toEntity(), nil
+}
+
+// Update updates a meal plan entry by its ID
+func (r *MealPlanPostgresRepository) Update(ctx context.Context, id uint64, params usecase.MealPlanEntryParams) error {
+	dto, query := mealPlanEntryDtoForUpdate(id, params)
+
+	result, err := r.db.NamedExecContext(ctx, query, &dto)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrMealPlanEntryNotFound
+	}
+
+	return nil
+}
+
+const deleteMealPlanEntryQuery = `
+DELETE FROM meal_plan_entries WHERE id = $1
+`
+
+// Delete permanently deletes a meal plan entry by its ID
+func (r *MealPlanPostgresRepository) Delete(ctx context.Context, id uint64) error {
+	result, err := r.db.ExecContext(ctx, deleteMealPlanEntryQuery, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return entity.ErrMealPlanEntryNotFound
+	}
+
+	return nil
+}
+
+func mealPlanEntryDtoForUpdate(id uint64, params usecase.MealPlanEntryParams) (dto mealPlanEntryDto, query string) {
+	var qb strings.Builder
+
+	qb.WriteString("UPDATE meal_plan_entries SET ")
+
+	if !params.Date.IsZero() {
+		qb.WriteString("plan_date = :plan_date, ")
+		dto.Date = params.Date
+	}
+
+	if params.Slot != "" {
+		qb.WriteString("slot = :slot, ")
+		dto.Slot = params.Slot
+	}
+
+	if params.RecipeID != 0 {
+		qb.WriteString("recipe_id = :recipe_id, ")
+		dto.RecipeID = params.RecipeID
+	}
+
+	if params.Servings > 0 {
+		qb.WriteString("servings = :servings, ")
+		dto.Servings = params.Servings
+	}
+
+	if params.Notes != "" {
+		qb.WriteString("notes = :notes, ")
+		dto.Notes = null.StringFrom(params.Notes)
+	}
+
+	qb.WriteString("updated_at = :updated_at, ")
+	dto.UpdatedAt = null.TimeFrom(time.Now())
+
+	qb.WriteString("updated_by = :updated_by ")
+	dto.UpdatedBy = null.StringFrom(params.Actor)
+
+	qb.WriteString("WHERE id = :id")
+	dto.ID = id
+
+	return dto, qb.String()
+}
@@ -0,0 +1,257 @@
+package usecase
+
+//go:generate mockgen -destination=../repository/mock/meal_plan_repo.go -source=meal_plan_usecase.go -package=mock MealPlanRepository
+//go:generate mockgen -destination=../repository/mock/recipe_reader.go -source=meal_plan_usecase.go -package=mock RecipeReader
+
+import (
+	"context"
+	"time"
+
+	cookbookEntity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+)
+
+// maxPlanDays is the longest date range a meal plan or a shopping list is read for
+const maxPlanDays = 92
+
+// MealPlanEntryParams holds a meal plan entry, zero fields are left unchanged on update.
+// Servings default to the servings of the recipe on create
+type MealPlanEntryParams struct {
+	Date     time.Time
+	Slot     string
+	RecipeID uint64
+	Servings int
+	Notes    string
+	Actor    string
+}
+
+// MealPlanRepository defines contract for meal plan repository dependency
+type MealPlanRepository interface {
+	Create(ctx context.Context, params MealPlanEntryParams) (*entity.MealPlanEntry, error)
+	Update(ctx context.Context, id uint64, params MealPlanEntryParams) error
+	Delete(ctx context.Context, id uint64) error
+	Get(ctx context.Context, id uint64) (*entity.MealPlanEntry, error)
+	ListByDateRange(ctx context.Context, from, to time.Time) (entity.MealPlanEntries, error)
+}
+
+// RecipeReader defines contract for reading the recipes of the cookbook module. The summary has the sub-recipes
+// expanded into its flattened ingredients, and the ID of a deleted or missing recipe, or of a draft the viewer cannot
+// see, is 0. The names leave those recipes out
+type RecipeReader interface {
+	GetRecipeSummary(ctx context.Context, id uint64, viewer string) (cookbookEntity.RecipeSummary, error)
+	ListRecipeNames(ctx context.Context, ids []uint64, viewer string) (map[uint64]string, error)
+}
+
+// MealPlanUsecase is our meal plan usecase object
+type MealPlanUsecase struct {
+	mealPlanRepo MealPlanRepository
+	recipeReader RecipeReader
+}
+
+// NewMealPlanUsecase instantiates MealPlanUsecase
+func NewMealPlanUsecase(mealPlanRepo MealPlanRepository, recipeReader RecipeReader) *MealPlanUsecase {
+	return &MealPlanUsecase{
+		mealPlanRepo: mealPlanRepo,
+		recipeReader: recipeReader,
+	}
+}
+
+// CreateMealPlanEntry assigns a recipe to a meal slot of a date
+func (u *MealPlanUsecase) CreateMealPlanEntry(ctx context.Context, params MealPlanEntryParams) (*entity.MealPlanEntry, error) {
+	if !isMealSlot(params.Slot) {
+		return nil, entity.ErrInvalidMealSlot
+	}
+
+	if params.Servings < 0 {
+		return nil, entity.ErrInvalidServings
+	}
+
+	recipe, err := u.getRecipe(ctx, params.RecipeID, params.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Servings == 0 {
+		params.Servings = recipe.Servings
+	}
+
+	if params.Servings <= 0 {
+		params.Servings = defaultServings
+	}
+
+	params.Date = truncateDate(params.Date)
+
+	entry, err := u.mealPlanRepo.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.RecipeName = recipe.Name
+
+	return entry, nil
+}
+
+// UpdateMealPlanEntry moves a meal plan entry to another date or slot, or changes its recipe, servings or notes
+func (u *MealPlanUsecase) UpdateMealPlanEntry(ctx context.Context, id uint64, params MealPlanEntryParams) (*entity.MealPlanEntry, error) {
+	if params.Slot != "" && !isMealSlot(params.Slot) {
+		return nil, entity.ErrInvalidMealSlot
+	}
+
+	if params.Servings < 0 {
+		return nil, entity.ErrInvalidServings
+	}
+
+	if params.RecipeID != 0 {
+		_, err := u.getRecipe(ctx, params.RecipeID, params.Actor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !params.Date.IsZero() {
+		params.Date = truncateDate(params.Date)
+	}
+
+	err := u.mealPlanRepo.Update(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := u.mealPlanRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.fillRecipeNames(ctx, entity.MealPlanEntries{entry}, params.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// DeleteMealPlanEntry removes a meal plan entry
+func (u *MealPlanUsecase) DeleteMealPlanEntry(ctx context.Context, id uint64) error {
+	return u.mealPlanRepo.Delete(ctx, id)
+}
+
+// GetMealPlan retrieves the meal plan of a date range, every date has a day even when nothing is planned on it.
+// The recipes the viewer cannot see are flagged as missing
+func (u *MealPlanUsecase) GetMealPlan(ctx context.Context, from, to time.Time, viewer string) (entity.MealPlan, error) {
+	from, to, err := validateDateRange(from, to)
+	if err != nil {
+		return entity.MealPlan{}, err
+	}
+
+	entries, err := u.mealPlanRepo.ListByDateRange(ctx, from, to)
+	if err != nil {
+		return entity.MealPlan{}, err
+	}
+
+	err = u.fillRecipeNames(ctx, entries, viewer)
+	if err != nil {
+		return entity.MealPlan{}, err
+	}
+
+	res := entity.MealPlan{From: from, To: to}
+	days := map[time.Time]int{}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		days[date] = len(res.Days)
+		res.Days = append(res.Days, entity.MealPlanDay{Date: date, Entries: entity.MealPlanEntries{}})
+	}
+
+	for _, entry := range entries {
+		i, ok := days[truncateDate(entry.Date)]
+		if !ok {
+			continue
+		}
+
+		res.Days[i].Entries = append(res.Days[i].Entries, entry)
+	}
+
+	return res, nil
+}
+
+// fillRecipeNames sets the recipe names of meal plan entries and flags the missing recipes, the names are read at once
+func (u *MealPlanUsecase) fillRecipeNames(ctx context.Context, entries entity.MealPlanEntries, viewer string) error {
+	var recipeIDs []uint64
+	seen := map[uint64]bool{}
+
+	for _, entry := range entries {
+		if !seen[entry.RecipeID] {
+			seen[entry.RecipeID] = true
+			recipeIDs = append(recipeIDs, entry.RecipeID)
+		}
+	}
+
+	names, err := u.recipeReader.ListRecipeNames(ctx, recipeIDs, viewer)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name, ok := names[entry.RecipeID]
+
+		entry.RecipeName = name
+		entry.RecipeMissing = !ok
+	}
+
+	return nil
+}
+
+// readRecipe reads the summary of a recipe once, summaries caches the recipes already read
+func (u *MealPlanUsecase) readRecipe(ctx context.Context, id uint64, viewer string, summaries map[uint64]cookbookEntity.RecipeSummary) (cookbookEntity.RecipeSummary, error) {
+	if summary, ok := summaries[id]; ok {
+		return summary, nil
+	}
+
+	summary, err := u.recipeReader.GetRecipeSummary(ctx, id, viewer)
+	if err != nil {
+		return cookbookEntity.RecipeSummary{}, err
+	}
+
+	summaries[id] = summary
+
+	return summary, nil
+}
+
+// getRecipe reads a recipe that must exist and be visible to the viewer
+func (u *MealPlanUsecase) getRecipe(ctx context.Context, id uint64, viewer string) (cookbookEntity.RecipeSummary, error) {
+	summary, err := u.recipeReader.GetRecipeSummary(ctx, id, viewer)
+	if err != nil {
+		return cookbookEntity.RecipeSummary{}, err
+	}
+
+	if summary.ID == 0 {
+		return cookbookEntity.RecipeSummary{}, entity.ErrRecipeNotFound
+	}
+
+	return summary, nil
+}
+
+// validateDateRange truncates a date range to whole dates and checks that it is not reversed nor too long
+func validateDateRange(from, to time.Time) (time.Time, time.Time, error) {
+	from, to = truncateDate(from), truncateDate(to)
+
+	if to.Before(from) || to.After(from.AddDate(0, 0, maxPlanDays-1)) {
+		return from, to, entity.ErrInvalidDateRange
+	}
+
+	return from, to, nil
+}
+
+// truncateDate drops the time of day, meal plans are kept by date
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isMealSlot(slot string) bool {
+	for _, s := range entity.MealSlots {
+		if s == slot {
+			return true
+		}
+	}
+
+	return false
+}
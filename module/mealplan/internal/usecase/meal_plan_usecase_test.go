@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	cookbookEntity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/repository/mock"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/usecase"
+)
+
+func date(day int) time.Time {
+	return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNewMealPlanUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mealPlanRepo := mock.NewMockMealPlanRepository(ctrl)
+	recipeReader := mock.NewMockRecipeReader(ctrl)
+	uc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	assert.NotEmpty(t, uc)
+}
+
+func TestMealPlanUsecase_CreateMealPlanEntry_DefaultServings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mealPlanRepo := mock.NewMockMealPlanRepository(ctrl)
+	recipeReader := mock.NewMockRecipeReader(ctrl)
+	uc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	recipeReader.EXPECT().GetRecipeSummary(gomock.Any(), uint64(3), "").
+		Return(cookbookEntity.RecipeSummary{Recipe: cookbookEntity.Recipe{ID: 3, Name: "Nasi goreng", Servings: 2}}, nil)
+	mealPlanRepo.EXPECT().Create(gomock.Any(), usecase.MealPlanEntryParams{
+		Date:     date(20),
+		Slot:     entity.MealSlotDinner,
+		RecipeID: 3,
+		Servings: 2,
+	}).Return(&entity.MealPlanEntry{ID: 1, Date: date(20), Slot: entity.MealSlotDinner, RecipeID: 3, Servings: 2}, nil)
+
+	entry, err := uc.CreateMealPlanEntry(context.Background(), usecase.MealPlanEntryParams{
+		Date:     date(20).Add(15 * time.Hour),
+		Slot:     entity.MealSlotDinner,
+		RecipeID: 3,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Nasi goreng", entry.RecipeName)
+}
+
+func TestMealPlanUsecase_CreateMealPlanEntry_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mealPlanRepo := mock.NewMockMealPlanRepository(ctrl)
+	recipeReader := mock.NewMockRecipeReader(ctrl)
+	uc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	_, err := uc.CreateMealPlanEntry(context.Background(), usecase.MealPlanEntryParams{Date: date(20), Slot: "supper", RecipeID: 3})
+	assert.Equal(t, entity.ErrInvalidMealSlot, err)
+
+	recipeReader.EXPECT().GetRecipeSummary(gomock.Any(), uint64(4), "").Return(cookbookEntity.RecipeSummary{}, nil)
+
+	_, err = uc.CreateMealPlanEntry(context.Background(), usecase.MealPlanEntryParams{Date: date(20), Slot: entity.MealSlotLunch, RecipeID: 4})
+	assert.Equal(t, entity.ErrRecipeNotFound, err)
+}
+
+func TestMealPlanUsecase_GetMealPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mealPlanRepo := mock.NewMockMealPlanRepository(ctrl)
+	recipeReader := mock.NewMockRecipeReader(ctrl)
+	uc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	mealPlanRepo.EXPECT().ListByDateRange(gomock.Any(), date(19), date(25)).Return(entity.MealPlanEntries{
+		{ID: 1, Date: date(20), Slot: entity.MealSlotLunch, RecipeID: 3, Servings: 2},
+		{ID: 2, Date: date(20), Slot: entity.MealSlotDinner, RecipeID: 3, Servings: 4},
+		{ID: 3, Date: date(22), Slot: entity.MealSlotBreakfast, RecipeID: 5, Servings: 1},
+	}, nil)
+
+	// the names are read at once, a recipe once however often it is planned
+	recipeReader.EXPECT().ListRecipeNames(gomock.Any(), []uint64{3, 5}, "Naufal").Return(map[uint64]string{3: "Nasi goreng"}, nil)
+
+	plan, err := uc.GetMealPlan(context.Background(), date(19), date(25), "Naufal")
+
+	assert.NoError(t, err)
+	assert.Len(t, plan.Days, 7)
+	assert.Len(t, plan.Days[1].Entries, 2)
+	assert.Equal(t, "Nasi goreng", plan.Days[1].Entries[0].RecipeName)
+	assert.True(t, plan.Days[3].Entries[0].RecipeMissing)
+	assert.Empty(t, plan.Days[0].Entries)
+}
+
+func TestMealPlanUsecase_GetMealPlan_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mealPlanRepo := mock.NewMockMealPlanRepository(ctrl)
+	recipeReader := mock.NewMockRecipeReader(ctrl)
+	uc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	_, err := uc.GetMealPlan(context.Background(), date(25), date(19), "")
+	assert.Equal(t, entity.ErrInvalidDateRange, err)
+
+	_, err = uc.GetMealPlan(context.Background(), date(1), date(1).AddDate(1, 0, 0), "")
+	assert.Equal(t, entity.ErrInvalidDateRange, err)
+}
+
+func TestMealPlanUsecase_GetShoppingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mealPlanRepo := mock.NewMockMealPlanRepository(ctrl)
+	recipeReader := mock.NewMockRecipeReader(ctrl)
+	uc := usecase.NewMealPlanUsecase(mealPlanRepo, recipeReader)
+
+	mealPlanRepo.EXPECT().ListByDateRange(gomock.Any(), date(19), date(25)).Return(entity.MealPlanEntries{
+		{ID: 1, Date: date(20), Slot: entity.MealSlotLunch, RecipeID: 3, Servings: 4},
+		{ID: 2, Date: date(21), Slot: entity.MealSlotDinner, RecipeID: 6, Servings: 1},
+		{ID: 3, Date: date(22), Slot: entity.MealSlotDinner, RecipeID: 5, Servings: 1},
+	}, nil)
+
+	recipeReader.EXPECT().GetRecipeSummary(gomock.Any(), uint64(3), "").Return(cookbookEntity.RecipeSummary{
+		Recipe: cookbookEntity.Recipe{ID: 3, Name: "Nasi goreng", Servings: 2},
+		FlattenedIngredients: cookbookEntity.RecipeIngredients{
+			{IngredientID: 1, IngredientName: "Nasi", IngredientUnitName: "g", Amount: null.FloatFrom(300)},
+			{IngredientID: 2, IngredientName: "Garam", IngredientUnitName: "sdt"},
+		},
+	}, nil)
+	recipeReader.EXPECT().GetRecipeSummary(gomock.Any(), uint64(6), "").Return(cookbookEntity.RecipeSummary{
+		Recipe: cookbookEntity.Recipe{ID: 6, Name: "Nasi uduk", Servings: 1},
+		FlattenedIngredients: cookbookEntity.RecipeIngredients{
+			{IngredientID: 1, IngredientName: "Nasi", IngredientUnitName: "g", Amount: null.FloatFrom(150)},
+		},
+	}, nil)
+	recipeReader.EXPECT().GetRecipeSummary(gomock.Any(), uint64(5), "").Return(cookbookEntity.RecipeSummary{}, nil)
+
+	list, err := uc.GetShoppingList(context.Background(), date(19), date(25), "")
+
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+	assert.Equal(t, "Garam", list.Items[0].IngredientName)
+	assert.False(t, list.Items[0].Amount.Valid)
+	assert.Equal(t, "Nasi", list.Items[1].IngredientName)
+	assert.Equal(t, null.FloatFrom(750), list.Items[1].Amount)
+	assert.Equal(t, []string{"Nasi goreng", "Nasi uduk"}, list.Items[1].RecipeNames)
+	assert.Equal(t, []uint64{5}, list.MissingRecipeIDs)
+}
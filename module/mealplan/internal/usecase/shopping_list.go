@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/guregu/null"
+
+	cookbookEntity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+)
+
+// GetShoppingList consolidates the ingredients of every recipe planned in a date range, with the sub-recipes expanded.
+// Recipes are scaled from their own servings to the planned servings, and the amounts of an ingredient in the same unit
+// are summed across recipes. Units are not converted, so an ingredient bought in two units is listed twice.
+// The recipes the viewer cannot see are listed as missing
+func (u *MealPlanUsecase) GetShoppingList(ctx context.Context, from, to time.Time, viewer string) (entity.ShoppingList, error) {
+	from, to, err := validateDateRange(from, to)
+	if err != nil {
+		return entity.ShoppingList{}, err
+	}
+
+	entries, err := u.mealPlanRepo.ListByDateRange(ctx, from, to)
+	if err != nil {
+		return entity.ShoppingList{}, err
+	}
+
+	res := entity.ShoppingList{
+		From:  from,
+		To:    to,
+		Items: []*entity.ShoppingListItem{},
+	}
+
+	summaries := map[uint64]cookbookEntity.RecipeSummary{}
+	indexes := map[string]int{}
+	sums := cookbookEntity.RecipeIngredients{}
+	missing := map[uint64]bool{}
+
+	for _, entry := range entries {
+		summary, err := u.readRecipe(ctx, entry.RecipeID, viewer, summaries)
+		if err != nil {
+			return entity.ShoppingList{}, err
+		}
+
+		if summary.ID == 0 {
+			if !missing[entry.RecipeID] {
+				missing[entry.RecipeID] = true
+				res.MissingRecipeIDs = append(res.MissingRecipeIDs, entry.RecipeID)
+			}
+
+			continue
+		}
+
+		factor := float64(entry.Servings)
+		if summary.Servings > 0 {
+			factor /= float64(summary.Servings)
+		}
+
+		for _, ingredient := range summary.FlattenedIngredients {
+			scaled := ingredient.Scale(factor)
+
+			key := fmt.Sprintf("%d|%s|%t|%t", scaled.IngredientID, strings.ToLower(scaled.IngredientUnitName), scaled.IsToTaste(), scaled.Optional)
+
+			if i, ok := indexes[key]; ok {
+				sums[i] = sums[i].AddAmount(*scaled)
+				setShoppingListAmount(res.Items[i], sums[i])
+				res.Items[i].RecipeNames = appendUniqueString(res.Items[i].RecipeNames, summary.Name)
+				continue
+			}
+
+			indexes[key] = len(res.Items)
+			sums = append(sums, scaled)
+
+			item := &entity.ShoppingListItem{
+				IngredientID:   scaled.IngredientID,
+				IngredientName: scaled.IngredientName,
+				UnitName:       scaled.IngredientUnitName,
+				Optional:       scaled.Optional,
+				RecipeNames:    []string{summary.Name},
+			}
+			setShoppingListAmount(item, scaled)
+			res.Items = append(res.Items, item)
+		}
+	}
+
+	sort.SliceStable(res.Items, func(i, j int) bool {
+		a, b := strings.ToLower(res.Items[i].IngredientName), strings.ToLower(res.Items[j].IngredientName)
+		if a != b {
+			return a < b
+		}
+
+		return strings.ToLower(res.Items[i].UnitName) < strings.ToLower(res.Items[j].UnitName)
+	})
+
+	return res, nil
+}
+
+// setShoppingListAmount sets the amount of an item from the recipe ingredients summed into it
+func setShoppingListAmount(item *entity.ShoppingListItem, sum *cookbookEntity.RecipeIngredient) {
+	item.Amount = sum.Amount
+	item.AmountMax = null.NewFloat(sum.AmountMax.Float64, sum.IsRange())
+	item.AmountDenominator = sum.AmountDenominator
+}
+
+func appendUniqueString(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
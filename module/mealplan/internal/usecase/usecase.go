@@ -0,0 +1,3 @@
+package usecase
+
+const defaultServings = 1
@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/usecase"
+)
+
+// MealPlanUsecase defines the contract for meal plan usecase dependency
+type MealPlanUsecase interface {
+	CreateMealPlanEntry(ctx context.Context, params usecase.MealPlanEntryParams) (*entity.MealPlanEntry, error)
+	UpdateMealPlanEntry(ctx context.Context, id uint64, params usecase.MealPlanEntryParams) (*entity.MealPlanEntry, error)
+	DeleteMealPlanEntry(ctx context.Context, id uint64) error
+	GetMealPlan(ctx context.Context, from, to time.Time, viewer string) (entity.MealPlan, error)
+	GetShoppingList(ctx context.Context, from, to time.Time, viewer string) (entity.ShoppingList, error)
+}
+
+// MealPlanHandler is our meal plan handler object
+type MealPlanHandler struct {
+	mealPlanUsecase MealPlanUsecase
+}
+
+// NewMealPlanHandler instantiates MealPlanHandler
+func NewMealPlanHandler(mealPlanUsecase MealPlanUsecase) *MealPlanHandler {
+	return &MealPlanHandler{
+		mealPlanUsecase: mealPlanUsecase,
+	}
+}
@@ -0,0 +1,340 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+
+	"github.com/tlab-backend-test-naufal/cookbook-management/internal/libhttp"
+	cookbookEntity "github.com/tlab-backend-test-naufal/cookbook-management/module/cookbook/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/entity"
+	"github.com/tlab-backend-test-naufal/cookbook-management/module/mealplan/internal/usecase"
+)
+
+// dateLayout is how dates are written in requests and responses
+const dateLayout = "2006-01-02"
+
+type MealPlanEntryRequest struct {
+	Date     string `json:"date"`
+	Slot     string `json:"slot"`
+	RecipeID uint64 `json:"recipe_id"`
+	Servings int    `json:"servings"`
+	Notes    string `json:"notes"`
+	Actor    string `json:"actor"`
+}
+
+type MealPlanEntryResponse struct {
+	ID            uint64      `json:"id"`
+	Date          string      `json:"date"`
+	Slot          string      `json:"slot"`
+	RecipeID      uint64      `json:"recipe_id"`
+	RecipeName    string      `json:"recipe_name"`
+	RecipeMissing bool        `json:"recipe_missing"`
+	Servings      int         `json:"servings"`
+	Notes         null.String `json:"notes"`
+	CreatedAt     time.Time   `json:"created_at"`
+	CreatedBy     string      `json:"created_by"`
+	UpdatedAt     null.Time   `json:"updated_at"`
+	UpdatedBy     null.String `json:"updated_by"`
+}
+
+type MealPlanDayResponse struct {
+	Date      string                  `json:"date"`
+	Breakfast []MealPlanEntryResponse `json:"breakfast"`
+	Lunch     []MealPlanEntryResponse `json:"lunch"`
+	Dinner    []MealPlanEntryResponse `json:"dinner"`
+}
+
+type MealPlanResponse struct {
+	From string                `json:"from"`
+	To   string                `json:"to"`
+	Days []MealPlanDayResponse `json:"days"`
+}
+
+type ShoppingListItemResponse struct {
+	IngredientID   uint64     `json:"ingredient_id"`
+	IngredientName string     `json:"ingredient_name"`
+	UnitName       string     `json:"unit_name"`
+	Amount         null.Float `json:"amount"`
+	AmountMax      null.Float `json:"amount_max"`
+	AmountText     string     `json:"amount_text"`
+	ToTaste        bool       `json:"to_taste"`
+	Optional       bool       `json:"optional"`
+	Recipes        []string   `json:"recipes"`
+}
+
+type ShoppingListResponse struct {
+	From             string                     `json:"from"`
+	To               string                     `json:"to"`
+	Items            []ShoppingListItemResponse `json:"items"`
+	MissingRecipeIDs []uint64                   `json:"missing_recipe_ids"`
+}
+
+// CreateMealPlanEntry is a create meal plan entry handler, servings default to the servings of the recipe
+func (h *MealPlanHandler) CreateMealPlanEntry(w http.ResponseWriter, r *http.Request) {
+	var req MealPlanEntryRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	if req.RecipeID == 0 {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("recipe_id cannot be empty"))
+		return
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("date must be written as YYYY-MM-DD"))
+		return
+	}
+
+	params := usecase.MealPlanEntryParams{
+		Date:     date,
+		Slot:     req.Slot,
+		RecipeID: req.RecipeID,
+		Servings: req.Servings,
+		Notes:    req.Notes,
+		Actor:    req.Actor,
+	}
+
+	entry, err := h.mealPlanUsecase.CreateMealPlanEntry(r.Context(), params)
+	if err != nil {
+		libhttp.WithError(w, mealPlanErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, mealPlanEntryResponseFromEntity(entry))
+}
+
+// UpdateMealPlanEntry is a update meal plan entry handler
+func (h *MealPlanHandler) UpdateMealPlanEntry(w http.ResponseWriter, r *http.Request) {
+	var req MealPlanEntryRequest
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("invalid payload"))
+		return
+	}
+
+	params := usecase.MealPlanEntryParams{
+		Slot:     req.Slot,
+		RecipeID: req.RecipeID,
+		Servings: req.Servings,
+		Notes:    req.Notes,
+		Actor:    req.Actor,
+	}
+
+	if req.Date != "" {
+		params.Date, err = time.Parse(dateLayout, req.Date)
+		if err != nil {
+			libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("date must be written as YYYY-MM-DD"))
+			return
+		}
+	}
+
+	entry, err := h.mealPlanUsecase.UpdateMealPlanEntry(r.Context(), id, params)
+	if err != nil {
+		libhttp.WithError(w, mealPlanErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, mealPlanEntryResponseFromEntity(entry))
+}
+
+// DeleteMealPlanEntry is a delete meal plan entry handler
+func (h *MealPlanHandler) DeleteMealPlanEntry(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, fmt.Errorf("id cannot be empty"))
+		return
+	}
+
+	err = h.mealPlanUsecase.DeleteMealPlanEntry(r.Context(), id)
+	if err != nil {
+		libhttp.WithError(w, mealPlanErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithMessage(w, http.StatusOK, "successfully deleted meal plan entry")
+}
+
+// GetMealPlan is a get meal plan handler, it returns every date of ?from= to ?to= with its meals by slot.
+// The range defaults to the current week from Monday, and the drafts ?actor= cannot see are flagged as missing
+func (h *MealPlanHandler) GetMealPlan(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	plan, err := h.mealPlanUsecase.GetMealPlan(r.Context(), from, to, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, mealPlanErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, mealPlanResponseFromEntity(plan))
+}
+
+// GetShoppingList is a get shopping list handler, it consolidates the ingredients of the meals planned
+// from ?from= to ?to=, the current week by default. The drafts ?actor= cannot see are listed as missing
+func (h *MealPlanHandler) GetShoppingList(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		libhttp.WithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.mealPlanUsecase.GetShoppingList(r.Context(), from, to, r.URL.Query().Get("actor"))
+	if err != nil {
+		libhttp.WithError(w, mealPlanErrorStatus(err), err)
+		return
+	}
+
+	libhttp.WithJSON(w, http.StatusOK, shoppingListResponseFromEntity(list))
+}
+
+// parseDateRange reads the ?from= and ?to= dates, from defaults to the Monday of the current week and to to a week after from
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from = from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+
+	if rawFrom := query.Get("from"); rawFrom != "" {
+		var err error
+
+		from, err = time.Parse(dateLayout, rawFrom)
+		if err != nil {
+			return from, from, fmt.Errorf("from must be written as YYYY-MM-DD")
+		}
+	}
+
+	to := from.AddDate(0, 0, 6)
+
+	if rawTo := query.Get("to"); rawTo != "" {
+		var err error
+
+		to, err = time.Parse(dateLayout, rawTo)
+		if err != nil {
+			return from, to, fmt.Errorf("to must be written as YYYY-MM-DD")
+		}
+	}
+
+	return from, to, nil
+}
+
+// mealPlanErrorStatus maps meal plan errors to a response status
+func mealPlanErrorStatus(err error) int {
+	switch err {
+	case entity.ErrRecipeNotFound, entity.ErrInvalidMealSlot, entity.ErrInvalidServings, entity.ErrInvalidDateRange:
+		return http.StatusBadRequest
+	case entity.ErrMealPlanEntryNotFound:
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
+
+// mealPlanEntryResponseFromEntity converts meal plan entry entity to response
+func mealPlanEntryResponseFromEntity(ent *entity.MealPlanEntry) MealPlanEntryResponse {
+	return MealPlanEntryResponse{
+		ID:            ent.ID,
+		Date:          ent.Date.Format(dateLayout),
+		Slot:          ent.Slot,
+		RecipeID:      ent.RecipeID,
+		RecipeName:    ent.RecipeName,
+		RecipeMissing: ent.RecipeMissing,
+		Servings:      ent.Servings,
+		Notes:         ent.Notes,
+		CreatedAt:     ent.CreatedAt,
+		CreatedBy:     ent.CreatedBy,
+		UpdatedAt:     ent.UpdatedAt,
+		UpdatedBy:     ent.UpdatedBy,
+	}
+}
+
+func mealPlanResponseFromEntity(ent entity.MealPlan) MealPlanResponse {
+	resp := MealPlanResponse{
+		From: ent.From.Format(dateLayout),
+		To:   ent.To.Format(dateLayout),
+		Days: []MealPlanDayResponse{},
+	}
+
+	for _, day := range ent.Days {
+		dayResp := MealPlanDayResponse{
+			Date:      day.Date.Format(dateLayout),
+			Breakfast: []MealPlanEntryResponse{},
+			Lunch:     []MealPlanEntryResponse{},
+			Dinner:    []MealPlanEntryResponse{},
+		}
+
+		for _, entry := range day.Entries {
+			switch entry.Slot {
+			case entity.MealSlotBreakfast:
+				dayResp.Breakfast = append(dayResp.Breakfast, mealPlanEntryResponseFromEntity(entry))
+			case entity.MealSlotLunch:
+				dayResp.Lunch = append(dayResp.Lunch, mealPlanEntryResponseFromEntity(entry))
+			case entity.MealSlotDinner:
+				dayResp.Dinner = append(dayResp.Dinner, mealPlanEntryResponseFromEntity(entry))
+			}
+		}
+
+		resp.Days = append(resp.Days, dayResp)
+	}
+
+	return resp
+}
+
+func shoppingListResponseFromEntity(ent entity.ShoppingList) ShoppingListResponse {
+	resp := ShoppingListResponse{
+		From:             ent.From.Format(dateLayout),
+		To:               ent.To.Format(dateLayout),
+		Items:            []ShoppingListItemResponse{},
+		MissingRecipeIDs: []uint64{},
+	}
+
+	for _, item := range ent.Items {
+		itemResp := ShoppingListItemResponse{
+			IngredientID:   item.IngredientID,
+			IngredientName: item.IngredientName,
+			UnitName:       item.UnitName,
+			Amount:         item.Amount,
+			AmountMax:      item.AmountMax,
+			ToTaste:        !item.Amount.Valid,
+			Optional:       item.Optional,
+			Recipes:        item.RecipeNames,
+		}
+
+		if item.Amount.Valid {
+			itemResp.AmountText = cookbookEntity.FormatAmount(item.Amount.Float64, item.AmountDenominator.Int64)
+			if item.AmountMax.Valid {
+				itemResp.AmountText += "-" + cookbookEntity.FormatAmount(item.AmountMax.Float64, item.AmountDenominator.Int64)
+			}
+		}
+
+		resp.Items = append(resp.Items, itemResp)
+	}
+
+	resp.MissingRecipeIDs = append(resp.MissingRecipeIDs, ent.MissingRecipeIDs...)
+
+	return resp
+}